@@ -0,0 +1,61 @@
+package gateway
+
+import (
+	"context"
+	_ "embed"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"inventoryService/interceptor"
+	pb "inventoryService/proto/inventory"
+	"net"
+	"net/http"
+	"strings"
+)
+
+//go:embed openapi/inventory.swagger.json
+var openAPISpec []byte
+
+// NewHandler returns an http.Handler that serves the REST/JSON facade of the
+// InventoryService by proxying requests to the gRPC server at grpcEndpoint.
+// The generated OpenAPI spec is served at /openapi.json.
+func NewHandler(ctx context.Context, grpcEndpoint string) (http.Handler, error) {
+	gwMux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(headerMatcher),
+		runtime.WithMetadata(forwardClientID),
+	)
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	if err := pb.RegisterInventoryServiceHandlerFromEndpoint(ctx, gwMux, grpcEndpoint, opts); err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/openapi.json", serveOpenAPISpec)
+	mux.Handle("/", gwMux)
+	return mux, nil
+}
+
+// headerMatcher forwards headers as the default matcher does, except that
+// clients cannot set the client identity the gateway forwards for them.
+func headerMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, runtime.MetadataHeaderPrefix+interceptor.ClientIDHeader) {
+		return "", false
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// forwardClientID names the remote host of the HTTP client so that the gRPC
+// server rate limits REST callers one by one instead of as the gateway.
+func forwardClientID(ctx context.Context, r *http.Request) metadata.MD {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return metadata.Pairs(interceptor.ClientIDHeader, host)
+}
+
+func serveOpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(openAPISpec)
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"inventoryService/model"
+	pb "inventoryService/proto/inventory"
+	"log"
+	"time"
+)
+
+func (h *InventoryHandler) ListAuditEvents(ctx context.Context, req *pb.ListAuditEventsRequest) (*pb.ListAuditEventsResponse, error) {
+	filter := model.AuditEventFilter{
+		EntityType: model.EntityType(req.EntityType),
+		Actor:      req.Actor,
+		Start:      timeFromPb(req.StartTime),
+		End:        timeFromPb(req.EndTime),
+	}
+	if req.EntityId != "" {
+		entityID, err := uuid.Parse(req.EntityId)
+		if err != nil {
+			log.Printf("Error parsing UUID: %v", err)
+			return nil, err
+		}
+		filter.EntityID = entityID
+	}
+	events, err := h.auditService.ListAuditEvents(ctx, filter)
+	if err != nil {
+		log.Printf("Error in ListAuditEvents: %v", err)
+		return nil, err
+	}
+
+	pbEvents := make([]*pb.AuditEvent, len(events))
+	for i, event := range events {
+		pbEvents[i] = convertAuditEventModelToPb(event)
+	}
+
+	return &pb.ListAuditEventsResponse{AuditEvents: pbEvents, Total: int32(len(pbEvents))}, nil
+}
+
+func (h *InventoryHandler) GetEntityHistory(ctx context.Context, req *pb.GetEntityHistoryRequest) (*pb.GetEntityHistoryResponse, error) {
+	entityID, err := uuid.Parse(req.EntityId)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	events, err := h.auditService.GetEntityHistory(ctx, model.EntityType(req.EntityType), entityID, timeFromPb(req.StartTime), timeFromPb(req.EndTime))
+	if err != nil {
+		log.Printf("Error in GetEntityHistory: %v", err)
+		return nil, err
+	}
+
+	pbEvents := make([]*pb.AuditEvent, len(events))
+	for i, event := range events {
+		pbEvents[i] = convertAuditEventModelToPb(event)
+	}
+
+	return &pb.GetEntityHistoryResponse{AuditEvents: pbEvents}, nil
+}
+
+func convertAuditEventModelToPb(event *model.AuditEvent) *pb.AuditEvent {
+	return &pb.AuditEvent{
+		Id:         event.ID.String(),
+		EntityType: string(event.EntityType),
+		EntityId:   event.EntityID.String(),
+		Action:     string(event.Action),
+		Actor:      event.Actor,
+		Rpc:        event.RPC,
+		Before:     event.Before,
+		After:      event.After,
+		Timestamp:  timestamppb.New(event.Timestamp),
+	}
+}
+
+// timeFromPb converts an optional timestamp, mapping an unset one to the zero
+// time rather than the Unix epoch.
+func timeFromPb(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+// timestampOrNil is the inverse of timeFromPb: the zero time becomes an unset
+// timestamp.
+func timestampOrNil(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
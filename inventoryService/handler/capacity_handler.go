@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"inventoryService/model"
+	pb "inventoryService/proto/inventory"
+	"log"
+)
+
+func (h *InventoryHandler) GetWarehouseUtilization(ctx context.Context, req *pb.GetWarehouseUtilizationRequest) (*pb.WarehouseUtilization, error) {
+	warehouseID, err := uuid.Parse(req.WarehouseId)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	utilization, err := h.capacityService.GetWarehouseUtilization(ctx, warehouseID)
+	if err != nil {
+		log.Printf("Error in GetWarehouseUtilization: %v", err)
+		return nil, err
+	}
+
+	pbBins := make([]*pb.BinUtilization, len(utilization.Bins))
+	for i, bin := range utilization.Bins {
+		pbBins[i] = &pb.BinUtilization{
+			Location:  convertLocationModelToPb(bin.Location),
+			Load:      convertStorageLoadModelToPb(bin.Load),
+			FillRatio: bin.FillRatio,
+		}
+	}
+
+	return &pb.WarehouseUtilization{
+		WarehouseId: utilization.WarehouseID.String(),
+		Capacity:    convertCapacityModelToPb(utilization.Capacity),
+		Load:        convertStorageLoadModelToPb(utilization.Load),
+		FillRatio:   utilization.FillRatio,
+		Bins:        pbBins,
+	}, nil
+}
+
+func convertPbToCapacityModel(pbCapacity *pb.Capacity) (model.Capacity, error) {
+	maxUnits, err := quantityFromPb("max units", pbCapacity.GetMaxUnits())
+	if err != nil {
+		return model.Capacity{}, err
+	}
+	return model.Capacity{
+		MaxUnits:  maxUnits,
+		MaxVolume: pbCapacity.GetMaxVolume(),
+		MaxWeight: pbCapacity.GetMaxWeight(),
+	}, nil
+}
+
+func convertCapacityModelToPb(capacity model.Capacity) *pb.Capacity {
+	return &pb.Capacity{
+		MaxUnits:  capacity.MaxUnits.String(),
+		MaxVolume: capacity.MaxVolume,
+		MaxWeight: capacity.MaxWeight,
+	}
+}
+
+func convertStorageLoadModelToPb(load model.StorageLoad) *pb.StorageLoad {
+	return &pb.StorageLoad{
+		Units:  load.Units.String(),
+		Volume: load.Volume,
+		Weight: load.Weight,
+	}
+}
@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"inventoryService/model"
+	pb "inventoryService/proto/inventory"
+	"log"
+)
+
+func (h *InventoryHandler) CreateCountPlan(ctx context.Context, req *pb.CreateCountPlanRequest) (*pb.CountPlan, error) {
+	internalPlan := convertPbToCountPlanModel(req.CountPlan)
+	createdPlan, err := h.countPlanService.CreateCountPlan(ctx, internalPlan)
+	if err != nil {
+		log.Printf("Error in CreateCountPlan: %v", err)
+		return nil, err
+	}
+	return convertCountPlanModelToPb(createdPlan), nil
+}
+
+func (h *InventoryHandler) GetCountPlan(ctx context.Context, req *pb.GetCountPlanRequest) (*pb.CountPlan, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	plan, err := h.countPlanService.GetCountPlan(ctx, id)
+	if err != nil {
+		log.Printf("Error in GetCountPlan: %v", err)
+		return nil, err
+	}
+	return convertCountPlanModelToPb(plan), nil
+}
+
+func (h *InventoryHandler) ListCountPlans(ctx context.Context, req *pb.ListCountPlansRequest) (*pb.ListCountPlansResponse, error) {
+	var warehouseID uuid.UUID
+	if req.WarehouseId != "" {
+		var err error
+		warehouseID, err = uuid.Parse(req.WarehouseId)
+		if err != nil {
+			log.Printf("Error parsing UUID: %v", err)
+			return nil, err
+		}
+	}
+	plans, err := h.countPlanService.ListCountPlans(ctx, warehouseID)
+	if err != nil {
+		log.Printf("Error in ListCountPlans: %v", err)
+		return nil, err
+	}
+
+	pbPlans := make([]*pb.CountPlan, len(plans))
+	for i, plan := range plans {
+		pbPlans[i] = convertCountPlanModelToPb(plan)
+	}
+
+	return &pb.ListCountPlansResponse{CountPlans: pbPlans, Total: int32(len(pbPlans))}, nil
+}
+
+func (h *InventoryHandler) UpdateCountPlan(ctx context.Context, req *pb.UpdateCountPlanRequest) (*pb.CountPlan, error) {
+	internalPlan := convertPbToCountPlanModel(req.CountPlan)
+	updated, err := h.countPlanService.UpdateCountPlan(ctx, internalPlan)
+	if err != nil {
+		log.Printf("Error in UpdateCountPlan: %v", err)
+		return nil, err
+	}
+	return convertCountPlanModelToPb(updated), nil
+}
+
+func (h *InventoryHandler) DeleteCountPlan(ctx context.Context, req *pb.DeleteCountPlanRequest) (*emptypb.Empty, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	err = h.countPlanService.DeleteCountPlan(ctx, id)
+	if err != nil {
+		log.Printf("Error in DeleteCountPlan: %v", err)
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (h *InventoryHandler) RunCountPlan(ctx context.Context, req *pb.RunCountPlanRequest) (*pb.RunCountPlanResponse, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	countSession, err := h.countPlanService.RunCountPlan(ctx, id)
+	if err != nil {
+		log.Printf("Error in RunCountPlan: %v", err)
+		return nil, err
+	}
+	if countSession == nil {
+		return &pb.RunCountPlanResponse{}, nil
+	}
+	return &pb.RunCountPlanResponse{CountSession: convertCountSessionModelToPb(countSession)}, nil
+}
+
+func (h *InventoryHandler) ListCountPlanItems(ctx context.Context, req *pb.ListCountPlanItemsRequest) (*pb.ListCountPlanItemsResponse, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	planItems, err := h.countPlanService.ListCountPlanItems(ctx, id)
+	if err != nil {
+		log.Printf("Error in ListCountPlanItems: %v", err)
+		return nil, err
+	}
+
+	pbItems := make([]*pb.CountPlanItem, len(planItems))
+	for i, planItem := range planItems {
+		pbItems[i] = &pb.CountPlanItem{
+			InventoryItemId: planItem.InventoryItemID.String(),
+			ProductId:       planItem.ProductID.String(),
+			Class:           pb.ABCClass(planItem.Class),
+			UsageValue:      planItem.UsageValue,
+			LastCountedAt:   timestampOrNil(planItem.LastCountedAt),
+			NextCountAt:     timestampOrNil(planItem.NextCountAt),
+			CountSessionId:  planItem.CountSessionID.String(),
+		}
+	}
+
+	return &pb.ListCountPlanItemsResponse{Items: pbItems, Total: int32(len(pbItems))}, nil
+}
+
+func convertPbToCountPlanModel(pbPlan *pb.CountPlan) *model.CountPlan {
+	id, _ := uuid.Parse(pbPlan.Id)
+	warehouseID, _ := uuid.Parse(pbPlan.WarehouseId)
+	return &model.CountPlan{
+		ID:                 id,
+		WarehouseID:        warehouseID,
+		Name:               pbPlan.Name,
+		ClassAIntervalDays: int(pbPlan.ClassAIntervalDays),
+		ClassBIntervalDays: int(pbPlan.ClassBIntervalDays),
+		ClassCIntervalDays: int(pbPlan.ClassCIntervalDays),
+		Blind:              pbPlan.Blind,
+		Active:             pbPlan.Active,
+	}
+}
+
+func convertCountPlanModelToPb(plan *model.CountPlan) *pb.CountPlan {
+	return &pb.CountPlan{
+		Id:                 plan.ID.String(),
+		WarehouseId:        plan.WarehouseID.String(),
+		Name:               plan.Name,
+		ClassAIntervalDays: int32(plan.ClassAIntervalDays),
+		ClassBIntervalDays: int32(plan.ClassBIntervalDays),
+		ClassCIntervalDays: int32(plan.ClassCIntervalDays),
+		Blind:              plan.Blind,
+		Active:             plan.Active,
+		CreatedAt:          timestamppb.New(plan.CreatedAt),
+		UpdatedAt:          timestamppb.New(plan.UpdatedAt),
+		LastRunAt:          timestampOrNil(plan.LastRunAt),
+	}
+}
@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"inventoryService/model"
+	pb "inventoryService/proto/inventory"
+	"log"
+)
+
+func (h *InventoryHandler) CreateCountSession(ctx context.Context, req *pb.CreateCountSessionRequest) (*pb.CountSession, error) {
+	internalSession := convertPbToCountSessionModel(req.CountSession)
+	itemIDs := make([]uuid.UUID, len(req.InventoryItemIds))
+	for i, s := range req.InventoryItemIds {
+		itemID, err := uuid.Parse(s)
+		if err != nil {
+			log.Printf("Error parsing UUID: %v", err)
+			return nil, err
+		}
+		itemIDs[i] = itemID
+	}
+	createdSession, err := h.countSessionService.CreateCountSession(ctx, internalSession, itemIDs)
+	if err != nil {
+		log.Printf("Error in CreateCountSession: %v", err)
+		return nil, err
+	}
+	return convertCountSessionModelToPb(createdSession), nil
+}
+
+func (h *InventoryHandler) GetCountSession(ctx context.Context, req *pb.GetCountSessionRequest) (*pb.CountSession, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	countSession, err := h.countSessionService.GetCountSession(ctx, id)
+	if err != nil {
+		log.Printf("Error in GetCountSession: %v", err)
+		return nil, err
+	}
+	return convertCountSessionModelToPb(countSession), nil
+}
+
+func (h *InventoryHandler) ListCountSessions(ctx context.Context, req *pb.ListCountSessionsRequest) (*pb.ListCountSessionsResponse, error) {
+	var warehouseID uuid.UUID
+	if req.WarehouseId != "" {
+		var err error
+		warehouseID, err = uuid.Parse(req.WarehouseId)
+		if err != nil {
+			log.Printf("Error parsing UUID: %v", err)
+			return nil, err
+		}
+	}
+	statuses := make([]model.CountSessionStatus, len(req.Statuses))
+	for i, s := range req.Statuses {
+		statuses[i] = model.CountSessionStatus(s)
+	}
+	countSessions, err := h.countSessionService.ListCountSessions(ctx, warehouseID, statuses)
+	if err != nil {
+		log.Printf("Error in ListCountSessions: %v", err)
+		return nil, err
+	}
+
+	pbSessions := make([]*pb.CountSession, len(countSessions))
+	for i, countSession := range countSessions {
+		pbSessions[i] = convertCountSessionModelToPb(countSession)
+	}
+
+	return &pb.ListCountSessionsResponse{CountSessions: pbSessions, Total: int32(len(pbSessions))}, nil
+}
+
+func (h *InventoryHandler) RecordCounts(ctx context.Context, req *pb.RecordCountsRequest) (*pb.CountSession, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	counts := make([]*model.CountLine, len(req.Counts))
+	for i, pbCount := range req.Counts {
+		itemID, err := uuid.Parse(pbCount.InventoryItemId)
+		if err != nil {
+			log.Printf("Error parsing UUID: %v", err)
+			return nil, err
+		}
+		counted, err := quantityFromPb("counted quantity", pbCount.CountedQuantity)
+		if err != nil {
+			return nil, err
+		}
+		counts[i] = &model.CountLine{InventoryItemID: itemID, CountedQuantity: counted}
+	}
+	countSession, err := h.countSessionService.RecordCounts(ctx, id, counts)
+	if err != nil {
+		log.Printf("Error in RecordCounts: %v", err)
+		return nil, err
+	}
+	return convertCountSessionModelToPb(countSession), nil
+}
+
+func (h *InventoryHandler) SubmitCountSession(ctx context.Context, req *pb.SubmitCountSessionRequest) (*pb.CountSession, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	countSession, err := h.countSessionService.SubmitCountSession(ctx, id)
+	if err != nil {
+		log.Printf("Error in SubmitCountSession: %v", err)
+		return nil, err
+	}
+	return convertCountSessionModelToPb(countSession), nil
+}
+
+func (h *InventoryHandler) ApproveCountSession(ctx context.Context, req *pb.ApproveCountSessionRequest) (*pb.CountSession, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	countSession, err := h.countSessionService.ApproveCountSession(ctx, id, req.Notes)
+	if err != nil {
+		log.Printf("Error in ApproveCountSession: %v", err)
+		return nil, err
+	}
+	return convertCountSessionModelToPb(countSession), nil
+}
+
+func (h *InventoryHandler) CancelCountSession(ctx context.Context, req *pb.CancelCountSessionRequest) (*pb.CountSession, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	countSession, err := h.countSessionService.CancelCountSession(ctx, id)
+	if err != nil {
+		log.Printf("Error in CancelCountSession: %v", err)
+		return nil, err
+	}
+	return convertCountSessionModelToPb(countSession), nil
+}
+
+func convertPbToCountSessionModel(pbSession *pb.CountSession) *model.CountSession {
+	id, _ := uuid.Parse(pbSession.Id)
+	warehouseID, _ := uuid.Parse(pbSession.WarehouseId)
+	categoryID, _ := uuid.Parse(pbSession.CategoryId)
+	return &model.CountSession{
+		ID:          id,
+		WarehouseID: warehouseID,
+		CategoryID:  categoryID,
+		Blind:       pbSession.Blind,
+		Notes:       pbSession.Notes,
+	}
+}
+
+// convertCountSessionModelToPb leaves out expected quantities and variances
+// while a blind session is still being counted.
+func convertCountSessionModelToPb(countSession *model.CountSession) *pb.CountSession {
+	hidden := countSession.Blind && countSession.Status == model.CountSessionOpen
+	lines := make([]*pb.CountLine, len(countSession.Lines))
+	for i, line := range countSession.Lines {
+		lines[i] = &pb.CountLine{
+			InventoryItemId:   line.InventoryItemID.String(),
+			ProductId:         line.ProductID.String(),
+			CountedQuantity:   line.CountedQuantity.String(),
+			Counted:           line.Counted,
+			CountedBy:         line.CountedBy,
+			CountedAt:         timestampOrNil(line.CountedAt),
+			StockAdjustmentId: line.StockAdjustmentID.String(),
+		}
+		if !hidden {
+			lines[i].ExpectedQuantity = line.ExpectedQuantity.String()
+			lines[i].Variance = line.Variance().String()
+		}
+	}
+	return &pb.CountSession{
+		Id:          countSession.ID.String(),
+		WarehouseId: countSession.WarehouseID.String(),
+		CategoryId:  countSession.CategoryID.String(),
+		PlanId:      countSession.PlanID.String(),
+		Blind:       countSession.Blind,
+		Status:      pb.CountSessionStatus(countSession.Status),
+		Notes:       countSession.Notes,
+		Lines:       lines,
+		CreatedBy:   countSession.CreatedBy,
+		CreatedAt:   timestamppb.New(countSession.CreatedAt),
+		UpdatedAt:   timestamppb.New(countSession.UpdatedAt),
+		SubmittedAt: timestampOrNil(countSession.SubmittedAt),
+		ApprovedAt:  timestampOrNil(countSession.ApprovedAt),
+	}
+}
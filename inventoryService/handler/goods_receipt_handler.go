@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"inventoryService/model"
+	pb "inventoryService/proto/inventory"
+	"log"
+)
+
+func (h *InventoryHandler) ReceiveGoods(ctx context.Context, req *pb.ReceiveGoodsRequest) (*pb.ReceiveGoodsResponse, error) {
+	purchaseOrderID, err := uuid.Parse(req.PurchaseOrderId)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	receipt := &model.GoodsReceipt{
+		PurchaseOrderID: purchaseOrderID,
+		ReceivedAt:      timeFromPb(req.ReceivedAt),
+		Notes:           req.Notes,
+	}
+	if req.ReceiptId != "" {
+		receipt.ID, err = uuid.Parse(req.ReceiptId)
+		if err != nil {
+			log.Printf("Error parsing UUID: %v", err)
+			return nil, err
+		}
+	}
+	for _, pbLine := range req.Lines {
+		lineID, err := uuid.Parse(pbLine.PurchaseOrderLineId)
+		if err != nil {
+			log.Printf("Error parsing UUID: %v", err)
+			return nil, err
+		}
+		received, err := quantityFromPb("received quantity", pbLine.ReceivedQuantity)
+		if err != nil {
+			return nil, err
+		}
+		damaged, err := quantityFromPb("damaged quantity", pbLine.DamagedQuantity)
+		if err != nil {
+			return nil, err
+		}
+		receipt.Lines = append(receipt.Lines, &model.GoodsReceiptLine{
+			PurchaseOrderLineID: lineID,
+			ReceivedQuantity:    received,
+			DamagedQuantity:     damaged,
+			Notes:               pbLine.Notes,
+			SerialNumbers:       pbLine.SerialNumbers,
+		})
+	}
+	createdReceipt, order, err := h.goodsReceiptService.ReceiveGoods(ctx, receipt)
+	if err != nil {
+		log.Printf("Error in ReceiveGoods: %v", err)
+		return nil, err
+	}
+	return &pb.ReceiveGoodsResponse{
+		Receipt:       convertGoodsReceiptModelToPb(createdReceipt),
+		PurchaseOrder: convertPurchaseOrderModelToPb(order),
+	}, nil
+}
+
+func (h *InventoryHandler) GetGoodsReceipt(ctx context.Context, req *pb.GetGoodsReceiptRequest) (*pb.GoodsReceipt, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	receipt, err := h.goodsReceiptService.GetGoodsReceipt(ctx, id)
+	if err != nil {
+		log.Printf("Error in GetGoodsReceipt: %v", err)
+		return nil, err
+	}
+	return convertGoodsReceiptModelToPb(receipt), nil
+}
+
+func (h *InventoryHandler) ListGoodsReceipts(ctx context.Context, req *pb.ListGoodsReceiptsRequest) (*pb.ListGoodsReceiptsResponse, error) {
+	var purchaseOrderID uuid.UUID
+	if req.PurchaseOrderId != "" {
+		var err error
+		purchaseOrderID, err = uuid.Parse(req.PurchaseOrderId)
+		if err != nil {
+			log.Printf("Error parsing UUID: %v", err)
+			return nil, err
+		}
+	}
+	receipts, err := h.goodsReceiptService.ListGoodsReceipts(ctx, purchaseOrderID)
+	if err != nil {
+		log.Printf("Error in ListGoodsReceipts: %v", err)
+		return nil, err
+	}
+
+	pbReceipts := make([]*pb.GoodsReceipt, len(receipts))
+	for i, receipt := range receipts {
+		pbReceipts[i] = convertGoodsReceiptModelToPb(receipt)
+	}
+
+	return &pb.ListGoodsReceiptsResponse{Receipts: pbReceipts, Total: int32(len(pbReceipts))}, nil
+}
+
+func convertGoodsReceiptModelToPb(receipt *model.GoodsReceipt) *pb.GoodsReceipt {
+	lines := make([]*pb.GoodsReceiptLine, len(receipt.Lines))
+	for i, line := range receipt.Lines {
+		lines[i] = &pb.GoodsReceiptLine{
+			PurchaseOrderLineId: line.PurchaseOrderLineID.String(),
+			ProductId:           line.ProductID.String(),
+			WarehouseId:         line.WarehouseID.String(),
+			ReceivedQuantity:    line.ReceivedQuantity.String(),
+			DamagedQuantity:     line.DamagedQuantity.String(),
+			AcceptedQuantity:    line.AcceptedQuantity.String(),
+			Variance:            line.Variance.String(),
+			StockMovementId:     line.StockMovementID.String(),
+			Notes:               line.Notes,
+			SerialNumbers:       line.SerialNumbers,
+		}
+	}
+	return &pb.GoodsReceipt{
+		Id:              receipt.ID.String(),
+		PurchaseOrderId: receipt.PurchaseOrderID.String(),
+		SupplierId:      receipt.SupplierID.String(),
+		ReceivedAt:      timestamppb.New(receipt.ReceivedAt),
+		Notes:           receipt.Notes,
+		Lines:           lines,
+	}
+}
@@ -3,6 +3,8 @@ package handler
 import (
 	"context"
 	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"inventoryService/model"
@@ -13,12 +15,32 @@ import (
 
 type InventoryHandler struct {
 	pb.UnimplementedInventoryServiceServer
-	productService       *service.ProductService
-	categoryService      *service.CategoryService
-	warehouseService     *service.WarehouseService
-	inventoryItemService *service.InventoryItemService
-	stockMovementService *service.StockMovementService
-	supplierService      *service.SupplierService
+	productService             *service.ProductService
+	categoryService            *service.CategoryService
+	warehouseService           *service.WarehouseService
+	inventoryItemService       *service.InventoryItemService
+	stockMovementService       *service.StockMovementService
+	supplierService            *service.SupplierService
+	auditService               *service.AuditService
+	replenishmentService       *service.ReplenishmentService
+	purchaseOrderService       *service.PurchaseOrderService
+	goodsReceiptService        *service.GoodsReceiptService
+	supplierProductService     *service.SupplierProductService
+	supplierScorecardService   *service.SupplierScorecardService
+	salesOrderService          *service.SalesOrderService
+	returnAuthorizationService *service.ReturnAuthorizationService
+	transferOrderService       *service.TransferOrderService
+	stockAdjustmentService     *service.StockAdjustmentService
+	countSessionService        *service.CountSessionService
+	countPlanService           *service.CountPlanService
+	lotService                 *service.LotService
+	serialService              *service.SerialService
+	locationService            *service.LocationService
+	capacityService            *service.CapacityService
+	valuationService           *service.ValuationService
+	priceListService           *service.PriceListService
+	exchangeRateService        *service.ExchangeRateService
+	priceChangeService         *service.PriceChangeService
 }
 
 func NewInventoryHandler(
@@ -28,19 +50,62 @@ func NewInventoryHandler(
 	inventoryItemService *service.InventoryItemService,
 	stockMovementService *service.StockMovementService,
 	supplierService *service.SupplierService,
+	auditService *service.AuditService,
+	replenishmentService *service.ReplenishmentService,
+	purchaseOrderService *service.PurchaseOrderService,
+	goodsReceiptService *service.GoodsReceiptService,
+	supplierProductService *service.SupplierProductService,
+	supplierScorecardService *service.SupplierScorecardService,
+	salesOrderService *service.SalesOrderService,
+	returnAuthorizationService *service.ReturnAuthorizationService,
+	transferOrderService *service.TransferOrderService,
+	stockAdjustmentService *service.StockAdjustmentService,
+	countSessionService *service.CountSessionService,
+	countPlanService *service.CountPlanService,
+	lotService *service.LotService,
+	serialService *service.SerialService,
+	locationService *service.LocationService,
+	capacityService *service.CapacityService,
+	valuationService *service.ValuationService,
+	priceListService *service.PriceListService,
+	exchangeRateService *service.ExchangeRateService,
+	priceChangeService *service.PriceChangeService,
 ) *InventoryHandler {
 	return &InventoryHandler{
-		productService:       productService,
-		categoryService:      categoryService,
-		warehouseService:     warehouseService,
-		inventoryItemService: inventoryItemService,
-		stockMovementService: stockMovementService,
-		supplierService:      supplierService,
+		productService:             productService,
+		categoryService:            categoryService,
+		warehouseService:           warehouseService,
+		inventoryItemService:       inventoryItemService,
+		stockMovementService:       stockMovementService,
+		supplierService:            supplierService,
+		auditService:               auditService,
+		replenishmentService:       replenishmentService,
+		purchaseOrderService:       purchaseOrderService,
+		goodsReceiptService:        goodsReceiptService,
+		supplierProductService:     supplierProductService,
+		supplierScorecardService:   supplierScorecardService,
+		salesOrderService:          salesOrderService,
+		returnAuthorizationService: returnAuthorizationService,
+		transferOrderService:       transferOrderService,
+		stockAdjustmentService:     stockAdjustmentService,
+		countSessionService:        countSessionService,
+		countPlanService:           countPlanService,
+		lotService:                 lotService,
+		serialService:              serialService,
+		locationService:            locationService,
+		capacityService:            capacityService,
+		valuationService:           valuationService,
+		priceListService:           priceListService,
+		exchangeRateService:        exchangeRateService,
+		priceChangeService:         priceChangeService,
 	}
 }
 
 func (h *InventoryHandler) CreateProduct(ctx context.Context, req *pb.CreateProductRequest) (*pb.Product, error) {
-	internalProduct := convertPbToProductModel(req.Product)
+	internalProduct, err := convertPbToProductModel(req.Product)
+	if err != nil {
+		return nil, err
+	}
 	createdProduct, err := h.productService.CreateProduct(ctx, internalProduct)
 	if err != nil {
 		log.Printf("Error in CreateProduct: %v", err)
@@ -64,8 +129,11 @@ func (h *InventoryHandler) GetProduct(ctx context.Context, req *pb.GetProductReq
 }
 
 func (h *InventoryHandler) UpdateProduct(ctx context.Context, req *pb.UpdateProductRequest) (*pb.Product, error) {
-	internalProduct := convertPbToProductModel(req.Product)
-	err := h.productService.UpdateProduct(ctx, internalProduct)
+	internalProduct, err := convertPbToProductModel(req.Product)
+	if err != nil {
+		return nil, err
+	}
+	err = h.productService.UpdateProduct(ctx, internalProduct)
 	if err != nil {
 		log.Printf("Error in UpdateProduct: %v", err)
 		return nil, err
@@ -102,31 +170,93 @@ func (h *InventoryHandler) ListProducts(ctx context.Context, req *pb.ListProduct
 	return &pb.ListProductsResponse{Products: pbProducts}, nil
 }
 
-func convertPbToProductModel(pbProduct *pb.Product) *model.Product {
+func convertPbToProductModel(pbProduct *pb.Product) (*model.Product, error) {
 	id, _ := uuid.Parse(pbProduct.Id)
 	categoryID, _ := uuid.Parse(pbProduct.CategoryId)
+	units := make([]*model.UnitOfMeasure, len(pbProduct.Units))
+	for i, pbUnit := range pbProduct.Units {
+		factor, err := quantityFromPb("unit factor", pbUnit.Factor)
+		if err != nil {
+			return nil, err
+		}
+		units[i] = &model.UnitOfMeasure{Name: pbUnit.Name, Factor: factor}
+	}
 
 	return &model.Product{
-		ID:          id,
-		Name:        pbProduct.Name,
-		Description: pbProduct.Description,
-		CategoryID:  categoryID,
-		Price:       pbProduct.Price,
-		SKU:         pbProduct.Sku,
-	}
+		ID:                id,
+		Name:              pbProduct.Name,
+		Description:       pbProduct.Description,
+		CategoryID:        categoryID,
+		Price:             convertPbToMoneyModel(pbProduct.Price),
+		SKU:               pbProduct.Sku,
+		Serialized:        pbProduct.Serialized,
+		Length:            pbProduct.Length,
+		Width:             pbProduct.Width,
+		Height:            pbProduct.Height,
+		Weight:            pbProduct.Weight,
+		CostingMethod:     model.CostingMethod(pbProduct.CostingMethod),
+		BaseUnit:          pbProduct.BaseUnit,
+		Units:             units,
+		QuantityPrecision: int(pbProduct.QuantityPrecision),
+	}, nil
 }
 
 func convertProductModelToPb(product *model.Product) *pb.Product {
+	pbUnits := make([]*pb.UnitOfMeasure, len(product.Units))
+	for i, unit := range product.Units {
+		pbUnits[i] = &pb.UnitOfMeasure{Name: unit.Name, Factor: unit.Factor.String()}
+	}
 	return &pb.Product{
-		Id:          product.ID.String(),
-		Name:        product.Name,
-		Description: product.Description,
-		CategoryId:  product.CategoryID.String(),
-		Price:       product.Price,
-		Sku:         product.SKU,
+		Id:                product.ID.String(),
+		Name:              product.Name,
+		Description:       product.Description,
+		CategoryId:        product.CategoryID.String(),
+		Price:             convertMoneyModelToPb(product.Price),
+		Sku:               product.SKU,
+		Serialized:        product.Serialized,
+		Length:            product.Length,
+		Width:             product.Width,
+		Height:            product.Height,
+		Weight:            product.Weight,
+		CostingMethod:     pb.CostingMethod(product.CostingMethod),
+		BaseUnit:          product.BaseUnit,
+		Units:             pbUnits,
+		QuantityPrecision: int32(product.QuantityPrecision),
+	}
+}
+
+func convertPbToMoneyModel(pbMoney *pb.Money) model.Money {
+	if pbMoney == nil {
+		return model.Money{}
+	}
+	return model.Money{
+		CurrencyCode: pbMoney.CurrencyCode,
+		Units:        pbMoney.Units,
+		Nanos:        pbMoney.Nanos,
 	}
 }
 
+func convertMoneyModelToPb(money model.Money) *pb.Money {
+	return &pb.Money{
+		CurrencyCode: money.CurrencyCode,
+		Units:        money.Units,
+		Nanos:        money.Nanos,
+	}
+}
+
+// quantityFromPb reads a quantity sent as a decimal string, such as "12.5";
+// an empty string is zero.
+func quantityFromPb(field, value string) (model.Quantity, error) {
+	if value == "" {
+		return 0, nil
+	}
+	quantity, err := model.ParseQuantity(value)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "%s: %v", field, err)
+	}
+	return quantity, nil
+}
+
 func (h *InventoryHandler) CreateCategory(ctx context.Context, req *pb.CreateCategoryRequest) (*pb.Category, error) {
 	internalCategory := convertPbToCategoryModel(req.Category)
 	createdCategory, err := h.categoryService.CreateCategory(ctx, internalCategory)
@@ -208,7 +338,10 @@ func convertCategoryModelToPb(category *model.Category) *pb.Category {
 }
 
 func (h *InventoryHandler) CreateInventoryItem(ctx context.Context, req *pb.CreateInventoryItemRequest) (*pb.InventoryItem, error) {
-	internalItem := convertPbToInventoryItemModel(req.InventoryItem)
+	internalItem, err := convertPbToInventoryItemModel(req.InventoryItem)
+	if err != nil {
+		return nil, err
+	}
 	createdItem, err := h.inventoryItemService.CreateInventoryItem(ctx, internalItem)
 	if err != nil {
 		log.Printf("Error in CreateInventoryItem: %v", err)
@@ -232,8 +365,11 @@ func (h *InventoryHandler) GetInventoryItem(ctx context.Context, req *pb.GetInve
 }
 
 func (h *InventoryHandler) UpdateInventoryItem(ctx context.Context, req *pb.UpdateInventoryItemRequest) (*pb.InventoryItem, error) {
-	internalItem := convertPbToInventoryItemModel(req.InventoryItem)
-	err := h.inventoryItemService.UpdateInventoryItem(ctx, internalItem)
+	internalItem, err := convertPbToInventoryItemModel(req.InventoryItem)
+	if err != nil {
+		return nil, err
+	}
+	err = h.inventoryItemService.UpdateInventoryItem(ctx, internalItem)
 	if err != nil {
 		log.Printf("Error in UpdateInventoryItem: %v", err)
 		return nil, err
@@ -270,34 +406,52 @@ func (h *InventoryHandler) ListInventoryItems(ctx context.Context, req *pb.ListI
 	return &pb.ListInventoryItemsResponse{InventoryItems: pbItems}, nil
 }
 
-func convertPbToInventoryItemModel(pbItem *pb.InventoryItem) *model.InventoryItem {
+func convertPbToInventoryItemModel(pbItem *pb.InventoryItem) (*model.InventoryItem, error) {
 	id, _ := uuid.Parse(pbItem.Id)
 	productID, _ := uuid.Parse(pbItem.ProductId)
 	warehouseID, _ := uuid.Parse(pbItem.WarehouseId)
+	quantity, err := quantityFromPb("quantity", pbItem.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	reorderLevel, err := quantityFromPb("reorder level", pbItem.ReorderLevel)
+	if err != nil {
+		return nil, err
+	}
+	reorderQuantity, err := quantityFromPb("reorder quantity", pbItem.ReorderQuantity)
+	if err != nil {
+		return nil, err
+	}
 
 	return &model.InventoryItem{
 		ID:              id,
 		ProductID:       productID,
 		WarehouseID:     warehouseID,
-		Quantity:        int(pbItem.Quantity),
-		ReorderLevel:    int(pbItem.ReorderLevel),
-		ReorderQuantity: int(pbItem.ReorderQuantity),
-	}
+		Quantity:        quantity,
+		ReorderLevel:    reorderLevel,
+		ReorderQuantity: reorderQuantity,
+	}, nil
 }
 
 func convertInventoryItemModelToPb(item *model.InventoryItem) *pb.InventoryItem {
 	return &pb.InventoryItem{
-		Id:              item.ID.String(),
-		ProductId:       item.ProductID.String(),
-		WarehouseId:     item.WarehouseID.String(),
-		Quantity:        int32(item.Quantity),
-		ReorderLevel:    int32(item.ReorderLevel),
-		ReorderQuantity: int32(item.ReorderQuantity),
+		Id:                  item.ID.String(),
+		ProductId:           item.ProductID.String(),
+		WarehouseId:         item.WarehouseID.String(),
+		Quantity:            item.Quantity.String(),
+		ReorderLevel:        item.ReorderLevel.String(),
+		ReorderQuantity:     item.ReorderQuantity.String(),
+		ReservedQuantity:    item.ReservedQuantity.String(),
+		AvailableQuantity:   item.AvailableQuantity().String(),
+		QuarantinedQuantity: item.QuarantinedQuantity.String(),
 	}
 }
 
 func (h *InventoryHandler) CreateStockMovement(ctx context.Context, req *pb.CreateStockMovementRequest) (*pb.StockMovement, error) {
-	internalMovement := convertPbToStockMovementModel(req.StockMovement)
+	internalMovement, err := convertPbToStockMovementModel(req.StockMovement)
+	if err != nil {
+		return nil, err
+	}
 	createdMovement, err := h.stockMovementService.CreateStockMovement(ctx, internalMovement)
 	if err != nil {
 		log.Printf("Error in CreateStockMovement: %v", err)
@@ -321,8 +475,11 @@ func (h *InventoryHandler) GetStockMovement(ctx context.Context, req *pb.GetStoc
 }
 
 func (h *InventoryHandler) UpdateStockMovement(ctx context.Context, req *pb.UpdateStockMovementRequest) (*pb.StockMovement, error) {
-	internalMovement := convertPbToStockMovementModel(req.StockMovement)
-	err := h.stockMovementService.UpdateStockMovement(ctx, internalMovement)
+	internalMovement, err := convertPbToStockMovementModel(req.StockMovement)
+	if err != nil {
+		return nil, err
+	}
+	err = h.stockMovementService.UpdateStockMovement(ctx, internalMovement)
 	if err != nil {
 		log.Printf("Error in UpdateStockMovement: %v", err)
 		return nil, err
@@ -359,20 +516,38 @@ func (h *InventoryHandler) ListStockMovements(ctx context.Context, req *pb.ListS
 	return &pb.ListStockMovementsResponse{StockMovements: pbMovements}, nil
 }
 
-func convertPbToStockMovementModel(pbMovement *pb.StockMovement) *model.StockMovement {
+func convertPbToStockMovementModel(pbMovement *pb.StockMovement) (*model.StockMovement, error) {
 	id, _ := uuid.Parse(pbMovement.Id)
 	inventoryItemID, _ := uuid.Parse(pbMovement.InventoryItemId)
 	sourceWarehouseID, _ := uuid.Parse(pbMovement.SourceWarehouseId)
 	destinationWarehouseID, _ := uuid.Parse(pbMovement.DestinationWarehouseId)
+	lotID, _ := uuid.Parse(pbMovement.LotId)
+	sourceBinID, _ := uuid.Parse(pbMovement.SourceBinId)
+	destinationBinID, _ := uuid.Parse(pbMovement.DestinationBinId)
+	quantity, err := quantityFromPb("quantity", pbMovement.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	unitQuantity, err := quantityFromPb("unit quantity", pbMovement.UnitQuantity)
+	if err != nil {
+		return nil, err
+	}
 	return &model.StockMovement{
 		ID:                     id,
 		InventoryItemID:        inventoryItemID,
 		Type:                   model.StockMovementType(pbMovement.Type),
-		Quantity:               int(pbMovement.Quantity),
+		Quantity:               quantity,
 		Date:                   pbMovement.Date.AsTime(),
 		SourceWarehouseID:      sourceWarehouseID,
 		DestinationWarehouseID: destinationWarehouseID,
-	}
+		LotID:                  lotID,
+		SerialNumbers:          pbMovement.SerialNumbers,
+		SourceBinID:            sourceBinID,
+		DestinationBinID:       destinationBinID,
+		UnitCost:               convertPbToMoneyModel(pbMovement.UnitCost),
+		Unit:                   pbMovement.Unit,
+		UnitQuantity:           unitQuantity,
+	}, nil
 }
 
 func convertStockMovementModelToPb(movement *model.StockMovement) *pb.StockMovement {
@@ -380,10 +555,19 @@ func convertStockMovementModelToPb(movement *model.StockMovement) *pb.StockMovem
 		Id:                     movement.ID.String(),
 		InventoryItemId:        movement.InventoryItemID.String(),
 		Type:                   pb.StockMovementType(movement.Type),
-		Quantity:               int32(movement.Quantity),
+		Quantity:               movement.Quantity.String(),
 		Date:                   timestamppb.New(movement.Date),
 		SourceWarehouseId:      movement.SourceWarehouseID.String(),
 		DestinationWarehouseId: movement.DestinationWarehouseID.String(),
+		LotId:                  movement.LotID.String(),
+		SerialNumbers:          movement.SerialNumbers,
+		SourceBinId:            movement.SourceBinID.String(),
+		DestinationBinId:       movement.DestinationBinID.String(),
+		UnitCost:               convertMoneyModelToPb(movement.UnitCost),
+		Cost:                   convertMoneyModelToPb(movement.Cost),
+		Unit:                   movement.Unit,
+		UnitQuantity:           movement.UnitQuantity.String(),
+		Posted:                 movement.Posted,
 	}
 }
 
@@ -468,7 +652,10 @@ func convertSupplierModelToPb(supplier *model.Supplier) *pb.Supplier {
 }
 
 func (h *InventoryHandler) CreateWarehouse(ctx context.Context, req *pb.CreateWarehouseRequest) (*pb.Warehouse, error) {
-	internalWarehouse := convertPbToWarehouseModel(req.Warehouse)
+	internalWarehouse, err := convertPbToWarehouseModel(req.Warehouse)
+	if err != nil {
+		return nil, err
+	}
 	createdWarehouse, err := h.warehouseService.CreateWarehouse(ctx, internalWarehouse)
 	if err != nil {
 		log.Printf("Error in CreateWarehouse: %v", err)
@@ -492,8 +679,11 @@ func (h *InventoryHandler) GetWarehouse(ctx context.Context, req *pb.GetWarehous
 }
 
 func (h *InventoryHandler) UpdateWarehouse(ctx context.Context, req *pb.UpdateWarehouseRequest) (*pb.Warehouse, error) {
-	internalWarehouse := convertPbToWarehouseModel(req.Warehouse)
-	err := h.warehouseService.UpdateWarehouse(ctx, internalWarehouse)
+	internalWarehouse, err := convertPbToWarehouseModel(req.Warehouse)
+	if err != nil {
+		return nil, err
+	}
+	err = h.warehouseService.UpdateWarehouse(ctx, internalWarehouse)
 	if err != nil {
 		log.Printf("Error in UpdateWarehouse: %v", err)
 		return nil, err
@@ -530,19 +720,27 @@ func (h *InventoryHandler) ListWarehouses(ctx context.Context, req *pb.ListWareh
 	return &pb.ListWarehousesResponse{Warehouses: pbWarehouses}, nil
 }
 
-func convertPbToWarehouseModel(pbWarehouse *pb.Warehouse) *model.Warehouse {
+func convertPbToWarehouseModel(pbWarehouse *pb.Warehouse) (*model.Warehouse, error) {
 	id, _ := uuid.Parse(pbWarehouse.Id)
-	return &model.Warehouse{
-		ID:       id,
-		Name:     pbWarehouse.Name,
-		Location: pbWarehouse.Location,
+	capacity, err := convertPbToCapacityModel(pbWarehouse.Capacity)
+	if err != nil {
+		return nil, err
 	}
+	return &model.Warehouse{
+		ID:             id,
+		Name:           pbWarehouse.Name,
+		Location:       pbWarehouse.Location,
+		Capacity:       capacity,
+		CapacityPolicy: model.CapacityPolicy(pbWarehouse.CapacityPolicy),
+	}, nil
 }
 
 func convertWarehouseModelToPb(warehouse *model.Warehouse) *pb.Warehouse {
 	return &pb.Warehouse{
-		Id:       warehouse.ID.String(),
-		Name:     warehouse.Name,
-		Location: warehouse.Location,
+		Id:             warehouse.ID.String(),
+		Name:           warehouse.Name,
+		Location:       warehouse.Location,
+		Capacity:       convertCapacityModelToPb(warehouse.Capacity),
+		CapacityPolicy: pb.CapacityPolicy(warehouse.CapacityPolicy),
 	}
 }
@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"inventoryService/model"
+	pb "inventoryService/proto/inventory"
+	"log"
+)
+
+func (h *InventoryHandler) CreateLocation(ctx context.Context, req *pb.CreateLocationRequest) (*pb.Location, error) {
+	internalLocation, err := convertPbToLocationModel(req.Location)
+	if err != nil {
+		return nil, err
+	}
+	createdLocation, err := h.locationService.CreateLocation(ctx, internalLocation)
+	if err != nil {
+		log.Printf("Error in CreateLocation: %v", err)
+		return nil, err
+	}
+	return convertLocationModelToPb(createdLocation), nil
+}
+
+func (h *InventoryHandler) GetLocation(ctx context.Context, req *pb.GetLocationRequest) (*pb.Location, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	location, err := h.locationService.GetLocation(ctx, id)
+	if err != nil {
+		log.Printf("Error in GetLocation: %v", err)
+		return nil, err
+	}
+	return convertLocationModelToPb(location), nil
+}
+
+func (h *InventoryHandler) ListLocations(ctx context.Context, req *pb.ListLocationsRequest) (*pb.ListLocationsResponse, error) {
+	warehouseID, err := uuid.Parse(req.WarehouseId)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	var parentID uuid.UUID
+	if req.ParentId != "" {
+		parentID, err = uuid.Parse(req.ParentId)
+		if err != nil {
+			log.Printf("Error parsing UUID: %v", err)
+			return nil, err
+		}
+	}
+	locations, err := h.locationService.ListLocations(ctx, warehouseID, parentID)
+	if err != nil {
+		log.Printf("Error in ListLocations: %v", err)
+		return nil, err
+	}
+
+	pbLocations := make([]*pb.Location, len(locations))
+	for i, location := range locations {
+		pbLocations[i] = convertLocationModelToPb(location)
+	}
+
+	return &pb.ListLocationsResponse{Locations: pbLocations, Total: int32(len(pbLocations))}, nil
+}
+
+func (h *InventoryHandler) UpdateLocation(ctx context.Context, req *pb.UpdateLocationRequest) (*pb.Location, error) {
+	internalLocation, err := convertPbToLocationModel(req.Location)
+	if err != nil {
+		return nil, err
+	}
+	updatedLocation, err := h.locationService.UpdateLocation(ctx, internalLocation)
+	if err != nil {
+		log.Printf("Error in UpdateLocation: %v", err)
+		return nil, err
+	}
+	return convertLocationModelToPb(updatedLocation), nil
+}
+
+func (h *InventoryHandler) DeleteLocation(ctx context.Context, req *pb.DeleteLocationRequest) (*emptypb.Empty, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	err = h.locationService.DeleteLocation(ctx, id)
+	if err != nil {
+		log.Printf("Error in DeleteLocation: %v", err)
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (h *InventoryHandler) ListBinStock(ctx context.Context, req *pb.ListBinStockRequest) (*pb.ListBinStockResponse, error) {
+	itemID, err := uuid.Parse(req.InventoryItemId)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	bins, unplaced, err := h.locationService.ListBinStock(ctx, itemID)
+	if err != nil {
+		log.Printf("Error in ListBinStock: %v", err)
+		return nil, err
+	}
+
+	pbBins := make([]*pb.BinStock, len(bins))
+	for i, bin := range bins {
+		pbBins[i] = &pb.BinStock{
+			InventoryItemId: bin.InventoryItemID.String(),
+			LocationId:      bin.LocationID.String(),
+			WarehouseId:     bin.WarehouseID.String(),
+			Quantity:        bin.Quantity.String(),
+		}
+	}
+
+	return &pb.ListBinStockResponse{Bins: pbBins, Total: int32(len(pbBins)), UnplacedQuantity: unplaced.String()}, nil
+}
+
+func (h *InventoryHandler) MoveBinStock(ctx context.Context, req *pb.MoveBinStockRequest) (*pb.StockMovement, error) {
+	itemID, err := uuid.Parse(req.InventoryItemId)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	var binIDs [2]uuid.UUID
+	for i, s := range []string{req.SourceBinId, req.DestinationBinId} {
+		if s == "" {
+			continue
+		}
+		binIDs[i], err = uuid.Parse(s)
+		if err != nil {
+			log.Printf("Error parsing UUID: %v", err)
+			return nil, err
+		}
+	}
+	quantity, err := quantityFromPb("quantity", req.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	movement, err := h.locationService.MoveBinStock(ctx, itemID, binIDs[0], binIDs[1], quantity)
+	if err != nil {
+		log.Printf("Error in MoveBinStock: %v", err)
+		return nil, err
+	}
+	return convertStockMovementModelToPb(movement), nil
+}
+
+func (h *InventoryHandler) SuggestPutaway(ctx context.Context, req *pb.SuggestPutawayRequest) (*pb.SuggestPutawayResponse, error) {
+	productID, err := uuid.Parse(req.ProductId)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	warehouseID, err := uuid.Parse(req.WarehouseId)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	quantity, err := quantityFromPb("quantity", req.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	suggestions, err := h.locationService.SuggestPutaway(ctx, productID, warehouseID, quantity, int(req.Limit))
+	if err != nil {
+		log.Printf("Error in SuggestPutaway: %v", err)
+		return nil, err
+	}
+
+	pbSuggestions := make([]*pb.PutawaySuggestion, len(suggestions))
+	for i, suggestion := range suggestions {
+		pbSuggestions[i] = &pb.PutawaySuggestion{
+			Location: convertLocationModelToPb(suggestion.Location),
+			Quantity: suggestion.Quantity.String(),
+			Reason:   suggestion.Reason,
+		}
+	}
+
+	return &pb.SuggestPutawayResponse{Suggestions: pbSuggestions, Total: int32(len(pbSuggestions))}, nil
+}
+
+func convertPbToLocationModel(pbLocation *pb.Location) (*model.Location, error) {
+	id, _ := uuid.Parse(pbLocation.Id)
+	warehouseID, _ := uuid.Parse(pbLocation.WarehouseId)
+	parentID, _ := uuid.Parse(pbLocation.ParentId)
+	capacity, err := convertPbToCapacityModel(pbLocation.Capacity)
+	if err != nil {
+		return nil, err
+	}
+	return &model.Location{
+		ID:          id,
+		WarehouseID: warehouseID,
+		ParentID:    parentID,
+		Type:        model.LocationType(pbLocation.Type),
+		Code:        pbLocation.Code,
+		Name:        pbLocation.Name,
+		Capacity:    capacity,
+	}, nil
+}
+
+func convertLocationModelToPb(location *model.Location) *pb.Location {
+	return &pb.Location{
+		Id:          location.ID.String(),
+		WarehouseId: location.WarehouseID.String(),
+		ParentId:    location.ParentID.String(),
+		Type:        pb.LocationType(location.Type),
+		Code:        location.Code,
+		Name:        location.Name,
+		Path:        location.Path,
+		CreatedAt:   timestamppb.New(location.CreatedAt),
+		UpdatedAt:   timestamppb.New(location.UpdatedAt),
+		Capacity:    convertCapacityModelToPb(location.Capacity),
+	}
+}
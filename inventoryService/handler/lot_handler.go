@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"inventoryService/model"
+	pb "inventoryService/proto/inventory"
+	"log"
+)
+
+func (h *InventoryHandler) CreateLot(ctx context.Context, req *pb.CreateLotRequest) (*pb.Lot, error) {
+	internalLot, err := convertPbToLotModel(req.Lot)
+	if err != nil {
+		return nil, err
+	}
+	createdLot, err := h.lotService.CreateLot(ctx, internalLot)
+	if err != nil {
+		log.Printf("Error in CreateLot: %v", err)
+		return nil, err
+	}
+	return convertLotModelToPb(createdLot), nil
+}
+
+func (h *InventoryHandler) GetLot(ctx context.Context, req *pb.GetLotRequest) (*pb.Lot, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	lot, err := h.lotService.GetLot(ctx, id)
+	if err != nil {
+		log.Printf("Error in GetLot: %v", err)
+		return nil, err
+	}
+	return convertLotModelToPb(lot), nil
+}
+
+func (h *InventoryHandler) ListLots(ctx context.Context, req *pb.ListLotsRequest) (*pb.ListLotsResponse, error) {
+	var itemID uuid.UUID
+	if req.InventoryItemId != "" {
+		var err error
+		itemID, err = uuid.Parse(req.InventoryItemId)
+		if err != nil {
+			log.Printf("Error parsing UUID: %v", err)
+			return nil, err
+		}
+	}
+	lots, err := h.lotService.ListLots(ctx, itemID)
+	if err != nil {
+		log.Printf("Error in ListLots: %v", err)
+		return nil, err
+	}
+	return convertLotsModelToPb(lots), nil
+}
+
+func (h *InventoryHandler) ListExpiringLots(ctx context.Context, req *pb.ListExpiringLotsRequest) (*pb.ListLotsResponse, error) {
+	var warehouseID, productID uuid.UUID
+	var err error
+	if req.WarehouseId != "" {
+		warehouseID, err = uuid.Parse(req.WarehouseId)
+		if err != nil {
+			log.Printf("Error parsing UUID: %v", err)
+			return nil, err
+		}
+	}
+	if req.ProductId != "" {
+		productID, err = uuid.Parse(req.ProductId)
+		if err != nil {
+			log.Printf("Error parsing UUID: %v", err)
+			return nil, err
+		}
+	}
+	lots, err := h.lotService.ListExpiringLots(ctx, int(req.WithinDays), warehouseID, productID)
+	if err != nil {
+		log.Printf("Error in ListExpiringLots: %v", err)
+		return nil, err
+	}
+	return convertLotsModelToPb(lots), nil
+}
+
+func convertPbToLotModel(pbLot *pb.Lot) (*model.Lot, error) {
+	id, _ := uuid.Parse(pbLot.Id)
+	itemID, _ := uuid.Parse(pbLot.InventoryItemId)
+	supplierID, _ := uuid.Parse(pbLot.SupplierId)
+	quantity, err := quantityFromPb("quantity", pbLot.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	return &model.Lot{
+		ID:              id,
+		InventoryItemID: itemID,
+		LotNumber:       pbLot.LotNumber,
+		ManufactureDate: timeFromPb(pbLot.ManufactureDate),
+		ExpiryDate:      timeFromPb(pbLot.ExpiryDate),
+		SupplierID:      supplierID,
+		Quantity:        quantity,
+	}, nil
+}
+
+func convertLotModelToPb(lot *model.Lot) *pb.Lot {
+	return &pb.Lot{
+		Id:              lot.ID.String(),
+		InventoryItemId: lot.InventoryItemID.String(),
+		ProductId:       lot.ProductID.String(),
+		WarehouseId:     lot.WarehouseID.String(),
+		LotNumber:       lot.LotNumber,
+		ManufactureDate: timestampOrNil(lot.ManufactureDate),
+		ExpiryDate:      timestampOrNil(lot.ExpiryDate),
+		SupplierId:      lot.SupplierID.String(),
+		Quantity:        lot.Quantity.String(),
+		CreatedAt:       timestamppb.New(lot.CreatedAt),
+	}
+}
+
+func convertLotsModelToPb(lots []*model.Lot) *pb.ListLotsResponse {
+	pbLots := make([]*pb.Lot, len(lots))
+	for i, lot := range lots {
+		pbLots[i] = convertLotModelToPb(lot)
+	}
+	return &pb.ListLotsResponse{Lots: pbLots, Total: int32(len(pbLots))}
+}
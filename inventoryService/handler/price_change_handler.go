@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"inventoryService/model"
+	pb "inventoryService/proto/inventory"
+	"log"
+)
+
+func (h *InventoryHandler) SchedulePriceChange(ctx context.Context, req *pb.SchedulePriceChangeRequest) (*pb.PriceChange, error) {
+	productID, err := uuid.Parse(req.ProductId)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	change, err := h.priceChangeService.SchedulePriceChange(ctx, productID, convertPbToMoneyModel(req.Price), timeFromPb(req.EffectiveAt))
+	if err != nil {
+		log.Printf("Error in SchedulePriceChange: %v", err)
+		return nil, err
+	}
+	return convertPriceChangeModelToPb(change), nil
+}
+
+func (h *InventoryHandler) CancelPriceChange(ctx context.Context, req *pb.CancelPriceChangeRequest) (*pb.PriceChange, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	change, err := h.priceChangeService.CancelPriceChange(ctx, id)
+	if err != nil {
+		log.Printf("Error in CancelPriceChange: %v", err)
+		return nil, err
+	}
+	return convertPriceChangeModelToPb(change), nil
+}
+
+func (h *InventoryHandler) GetPriceHistory(ctx context.Context, req *pb.GetPriceHistoryRequest) (*pb.GetPriceHistoryResponse, error) {
+	productID, err := uuid.Parse(req.ProductId)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	changes, err := h.priceChangeService.GetPriceHistory(ctx, productID)
+	if err != nil {
+		log.Printf("Error in GetPriceHistory: %v", err)
+		return nil, err
+	}
+	pbChanges := make([]*pb.PriceChange, len(changes))
+	for i, change := range changes {
+		pbChanges[i] = convertPriceChangeModelToPb(change)
+	}
+	return &pb.GetPriceHistoryResponse{PriceChanges: pbChanges, Total: int32(len(pbChanges))}, nil
+}
+
+func convertPriceChangeModelToPb(change *model.PriceChange) *pb.PriceChange {
+	pbChange := &pb.PriceChange{
+		Id:          change.ID.String(),
+		ProductId:   change.ProductID.String(),
+		NewPrice:    convertMoneyModelToPb(change.NewPrice),
+		EffectiveAt: timestamppb.New(change.EffectiveAt),
+		Actor:       change.Actor,
+		Status:      pb.PriceChangeStatus(change.Status),
+		CreatedAt:   timestamppb.New(change.CreatedAt),
+		AppliedAt:   timestampOrNil(change.AppliedAt),
+	}
+	if change.Status == model.PriceChangeApplied && (change.OldPrice.CurrencyCode != "" || !change.OldPrice.IsZero()) {
+		pbChange.OldPrice = convertMoneyModelToPb(change.OldPrice)
+	}
+	return pbChange
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"inventoryService/model"
+	pb "inventoryService/proto/inventory"
+	"log"
+)
+
+func (h *InventoryHandler) CreatePriceList(ctx context.Context, req *pb.CreatePriceListRequest) (*pb.PriceList, error) {
+	internalPriceList := convertPbToPriceListModel(req.PriceList)
+	created, err := h.priceListService.CreatePriceList(ctx, internalPriceList)
+	if err != nil {
+		log.Printf("Error in CreatePriceList: %v", err)
+		return nil, err
+	}
+	return convertPriceListModelToPb(created), nil
+}
+
+func (h *InventoryHandler) GetPriceList(ctx context.Context, req *pb.GetPriceListRequest) (*pb.PriceList, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	priceList, err := h.priceListService.GetPriceList(ctx, id)
+	if err != nil {
+		log.Printf("Error in GetPriceList: %v", err)
+		return nil, err
+	}
+	return convertPriceListModelToPb(priceList), nil
+}
+
+func (h *InventoryHandler) ListPriceLists(ctx context.Context, req *pb.ListPriceListsRequest) (*pb.ListPriceListsResponse, error) {
+	priceLists, err := h.priceListService.ListPriceLists(ctx)
+	if err != nil {
+		log.Printf("Error in ListPriceLists: %v", err)
+		return nil, err
+	}
+	pbPriceLists := make([]*pb.PriceList, len(priceLists))
+	for i, priceList := range priceLists {
+		pbPriceLists[i] = convertPriceListModelToPb(priceList)
+	}
+	return &pb.ListPriceListsResponse{PriceLists: pbPriceLists, Total: int32(len(pbPriceLists))}, nil
+}
+
+func (h *InventoryHandler) UpdatePriceList(ctx context.Context, req *pb.UpdatePriceListRequest) (*pb.PriceList, error) {
+	internalPriceList := convertPbToPriceListModel(req.PriceList)
+	updated, err := h.priceListService.UpdatePriceList(ctx, internalPriceList)
+	if err != nil {
+		log.Printf("Error in UpdatePriceList: %v", err)
+		return nil, err
+	}
+	return convertPriceListModelToPb(updated), nil
+}
+
+func (h *InventoryHandler) DeletePriceList(ctx context.Context, req *pb.DeletePriceListRequest) (*emptypb.Empty, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	err = h.priceListService.DeletePriceList(ctx, id)
+	if err != nil {
+		log.Printf("Error in DeletePriceList: %v", err)
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (h *InventoryHandler) GetEffectivePrice(ctx context.Context, req *pb.GetEffectivePriceRequest) (*pb.EffectivePrice, error) {
+	productID, err := uuid.Parse(req.ProductId)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	price, err := h.priceListService.GetEffectivePrice(ctx, productID, req.CurrencyCode, timeFromPb(req.At), req.CustomerGroup)
+	if err != nil {
+		log.Printf("Error in GetEffectivePrice: %v", err)
+		return nil, err
+	}
+
+	pbPrice := &pb.EffectivePrice{
+		ProductId: price.ProductID.String(),
+		Price:     convertMoneyModelToPb(price.Price),
+	}
+	if price.PriceListID != uuid.Nil {
+		pbPrice.PriceListId = price.PriceListID.String()
+	}
+	if price.ExchangeRate != nil {
+		pbPrice.SourcePrice = convertMoneyModelToPb(price.SourcePrice)
+		pbPrice.ExchangeRate = convertExchangeRateModelToPb(price.ExchangeRate)
+	}
+	return pbPrice, nil
+}
+
+func (h *InventoryHandler) ListExchangeRates(ctx context.Context, req *pb.ListExchangeRatesRequest) (*pb.ListExchangeRatesResponse, error) {
+	rates, err := h.exchangeRateService.ListExchangeRates(ctx)
+	if err != nil {
+		log.Printf("Error in ListExchangeRates: %v", err)
+		return nil, err
+	}
+	pbRates := make([]*pb.ExchangeRate, len(rates))
+	for i, rate := range rates {
+		pbRates[i] = convertExchangeRateModelToPb(rate)
+	}
+	return &pb.ListExchangeRatesResponse{ExchangeRates: pbRates, Total: int32(len(pbRates))}, nil
+}
+
+func convertPbToPriceListModel(pbPriceList *pb.PriceList) *model.PriceList {
+	id, _ := uuid.Parse(pbPriceList.Id)
+	prices := make([]*model.PriceListPrice, len(pbPriceList.Prices))
+	for i, pbPrice := range pbPriceList.Prices {
+		productID, _ := uuid.Parse(pbPrice.ProductId)
+		prices[i] = &model.PriceListPrice{
+			ProductID: productID,
+			Price:     convertPbToMoneyModel(pbPrice.Price),
+		}
+	}
+	return &model.PriceList{
+		ID:            id,
+		Name:          pbPriceList.Name,
+		CurrencyCode:  pbPriceList.CurrencyCode,
+		CustomerGroup: pbPriceList.CustomerGroup,
+		EffectiveFrom: timeFromPb(pbPriceList.EffectiveFrom),
+		EffectiveTo:   timeFromPb(pbPriceList.EffectiveTo),
+		Prices:        prices,
+	}
+}
+
+func convertPriceListModelToPb(priceList *model.PriceList) *pb.PriceList {
+	pbPrices := make([]*pb.PriceListPrice, len(priceList.Prices))
+	for i, price := range priceList.Prices {
+		pbPrices[i] = &pb.PriceListPrice{
+			ProductId: price.ProductID.String(),
+			Price:     convertMoneyModelToPb(price.Price),
+		}
+	}
+	return &pb.PriceList{
+		Id:            priceList.ID.String(),
+		Name:          priceList.Name,
+		CurrencyCode:  priceList.CurrencyCode,
+		CustomerGroup: priceList.CustomerGroup,
+		EffectiveFrom: timestamppb.New(priceList.EffectiveFrom),
+		EffectiveTo:   timestampOrNil(priceList.EffectiveTo),
+		Prices:        pbPrices,
+		CreatedAt:     timestamppb.New(priceList.CreatedAt),
+		UpdatedAt:     timestamppb.New(priceList.UpdatedAt),
+	}
+}
+
+func convertExchangeRateModelToPb(rate *model.ExchangeRate) *pb.ExchangeRate {
+	return &pb.ExchangeRate{
+		FromCurrency:  rate.FromCurrency,
+		ToCurrency:    rate.ToCurrency,
+		Rate:          rate.Rate,
+		EffectiveFrom: timestamppb.New(rate.EffectiveFrom),
+	}
+}
@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"inventoryService/model"
+	pb "inventoryService/proto/inventory"
+	"log"
+)
+
+func (h *InventoryHandler) CreatePurchaseOrder(ctx context.Context, req *pb.CreatePurchaseOrderRequest) (*pb.PurchaseOrder, error) {
+	internalOrder, err := convertPbToPurchaseOrderModel(req.PurchaseOrder)
+	if err != nil {
+		return nil, err
+	}
+	createdOrder, err := h.purchaseOrderService.CreatePurchaseOrder(ctx, internalOrder)
+	if err != nil {
+		log.Printf("Error in CreatePurchaseOrder: %v", err)
+		return nil, err
+	}
+	return convertPurchaseOrderModelToPb(createdOrder), nil
+}
+
+func (h *InventoryHandler) GetPurchaseOrder(ctx context.Context, req *pb.GetPurchaseOrderRequest) (*pb.PurchaseOrder, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	order, err := h.purchaseOrderService.GetPurchaseOrder(ctx, id)
+	if err != nil {
+		log.Printf("Error in GetPurchaseOrder: %v", err)
+		return nil, err
+	}
+	return convertPurchaseOrderModelToPb(order), nil
+}
+
+func (h *InventoryHandler) UpdatePurchaseOrder(ctx context.Context, req *pb.UpdatePurchaseOrderRequest) (*pb.PurchaseOrder, error) {
+	internalOrder, err := convertPbToPurchaseOrderModel(req.PurchaseOrder)
+	if err != nil {
+		return nil, err
+	}
+	updatedOrder, err := h.purchaseOrderService.UpdatePurchaseOrder(ctx, internalOrder)
+	if err != nil {
+		log.Printf("Error in UpdatePurchaseOrder: %v", err)
+		return nil, err
+	}
+	return convertPurchaseOrderModelToPb(updatedOrder), nil
+}
+
+func (h *InventoryHandler) DeletePurchaseOrder(ctx context.Context, req *pb.DeletePurchaseOrderRequest) (*emptypb.Empty, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	err = h.purchaseOrderService.DeletePurchaseOrder(ctx, id)
+	if err != nil {
+		log.Printf("Error in DeletePurchaseOrder: %v", err)
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (h *InventoryHandler) ListPurchaseOrders(ctx context.Context, req *pb.ListPurchaseOrdersRequest) (*pb.ListPurchaseOrdersResponse, error) {
+	var supplierID uuid.UUID
+	if req.SupplierId != "" {
+		var err error
+		supplierID, err = uuid.Parse(req.SupplierId)
+		if err != nil {
+			log.Printf("Error parsing UUID: %v", err)
+			return nil, err
+		}
+	}
+	statuses := make([]model.PurchaseOrderStatus, len(req.Statuses))
+	for i, s := range req.Statuses {
+		statuses[i] = model.PurchaseOrderStatus(s)
+	}
+	orders, err := h.purchaseOrderService.ListPurchaseOrders(ctx, supplierID, statuses)
+	if err != nil {
+		log.Printf("Error in ListPurchaseOrders: %v", err)
+		return nil, err
+	}
+
+	pbOrders := make([]*pb.PurchaseOrder, len(orders))
+	for i, order := range orders {
+		pbOrders[i] = convertPurchaseOrderModelToPb(order)
+	}
+
+	return &pb.ListPurchaseOrdersResponse{PurchaseOrders: pbOrders, Total: int32(len(pbOrders))}, nil
+}
+
+func (h *InventoryHandler) SubmitPurchaseOrder(ctx context.Context, req *pb.SubmitPurchaseOrderRequest) (*pb.PurchaseOrder, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	order, err := h.purchaseOrderService.SubmitPurchaseOrder(ctx, id)
+	if err != nil {
+		log.Printf("Error in SubmitPurchaseOrder: %v", err)
+		return nil, err
+	}
+	return convertPurchaseOrderModelToPb(order), nil
+}
+
+func (h *InventoryHandler) CancelPurchaseOrder(ctx context.Context, req *pb.CancelPurchaseOrderRequest) (*pb.PurchaseOrder, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	order, err := h.purchaseOrderService.CancelPurchaseOrder(ctx, id)
+	if err != nil {
+		log.Printf("Error in CancelPurchaseOrder: %v", err)
+		return nil, err
+	}
+	return convertPurchaseOrderModelToPb(order), nil
+}
+
+func (h *InventoryHandler) CreatePurchaseOrderFromSuggestions(ctx context.Context, req *pb.CreatePurchaseOrderFromSuggestionsRequest) (*pb.PurchaseOrder, error) {
+	supplierID, err := uuid.Parse(req.SupplierId)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	itemIDs := make([]uuid.UUID, len(req.InventoryItemIds))
+	for i, rawID := range req.InventoryItemIds {
+		itemIDs[i], err = uuid.Parse(rawID)
+		if err != nil {
+			log.Printf("Error parsing UUID: %v", err)
+			return nil, err
+		}
+	}
+	order, err := h.purchaseOrderService.CreatePurchaseOrderFromSuggestions(ctx, supplierID, itemIDs)
+	if err != nil {
+		log.Printf("Error in CreatePurchaseOrderFromSuggestions: %v", err)
+		return nil, err
+	}
+	return convertPurchaseOrderModelToPb(order), nil
+}
+
+func convertPbToPurchaseOrderModel(pbOrder *pb.PurchaseOrder) (*model.PurchaseOrder, error) {
+	id, _ := uuid.Parse(pbOrder.Id)
+	supplierID, _ := uuid.Parse(pbOrder.SupplierId)
+	lines := make([]*model.PurchaseOrderLine, len(pbOrder.Lines))
+	for i, pbLine := range pbOrder.Lines {
+		lineID, _ := uuid.Parse(pbLine.Id)
+		productID, _ := uuid.Parse(pbLine.ProductId)
+		warehouseID, _ := uuid.Parse(pbLine.WarehouseId)
+		quantity, err := quantityFromPb("quantity", pbLine.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		received, err := quantityFromPb("received quantity", pbLine.ReceivedQuantity)
+		if err != nil {
+			return nil, err
+		}
+		lines[i] = &model.PurchaseOrderLine{
+			ID:               lineID,
+			ProductID:        productID,
+			WarehouseID:      warehouseID,
+			Quantity:         quantity,
+			Unit:             pbLine.Unit,
+			UnitCost:         convertPbToMoneyModel(pbLine.UnitCost),
+			ReceivedQuantity: received,
+		}
+	}
+	return &model.PurchaseOrder{
+		ID:         id,
+		SupplierID: supplierID,
+		Status:     model.PurchaseOrderStatus(pbOrder.Status),
+		Notes:      pbOrder.Notes,
+		Lines:      lines,
+	}, nil
+}
+
+func convertPurchaseOrderModelToPb(order *model.PurchaseOrder) *pb.PurchaseOrder {
+	lines := make([]*pb.PurchaseOrderLine, len(order.Lines))
+	for i, line := range order.Lines {
+		lines[i] = &pb.PurchaseOrderLine{
+			Id:               line.ID.String(),
+			ProductId:        line.ProductID.String(),
+			WarehouseId:      line.WarehouseID.String(),
+			Quantity:         line.Quantity.String(),
+			Unit:             line.Unit,
+			UnitCost:         convertMoneyModelToPb(line.UnitCost),
+			ReceivedQuantity: line.ReceivedQuantity.String(),
+		}
+	}
+	return &pb.PurchaseOrder{
+		Id:          order.ID.String(),
+		SupplierId:  order.SupplierID.String(),
+		Status:      pb.PurchaseOrderStatus(order.Status),
+		Notes:       order.Notes,
+		Lines:       lines,
+		CreatedAt:   timestamppb.New(order.CreatedAt),
+		UpdatedAt:   timestamppb.New(order.UpdatedAt),
+		SubmittedAt: timestampOrNil(order.SubmittedAt),
+		Total:       convertMoneyModelToPb(order.Total()),
+	}
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"inventoryService/model"
+	pb "inventoryService/proto/inventory"
+	"log"
+	"sort"
+)
+
+func (h *InventoryHandler) ListReorderSuggestions(ctx context.Context, req *pb.ListReorderSuggestionsRequest) (*pb.ListReorderSuggestionsResponse, error) {
+	var warehouseID uuid.UUID
+	if req.WarehouseId != "" {
+		var err error
+		warehouseID, err = uuid.Parse(req.WarehouseId)
+		if err != nil {
+			log.Printf("Error parsing UUID: %v", err)
+			return nil, err
+		}
+	}
+	grouped, err := h.replenishmentService.ListReorderSuggestions(ctx, warehouseID)
+	if err != nil {
+		log.Printf("Error in ListReorderSuggestions: %v", err)
+		return nil, err
+	}
+
+	resp := &pb.ListReorderSuggestionsResponse{}
+	for id, suggestions := range grouped {
+		pbSuggestions := make([]*pb.ReorderSuggestion, len(suggestions))
+		for i, suggestion := range suggestions {
+			pbSuggestions[i] = convertReorderSuggestionModelToPb(suggestion)
+		}
+		resp.Warehouses = append(resp.Warehouses, &pb.WarehouseReorderSuggestions{
+			WarehouseId: id.String(),
+			Suggestions: pbSuggestions,
+		})
+		resp.Total += int32(len(pbSuggestions))
+	}
+	sort.Slice(resp.Warehouses, func(i, j int) bool {
+		return resp.Warehouses[i].WarehouseId < resp.Warehouses[j].WarehouseId
+	})
+
+	return resp, nil
+}
+
+func (h *InventoryHandler) StreamReorderAlerts(req *pb.StreamReorderAlertsRequest, stream pb.InventoryService_StreamReorderAlertsServer) error {
+	var warehouseID uuid.UUID
+	if req.WarehouseId != "" {
+		var err error
+		warehouseID, err = uuid.Parse(req.WarehouseId)
+		if err != nil {
+			log.Printf("Error parsing UUID: %v", err)
+			return err
+		}
+	}
+
+	alerts, unsubscribe := h.replenishmentService.Subscribe()
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case suggestion := <-alerts:
+			if warehouseID != uuid.Nil && suggestion.WarehouseID != warehouseID {
+				continue
+			}
+			if err := stream.Send(convertReorderSuggestionModelToPb(suggestion)); err != nil {
+				log.Printf("Error in StreamReorderAlerts: %v", err)
+				return err
+			}
+		}
+	}
+}
+
+func convertReorderSuggestionModelToPb(suggestion *model.ReorderSuggestion) *pb.ReorderSuggestion {
+	return &pb.ReorderSuggestion{
+		InventoryItemId:   suggestion.InventoryItemID.String(),
+		ProductId:         suggestion.ProductID.String(),
+		WarehouseId:       suggestion.WarehouseID.String(),
+		QuantityOnHand:    suggestion.QuantityOnHand.String(),
+		ReorderLevel:      suggestion.ReorderLevel.String(),
+		SuggestedQuantity: suggestion.SuggestedQuantity.String(),
+		CreatedAt:         timestamppb.New(suggestion.CreatedAt),
+		PurchaseOrderId:   suggestion.PurchaseOrderID.String(),
+	}
+}
@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"inventoryService/model"
+	pb "inventoryService/proto/inventory"
+	"log"
+)
+
+func (h *InventoryHandler) CreateReturnAuthorization(ctx context.Context, req *pb.CreateReturnAuthorizationRequest) (*pb.ReturnAuthorization, error) {
+	internalRMA, err := convertPbToReturnAuthorizationModel(req.ReturnAuthorization)
+	if err != nil {
+		return nil, err
+	}
+	createdRMA, err := h.returnAuthorizationService.CreateReturnAuthorization(ctx, internalRMA)
+	if err != nil {
+		log.Printf("Error in CreateReturnAuthorization: %v", err)
+		return nil, err
+	}
+	return convertReturnAuthorizationModelToPb(createdRMA), nil
+}
+
+func (h *InventoryHandler) GetReturnAuthorization(ctx context.Context, req *pb.GetReturnAuthorizationRequest) (*pb.ReturnAuthorization, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	rma, err := h.returnAuthorizationService.GetReturnAuthorization(ctx, id)
+	if err != nil {
+		log.Printf("Error in GetReturnAuthorization: %v", err)
+		return nil, err
+	}
+	return convertReturnAuthorizationModelToPb(rma), nil
+}
+
+func (h *InventoryHandler) ListReturnAuthorizations(ctx context.Context, req *pb.ListReturnAuthorizationsRequest) (*pb.ListReturnAuthorizationsResponse, error) {
+	var salesOrderID uuid.UUID
+	if req.SalesOrderId != "" {
+		var err error
+		salesOrderID, err = uuid.Parse(req.SalesOrderId)
+		if err != nil {
+			log.Printf("Error parsing UUID: %v", err)
+			return nil, err
+		}
+	}
+	statuses := make([]model.ReturnAuthorizationStatus, len(req.Statuses))
+	for i, s := range req.Statuses {
+		statuses[i] = model.ReturnAuthorizationStatus(s)
+	}
+	rmas, err := h.returnAuthorizationService.ListReturnAuthorizations(ctx, salesOrderID, statuses)
+	if err != nil {
+		log.Printf("Error in ListReturnAuthorizations: %v", err)
+		return nil, err
+	}
+
+	pbRMAs := make([]*pb.ReturnAuthorization, len(rmas))
+	for i, rma := range rmas {
+		pbRMAs[i] = convertReturnAuthorizationModelToPb(rma)
+	}
+
+	return &pb.ListReturnAuthorizationsResponse{ReturnAuthorizations: pbRMAs, Total: int32(len(pbRMAs))}, nil
+}
+
+func (h *InventoryHandler) InspectReturnAuthorization(ctx context.Context, req *pb.InspectReturnAuthorizationRequest) (*pb.ReturnAuthorization, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	dispositions := make([]*model.ReturnDisposition, len(req.Dispositions))
+	for i, pbDisposition := range req.Dispositions {
+		dispositions[i], err = convertPbToReturnDispositionModel(pbDisposition)
+		if err != nil {
+			return nil, err
+		}
+	}
+	rma, err := h.returnAuthorizationService.InspectReturnAuthorization(ctx, id, dispositions)
+	if err != nil {
+		log.Printf("Error in InspectReturnAuthorization: %v", err)
+		return nil, err
+	}
+	return convertReturnAuthorizationModelToPb(rma), nil
+}
+
+func (h *InventoryHandler) CancelReturnAuthorization(ctx context.Context, req *pb.CancelReturnAuthorizationRequest) (*pb.ReturnAuthorization, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	rma, err := h.returnAuthorizationService.CancelReturnAuthorization(ctx, id)
+	if err != nil {
+		log.Printf("Error in CancelReturnAuthorization: %v", err)
+		return nil, err
+	}
+	return convertReturnAuthorizationModelToPb(rma), nil
+}
+
+func convertPbToReturnAuthorizationModel(pbRMA *pb.ReturnAuthorization) (*model.ReturnAuthorization, error) {
+	id, _ := uuid.Parse(pbRMA.Id)
+	salesOrderID, _ := uuid.Parse(pbRMA.SalesOrderId)
+	productID, _ := uuid.Parse(pbRMA.ProductId)
+	warehouseID, _ := uuid.Parse(pbRMA.WarehouseId)
+	quantity, err := quantityFromPb("quantity", pbRMA.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	return &model.ReturnAuthorization{
+		ID:           id,
+		SalesOrderID: salesOrderID,
+		Customer:     pbRMA.Customer,
+		ProductID:    productID,
+		WarehouseID:  warehouseID,
+		Quantity:     quantity,
+		Reason:       pbRMA.Reason,
+		Status:       model.ReturnAuthorizationStatus(pbRMA.Status),
+	}, nil
+}
+
+func convertPbToReturnDispositionModel(pbDisposition *pb.ReturnDisposition) (*model.ReturnDisposition, error) {
+	supplierID, _ := uuid.Parse(pbDisposition.SupplierId)
+	quantity, err := quantityFromPb("disposition quantity", pbDisposition.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	return &model.ReturnDisposition{
+		Disposition:   model.Disposition(pbDisposition.Disposition),
+		Quantity:      quantity,
+		SupplierID:    supplierID,
+		Notes:         pbDisposition.Notes,
+		SerialNumbers: pbDisposition.SerialNumbers,
+	}, nil
+}
+
+func convertReturnAuthorizationModelToPb(rma *model.ReturnAuthorization) *pb.ReturnAuthorization {
+	dispositions := make([]*pb.ReturnDisposition, len(rma.Dispositions))
+	for i, disposition := range rma.Dispositions {
+		dispositions[i] = &pb.ReturnDisposition{
+			Disposition:     pb.Disposition(disposition.Disposition),
+			Quantity:        disposition.Quantity.String(),
+			SupplierId:      disposition.SupplierID.String(),
+			StockMovementId: disposition.StockMovementID.String(),
+			Notes:           disposition.Notes,
+			SerialNumbers:   disposition.SerialNumbers,
+		}
+	}
+	return &pb.ReturnAuthorization{
+		Id:           rma.ID.String(),
+		SalesOrderId: rma.SalesOrderID.String(),
+		Customer:     rma.Customer,
+		ProductId:    rma.ProductID.String(),
+		WarehouseId:  rma.WarehouseID.String(),
+		Quantity:     rma.Quantity.String(),
+		Reason:       rma.Reason,
+		Status:       pb.ReturnAuthorizationStatus(rma.Status),
+		Dispositions: dispositions,
+		CreatedAt:    timestamppb.New(rma.CreatedAt),
+		UpdatedAt:    timestamppb.New(rma.UpdatedAt),
+		InspectedAt:  timestampOrNil(rma.InspectedAt),
+	}
+}
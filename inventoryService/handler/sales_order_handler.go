@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"inventoryService/model"
+	pb "inventoryService/proto/inventory"
+	"log"
+)
+
+func (h *InventoryHandler) CreateSalesOrder(ctx context.Context, req *pb.CreateSalesOrderRequest) (*pb.SalesOrder, error) {
+	internalOrder, err := convertPbToSalesOrderModel(req.SalesOrder)
+	if err != nil {
+		return nil, err
+	}
+	createdOrder, err := h.salesOrderService.CreateSalesOrder(ctx, internalOrder)
+	if err != nil {
+		log.Printf("Error in CreateSalesOrder: %v", err)
+		return nil, err
+	}
+	return convertSalesOrderModelToPb(createdOrder), nil
+}
+
+func (h *InventoryHandler) GetSalesOrder(ctx context.Context, req *pb.GetSalesOrderRequest) (*pb.SalesOrder, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	order, err := h.salesOrderService.GetSalesOrder(ctx, id)
+	if err != nil {
+		log.Printf("Error in GetSalesOrder: %v", err)
+		return nil, err
+	}
+	return convertSalesOrderModelToPb(order), nil
+}
+
+func (h *InventoryHandler) ListSalesOrders(ctx context.Context, req *pb.ListSalesOrdersRequest) (*pb.ListSalesOrdersResponse, error) {
+	statuses := make([]model.SalesOrderStatus, len(req.Statuses))
+	for i, s := range req.Statuses {
+		statuses[i] = model.SalesOrderStatus(s)
+	}
+	orders, err := h.salesOrderService.ListSalesOrders(ctx, req.Customer, statuses)
+	if err != nil {
+		log.Printf("Error in ListSalesOrders: %v", err)
+		return nil, err
+	}
+
+	pbOrders := make([]*pb.SalesOrder, len(orders))
+	for i, order := range orders {
+		pbOrders[i] = convertSalesOrderModelToPb(order)
+	}
+
+	return &pb.ListSalesOrdersResponse{SalesOrders: pbOrders, Total: int32(len(pbOrders))}, nil
+}
+
+func (h *InventoryHandler) CancelSalesOrder(ctx context.Context, req *pb.CancelSalesOrderRequest) (*pb.SalesOrder, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	order, err := h.salesOrderService.CancelSalesOrder(ctx, id)
+	if err != nil {
+		log.Printf("Error in CancelSalesOrder: %v", err)
+		return nil, err
+	}
+	return convertSalesOrderModelToPb(order), nil
+}
+
+func (h *InventoryHandler) ShipSalesOrder(ctx context.Context, req *pb.ShipSalesOrderRequest) (*pb.SalesOrder, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	serialNumbers, err := convertPbToLineSerialNumbers(req.SerialNumbers)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	order, err := h.salesOrderService.ShipSalesOrder(ctx, id, serialNumbers)
+	if err != nil {
+		log.Printf("Error in ShipSalesOrder: %v", err)
+		return nil, err
+	}
+	return convertSalesOrderModelToPb(order), nil
+}
+
+// convertPbToLineSerialNumbers keys the serial numbers given for order lines
+// by line ID.
+func convertPbToLineSerialNumbers(pbLines []*pb.LineSerialNumbers) (map[uuid.UUID][]string, error) {
+	serialNumbers := make(map[uuid.UUID][]string, len(pbLines))
+	for _, pbLine := range pbLines {
+		lineID, err := uuid.Parse(pbLine.LineId)
+		if err != nil {
+			return nil, err
+		}
+		serialNumbers[lineID] = append(serialNumbers[lineID], pbLine.SerialNumbers...)
+	}
+	return serialNumbers, nil
+}
+
+func convertPbToSalesOrderModel(pbOrder *pb.SalesOrder) (*model.SalesOrder, error) {
+	id, _ := uuid.Parse(pbOrder.Id)
+	lines := make([]*model.SalesOrderLine, len(pbOrder.Lines))
+	for i, pbLine := range pbOrder.Lines {
+		lineID, _ := uuid.Parse(pbLine.Id)
+		productID, _ := uuid.Parse(pbLine.ProductId)
+		warehouseID, _ := uuid.Parse(pbLine.WarehouseId)
+		quantity, err := quantityFromPb("quantity", pbLine.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		lines[i] = &model.SalesOrderLine{
+			ID:          lineID,
+			ProductID:   productID,
+			WarehouseID: warehouseID,
+			Quantity:    quantity,
+		}
+	}
+	return &model.SalesOrder{
+		ID:        id,
+		Customer:  pbOrder.Customer,
+		Status:    model.SalesOrderStatus(pbOrder.Status),
+		Notes:     pbOrder.Notes,
+		Lines:     lines,
+		ExpiresAt: timeFromPb(pbOrder.ExpiresAt),
+	}, nil
+}
+
+func convertSalesOrderModelToPb(order *model.SalesOrder) *pb.SalesOrder {
+	lines := make([]*pb.SalesOrderLine, len(order.Lines))
+	for i, line := range order.Lines {
+		allocations := make([]*pb.SalesOrderAllocation, len(line.Allocations))
+		for j, allocation := range line.Allocations {
+			allocations[j] = &pb.SalesOrderAllocation{
+				InventoryItemId: allocation.InventoryItemID.String(),
+				WarehouseId:     allocation.WarehouseID.String(),
+				Quantity:        allocation.Quantity.String(),
+				StockMovementId: allocation.StockMovementID.String(),
+				SerialNumbers:   allocation.SerialNumbers,
+				Shipped:         allocation.Shipped,
+			}
+		}
+		lines[i] = &pb.SalesOrderLine{
+			Id:          line.ID.String(),
+			ProductId:   line.ProductID.String(),
+			WarehouseId: line.WarehouseID.String(),
+			Quantity:    line.Quantity.String(),
+			Allocations: allocations,
+		}
+	}
+	return &pb.SalesOrder{
+		Id:        order.ID.String(),
+		Customer:  order.Customer,
+		Status:    pb.SalesOrderStatus(order.Status),
+		Notes:     order.Notes,
+		Lines:     lines,
+		ExpiresAt: timestampOrNil(order.ExpiresAt),
+		CreatedAt: timestamppb.New(order.CreatedAt),
+		UpdatedAt: timestamppb.New(order.UpdatedAt),
+		ShippedAt: timestampOrNil(order.ShippedAt),
+	}
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"inventoryService/model"
+	pb "inventoryService/proto/inventory"
+	"log"
+)
+
+func (h *InventoryHandler) LookupSerial(ctx context.Context, req *pb.LookupSerialRequest) (*pb.Serial, error) {
+	var productID uuid.UUID
+	if req.ProductId != "" {
+		var err error
+		productID, err = uuid.Parse(req.ProductId)
+		if err != nil {
+			log.Printf("Error parsing UUID: %v", err)
+			return nil, err
+		}
+	}
+	serial, err := h.serialService.LookupSerial(ctx, req.SerialNumber, productID)
+	if err != nil {
+		log.Printf("Error in LookupSerial: %v", err)
+		return nil, err
+	}
+	return convertSerialModelToPb(serial), nil
+}
+
+func (h *InventoryHandler) GetSerialHistory(ctx context.Context, req *pb.GetSerialHistoryRequest) (*pb.GetSerialHistoryResponse, error) {
+	var productID uuid.UUID
+	if req.ProductId != "" {
+		var err error
+		productID, err = uuid.Parse(req.ProductId)
+		if err != nil {
+			log.Printf("Error parsing UUID: %v", err)
+			return nil, err
+		}
+	}
+	events, err := h.serialService.GetSerialHistory(ctx, req.SerialNumber, productID)
+	if err != nil {
+		log.Printf("Error in GetSerialHistory: %v", err)
+		return nil, err
+	}
+
+	pbEvents := make([]*pb.SerialEvent, len(events))
+	for i, event := range events {
+		pbEvents[i] = convertSerialEventModelToPb(event)
+	}
+
+	return &pb.GetSerialHistoryResponse{Events: pbEvents, Total: int32(len(pbEvents))}, nil
+}
+
+func convertSerialModelToPb(serial *model.Serial) *pb.Serial {
+	return &pb.Serial{
+		SerialNumber:    serial.SerialNumber,
+		ProductId:       serial.ProductID.String(),
+		InventoryItemId: serial.InventoryItemID.String(),
+		WarehouseId:     serial.WarehouseID.String(),
+		Status:          pb.SerialStatus(serial.Status),
+		StockMovementId: serial.StockMovementID.String(),
+		CreatedAt:       timestamppb.New(serial.CreatedAt),
+		UpdatedAt:       timestamppb.New(serial.UpdatedAt),
+	}
+}
+
+func convertSerialEventModelToPb(event *model.SerialEvent) *pb.SerialEvent {
+	return &pb.SerialEvent{
+		Id:              event.ID.String(),
+		SerialNumber:    event.SerialNumber,
+		ProductId:       event.ProductID.String(),
+		StockMovementId: event.StockMovementID.String(),
+		MovementType:    pb.StockMovementType(event.MovementType),
+		Status:          pb.SerialStatus(event.Status),
+		InventoryItemId: event.InventoryItemID.String(),
+		WarehouseId:     event.WarehouseID.String(),
+		Actor:           event.Actor,
+		RecordedAt:      timestamppb.New(event.RecordedAt),
+	}
+}
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"inventoryService/model"
+	pb "inventoryService/proto/inventory"
+	"log"
+)
+
+func (h *InventoryHandler) AdjustStock(ctx context.Context, req *pb.AdjustStockRequest) (*pb.StockAdjustment, error) {
+	itemID, err := uuid.Parse(req.InventoryItemId)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	var lotID uuid.UUID
+	if req.LotId != "" {
+		lotID, err = uuid.Parse(req.LotId)
+		if err != nil {
+			log.Printf("Error parsing UUID: %v", err)
+			return nil, err
+		}
+	}
+	quantityChange, err := quantityFromPb("quantity change", req.QuantityChange)
+	if err != nil {
+		return nil, err
+	}
+	adjustment, err := h.stockAdjustmentService.AdjustStock(ctx, &model.StockAdjustment{
+		InventoryItemID: itemID,
+		QuantityChange:  quantityChange,
+		Reason:          model.AdjustmentReason(req.Reason),
+		Notes:           req.Notes,
+		LotID:           lotID,
+		SerialNumbers:   req.SerialNumbers,
+	})
+	if err != nil {
+		log.Printf("Error in AdjustStock: %v", err)
+		return nil, err
+	}
+	return convertStockAdjustmentModelToPb(adjustment), nil
+}
+
+func (h *InventoryHandler) GetStockAdjustment(ctx context.Context, req *pb.GetStockAdjustmentRequest) (*pb.StockAdjustment, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	adjustment, err := h.stockAdjustmentService.GetStockAdjustment(ctx, id)
+	if err != nil {
+		log.Printf("Error in GetStockAdjustment: %v", err)
+		return nil, err
+	}
+	return convertStockAdjustmentModelToPb(adjustment), nil
+}
+
+func (h *InventoryHandler) ListStockAdjustments(ctx context.Context, req *pb.ListStockAdjustmentsRequest) (*pb.ListStockAdjustmentsResponse, error) {
+	var itemID uuid.UUID
+	if req.InventoryItemId != "" {
+		var err error
+		itemID, err = uuid.Parse(req.InventoryItemId)
+		if err != nil {
+			log.Printf("Error parsing UUID: %v", err)
+			return nil, err
+		}
+	}
+	statuses := make([]model.AdjustmentStatus, len(req.Statuses))
+	for i, s := range req.Statuses {
+		statuses[i] = model.AdjustmentStatus(s)
+	}
+	adjustments, err := h.stockAdjustmentService.ListStockAdjustments(ctx, itemID, statuses)
+	if err != nil {
+		log.Printf("Error in ListStockAdjustments: %v", err)
+		return nil, err
+	}
+
+	pbAdjustments := make([]*pb.StockAdjustment, len(adjustments))
+	for i, adjustment := range adjustments {
+		pbAdjustments[i] = convertStockAdjustmentModelToPb(adjustment)
+	}
+
+	return &pb.ListStockAdjustmentsResponse{StockAdjustments: pbAdjustments, Total: int32(len(pbAdjustments))}, nil
+}
+
+func (h *InventoryHandler) ApproveStockAdjustment(ctx context.Context, req *pb.ApproveStockAdjustmentRequest) (*pb.StockAdjustment, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	adjustment, err := h.stockAdjustmentService.ApproveStockAdjustment(ctx, id, req.Notes)
+	if err != nil {
+		log.Printf("Error in ApproveStockAdjustment: %v", err)
+		return nil, err
+	}
+	return convertStockAdjustmentModelToPb(adjustment), nil
+}
+
+func (h *InventoryHandler) RejectStockAdjustment(ctx context.Context, req *pb.RejectStockAdjustmentRequest) (*pb.StockAdjustment, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	adjustment, err := h.stockAdjustmentService.RejectStockAdjustment(ctx, id, req.Notes)
+	if err != nil {
+		log.Printf("Error in RejectStockAdjustment: %v", err)
+		return nil, err
+	}
+	return convertStockAdjustmentModelToPb(adjustment), nil
+}
+
+func convertStockAdjustmentModelToPb(adjustment *model.StockAdjustment) *pb.StockAdjustment {
+	return &pb.StockAdjustment{
+		Id:              adjustment.ID.String(),
+		InventoryItemId: adjustment.InventoryItemID.String(),
+		ProductId:       adjustment.ProductID.String(),
+		WarehouseId:     adjustment.WarehouseID.String(),
+		QuantityChange:  adjustment.QuantityChange.String(),
+		Reason:          pb.AdjustmentReason(adjustment.Reason),
+		Notes:           adjustment.Notes,
+		Value:           convertMoneyModelToPb(adjustment.Value),
+		Status:          pb.StockAdjustmentStatus(adjustment.Status),
+		RequestedBy:     adjustment.RequestedBy,
+		ReviewedBy:      adjustment.ReviewedBy,
+		ReviewNotes:     adjustment.ReviewNotes,
+		StockMovementId: adjustment.StockMovementID.String(),
+		CreatedAt:       timestamppb.New(adjustment.CreatedAt),
+		ReviewedAt:      timestampOrNil(adjustment.ReviewedAt),
+		LotId:           adjustment.LotID.String(),
+		SerialNumbers:   adjustment.SerialNumbers,
+	}
+}
@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"inventoryService/model"
+	pb "inventoryService/proto/inventory"
+	"log"
+)
+
+func (h *InventoryHandler) CreateSupplierProduct(ctx context.Context, req *pb.CreateSupplierProductRequest) (*pb.SupplierProduct, error) {
+	internalSupplierProduct := convertPbToSupplierProductModel(req.SupplierProduct)
+	created, err := h.supplierProductService.CreateSupplierProduct(ctx, internalSupplierProduct)
+	if err != nil {
+		log.Printf("Error in CreateSupplierProduct: %v", err)
+		return nil, err
+	}
+	return convertSupplierProductModelToPb(created), nil
+}
+
+func (h *InventoryHandler) GetSupplierProduct(ctx context.Context, req *pb.GetSupplierProductRequest) (*pb.SupplierProduct, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	supplierProduct, err := h.supplierProductService.GetSupplierProduct(ctx, id)
+	if err != nil {
+		log.Printf("Error in GetSupplierProduct: %v", err)
+		return nil, err
+	}
+	return convertSupplierProductModelToPb(supplierProduct), nil
+}
+
+func (h *InventoryHandler) UpdateSupplierProduct(ctx context.Context, req *pb.UpdateSupplierProductRequest) (*pb.SupplierProduct, error) {
+	internalSupplierProduct := convertPbToSupplierProductModel(req.SupplierProduct)
+	updated, err := h.supplierProductService.UpdateSupplierProduct(ctx, internalSupplierProduct)
+	if err != nil {
+		log.Printf("Error in UpdateSupplierProduct: %v", err)
+		return nil, err
+	}
+	return convertSupplierProductModelToPb(updated), nil
+}
+
+func (h *InventoryHandler) DeleteSupplierProduct(ctx context.Context, req *pb.DeleteSupplierProductRequest) (*emptypb.Empty, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	err = h.supplierProductService.DeleteSupplierProduct(ctx, id)
+	if err != nil {
+		log.Printf("Error in DeleteSupplierProduct: %v", err)
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (h *InventoryHandler) ListSuppliersForProduct(ctx context.Context, req *pb.ListSuppliersForProductRequest) (*pb.ListSupplierProductsResponse, error) {
+	productID, err := uuid.Parse(req.ProductId)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	offers, err := h.supplierProductService.ListSuppliersForProduct(ctx, productID)
+	if err != nil {
+		log.Printf("Error in ListSuppliersForProduct: %v", err)
+		return nil, err
+	}
+	return convertSupplierProductsToListResponse(offers), nil
+}
+
+func (h *InventoryHandler) ListProductsForSupplier(ctx context.Context, req *pb.ListProductsForSupplierRequest) (*pb.ListSupplierProductsResponse, error) {
+	supplierID, err := uuid.Parse(req.SupplierId)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	catalog, err := h.supplierProductService.ListProductsForSupplier(ctx, supplierID)
+	if err != nil {
+		log.Printf("Error in ListProductsForSupplier: %v", err)
+		return nil, err
+	}
+	return convertSupplierProductsToListResponse(catalog), nil
+}
+
+func convertSupplierProductsToListResponse(supplierProducts []*model.SupplierProduct) *pb.ListSupplierProductsResponse {
+	pbSupplierProducts := make([]*pb.SupplierProduct, len(supplierProducts))
+	for i, supplierProduct := range supplierProducts {
+		pbSupplierProducts[i] = convertSupplierProductModelToPb(supplierProduct)
+	}
+	return &pb.ListSupplierProductsResponse{SupplierProducts: pbSupplierProducts, Total: int32(len(pbSupplierProducts))}
+}
+
+func convertPbToSupplierProductModel(pbSupplierProduct *pb.SupplierProduct) *model.SupplierProduct {
+	id, _ := uuid.Parse(pbSupplierProduct.Id)
+	supplierID, _ := uuid.Parse(pbSupplierProduct.SupplierId)
+	productID, _ := uuid.Parse(pbSupplierProduct.ProductId)
+	return &model.SupplierProduct{
+		ID:                   id,
+		SupplierID:           supplierID,
+		ProductID:            productID,
+		SupplierSKU:          pbSupplierProduct.SupplierSku,
+		UnitCost:             convertPbToMoneyModel(pbSupplierProduct.UnitCost),
+		MinimumOrderQuantity: int(pbSupplierProduct.MinimumOrderQuantity),
+		PackSize:             int(pbSupplierProduct.PackSize),
+		LeadTimeDays:         int(pbSupplierProduct.LeadTimeDays),
+		Preferred:            pbSupplierProduct.Preferred,
+	}
+}
+
+func convertSupplierProductModelToPb(supplierProduct *model.SupplierProduct) *pb.SupplierProduct {
+	return &pb.SupplierProduct{
+		Id:                   supplierProduct.ID.String(),
+		SupplierId:           supplierProduct.SupplierID.String(),
+		ProductId:            supplierProduct.ProductID.String(),
+		SupplierSku:          supplierProduct.SupplierSKU,
+		UnitCost:             convertMoneyModelToPb(supplierProduct.UnitCost),
+		MinimumOrderQuantity: int32(supplierProduct.MinimumOrderQuantity),
+		PackSize:             int32(supplierProduct.PackSize),
+		LeadTimeDays:         int32(supplierProduct.LeadTimeDays),
+		Preferred:            supplierProduct.Preferred,
+	}
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"inventoryService/model"
+	pb "inventoryService/proto/inventory"
+	"log"
+)
+
+func (h *InventoryHandler) GetSupplierScorecard(ctx context.Context, req *pb.GetSupplierScorecardRequest) (*pb.SupplierScorecard, error) {
+	supplierID, err := uuid.Parse(req.SupplierId)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	scorecard, err := h.supplierScorecardService.GetSupplierScorecard(ctx, supplierID, timeFromPb(req.From), timeFromPb(req.To))
+	if err != nil {
+		log.Printf("Error in GetSupplierScorecard: %v", err)
+		return nil, err
+	}
+	return convertSupplierScorecardModelToPb(scorecard), nil
+}
+
+func (h *InventoryHandler) RankSuppliers(ctx context.Context, req *pb.RankSuppliersRequest) (*pb.RankSuppliersResponse, error) {
+	scorecards, err := h.supplierScorecardService.RankSuppliers(ctx, timeFromPb(req.From), timeFromPb(req.To), model.SupplierRankingMetric(req.Metric), int(req.Limit))
+	if err != nil {
+		log.Printf("Error in RankSuppliers: %v", err)
+		return nil, err
+	}
+
+	pbScorecards := make([]*pb.SupplierScorecard, len(scorecards))
+	for i, scorecard := range scorecards {
+		pbScorecards[i] = convertSupplierScorecardModelToPb(scorecard)
+	}
+
+	return &pb.RankSuppliersResponse{Scorecards: pbScorecards, Total: int32(len(pbScorecards))}, nil
+}
+
+func convertSupplierScorecardModelToPb(scorecard *model.SupplierScorecard) *pb.SupplierScorecard {
+	return &pb.SupplierScorecard{
+		SupplierId:                  scorecard.SupplierID.String(),
+		From:                        timestamppb.New(scorecard.From),
+		To:                          timestamppb.New(scorecard.To),
+		PurchaseOrders:              int32(scorecard.PurchaseOrders),
+		Receipts:                    int32(scorecard.Receipts),
+		OrderedQuantity:             scorecard.OrderedQuantity.String(),
+		ReceivedQuantity:            scorecard.ReceivedQuantity.String(),
+		DamagedQuantity:             scorecard.DamagedQuantity.String(),
+		OnTimeRate:                  scorecard.OnTimeRate,
+		FillRate:                    scorecard.FillRate,
+		AverageLeadTimeDays:         scorecard.AverageLeadTimeDays,
+		AveragePromisedLeadTimeDays: scorecard.AveragePromisedLeadTimeDays,
+		DamageRate:                  scorecard.DamageRate,
+		Score:                       scorecard.Score,
+	}
+}
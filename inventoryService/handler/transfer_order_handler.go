@@ -0,0 +1,248 @@
+package handler
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"inventoryService/model"
+	pb "inventoryService/proto/inventory"
+	"log"
+)
+
+func (h *InventoryHandler) CreateTransferOrder(ctx context.Context, req *pb.CreateTransferOrderRequest) (*pb.TransferOrder, error) {
+	internalOrder, err := convertPbToTransferOrderModel(req.TransferOrder)
+	if err != nil {
+		return nil, err
+	}
+	createdOrder, err := h.transferOrderService.CreateTransferOrder(ctx, internalOrder)
+	if err != nil {
+		log.Printf("Error in CreateTransferOrder: %v", err)
+		return nil, err
+	}
+	return convertTransferOrderModelToPb(createdOrder), nil
+}
+
+func (h *InventoryHandler) GetTransferOrder(ctx context.Context, req *pb.GetTransferOrderRequest) (*pb.TransferOrder, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	order, err := h.transferOrderService.GetTransferOrder(ctx, id)
+	if err != nil {
+		log.Printf("Error in GetTransferOrder: %v", err)
+		return nil, err
+	}
+	return convertTransferOrderModelToPb(order), nil
+}
+
+func (h *InventoryHandler) ListTransferOrders(ctx context.Context, req *pb.ListTransferOrdersRequest) (*pb.ListTransferOrdersResponse, error) {
+	var warehouseID uuid.UUID
+	if req.WarehouseId != "" {
+		var err error
+		warehouseID, err = uuid.Parse(req.WarehouseId)
+		if err != nil {
+			log.Printf("Error parsing UUID: %v", err)
+			return nil, err
+		}
+	}
+	statuses := make([]model.TransferOrderStatus, len(req.Statuses))
+	for i, s := range req.Statuses {
+		statuses[i] = model.TransferOrderStatus(s)
+	}
+	orders, err := h.transferOrderService.ListTransferOrders(ctx, warehouseID, statuses)
+	if err != nil {
+		log.Printf("Error in ListTransferOrders: %v", err)
+		return nil, err
+	}
+
+	pbOrders := make([]*pb.TransferOrder, len(orders))
+	for i, order := range orders {
+		pbOrders[i] = convertTransferOrderModelToPb(order)
+	}
+
+	return &pb.ListTransferOrdersResponse{TransferOrders: pbOrders, Total: int32(len(pbOrders))}, nil
+}
+
+func (h *InventoryHandler) ShipTransferOrder(ctx context.Context, req *pb.ShipTransferOrderRequest) (*pb.TransferOrder, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	serialNumbers, err := convertPbToLineSerialNumbers(req.SerialNumbers)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	order, err := h.transferOrderService.ShipTransferOrder(ctx, id, serialNumbers)
+	if err != nil {
+		log.Printf("Error in ShipTransferOrder: %v", err)
+		return nil, err
+	}
+	return convertTransferOrderModelToPb(order), nil
+}
+
+func (h *InventoryHandler) ReceiveTransferOrder(ctx context.Context, req *pb.ReceiveTransferOrderRequest) (*pb.TransferOrder, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	var receivingID uuid.UUID
+	if req.ReceivingId != "" {
+		receivingID, err = uuid.Parse(req.ReceivingId)
+		if err != nil {
+			log.Printf("Error parsing UUID: %v", err)
+			return nil, err
+		}
+	}
+	receipts := make([]*model.TransferReceiptLine, len(req.Lines))
+	for i, pbLine := range req.Lines {
+		lineID, err := uuid.Parse(pbLine.LineId)
+		if err != nil {
+			log.Printf("Error parsing UUID: %v", err)
+			return nil, err
+		}
+		quantity, err := quantityFromPb("quantity", pbLine.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		receipts[i] = &model.TransferReceiptLine{
+			LineID:        lineID,
+			Quantity:      quantity,
+			Notes:         pbLine.Notes,
+			SerialNumbers: pbLine.SerialNumbers,
+		}
+	}
+	order, err := h.transferOrderService.ReceiveTransferOrder(ctx, id, receivingID, receipts, req.Close)
+	if err != nil {
+		log.Printf("Error in ReceiveTransferOrder: %v", err)
+		return nil, err
+	}
+	return convertTransferOrderModelToPb(order), nil
+}
+
+func (h *InventoryHandler) CancelTransferOrder(ctx context.Context, req *pb.CancelTransferOrderRequest) (*pb.TransferOrder, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	order, err := h.transferOrderService.CancelTransferOrder(ctx, id)
+	if err != nil {
+		log.Printf("Error in CancelTransferOrder: %v", err)
+		return nil, err
+	}
+	return convertTransferOrderModelToPb(order), nil
+}
+
+func (h *InventoryHandler) ListInTransitStock(ctx context.Context, req *pb.ListInTransitStockRequest) (*pb.ListInTransitStockResponse, error) {
+	var ids [3]uuid.UUID
+	for i, s := range []string{req.SourceWarehouseId, req.DestinationWarehouseId, req.ProductId} {
+		if s == "" {
+			continue
+		}
+		id, err := uuid.Parse(s)
+		if err != nil {
+			log.Printf("Error parsing UUID: %v", err)
+			return nil, err
+		}
+		ids[i] = id
+	}
+	stock, err := h.transferOrderService.ListInTransitStock(ctx, ids[0], ids[1], ids[2])
+	if err != nil {
+		log.Printf("Error in ListInTransitStock: %v", err)
+		return nil, err
+	}
+
+	pbStock := make([]*pb.InTransitStock, len(stock))
+	for i, s := range stock {
+		pbStock[i] = &pb.InTransitStock{
+			TransferOrderId:        s.TransferOrderID.String(),
+			LineId:                 s.LineID.String(),
+			ProductId:              s.ProductID.String(),
+			SourceWarehouseId:      s.SourceWarehouseID.String(),
+			DestinationWarehouseId: s.DestinationWarehouseID.String(),
+			Quantity:               s.Quantity.String(),
+			ShippedAt:              timestampOrNil(s.ShippedAt),
+		}
+	}
+
+	return &pb.ListInTransitStockResponse{InTransitStock: pbStock, Total: int32(len(pbStock))}, nil
+}
+
+func convertPbToTransferOrderModel(pbOrder *pb.TransferOrder) (*model.TransferOrder, error) {
+	id, _ := uuid.Parse(pbOrder.Id)
+	sourceWarehouseID, _ := uuid.Parse(pbOrder.SourceWarehouseId)
+	destinationWarehouseID, _ := uuid.Parse(pbOrder.DestinationWarehouseId)
+	lines := make([]*model.TransferOrderLine, len(pbOrder.Lines))
+	for i, pbLine := range pbOrder.Lines {
+		lineID, _ := uuid.Parse(pbLine.Id)
+		productID, _ := uuid.Parse(pbLine.ProductId)
+		quantity, err := quantityFromPb("quantity", pbLine.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		lines[i] = &model.TransferOrderLine{
+			ID:        lineID,
+			ProductID: productID,
+			Quantity:  quantity,
+		}
+	}
+	return &model.TransferOrder{
+		ID:                     id,
+		SourceWarehouseID:      sourceWarehouseID,
+		DestinationWarehouseID: destinationWarehouseID,
+		Status:                 model.TransferOrderStatus(pbOrder.Status),
+		Notes:                  pbOrder.Notes,
+		Lines:                  lines,
+	}, nil
+}
+
+func convertTransferOrderModelToPb(order *model.TransferOrder) *pb.TransferOrder {
+	lines := make([]*pb.TransferOrderLine, len(order.Lines))
+	for i, line := range order.Lines {
+		lines[i] = &pb.TransferOrderLine{
+			Id:                  line.ID.String(),
+			ProductId:           line.ProductID.String(),
+			Quantity:            line.Quantity.String(),
+			ShippedQuantity:     line.ShippedQuantity.String(),
+			ReceivedQuantity:    line.ReceivedQuantity.String(),
+			DiscrepancyQuantity: line.DiscrepancyQuantity.String(),
+			ShipmentMovementId:  line.ShipmentMovementID.String(),
+			SerialNumbers:       line.SerialNumbers,
+		}
+	}
+	receipts := make([]*pb.TransferReceiptLine, len(order.Receipts))
+	for i, receipt := range order.Receipts {
+		receipts[i] = &pb.TransferReceiptLine{
+			Id:              receipt.ID.String(),
+			LineId:          receipt.LineID.String(),
+			Quantity:        receipt.Quantity.String(),
+			ReceivedAt:      timestamppb.New(receipt.ReceivedAt),
+			StockMovementId: receipt.StockMovementID.String(),
+			Notes:           receipt.Notes,
+			SerialNumbers:   receipt.SerialNumbers,
+			Posted:          receipt.Posted,
+		}
+	}
+	var receivingID string
+	if order.ReceivingID != uuid.Nil {
+		receivingID = order.ReceivingID.String()
+	}
+	return &pb.TransferOrder{
+		Id:                     order.ID.String(),
+		SourceWarehouseId:      order.SourceWarehouseID.String(),
+		DestinationWarehouseId: order.DestinationWarehouseID.String(),
+		Status:                 pb.TransferOrderStatus(order.Status),
+		Notes:                  order.Notes,
+		Lines:                  lines,
+		Receipts:               receipts,
+		CreatedAt:              timestamppb.New(order.CreatedAt),
+		UpdatedAt:              timestamppb.New(order.UpdatedAt),
+		ShippedAt:              timestampOrNil(order.ShippedAt),
+		ReceivedAt:             timestampOrNil(order.ReceivedAt),
+		ReceivingId:            receivingID,
+	}
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	pb "inventoryService/proto/inventory"
+	"log"
+)
+
+func (h *InventoryHandler) ListCostLayers(ctx context.Context, req *pb.ListCostLayersRequest) (*pb.ListCostLayersResponse, error) {
+	itemID, err := uuid.Parse(req.InventoryItemId)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+		return nil, err
+	}
+	layers, err := h.valuationService.ListCostLayers(ctx, itemID)
+	if err != nil {
+		log.Printf("Error in ListCostLayers: %v", err)
+		return nil, err
+	}
+
+	pbLayers := make([]*pb.CostLayer, len(layers))
+	for i, layer := range layers {
+		pbLayers[i] = &pb.CostLayer{
+			Id:                layer.ID.String(),
+			InventoryItemId:   layer.InventoryItemID.String(),
+			StockMovementId:   layer.StockMovementID.String(),
+			UnitCost:          convertMoneyModelToPb(layer.UnitCost),
+			ReceivedQuantity:  layer.ReceivedQuantity.String(),
+			RemainingQuantity: layer.RemainingQuantity.String(),
+			ReceivedAt:        timestamppb.New(layer.ReceivedAt),
+		}
+	}
+
+	return &pb.ListCostLayersResponse{Layers: pbLayers, Total: int32(len(pbLayers))}, nil
+}
+
+func (h *InventoryHandler) GetInventoryValuation(ctx context.Context, req *pb.GetInventoryValuationRequest) (*pb.InventoryValuation, error) {
+	var ids [2]uuid.UUID
+	for i, s := range []string{req.WarehouseId, req.CategoryId} {
+		if s == "" {
+			continue
+		}
+		var err error
+		ids[i], err = uuid.Parse(s)
+		if err != nil {
+			log.Printf("Error parsing UUID: %v", err)
+			return nil, err
+		}
+	}
+	valuation, err := h.valuationService.GetInventoryValuation(ctx, ids[0], ids[1], timeFromPb(req.AsOf), timeFromPb(req.Since))
+	if err != nil {
+		log.Printf("Error in GetInventoryValuation: %v", err)
+		return nil, err
+	}
+
+	pbLines := make([]*pb.ValuationLine, len(valuation.Lines))
+	for i, line := range valuation.Lines {
+		pbLines[i] = &pb.ValuationLine{
+			InventoryItemId:    line.InventoryItemID.String(),
+			ProductId:          line.ProductID.String(),
+			WarehouseId:        line.WarehouseID.String(),
+			CategoryId:         line.CategoryID.String(),
+			CostingMethod:      pb.CostingMethod(line.CostingMethod),
+			Quantity:           line.Quantity.String(),
+			Value:              convertMoneyModelToPb(line.Value),
+			AverageUnitCost:    convertMoneyModelToPb(line.AverageUnitCost()),
+			CostOfGoodsRemoved: convertMoneyModelToPb(line.CostOfGoodsRemoved),
+		}
+	}
+
+	pbValue := make([]*pb.Money, len(valuation.Value))
+	for i, total := range valuation.Value {
+		pbValue[i] = convertMoneyModelToPb(total)
+	}
+	pbCostOfGoodsRemoved := make([]*pb.Money, len(valuation.CostOfGoodsRemoved))
+	for i, total := range valuation.CostOfGoodsRemoved {
+		pbCostOfGoodsRemoved[i] = convertMoneyModelToPb(total)
+	}
+
+	return &pb.InventoryValuation{
+		AsOf:               timestamppb.New(valuation.AsOf),
+		Since:              timestampOrNil(valuation.Since),
+		Lines:              pbLines,
+		Quantity:           valuation.Quantity.String(),
+		Value:              pbValue,
+		CostOfGoodsRemoved: pbCostOfGoodsRemoved,
+	}, nil
+}
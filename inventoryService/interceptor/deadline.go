@@ -0,0 +1,104 @@
+package interceptor
+
+import (
+	"context"
+	"encoding/json"
+	"google.golang.org/grpc"
+	"os"
+	"time"
+)
+
+// Duration is a time.Duration that is read from JSON as a string such as "5s".
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// Deadline is applied to a call that arrives without a deadline (Default) and
+// caps the deadline of any call that asks for more time (Max).
+type Deadline struct {
+	Default Duration `json:"default"`
+	Max     Duration `json:"max"`
+}
+
+type DeadlineConfig struct {
+	Default Deadline `json:"default"`
+	// Methods overrides the deadline of individual RPCs by full method name.
+	Methods map[string]Deadline `json:"methods"`
+}
+
+func DefaultDeadlineConfig() *DeadlineConfig {
+	listDeadline := Deadline{Default: Duration(30 * time.Second), Max: Duration(60 * time.Second)}
+	methods := make(map[string]Deadline)
+	for _, method := range expensiveMethods() {
+		methods[method] = listDeadline
+	}
+	return &DeadlineConfig{
+		Default: Deadline{Default: Duration(5 * time.Second), Max: Duration(30 * time.Second)},
+		Methods: methods,
+	}
+}
+
+// LoadDeadlineConfig reads a JSON config from path on top of the defaults.
+// An empty path returns the defaults.
+func LoadDeadlineConfig(path string) (*DeadlineConfig, error) {
+	cfg := DefaultDeadlineConfig()
+	if path == "" {
+		return cfg, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (c *DeadlineConfig) deadlineFor(method string) Deadline {
+	if deadline, ok := c.Methods[method]; ok {
+		return deadline
+	}
+	return c.Default
+}
+
+// DeadlineUnaryServerInterceptor bounds every unary call by the configured
+// default and maximum deadlines so that repository calls never run unbounded.
+// Streaming RPCs are long-lived by design and are not bounded here.
+func DeadlineUnaryServerInterceptor(config *DeadlineConfig) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, cancel := withBoundedDeadline(ctx, config.deadlineFor(info.FullMethod))
+		defer cancel()
+		return handler(ctx, req)
+	}
+}
+
+func withBoundedDeadline(ctx context.Context, deadline Deadline) (context.Context, context.CancelFunc) {
+	now := time.Now()
+	current, ok := ctx.Deadline()
+	if !ok {
+		if deadline.Default <= 0 {
+			return context.WithCancel(ctx)
+		}
+		return context.WithDeadline(ctx, now.Add(time.Duration(deadline.Default)))
+	}
+	if deadline.Max > 0 && current.Sub(now) > time.Duration(deadline.Max) {
+		return context.WithDeadline(ctx, now.Add(time.Duration(deadline.Max)))
+	}
+	return context.WithCancel(ctx)
+}
@@ -0,0 +1,297 @@
+package interceptor
+
+import (
+	"context"
+	"encoding/json"
+	"golang.org/x/time/rate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	pb "inventoryService/proto/inventory"
+	"log"
+	"math"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Limit is a token bucket refilled at RequestsPerSecond and holding at most
+// Burst tokens.
+type Limit struct {
+	RequestsPerSecond float64 `json:"requests_per_second"`
+	Burst             int     `json:"burst"`
+}
+
+type RateLimitConfig struct {
+	// PerClient bounds the total request rate of a single client across all RPCs.
+	PerClient Limit `json:"per_client"`
+	// Default applies to each RPC of a client unless overridden.
+	Default Limit `json:"default"`
+	// Expensive applies to each RPC listed in ExpensiveMethods.
+	Expensive        Limit    `json:"expensive"`
+	ExpensiveMethods []string `json:"expensive_methods"`
+	// ExpensiveTotal bounds the combined rate of the expensive RPCs across all
+	// clients, so that spreading calls over many client identities does not
+	// get around Expensive.
+	ExpensiveTotal Limit `json:"expensive_total"`
+	// Methods overrides the limit of individual RPCs by full method name.
+	Methods map[string]Limit `json:"methods"`
+}
+
+func DefaultRateLimitConfig() *RateLimitConfig {
+	return &RateLimitConfig{
+		PerClient:        Limit{RequestsPerSecond: 100, Burst: 200},
+		Default:          Limit{RequestsPerSecond: 50, Burst: 100},
+		Expensive:        Limit{RequestsPerSecond: 1, Burst: 5},
+		ExpensiveTotal:   Limit{RequestsPerSecond: 10, Burst: 20},
+		ExpensiveMethods: expensiveMethods(),
+		Methods:          map[string]Limit{},
+	}
+}
+
+// LoadRateLimitConfig reads a JSON config from path on top of the defaults.
+// An empty path returns the defaults.
+func LoadRateLimitConfig(path string) (*RateLimitConfig, error) {
+	cfg := DefaultRateLimitConfig()
+	if path == "" {
+		return cfg, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// expensiveMethods are the RPCs that scan whole tables or aggregate across
+// them. They get the Expensive rate limit and a longer deadline by default.
+func expensiveMethods() []string {
+	return []string{
+		pb.InventoryService_ListProducts_FullMethodName,
+		pb.InventoryService_ListCategories_FullMethodName,
+		pb.InventoryService_ListInventoryItems_FullMethodName,
+		pb.InventoryService_ListWarehouses_FullMethodName,
+		pb.InventoryService_ListSuppliers_FullMethodName,
+		pb.InventoryService_ListStockMovements_FullMethodName,
+		pb.InventoryService_ListAuditEvents_FullMethodName,
+		pb.InventoryService_ListReorderSuggestions_FullMethodName,
+		pb.InventoryService_ListPurchaseOrders_FullMethodName,
+		pb.InventoryService_ListGoodsReceipts_FullMethodName,
+		pb.InventoryService_ListSuppliersForProduct_FullMethodName,
+		pb.InventoryService_ListProductsForSupplier_FullMethodName,
+		pb.InventoryService_GetSupplierScorecard_FullMethodName,
+		pb.InventoryService_RankSuppliers_FullMethodName,
+		pb.InventoryService_ListSalesOrders_FullMethodName,
+		pb.InventoryService_ListReturnAuthorizations_FullMethodName,
+		pb.InventoryService_ListTransferOrders_FullMethodName,
+		pb.InventoryService_ListInTransitStock_FullMethodName,
+		pb.InventoryService_ListStockAdjustments_FullMethodName,
+		pb.InventoryService_ListCountSessions_FullMethodName,
+		pb.InventoryService_ListCountPlans_FullMethodName,
+		pb.InventoryService_ListCountPlanItems_FullMethodName,
+		pb.InventoryService_ListLots_FullMethodName,
+		pb.InventoryService_ListExpiringLots_FullMethodName,
+		pb.InventoryService_ListLocations_FullMethodName,
+		pb.InventoryService_ListBinStock_FullMethodName,
+		pb.InventoryService_SuggestPutaway_FullMethodName,
+		pb.InventoryService_ListCostLayers_FullMethodName,
+		pb.InventoryService_GetInventoryValuation_FullMethodName,
+		pb.InventoryService_GetPriceList_FullMethodName,
+		pb.InventoryService_ListPriceLists_FullMethodName,
+		pb.InventoryService_GetEffectivePrice_FullMethodName,
+		pb.InventoryService_ListExchangeRates_FullMethodName,
+		pb.InventoryService_GetPriceHistory_FullMethodName,
+	}
+}
+
+func (c *RateLimitConfig) limitFor(method string) Limit {
+	if limit, ok := c.Methods[method]; ok {
+		return limit
+	}
+	if c.isExpensive(method) {
+		return c.Expensive
+	}
+	return c.Default
+}
+
+func (c *RateLimitConfig) isExpensive(method string) bool {
+	for _, expensive := range c.ExpensiveMethods {
+		if expensive == method {
+			return true
+		}
+	}
+	return false
+}
+
+const bucketIdleTimeout = 10 * time.Minute
+
+// expensiveTotalKey is the bucket shared by the expensive RPCs of all
+// clients. Client keys always carry a prefix, so it cannot clash with one.
+const expensiveTotalKey = "expensive"
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// RateLimiter keeps a token bucket per client and per client and RPC.
+type RateLimiter struct {
+	config    *RateLimitConfig
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+func NewRateLimiter(config *RateLimitConfig) *RateLimiter {
+	return &RateLimiter{
+		config:    config,
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+	}
+}
+
+func (l *RateLimiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := l.allow(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (l *RateLimiter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := l.allow(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func (l *RateLimiter) allow(ctx context.Context, method string) error {
+	client := ClientID(ctx)
+	now := time.Now()
+
+	l.mu.Lock()
+	l.sweep(now)
+	reservations := []*rate.Reservation{
+		l.reserve(client, l.config.PerClient, now),
+		l.reserve(client+" "+method, l.config.limitFor(method), now),
+	}
+	if l.config.isExpensive(method) {
+		reservations = append(reservations, l.reserve(expensiveTotalKey, l.config.ExpensiveTotal, now))
+	}
+	l.mu.Unlock()
+
+	delay := maxDelay(reservations, now)
+	if delay == 0 {
+		return nil
+	}
+	for _, r := range reservations {
+		r.CancelAt(now)
+	}
+
+	log.Printf("Rate limit exceeded for client %s on %s, retry after %v", client, method, delay)
+	return rateLimitError(ctx, method, delay)
+}
+
+func (l *RateLimiter) reserve(key string, limit Limit, now time.Time) *rate.Reservation {
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(rate.Limit(limit.RequestsPerSecond), limit.Burst)}
+		l.buckets[key] = b
+	}
+	b.lastSeen = now
+	return b.limiter.ReserveN(now, 1)
+}
+
+// sweep drops buckets that have not been used for a while so that the number
+// of tracked clients does not grow without bound. Callers must hold l.mu.
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < bucketIdleTimeout {
+		return
+	}
+	for key, b := range l.buckets {
+		if now.Sub(b.lastSeen) > bucketIdleTimeout {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+// maxDelay returns how long the caller would have to wait until all
+// reservations can be honoured. A reservation that can never be honoured, such
+// as one against a zero burst, is reported as a one minute wait.
+func maxDelay(reservations []*rate.Reservation, now time.Time) time.Duration {
+	var delay time.Duration
+	for _, r := range reservations {
+		if !r.OK() {
+			return time.Minute
+		}
+		if d := r.DelayFrom(now); d > delay {
+			delay = d
+		}
+	}
+	return delay
+}
+
+func rateLimitError(ctx context.Context, method string, delay time.Duration) error {
+	seconds := int(math.Ceil(delay.Seconds()))
+	if err := grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(seconds))); err != nil {
+		log.Printf("Error setting retry-after header: %v", err)
+	}
+	st := status.Newf(codes.ResourceExhausted, "rate limit exceeded for %s, retry after %ds", method, seconds)
+	detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// ClientIDHeader carries the identity of the client a proxy such as the REST
+// gateway calls on behalf of.
+const ClientIDHeader = "x-client-id"
+
+// ClientID identifies the caller by its authenticated identity or, for
+// unauthenticated calls, by its remote host. Only a proxy on the loopback
+// interface, such as the in-process REST gateway, may name the client it
+// forwards for in the x-client-id header; the header is ignored from any
+// other peer so that callers cannot pick their own identity.
+func ClientID(ctx context.Context) string {
+	if identity, ok := Identity(ctx); ok {
+		return "user:" + identity
+	}
+	host := peerHost(ctx)
+	if host == "" {
+		return "anonymous"
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(ClientIDHeader); len(values) > 0 && values[0] != "" {
+				return "client:" + values[0]
+			}
+		}
+	}
+	return "peer:" + host
+}
+
+func peerHost(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
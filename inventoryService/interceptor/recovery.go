@@ -0,0 +1,39 @@
+package interceptor
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+	"runtime/debug"
+)
+
+// RecoveryUnaryServerInterceptor turns a panic in a handler into an Internal
+// error so that a single bad request or corrupt row cannot crash the server.
+func RecoveryUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recoverPanic(info.FullMethod, r)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+func RecoveryStreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recoverPanic(info.FullMethod, r)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+func recoverPanic(method string, r interface{}) error {
+	log.Printf("Panic in %s: %v\n%s", method, r, debug.Stack())
+	return status.Errorf(codes.Internal, "internal error in %s", method)
+}
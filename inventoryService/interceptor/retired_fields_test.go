@@ -0,0 +1,96 @@
+package interceptor
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	pb "inventoryService/proto/inventory"
+	"testing"
+)
+
+// withUnknownVarint returns m with an unknown varint field of the given number
+// set, as sent by a client built against another version of the schema.
+func withUnknownVarint[M proto.Message](m M, num protowire.Number) M {
+	raw := protowire.AppendTag(nil, num, protowire.VarintType)
+	raw = protowire.AppendVarint(raw, 5)
+	m.ProtoReflect().SetUnknown(raw)
+	return m
+}
+
+func TestCheckRetiredFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     proto.Message
+		wantErr bool
+	}{
+		{
+			name:    "no unknown fields",
+			req:     &pb.CreateInventoryItemRequest{InventoryItem: &pb.InventoryItem{Quantity: "5"}},
+			wantErr: false,
+		},
+		{
+			name:    "retired field of a nested message",
+			req:     &pb.CreateInventoryItemRequest{InventoryItem: withUnknownVarint(&pb.InventoryItem{}, 4)},
+			wantErr: true,
+		},
+		{
+			name: "retired field of a repeated message",
+			req: &pb.ReceiveGoodsRequest{Lines: []*pb.ReceiveGoodsLine{
+				{ReceivedQuantity: "1"},
+				withUnknownVarint(&pb.ReceiveGoodsLine{}, 2),
+			}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown field that was never reserved",
+			req:     &pb.CreateInventoryItemRequest{InventoryItem: withUnknownVarint(&pb.InventoryItem{}, 99)},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkRetiredFields(tt.req.ProtoReflect())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkRetiredFields() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && status.Code(err) != codes.InvalidArgument {
+				t.Errorf("checkRetiredFields() code = %v, want %v", status.Code(err), codes.InvalidArgument)
+			}
+		})
+	}
+}
+
+// recvStream is a server stream that receives one message.
+type recvStream struct {
+	grpc.ServerStream
+	msg proto.Message
+}
+
+func (s *recvStream) RecvMsg(m interface{}) error {
+	proto.Merge(m.(proto.Message), s.msg)
+	return nil
+}
+
+func TestRetiredFieldsStreamServerInterceptor(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      proto.Message
+		wantCode codes.Code
+	}{
+		{name: "current message", msg: &pb.InventoryItem{Quantity: "5"}, wantCode: codes.OK},
+		{name: "retired field", msg: withUnknownVarint(&pb.InventoryItem{}, 4), wantCode: codes.InvalidArgument},
+	}
+	intercept := RetiredFieldsStreamServerInterceptor()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := intercept(nil, &recvStream{msg: tt.msg}, &grpc.StreamServerInfo{}, func(_ interface{}, ss grpc.ServerStream) error {
+				return ss.RecvMsg(&pb.InventoryItem{})
+			})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("RecvMsg() error = %v, want code %v", err, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+	pb "inventoryService/proto/inventory"
+)
+
+func newCategoryCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "category",
+		Aliases: []string{"categories"},
+		Short:   "Manage categories",
+	}
+
+	var category pb.Category
+	create := &cobra.Command{
+		Use:   "create",
+		Short: "Create a category",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ctx, done, err := connect()
+			if err != nil {
+				return err
+			}
+			defer done()
+			created, err := client.CreateCategory(ctx, &pb.CreateCategoryRequest{Category: &category})
+			if err != nil {
+				return err
+			}
+			return printSingle(categoryResult(created))
+		},
+	}
+	addCategoryFlags(create, &category)
+	create.MarkFlagRequired("name")
+
+	get := &cobra.Command{
+		Use:   "get ID",
+		Short: "Show a category",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ctx, done, err := connect()
+			if err != nil {
+				return err
+			}
+			defer done()
+			found, err := client.GetCategory(ctx, &pb.GetCategoryRequest{Id: args[0]})
+			if err != nil {
+				return err
+			}
+			return printSingle(categoryResult(found))
+		},
+	}
+
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List categories",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ctx, done, err := connect()
+			if err != nil {
+				return err
+			}
+			defer done()
+			resp, err := client.ListCategories(ctx, &pb.ListCategoriesRequest{})
+			if err != nil {
+				return err
+			}
+			return printResult(categoryResult(resp.Categories...))
+		},
+	}
+
+	var changes pb.Category
+	update := &cobra.Command{
+		Use:   "update ID",
+		Short: "Update a category, changing only the given fields",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ctx, done, err := connect()
+			if err != nil {
+				return err
+			}
+			defer done()
+			current, err := client.GetCategory(ctx, &pb.GetCategoryRequest{Id: args[0]})
+			if err != nil {
+				return err
+			}
+			flags := cmd.Flags()
+			if flags.Changed("name") {
+				current.Name = changes.Name
+			}
+			if flags.Changed("description") {
+				current.Description = changes.Description
+			}
+			updated, err := client.UpdateCategory(ctx, &pb.UpdateCategoryRequest{Category: current})
+			if err != nil {
+				return err
+			}
+			return printSingle(categoryResult(updated))
+		},
+	}
+	addCategoryFlags(update, &changes)
+
+	del := &cobra.Command{
+		Use:   "delete ID",
+		Short: "Delete a category",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ctx, done, err := connect()
+			if err != nil {
+				return err
+			}
+			defer done()
+			_, err = client.DeleteCategory(ctx, &pb.DeleteCategoryRequest{Id: args[0]})
+			return err
+		},
+	}
+
+	cmd.AddCommand(create, get, list, update, del)
+	return cmd
+}
+
+func addCategoryFlags(cmd *cobra.Command, category *pb.Category) {
+	flags := cmd.Flags()
+	flags.StringVar(&category.Name, "name", "", "category name")
+	flags.StringVar(&category.Description, "description", "", "category description")
+}
+
+func categoryResult(categories ...*pb.Category) *result {
+	r := &result{
+		headers: []string{"ID", "NAME", "DESCRIPTION"},
+	}
+	for _, c := range categories {
+		r.add(c, c.Id, c.Name, c.Description)
+	}
+	return r
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+const defaultProfileName = "default"
+
+type Profile struct {
+	Address  string `json:"address"`
+	Token    string `json:"token,omitempty"`
+	Insecure bool   `json:"insecure"`
+}
+
+type Config struct {
+	CurrentProfile string              `json:"current_profile"`
+	Profiles       map[string]*Profile `json:"profiles"`
+}
+
+func defaultConfig() *Config {
+	return &Config{
+		CurrentProfile: defaultProfileName,
+		Profiles: map[string]*Profile{
+			defaultProfileName: {Address: "localhost:50051", Insecure: true},
+		},
+	}
+}
+
+func defaultConfigPath() string {
+	if path := os.Getenv("INVCTL_CONFIG"); path != "" {
+		return path
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ".invctl.json"
+	}
+	return filepath.Join(dir, "invctl", "config.json")
+}
+
+func loadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return defaultConfig(), nil
+		}
+		return nil, err
+	}
+	cfg := &Config{}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("error parsing config %s: %w", path, err)
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = map[string]*Profile{}
+	}
+	return cfg, nil
+}
+
+func saveConfig(path string, cfg *Config) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
+
+func (c *Config) profile(name string) (*Profile, error) {
+	if name == "" {
+		name = c.CurrentProfile
+	}
+	profile, ok := c.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("profile %q not found", name)
+	}
+	return profile, nil
+}
+
+func (c *Config) profileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"github.com/spf13/cobra"
+	"os"
+	"strconv"
+	"text/tabwriter"
+)
+
+func newConfigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage connection profiles",
+	}
+
+	completeProfiles := func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		cfg, err := loadConfig(opts.configPath)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		return cfg.profileNames(), cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var profile Profile
+	set := &cobra.Command{
+		Use:               "set-profile NAME",
+		Short:             "Create or update a profile",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeProfiles,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig(opts.configPath)
+			if err != nil {
+				return err
+			}
+			existing, ok := cfg.Profiles[args[0]]
+			if !ok {
+				existing = &Profile{Address: "localhost:50051", Insecure: true}
+				cfg.Profiles[args[0]] = existing
+			}
+			flags := cmd.Flags()
+			if flags.Changed("server") {
+				existing.Address = profile.Address
+			}
+			if flags.Changed("bearer-token") {
+				existing.Token = profile.Token
+			}
+			if flags.Changed("insecure") {
+				existing.Insecure = profile.Insecure
+			}
+			if cfg.CurrentProfile == "" {
+				cfg.CurrentProfile = args[0]
+			}
+			return saveConfig(opts.configPath, cfg)
+		},
+	}
+	set.Flags().StringVar(&profile.Address, "server", "", "server address (host:port)")
+	set.Flags().StringVar(&profile.Token, "bearer-token", "", "bearer token sent with every request")
+	set.Flags().BoolVar(&profile.Insecure, "insecure", true, "connect without TLS")
+
+	use := &cobra.Command{
+		Use:               "use-profile NAME",
+		Short:             "Make a profile the current profile",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeProfiles,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig(opts.configPath)
+			if err != nil {
+				return err
+			}
+			if _, err := cfg.profile(args[0]); err != nil {
+				return err
+			}
+			cfg.CurrentProfile = args[0]
+			return saveConfig(opts.configPath, cfg)
+		},
+	}
+
+	del := &cobra.Command{
+		Use:               "delete-profile NAME",
+		Short:             "Delete a profile",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeProfiles,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig(opts.configPath)
+			if err != nil {
+				return err
+			}
+			if _, err := cfg.profile(args[0]); err != nil {
+				return err
+			}
+			delete(cfg.Profiles, args[0])
+			if cfg.CurrentProfile == args[0] {
+				cfg.CurrentProfile = ""
+			}
+			return saveConfig(opts.configPath, cfg)
+		},
+	}
+
+	view := &cobra.Command{
+		Use:   "view",
+		Short: "List the configured profiles",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig(opts.configPath)
+			if err != nil {
+				return err
+			}
+			tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(tw, "CURRENT\tNAME\tADDRESS\tINSECURE\tTOKEN")
+			for _, name := range cfg.profileNames() {
+				p := cfg.Profiles[name]
+				current := ""
+				if name == cfg.CurrentProfile {
+					current = "*"
+				}
+				token := ""
+				if p.Token != "" {
+					token = "<set>"
+				}
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", current, name, p.Address, strconv.FormatBool(p.Insecure), token)
+			}
+			return tw.Flush()
+		},
+	}
+
+	cmd.AddCommand(set, use, del, view)
+	return cmd
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+	pb "inventoryService/proto/inventory"
+)
+
+func newInventoryItemCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "inventory-item",
+		Aliases: []string{"inventory-items", "item", "items"},
+		Short:   "Manage inventory items",
+	}
+
+	var item pb.InventoryItem
+	create := &cobra.Command{
+		Use:   "create",
+		Short: "Create a inventory item",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ctx, done, err := connect()
+			if err != nil {
+				return err
+			}
+			defer done()
+			created, err := client.CreateInventoryItem(ctx, &pb.CreateInventoryItemRequest{InventoryItem: &item})
+			if err != nil {
+				return err
+			}
+			return printSingle(itemResult(created))
+		},
+	}
+	addInventoryItemFlags(create, &item)
+	// Stock of an existing item changes through the stock commands.
+	create.Flags().StringVar(&item.Quantity, "quantity", "", `quantity on hand, such as "12.5"`)
+	create.MarkFlagRequired("product-id")
+	create.MarkFlagRequired("warehouse-id")
+
+	get := &cobra.Command{
+		Use:   "get ID",
+		Short: "Show a inventory item",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ctx, done, err := connect()
+			if err != nil {
+				return err
+			}
+			defer done()
+			found, err := client.GetInventoryItem(ctx, &pb.GetInventoryItemRequest{Id: args[0]})
+			if err != nil {
+				return err
+			}
+			return printSingle(itemResult(found))
+		},
+	}
+
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List inventory items",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ctx, done, err := connect()
+			if err != nil {
+				return err
+			}
+			defer done()
+			resp, err := client.ListInventoryItems(ctx, &pb.ListInventoryItemsRequest{})
+			if err != nil {
+				return err
+			}
+			return printResult(itemResult(resp.InventoryItems...))
+		},
+	}
+
+	var changes pb.InventoryItem
+	update := &cobra.Command{
+		Use:   "update ID",
+		Short: "Update a inventory item, changing only the given fields",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ctx, done, err := connect()
+			if err != nil {
+				return err
+			}
+			defer done()
+			current, err := client.GetInventoryItem(ctx, &pb.GetInventoryItemRequest{Id: args[0]})
+			if err != nil {
+				return err
+			}
+			flags := cmd.Flags()
+			if flags.Changed("product-id") {
+				current.ProductId = changes.ProductId
+			}
+			if flags.Changed("warehouse-id") {
+				current.WarehouseId = changes.WarehouseId
+			}
+			if flags.Changed("reorder-level") {
+				current.ReorderLevel = changes.ReorderLevel
+			}
+			if flags.Changed("reorder-quantity") {
+				current.ReorderQuantity = changes.ReorderQuantity
+			}
+			updated, err := client.UpdateInventoryItem(ctx, &pb.UpdateInventoryItemRequest{InventoryItem: current})
+			if err != nil {
+				return err
+			}
+			return printSingle(itemResult(updated))
+		},
+	}
+	addInventoryItemFlags(update, &changes)
+
+	del := &cobra.Command{
+		Use:   "delete ID",
+		Short: "Delete a inventory item",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ctx, done, err := connect()
+			if err != nil {
+				return err
+			}
+			defer done()
+			_, err = client.DeleteInventoryItem(ctx, &pb.DeleteInventoryItemRequest{Id: args[0]})
+			return err
+		},
+	}
+
+	cmd.AddCommand(create, get, list, update, del)
+	return cmd
+}
+
+func addInventoryItemFlags(cmd *cobra.Command, item *pb.InventoryItem) {
+	flags := cmd.Flags()
+	flags.StringVar(&item.ProductId, "product-id", "", "ID of the product")
+	flags.StringVar(&item.WarehouseId, "warehouse-id", "", "ID of the warehouse")
+	flags.StringVar(&item.ReorderLevel, "reorder-level", "", "quantity at or below which the item is reordered")
+	flags.StringVar(&item.ReorderQuantity, "reorder-quantity", "", "quantity to reorder")
+}
+
+func itemResult(items ...*pb.InventoryItem) *result {
+	r := &result{
+		headers: []string{"ID", "PRODUCT ID", "WAREHOUSE ID", "QUANTITY", "RESERVED", "AVAILABLE", "QUARANTINED", "REORDER LEVEL", "REORDER QUANTITY"},
+	}
+	for _, i := range items {
+		r.add(i, i.Id, i.ProductId, i.WarehouseId, i.Quantity, i.ReservedQuantity, i.AvailableQuantity,
+			i.QuarantinedQuantity, i.ReorderLevel, i.ReorderQuantity)
+	}
+	return r
+}
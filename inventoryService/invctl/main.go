@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	pb "inventoryService/proto/inventory"
+	"os"
+	"time"
+)
+
+type globalOptions struct {
+	configPath string
+	profile    string
+	address    string
+	token      string
+	output     string
+	timeout    time.Duration
+}
+
+var opts globalOptions
+
+func main() {
+	if err := newRootCommand().Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func newRootCommand() *cobra.Command {
+	root := &cobra.Command{
+		Use:          "invctl",
+		Short:        "Command-line client for the inventory service",
+		SilenceUsage: true,
+	}
+
+	flags := root.PersistentFlags()
+	flags.StringVar(&opts.configPath, "config", defaultConfigPath(), "path to the invctl config file")
+	flags.StringVar(&opts.profile, "profile", "", "config profile to use (defaults to the current profile)")
+	flags.StringVar(&opts.address, "address", "", "server address, overrides the profile")
+	flags.StringVar(&opts.token, "token", "", "bearer token, overrides the profile")
+	flags.StringVarP(&opts.output, "output", "o", formatTable, "output format: table, json or csv")
+	flags.DurationVar(&opts.timeout, "timeout", 10*time.Second, "timeout for each request")
+
+	root.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{formatTable, formatJSON, formatCSV}, cobra.ShellCompDirectiveNoFileComp
+	})
+	root.RegisterFlagCompletionFunc("profile", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		cfg, err := loadConfig(opts.configPath)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		return cfg.profileNames(), cobra.ShellCompDirectiveNoFileComp
+	})
+
+	root.AddCommand(
+		newProductCommand(),
+		newCategoryCommand(),
+		newWarehouseCommand(),
+		newSupplierCommand(),
+		newInventoryItemCommand(),
+		newStockCommand(),
+		newConfigCommand(),
+	)
+	return root
+}
+
+// connect dials the server selected by the active profile and returns a
+// client together with a context that carries the request timeout and the
+// profile credentials. The returned function must be called when done.
+func connect() (pb.InventoryServiceClient, context.Context, func(), error) {
+	cfg, err := loadConfig(opts.configPath)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	profile, err := cfg.profile(opts.profile)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	address := profile.Address
+	if opts.address != "" {
+		address = opts.address
+	}
+	token := profile.Token
+	if opts.token != "" {
+		token = opts.token
+	}
+
+	transport := grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(nil, ""))
+	if profile.Insecure {
+		transport = grpc.WithTransportCredentials(insecure.NewCredentials())
+	}
+	conn, err := grpc.Dial(address, transport)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error connecting to %s: %w", address, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.timeout)
+	if token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+	}
+	cleanup := func() {
+		cancel()
+		conn.Close()
+	}
+	return pb.NewInventoryServiceClient(conn), ctx, cleanup, nil
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"inventoryService/model"
+	pb "inventoryService/proto/inventory"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatCSV   = "csv"
+)
+
+// result is a set of records ready to be rendered in any output format.
+// Rows and messages are index-aligned. A single result is rendered as a JSON
+// object rather than an array.
+type result struct {
+	headers  []string
+	rows     [][]string
+	messages []proto.Message
+	single   bool
+}
+
+func (r *result) add(msg proto.Message, row ...string) {
+	r.messages = append(r.messages, msg)
+	r.rows = append(r.rows, row)
+}
+
+func printResult(r *result) error {
+	return writeResult(os.Stdout, opts.output, r)
+}
+
+func printSingle(r *result) error {
+	r.single = true
+	return printResult(r)
+}
+
+func writeResult(w io.Writer, format string, r *result) error {
+	switch strings.ToLower(format) {
+	case formatTable:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.Join(r.headers, "\t"))
+		for _, row := range r.rows {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		return tw.Flush()
+	case formatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(r.headers); err != nil {
+			return err
+		}
+		if err := cw.WriteAll(r.rows); err != nil {
+			return err
+		}
+		return cw.Error()
+	case formatJSON:
+		items := make([]json.RawMessage, len(r.messages))
+		for i, msg := range r.messages {
+			data, err := protojson.Marshal(msg)
+			if err != nil {
+				return err
+			}
+			items[i] = data
+		}
+		var data []byte
+		var err error
+		if r.single && len(items) == 1 {
+			data, err = json.MarshalIndent(items[0], "", "  ")
+		} else {
+			data, err = json.MarshalIndent(items, "", "  ")
+		}
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	default:
+		return fmt.Errorf("unknown output format %q", format)
+	}
+}
+
+// formatMoney writes an amount of money as "12.50 USD", or "" when unset.
+func formatMoney(money *pb.Money) string {
+	if money == nil {
+		return ""
+	}
+	return model.Money{CurrencyCode: money.CurrencyCode, Units: money.Units, Nanos: money.Nanos}.String()
+}
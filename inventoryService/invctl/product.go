@@ -0,0 +1,191 @@
+package main
+
+import (
+	"fmt"
+	"github.com/spf13/cobra"
+	"inventoryService/model"
+	pb "inventoryService/proto/inventory"
+	"strings"
+)
+
+func newProductCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "product",
+		Aliases: []string{"products"},
+		Short:   "Manage products",
+	}
+
+	var product pb.Product
+	create := &cobra.Command{
+		Use:   "create",
+		Short: "Create a product",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ctx, done, err := connect()
+			if err != nil {
+				return err
+			}
+			defer done()
+			created, err := client.CreateProduct(ctx, &pb.CreateProductRequest{Product: &product})
+			if err != nil {
+				return err
+			}
+			return printSingle(productResult(created))
+		},
+	}
+	addProductFlags(create, &product)
+	create.MarkFlagRequired("name")
+	create.MarkFlagRequired("sku")
+	create.MarkFlagRequired("category-id")
+
+	get := &cobra.Command{
+		Use:   "get ID",
+		Short: "Show a product",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ctx, done, err := connect()
+			if err != nil {
+				return err
+			}
+			defer done()
+			found, err := client.GetProduct(ctx, &pb.GetProductRequest{Id: args[0]})
+			if err != nil {
+				return err
+			}
+			return printSingle(productResult(found))
+		},
+	}
+
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List products",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ctx, done, err := connect()
+			if err != nil {
+				return err
+			}
+			defer done()
+			resp, err := client.ListProducts(ctx, &pb.ListProductsRequest{})
+			if err != nil {
+				return err
+			}
+			return printResult(productResult(resp.Products...))
+		},
+	}
+
+	var changes pb.Product
+	update := &cobra.Command{
+		Use:   "update ID",
+		Short: "Update a product, changing only the given fields",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ctx, done, err := connect()
+			if err != nil {
+				return err
+			}
+			defer done()
+			current, err := client.GetProduct(ctx, &pb.GetProductRequest{Id: args[0]})
+			if err != nil {
+				return err
+			}
+			flags := cmd.Flags()
+			if flags.Changed("name") {
+				current.Name = changes.Name
+			}
+			if flags.Changed("description") {
+				current.Description = changes.Description
+			}
+			if flags.Changed("category-id") {
+				current.CategoryId = changes.CategoryId
+			}
+			if flags.Changed("price") {
+				current.Price = changes.Price
+			}
+			if flags.Changed("sku") {
+				current.Sku = changes.Sku
+			}
+			if flags.Changed("base-unit") {
+				current.BaseUnit = changes.BaseUnit
+			}
+			if flags.Changed("quantity-precision") {
+				current.QuantityPrecision = changes.QuantityPrecision
+			}
+			updated, err := client.UpdateProduct(ctx, &pb.UpdateProductRequest{Product: current})
+			if err != nil {
+				return err
+			}
+			return printSingle(productResult(updated))
+		},
+	}
+	addProductFlags(update, &changes)
+
+	del := &cobra.Command{
+		Use:   "delete ID",
+		Short: "Delete a product",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ctx, done, err := connect()
+			if err != nil {
+				return err
+			}
+			defer done()
+			_, err = client.DeleteProduct(ctx, &pb.DeleteProductRequest{Id: args[0]})
+			return err
+		},
+	}
+
+	cmd.AddCommand(create, get, list, update, del)
+	return cmd
+}
+
+func addProductFlags(cmd *cobra.Command, product *pb.Product) {
+	flags := cmd.Flags()
+	flags.StringVar(&product.Name, "name", "", "product name")
+	flags.StringVar(&product.Description, "description", "", "product description")
+	flags.StringVar(&product.CategoryId, "category-id", "", "ID of the product category")
+	flags.Var(moneyValue{&product.Price}, "price", `unit price with its currency, such as "12.50 USD"`)
+	flags.StringVar(&product.Sku, "sku", "", "stock keeping unit")
+	flags.StringVar(&product.BaseUnit, "base-unit", "", `unit stock is counted in (default "each")`)
+	flags.Int32Var(&product.QuantityPrecision, "quantity-precision", 0, "number of decimals stock is counted in, up to 6")
+}
+
+func productResult(products ...*pb.Product) *result {
+	r := &result{
+		headers: []string{"ID", "NAME", "SKU", "CATEGORY ID", "PRICE", "DESCRIPTION"},
+	}
+	for _, p := range products {
+		r.add(p, p.Id, p.Name, p.Sku, p.CategoryId, formatMoney(p.Price), p.Description)
+	}
+	return r
+}
+
+// moneyValue is a flag holding an amount of money written as the amount and
+// the currency code, such as "12.50 USD".
+type moneyValue struct {
+	money **pb.Money
+}
+
+func (v moneyValue) String() string {
+	if v.money == nil || *v.money == nil {
+		return ""
+	}
+	return formatMoney(*v.money)
+}
+
+func (v moneyValue) Set(s string) error {
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		return fmt.Errorf("want an amount and a currency code, such as \"12.50 USD\"")
+	}
+	money, err := model.ParseMoney(fields[0], strings.ToUpper(fields[1]))
+	if err != nil {
+		return err
+	}
+	*v.money = &pb.Money{CurrencyCode: money.CurrencyCode, Units: money.Units, Nanos: money.Nanos}
+	return nil
+}
+
+func (v moneyValue) Type() string {
+	return "money"
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	pb "inventoryService/proto/inventory"
+	"strconv"
+	"time"
+)
+
+func newStockCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "stock",
+		Aliases: []string{"stock-movement", "stock-movements"},
+		Short:   "Journal and inspect stock movements",
+		Long: `Journal and inspect stock movements.
+
+The add, remove and transfer commands only record a movement in the journal;
+they do not change the stock of any inventory item. Stock changes through
+goods receipts, sales orders, transfer orders, returns and stock adjustments,
+whose movements are listed as posted.`,
+	}
+
+	cmd.AddCommand(
+		newStockMovementCommand("add", "Journal an addition to an inventory item without changing its stock", pb.StockMovementType_ADDITION),
+		newStockMovementCommand("remove", "Journal a removal from an inventory item without changing its stock", pb.StockMovementType_REMOVAL),
+		newStockMovementCommand("transfer", "Journal a transfer between warehouses without changing stock", pb.StockMovementType_TRANSFER),
+	)
+
+	get := &cobra.Command{
+		Use:   "get ID",
+		Short: "Show a stock movement",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ctx, done, err := connect()
+			if err != nil {
+				return err
+			}
+			defer done()
+			found, err := client.GetStockMovement(ctx, &pb.GetStockMovementRequest{Id: args[0]})
+			if err != nil {
+				return err
+			}
+			return printSingle(stockMovementResult(found))
+		},
+	}
+
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List stock movements",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ctx, done, err := connect()
+			if err != nil {
+				return err
+			}
+			defer done()
+			resp, err := client.ListStockMovements(ctx, &pb.ListStockMovementsRequest{})
+			if err != nil {
+				return err
+			}
+			return printResult(stockMovementResult(resp.StockMovements...))
+		},
+	}
+
+	del := &cobra.Command{
+		Use:   "delete ID",
+		Short: "Delete a stock movement that was only journaled",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ctx, done, err := connect()
+			if err != nil {
+				return err
+			}
+			defer done()
+			_, err = client.DeleteStockMovement(ctx, &pb.DeleteStockMovementRequest{Id: args[0]})
+			return err
+		},
+	}
+
+	cmd.AddCommand(get, list, del)
+	return cmd
+}
+
+func newStockMovementCommand(use, short string, movementType pb.StockMovementType) *cobra.Command {
+	var movement pb.StockMovement
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ctx, done, err := connect()
+			if err != nil {
+				return err
+			}
+			defer done()
+			movement.Type = movementType
+			movement.Date = timestamppb.Now()
+			if movement.Unit != "" {
+				movement.UnitQuantity = movement.Quantity
+				movement.Quantity = ""
+			}
+			created, err := client.CreateStockMovement(ctx, &pb.CreateStockMovementRequest{StockMovement: &movement})
+			if err != nil {
+				return err
+			}
+			return printSingle(stockMovementResult(created))
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&movement.InventoryItemId, "item-id", "", "ID of the inventory item")
+	flags.StringVar(&movement.Quantity, "quantity", "", `quantity moved, such as "12.5"`)
+	flags.StringVar(&movement.Unit, "unit", "", "unit of the product the quantity is in (default its base unit)")
+	cmd.MarkFlagRequired("item-id")
+	cmd.MarkFlagRequired("quantity")
+	switch movementType {
+	case pb.StockMovementType_ADDITION:
+		flags.StringVar(&movement.DestinationWarehouseId, "warehouse-id", "", "ID of the receiving warehouse")
+	case pb.StockMovementType_REMOVAL:
+		flags.StringVar(&movement.SourceWarehouseId, "warehouse-id", "", "ID of the issuing warehouse")
+	case pb.StockMovementType_TRANSFER:
+		flags.StringVar(&movement.SourceWarehouseId, "from", "", "ID of the source warehouse")
+		flags.StringVar(&movement.DestinationWarehouseId, "to", "", "ID of the destination warehouse")
+		cmd.MarkFlagRequired("from")
+		cmd.MarkFlagRequired("to")
+	}
+	return cmd
+}
+
+func stockMovementResult(movements ...*pb.StockMovement) *result {
+	r := &result{
+		headers: []string{"ID", "ITEM ID", "TYPE", "QUANTITY", "DATE", "SOURCE WAREHOUSE", "DESTINATION WAREHOUSE", "POSTED"},
+	}
+	for _, m := range movements {
+		r.add(m, m.Id, m.InventoryItemId, m.Type.String(), m.Quantity,
+			m.Date.AsTime().Format(time.RFC3339), m.SourceWarehouseId, m.DestinationWarehouseId, strconv.FormatBool(m.Posted))
+	}
+	return r
+}
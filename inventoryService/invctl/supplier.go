@@ -0,0 +1,136 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+	pb "inventoryService/proto/inventory"
+)
+
+func newSupplierCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "supplier",
+		Aliases: []string{"suppliers"},
+		Short:   "Manage suppliers",
+	}
+
+	var supplier pb.Supplier
+	create := &cobra.Command{
+		Use:   "create",
+		Short: "Create a supplier",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ctx, done, err := connect()
+			if err != nil {
+				return err
+			}
+			defer done()
+			created, err := client.CreateSupplier(ctx, &pb.CreateSupplierRequest{Supplier: &supplier})
+			if err != nil {
+				return err
+			}
+			return printSingle(supplierResult(created))
+		},
+	}
+	addSupplierFlags(create, &supplier)
+	create.MarkFlagRequired("name")
+
+	get := &cobra.Command{
+		Use:   "get ID",
+		Short: "Show a supplier",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ctx, done, err := connect()
+			if err != nil {
+				return err
+			}
+			defer done()
+			found, err := client.GetSupplier(ctx, &pb.GetSupplierRequest{Id: args[0]})
+			if err != nil {
+				return err
+			}
+			return printSingle(supplierResult(found))
+		},
+	}
+
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List suppliers",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ctx, done, err := connect()
+			if err != nil {
+				return err
+			}
+			defer done()
+			resp, err := client.ListSuppliers(ctx, &pb.ListSuppliersRequest{})
+			if err != nil {
+				return err
+			}
+			return printResult(supplierResult(resp.Suppliers...))
+		},
+	}
+
+	var changes pb.Supplier
+	update := &cobra.Command{
+		Use:   "update ID",
+		Short: "Update a supplier, changing only the given fields",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ctx, done, err := connect()
+			if err != nil {
+				return err
+			}
+			defer done()
+			current, err := client.GetSupplier(ctx, &pb.GetSupplierRequest{Id: args[0]})
+			if err != nil {
+				return err
+			}
+			flags := cmd.Flags()
+			if flags.Changed("name") {
+				current.Name = changes.Name
+			}
+			if flags.Changed("contact-info") {
+				current.ContactInfo = changes.ContactInfo
+			}
+			updated, err := client.UpdateSupplier(ctx, &pb.UpdateSupplierRequest{Supplier: current})
+			if err != nil {
+				return err
+			}
+			return printSingle(supplierResult(updated))
+		},
+	}
+	addSupplierFlags(update, &changes)
+
+	del := &cobra.Command{
+		Use:   "delete ID",
+		Short: "Delete a supplier",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ctx, done, err := connect()
+			if err != nil {
+				return err
+			}
+			defer done()
+			_, err = client.DeleteSupplier(ctx, &pb.DeleteSupplierRequest{Id: args[0]})
+			return err
+		},
+	}
+
+	cmd.AddCommand(create, get, list, update, del)
+	return cmd
+}
+
+func addSupplierFlags(cmd *cobra.Command, supplier *pb.Supplier) {
+	flags := cmd.Flags()
+	flags.StringVar(&supplier.Name, "name", "", "supplier name")
+	flags.StringVar(&supplier.ContactInfo, "contact-info", "", "supplier contact information")
+}
+
+func supplierResult(suppliers ...*pb.Supplier) *result {
+	r := &result{
+		headers: []string{"ID", "NAME", "CONTACT INFO"},
+	}
+	for _, s := range suppliers {
+		r.add(s, s.Id, s.Name, s.ContactInfo)
+	}
+	return r
+}
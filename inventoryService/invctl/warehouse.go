@@ -0,0 +1,178 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+	pb "inventoryService/proto/inventory"
+)
+
+func newWarehouseCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "warehouse",
+		Aliases: []string{"warehouses"},
+		Short:   "Manage warehouses",
+	}
+
+	var warehouse pb.Warehouse
+	create := &cobra.Command{
+		Use:   "create",
+		Short: "Create a warehouse",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ctx, done, err := connect()
+			if err != nil {
+				return err
+			}
+			defer done()
+			created, err := client.CreateWarehouse(ctx, &pb.CreateWarehouseRequest{Warehouse: &warehouse})
+			if err != nil {
+				return err
+			}
+			return printSingle(warehouseResult(created))
+		},
+	}
+	addWarehouseFlags(create, &warehouse)
+	create.MarkFlagRequired("name")
+
+	get := &cobra.Command{
+		Use:   "get ID",
+		Short: "Show a warehouse",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ctx, done, err := connect()
+			if err != nil {
+				return err
+			}
+			defer done()
+			found, err := client.GetWarehouse(ctx, &pb.GetWarehouseRequest{Id: args[0]})
+			if err != nil {
+				return err
+			}
+			return printSingle(warehouseResult(found))
+		},
+	}
+
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List warehouses",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ctx, done, err := connect()
+			if err != nil {
+				return err
+			}
+			defer done()
+			resp, err := client.ListWarehouses(ctx, &pb.ListWarehousesRequest{})
+			if err != nil {
+				return err
+			}
+			return printResult(warehouseResult(resp.Warehouses...))
+		},
+	}
+
+	var changes pb.Warehouse
+	update := &cobra.Command{
+		Use:   "update ID",
+		Short: "Update a warehouse, changing only the given fields",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ctx, done, err := connect()
+			if err != nil {
+				return err
+			}
+			defer done()
+			current, err := client.GetWarehouse(ctx, &pb.GetWarehouseRequest{Id: args[0]})
+			if err != nil {
+				return err
+			}
+			flags := cmd.Flags()
+			if flags.Changed("name") {
+				current.Name = changes.Name
+			}
+			if flags.Changed("location") {
+				current.Location = changes.Location
+			}
+			updated, err := client.UpdateWarehouse(ctx, &pb.UpdateWarehouseRequest{Warehouse: current})
+			if err != nil {
+				return err
+			}
+			return printSingle(warehouseResult(updated))
+		},
+	}
+	addWarehouseFlags(update, &changes)
+
+	del := &cobra.Command{
+		Use:   "delete ID",
+		Short: "Delete a warehouse",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ctx, done, err := connect()
+			if err != nil {
+				return err
+			}
+			defer done()
+			_, err = client.DeleteWarehouse(ctx, &pb.DeleteWarehouseRequest{Id: args[0]})
+			return err
+		},
+	}
+
+	cmd.AddCommand(create, get, list, update, del, newWarehouseStockCommand())
+	return cmd
+}
+
+func addWarehouseFlags(cmd *cobra.Command, warehouse *pb.Warehouse) {
+	flags := cmd.Flags()
+	flags.StringVar(&warehouse.Name, "name", "", "warehouse name")
+	flags.StringVar(&warehouse.Location, "location", "", "warehouse location")
+}
+
+func warehouseResult(warehouses ...*pb.Warehouse) *result {
+	r := &result{
+		headers: []string{"ID", "NAME", "LOCATION"},
+	}
+	for _, w := range warehouses {
+		r.add(w, w.Id, w.Name, w.Location)
+	}
+	return r
+}
+
+func newWarehouseStockCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "stock WAREHOUSE_ID",
+		Short: "Show the stock held in a warehouse",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ctx, done, err := connect()
+			if err != nil {
+				return err
+			}
+			defer done()
+			items, err := client.ListInventoryItems(ctx, &pb.ListInventoryItemsRequest{})
+			if err != nil {
+				return err
+			}
+			products, err := client.ListProducts(ctx, &pb.ListProductsRequest{})
+			if err != nil {
+				return err
+			}
+			productsByID := make(map[string]*pb.Product, len(products.Products))
+			for _, p := range products.Products {
+				productsByID[p.Id] = p
+			}
+
+			r := &result{
+				headers: []string{"ITEM ID", "PRODUCT ID", "SKU", "PRODUCT", "QUANTITY", "REORDER LEVEL"},
+			}
+			for _, i := range items.InventoryItems {
+				if i.WarehouseId != args[0] {
+					continue
+				}
+				var sku, name string
+				if p, ok := productsByID[i.ProductId]; ok {
+					sku, name = p.Sku, p.Name
+				}
+				r.add(i, i.Id, i.ProductId, sku, name, i.Quantity, i.ReorderLevel)
+			}
+			return printResult(r)
+		},
+	}
+}
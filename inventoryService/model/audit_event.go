@@ -0,0 +1,67 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type AuditEvent struct {
+	ID         uuid.UUID   `json:"id"`
+	EntityType EntityType  `json:"entity_type"`
+	EntityID   uuid.UUID   `json:"entity_id"`
+	Action     AuditAction `json:"action"`
+	Actor      string      `json:"actor"`
+	RPC        string      `json:"rpc"`
+	Before     string      `json:"before"`
+	After      string      `json:"after"`
+	Timestamp  time.Time   `json:"timestamp"`
+}
+
+type EntityType string
+
+const (
+	EntityProduct             EntityType = "product"
+	EntityCategory            EntityType = "category"
+	EntityWarehouse           EntityType = "warehouse"
+	EntityInventoryItem       EntityType = "inventory_item"
+	EntityStockMovement       EntityType = "stock_movement"
+	EntitySupplier            EntityType = "supplier"
+	EntityPurchaseOrder       EntityType = "purchase_order"
+	EntityGoodsReceipt        EntityType = "goods_receipt"
+	EntitySupplierProduct     EntityType = "supplier_product"
+	EntitySalesOrder          EntityType = "sales_order"
+	EntityReturnAuthorization EntityType = "return_authorization"
+	EntityTransferOrder       EntityType = "transfer_order"
+	EntityStockAdjustment     EntityType = "stock_adjustment"
+	EntityCountSession        EntityType = "count_session"
+	EntityCountPlan           EntityType = "count_plan"
+	EntityLot                 EntityType = "lot"
+	EntityLocation            EntityType = "location"
+	EntityPriceList           EntityType = "price_list"
+	EntityPriceChange         EntityType = "price_change"
+)
+
+type AuditAction string
+
+const (
+	AuditCreate AuditAction = "create"
+	AuditUpdate AuditAction = "update"
+	AuditDelete AuditAction = "delete"
+)
+
+type AuditEventFilter struct {
+	EntityType EntityType
+	EntityID   uuid.UUID
+	Actor      string
+	Start      time.Time
+	End        time.Time
+}
+
+// Matches reports whether event matches every non-zero field of the filter.
+func (f AuditEventFilter) Matches(event *AuditEvent) bool {
+	return (f.EntityType == "" || event.EntityType == f.EntityType) &&
+		(f.EntityID == uuid.Nil || event.EntityID == f.EntityID) &&
+		(f.Actor == "" || event.Actor == f.Actor) &&
+		(f.Start.IsZero() || !event.Timestamp.Before(f.Start)) &&
+		(f.End.IsZero() || !event.Timestamp.After(f.End))
+}
@@ -0,0 +1,104 @@
+package model
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"math"
+)
+
+// Capacity limits what a warehouse or bin can hold. A zero limit is not
+// applied.
+type Capacity struct {
+	MaxUnits Quantity `json:"max_units"`
+	// MaxVolume is in cubic metres.
+	MaxVolume float64 `json:"max_volume"`
+	// MaxWeight is in kilograms.
+	MaxWeight float64 `json:"max_weight"`
+}
+
+// Exceeded describes the first limit load goes over, or returns "" when it
+// fits.
+func (c Capacity) Exceeded(load StorageLoad) string {
+	if c.MaxUnits > 0 && load.Units > c.MaxUnits {
+		return fmt.Sprintf("%s units exceed the limit of %s", load.Units, c.MaxUnits)
+	}
+	if c.MaxVolume > 0 && load.Volume > c.MaxVolume {
+		return fmt.Sprintf("%.3f m3 exceed the limit of %.3f m3", load.Volume, c.MaxVolume)
+	}
+	if c.MaxWeight > 0 && load.Weight > c.MaxWeight {
+		return fmt.Sprintf("%.3f kg exceed the limit of %.3f kg", load.Weight, c.MaxWeight)
+	}
+	return ""
+}
+
+// FillRatio is the share of the tightest limit taken up by load, or 0 when
+// no limit is set.
+func (c Capacity) FillRatio(load StorageLoad) float64 {
+	ratio := 0.0
+	if c.MaxUnits > 0 {
+		ratio = math.Max(ratio, load.Units.Float64()/c.MaxUnits.Float64())
+	}
+	if c.MaxVolume > 0 {
+		ratio = math.Max(ratio, load.Volume/c.MaxVolume)
+	}
+	if c.MaxWeight > 0 {
+		ratio = math.Max(ratio, load.Weight/c.MaxWeight)
+	}
+	return ratio
+}
+
+// StorageLoad is what a warehouse or bin holds.
+type StorageLoad struct {
+	Units Quantity `json:"units"`
+	// Volume is in cubic metres.
+	Volume float64 `json:"volume"`
+	// Weight is in kilograms.
+	Weight float64 `json:"weight"`
+}
+
+// Add adds units of product to the load. Products without dimensions or
+// weight only count as units.
+func (l *StorageLoad) Add(product *Product, units Quantity) {
+	l.Units += units
+	if product != nil {
+		l.Volume += product.Volume() * units.Float64()
+		l.Weight += product.Weight * units.Float64()
+	}
+}
+
+// CapacityPolicy is what happens to stock that would take a warehouse or one
+// of its bins over capacity.
+type CapacityPolicy int
+
+const (
+	CapacityReject CapacityPolicy = iota
+	// CapacityWarn lets the stock in but reports that capacity is exceeded.
+	CapacityWarn
+)
+
+func (p CapacityPolicy) String() string {
+	switch p {
+	case CapacityReject:
+		return "reject"
+	case CapacityWarn:
+		return "warn"
+	}
+	return "unknown"
+}
+
+// WarehouseUtilization is how full a warehouse and its bins are.
+type WarehouseUtilization struct {
+	WarehouseID uuid.UUID `json:"warehouse_id"`
+	Capacity    Capacity  `json:"capacity"`
+	// Load counts stock on hand, quarantined stock and stock in transit to
+	// the warehouse.
+	Load      StorageLoad       `json:"load"`
+	FillRatio float64           `json:"fill_ratio"`
+	Bins      []*BinUtilization `json:"bins"`
+}
+
+type BinUtilization struct {
+	Location  *Location   `json:"location"`
+	Load      StorageLoad `json:"load"`
+	FillRatio float64     `json:"fill_ratio"`
+}
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// CountPlan schedules cycle counts of a warehouse by ABC class. Items are
+// ranked by the value of their usage over the past year: A items make up the
+// first 80% of it, B items the next 15% and C items the rest. Each item is
+// counted again once the interval of its class has passed since its last
+// approved count.
+type CountPlan struct {
+	ID                 uuid.UUID `json:"id"`
+	WarehouseID        uuid.UUID `json:"warehouse_id"`
+	Name               string    `json:"name"`
+	ClassAIntervalDays int       `json:"class_a_interval_days"`
+	ClassBIntervalDays int       `json:"class_b_interval_days"`
+	ClassCIntervalDays int       `json:"class_c_interval_days"`
+	// Blind is copied to the sessions the plan opens.
+	Blind     bool      `json:"blind"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	LastRunAt time.Time `json:"last_run_at"`
+}
+
+type ABCClass int
+
+const (
+	ClassA ABCClass = iota
+	ClassB
+	ClassC
+)
+
+func (c ABCClass) String() string {
+	switch c {
+	case ClassA:
+		return "A"
+	case ClassB:
+		return "B"
+	case ClassC:
+		return "C"
+	}
+	return "unknown"
+}
+
+// IntervalDays returns how often items of class are counted under the plan.
+func (p *CountPlan) IntervalDays(class ABCClass) int {
+	switch class {
+	case ClassA:
+		return p.ClassAIntervalDays
+	case ClassB:
+		return p.ClassBIntervalDays
+	}
+	return p.ClassCIntervalDays
+}
+
+// CountPlanItem is the schedule of one inventory item under a plan.
+type CountPlanItem struct {
+	InventoryItemID uuid.UUID `json:"inventory_item_id"`
+	ProductID       uuid.UUID `json:"product_id"`
+	Class           ABCClass  `json:"class"`
+	UsageValue      float64   `json:"usage_value"`
+	// LastCountedAt is zero when the item has never been counted, in which
+	// case it is due straight away.
+	LastCountedAt time.Time `json:"last_counted_at"`
+	NextCountAt   time.Time `json:"next_count_at"`
+	// CountSessionID is the unfinished session counting the item, if any.
+	CountSessionID uuid.UUID `json:"count_session_id"`
+}
@@ -0,0 +1,75 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// CountSession reconciles the system quantities of a warehouse with a
+// physical count. The expected quantity of every item in scope is
+// snapshotted when the session is opened; counters then record what they
+// find on the shelf and, once approved, each variance is posted as a count
+// correction adjustment.
+type CountSession struct {
+	ID          uuid.UUID `json:"id"`
+	WarehouseID uuid.UUID `json:"warehouse_id"`
+	// CategoryID restricts the session to products of a category; nil for
+	// every product.
+	CategoryID uuid.UUID `json:"category_id"`
+	// PlanID is the cycle count plan that scheduled the session, if any.
+	PlanID uuid.UUID `json:"plan_id"`
+	// Blind hides expected quantities from counters until the session is
+	// submitted.
+	Blind       bool               `json:"blind"`
+	Status      CountSessionStatus `json:"status"`
+	Notes       string             `json:"notes"`
+	Lines       []*CountLine       `json:"lines"`
+	CreatedBy   string             `json:"created_by"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+	SubmittedAt time.Time          `json:"submitted_at"`
+	ApprovedAt  time.Time          `json:"approved_at"`
+}
+
+type CountLine struct {
+	InventoryItemID   uuid.UUID `json:"inventory_item_id"`
+	ProductID         uuid.UUID `json:"product_id"`
+	ExpectedQuantity  Quantity  `json:"expected_quantity"`
+	CountedQuantity   Quantity  `json:"counted_quantity"`
+	Counted           bool      `json:"counted"`
+	CountedBy         string    `json:"counted_by"`
+	CountedAt         time.Time `json:"counted_at"`
+	StockAdjustmentID uuid.UUID `json:"stock_adjustment_id"`
+}
+
+// Variance returns the counted minus the expected quantity, or zero while
+// the line has not been counted.
+func (l *CountLine) Variance() Quantity {
+	if !l.Counted {
+		return 0
+	}
+	return l.CountedQuantity - l.ExpectedQuantity
+}
+
+type CountSessionStatus int
+
+const (
+	CountSessionOpen CountSessionStatus = iota
+	CountSessionSubmitted
+	CountSessionApproved
+	CountSessionCancelled
+)
+
+func (s CountSessionStatus) String() string {
+	switch s {
+	case CountSessionOpen:
+		return "open"
+	case CountSessionSubmitted:
+		return "submitted"
+	case CountSessionApproved:
+		return "approved"
+	case CountSessionCancelled:
+		return "cancelled"
+	}
+	return "unknown"
+}
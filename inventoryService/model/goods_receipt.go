@@ -0,0 +1,37 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// GoodsReceipt records a delivery received against a purchase order.
+type GoodsReceipt struct {
+	ID              uuid.UUID           `json:"id"`
+	PurchaseOrderID uuid.UUID           `json:"purchase_order_id"`
+	SupplierID      uuid.UUID           `json:"supplier_id"`
+	ReceivedAt      time.Time           `json:"received_at"`
+	Notes           string              `json:"notes"`
+	Lines           []*GoodsReceiptLine `json:"lines"`
+}
+
+// GoodsReceiptLine is the delivery of one purchase order line. Damaged units
+// are counted but not put into stock, so AcceptedQuantity is ReceivedQuantity
+// minus DamagedQuantity. Variance is AcceptedQuantity minus what was still
+// outstanding on the line: positive when over-delivered, negative when short.
+type GoodsReceiptLine struct {
+	PurchaseOrderLineID uuid.UUID `json:"purchase_order_line_id"`
+	ProductID           uuid.UUID `json:"product_id"`
+	WarehouseID         uuid.UUID `json:"warehouse_id"`
+	ReceivedQuantity    Quantity  `json:"received_quantity"`
+	DamagedQuantity     Quantity  `json:"damaged_quantity"`
+	AcceptedQuantity    Quantity  `json:"accepted_quantity"`
+	Variance            Quantity  `json:"variance"`
+	StockMovementID     uuid.UUID `json:"stock_movement_id"`
+	Notes               string    `json:"notes"`
+	// SerialNumbers are the accepted units of a serialized product.
+	SerialNumbers []string `json:"serial_numbers"`
+	// Posted is set once the accepted units are in stock and counted as
+	// received on the order line.
+	Posted bool `json:"posted"`
+}
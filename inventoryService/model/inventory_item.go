@@ -6,7 +6,17 @@ type InventoryItem struct {
 	ID              uuid.UUID `json:"id"`
 	ProductID       uuid.UUID `json:"product_id"`
 	WarehouseID     uuid.UUID `json:"warehouse_id"`
-	Quantity        int       `json:"quantity"`
-	ReorderLevel    int       `json:"reorder_level"`
-	ReorderQuantity int       `json:"reorder_quantity"`
+	Quantity        Quantity  `json:"quantity"`
+	ReorderLevel    Quantity  `json:"reorder_level"`
+	ReorderQuantity Quantity  `json:"reorder_quantity"`
+	// ReservedQuantity is the part of Quantity promised to open sales orders.
+	ReservedQuantity Quantity `json:"reserved_quantity"`
+	// QuarantinedQuantity is held apart from Quantity, for instance returned
+	// units awaiting refurbishment, and cannot be sold or reserved.
+	QuarantinedQuantity Quantity `json:"quarantined_quantity"`
+}
+
+// AvailableQuantity is the on-hand quantity that is not reserved.
+func (i *InventoryItem) AvailableQuantity() Quantity {
+	return i.Quantity - i.ReservedQuantity
 }
@@ -0,0 +1,70 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Location is a place inside a warehouse. Locations nest zone > aisle > rack
+// > shelf > bin, and stock is only ever held in bins.
+type Location struct {
+	ID          uuid.UUID `json:"id"`
+	WarehouseID uuid.UUID `json:"warehouse_id"`
+	// ParentID is nil for zones.
+	ParentID uuid.UUID    `json:"parent_id"`
+	Type     LocationType `json:"type"`
+	// Code is unique among the children of a parent, such as "A" for a zone
+	// or "03" for a shelf.
+	Code string `json:"code"`
+	Name string `json:"name"`
+	// Path joins the codes from the zone down, as in "A-12-R3-S2-B1".
+	Path string `json:"path"`
+	// Capacity limits the stock held in a bin.
+	Capacity  Capacity  `json:"capacity"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// BinStock is the part of an inventory item's quantity held in one bin. The
+// rest of the item's quantity is unplaced, such as stock received but not put
+// away yet.
+type BinStock struct {
+	InventoryItemID uuid.UUID `json:"inventory_item_id"`
+	LocationID      uuid.UUID `json:"location_id"`
+	WarehouseID     uuid.UUID `json:"warehouse_id"`
+	Quantity        Quantity  `json:"quantity"`
+}
+
+// PutawaySuggestion proposes a bin to put incoming stock in.
+type PutawaySuggestion struct {
+	Location *Location `json:"location"`
+	// Quantity is what the bin already holds of the item.
+	Quantity Quantity `json:"quantity"`
+	Reason   string   `json:"reason"`
+}
+
+type LocationType int
+
+const (
+	LocationZone LocationType = iota
+	LocationAisle
+	LocationRack
+	LocationShelf
+	LocationBin
+)
+
+func (t LocationType) String() string {
+	switch t {
+	case LocationZone:
+		return "zone"
+	case LocationAisle:
+		return "aisle"
+	case LocationRack:
+		return "rack"
+	case LocationShelf:
+		return "shelf"
+	case LocationBin:
+		return "bin"
+	}
+	return "unknown"
+}
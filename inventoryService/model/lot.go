@@ -0,0 +1,37 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Lot is a batch of an inventory item's stock sharing a lot number and
+// expiry date. The lots of an item hold part or all of its quantity; any
+// remainder is stock that predates lot tracking.
+type Lot struct {
+	ID              uuid.UUID `json:"id"`
+	InventoryItemID uuid.UUID `json:"inventory_item_id"`
+	ProductID       uuid.UUID `json:"product_id"`
+	WarehouseID     uuid.UUID `json:"warehouse_id"`
+	LotNumber       string    `json:"lot_number"`
+	ManufactureDate time.Time `json:"manufacture_date"`
+	// ExpiryDate is zero for lots that do not expire.
+	ExpiryDate time.Time `json:"expiry_date"`
+	SupplierID uuid.UUID `json:"supplier_id"`
+	Quantity   Quantity  `json:"quantity"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Expired reports whether the lot has reached its expiry date at now.
+func (l *Lot) Expired(now time.Time) bool {
+	return !l.ExpiryDate.IsZero() && !l.ExpiryDate.After(now)
+}
+
+// ExpiresBefore reports whether the lot must be used before other under
+// first-expired-first-out. Lots without an expiry date go last.
+func (l *Lot) ExpiresBefore(other *Lot) bool {
+	if l.ExpiryDate.IsZero() {
+		return false
+	}
+	return other.ExpiryDate.IsZero() || l.ExpiryDate.Before(other.ExpiryDate)
+}
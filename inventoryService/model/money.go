@@ -0,0 +1,192 @@
+package model
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+const nanosPerUnit = 1_000_000_000
+
+// Money is an amount in a currency, held like google.type.Money as whole
+// units and billionths of a unit so that amounts add up without rounding.
+type Money struct {
+	// CurrencyCode is the ISO 4217 code of the currency, such as "USD"; empty
+	// only on zero amounts.
+	CurrencyCode string `json:"currency_code"`
+	Units        int64  `json:"units"`
+	// Nanos has the sign of Units and lies between -999,999,999 and
+	// 999,999,999.
+	Nanos int32 `json:"nanos"`
+}
+
+// ParseMoney reads an amount written as a decimal number with at most nine
+// decimals, such as "12.50".
+func ParseMoney(amount, currencyCode string) (Money, error) {
+	value, ok := new(big.Rat).SetString(amount)
+	if !ok {
+		return Money{}, fmt.Errorf("invalid amount %q", amount)
+	}
+	nanos := new(big.Rat).Mul(value, big.NewRat(nanosPerUnit, 1))
+	if !nanos.IsInt() {
+		return Money{}, fmt.Errorf("amount %q has more than nine decimals", amount)
+	}
+	return MoneyFromNanos(currencyCode, nanos.Num()), nil
+}
+
+// MoneyFromNanos makes an amount of billionths of a unit, the inverse of
+// TotalNanos.
+func MoneyFromNanos(currencyCode string, nanos *big.Int) Money {
+	units, rest := new(big.Int).QuoRem(nanos, big.NewInt(nanosPerUnit), new(big.Int))
+	return Money{CurrencyCode: currencyCode, Units: units.Int64(), Nanos: int32(rest.Int64())}
+}
+
+// TotalNanos is the amount in billionths of a unit.
+func (m Money) TotalNanos() *big.Int {
+	nanos := new(big.Int).Mul(big.NewInt(m.Units), big.NewInt(nanosPerUnit))
+	return nanos.Add(nanos, big.NewInt(int64(m.Nanos)))
+}
+
+// Valid reports whether the nanos are in range and of the sign of the units.
+func (m Money) Valid() bool {
+	if m.Nanos <= -nanosPerUnit || m.Nanos >= nanosPerUnit {
+		return false
+	}
+	return !(m.Units > 0 && m.Nanos < 0) && !(m.Units < 0 && m.Nanos > 0)
+}
+
+func (m Money) IsZero() bool {
+	return m.Units == 0 && m.Nanos == 0
+}
+
+// Sign returns -1, 0 or 1 as the amount is negative, zero or positive.
+func (m Money) Sign() int {
+	switch {
+	case m.Units < 0 || m.Nanos < 0:
+		return -1
+	case m.Units > 0 || m.Nanos > 0:
+		return 1
+	}
+	return 0
+}
+
+// SameCurrency reports whether m and o can be added up: they are in the same
+// currency or one of them is a zero amount of no currency.
+func (m Money) SameCurrency(o Money) bool {
+	return m.CurrencyCode == o.CurrencyCode ||
+		(m.CurrencyCode == "" && m.IsZero()) || (o.CurrencyCode == "" && o.IsZero())
+}
+
+// currencyWith picks the currency of the result of an operation on m and o,
+// which share it unless one is a zero amount of no currency.
+func (m Money) currencyWith(o Money) string {
+	if m.CurrencyCode != "" {
+		return m.CurrencyCode
+	}
+	return o.CurrencyCode
+}
+
+// Add returns m + o; the amounts must be in the same currency.
+func (m Money) Add(o Money) Money {
+	return MoneyFromNanos(m.currencyWith(o), new(big.Int).Add(m.TotalNanos(), o.TotalNanos()))
+}
+
+// Sub returns m - o; the amounts must be in the same currency.
+func (m Money) Sub(o Money) Money {
+	return MoneyFromNanos(m.currencyWith(o), new(big.Int).Sub(m.TotalNanos(), o.TotalNanos()))
+}
+
+func (m Money) Neg() Money {
+	return Money{CurrencyCode: m.CurrencyCode, Units: -m.Units, Nanos: -m.Nanos}
+}
+
+func (m Money) Abs() Money {
+	if m.Sign() < 0 {
+		return m.Neg()
+	}
+	return m
+}
+
+// Mul returns the amount times a quantity, such as the cost of q units,
+// rounded half away from zero to the nano.
+func (m Money) Mul(q Quantity) Money {
+	nanos := new(big.Rat).Mul(new(big.Rat).SetInt(m.TotalNanos()), q.Rat())
+	return MoneyFromNanos(m.CurrencyCode, roundNanos(nanos))
+}
+
+// Div returns the amount divided by a quantity, such as the cost of one of q
+// units, rounded half away from zero to the nano. q must not be zero.
+func (m Money) Div(q Quantity) Money {
+	nanos := new(big.Rat).Quo(new(big.Rat).SetInt(m.TotalNanos()), q.Rat())
+	return MoneyFromNanos(m.CurrencyCode, roundNanos(nanos))
+}
+
+// roundNanos rounds a number of nanos half away from zero.
+func roundNanos(nanos *big.Rat) *big.Int {
+	half := big.NewRat(1, 2)
+	if nanos.Sign() < 0 {
+		half.Neg(half)
+	}
+	rounded := new(big.Rat).Add(nanos, half)
+	return new(big.Int).Quo(rounded.Num(), rounded.Denom())
+}
+
+// Cmp compares the amounts of m and o, which must be in the same currency,
+// returning -1, 0 or 1 as m is less than, equal to or greater than o.
+func (m Money) Cmp(o Money) int {
+	return m.TotalNanos().Cmp(o.TotalNanos())
+}
+
+// Float64 is the amount as a float, for ratios and rankings that do not need
+// to be exact.
+func (m Money) Float64() float64 {
+	return float64(m.Units) + float64(m.Nanos)/nanosPerUnit
+}
+
+// Amount writes the amount as a decimal number with at least two decimals,
+// such as "12.50".
+func (m Money) Amount() string {
+	sign := ""
+	if m.Sign() < 0 {
+		sign = "-"
+	}
+	units, nanos := m.Units, m.Nanos
+	if units < 0 {
+		units = -units
+	}
+	if nanos < 0 {
+		nanos = -nanos
+	}
+	decimals := strings.TrimRight(fmt.Sprintf("%09d", nanos), "0")
+	for len(decimals) < 2 {
+		decimals += "0"
+	}
+	return fmt.Sprintf("%s%d.%s", sign, units, decimals)
+}
+
+func (m Money) String() string {
+	if m.CurrencyCode == "" {
+		return m.Amount()
+	}
+	return m.Amount() + " " + m.CurrencyCode
+}
+
+// AddToTotals adds amount to the total in its currency among totals, which
+// hold one amount per currency ordered by currency code.
+func AddToTotals(totals []Money, amount Money) []Money {
+	if amount.IsZero() {
+		return totals
+	}
+	for i, total := range totals {
+		if total.CurrencyCode == amount.CurrencyCode {
+			totals[i] = total.Add(amount)
+			return totals
+		}
+	}
+	totals = append(totals, amount)
+	sort.Slice(totals, func(i, j int) bool {
+		return totals[i].CurrencyCode < totals[j].CurrencyCode
+	})
+	return totals
+}
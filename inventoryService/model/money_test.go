@@ -0,0 +1,182 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		amount  string
+		want    Money
+		wantErr bool
+	}{
+		{amount: "12.50", want: Money{CurrencyCode: "USD", Units: 12, Nanos: 500_000_000}},
+		{amount: "0", want: Money{CurrencyCode: "USD"}},
+		{amount: "-1.25", want: Money{CurrencyCode: "USD", Units: -1, Nanos: -250_000_000}},
+		{amount: "-0.5", want: Money{CurrencyCode: "USD", Nanos: -500_000_000}},
+		{amount: "0.000000001", want: Money{CurrencyCode: "USD", Nanos: 1}},
+		{amount: "0.0000000001", wantErr: true},
+		{amount: "twelve", wantErr: true},
+		{amount: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.amount, func(t *testing.T) {
+			got, err := ParseMoney(tt.amount, "USD")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseMoney(%q) error = %v, wantErr %v", tt.amount, err, tt.wantErr)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("ParseMoney(%q) = %+v, want %+v", tt.amount, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneyValid(t *testing.T) {
+	tests := []struct {
+		name  string
+		money Money
+		want  bool
+	}{
+		{name: "zero", money: Money{}, want: true},
+		{name: "positive", money: Money{Units: 3, Nanos: 10}, want: true},
+		{name: "negative", money: Money{Units: -3, Nanos: -10}, want: true},
+		{name: "negative nanos only", money: Money{Nanos: -10}, want: true},
+		{name: "nanos of the other sign", money: Money{Units: 3, Nanos: -10}, want: false},
+		{name: "nanos of the other sign when negative", money: Money{Units: -3, Nanos: 10}, want: false},
+		{name: "nanos out of range", money: Money{Units: 1, Nanos: 1_000_000_000}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.money.Valid(); got != tt.want {
+				t.Errorf("%+v.Valid() = %v, want %v", tt.money, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	usd := func(units int64, nanos int32) Money {
+		return Money{CurrencyCode: "USD", Units: units, Nanos: nanos}
+	}
+	tests := []struct {
+		name string
+		got  Money
+		want Money
+	}{
+		{name: "add carries nanos", got: usd(1, 600_000_000).Add(usd(0, 500_000_000)), want: usd(2, 100_000_000)},
+		{name: "add to zero of no currency", got: Money{}.Add(usd(2, 0)), want: usd(2, 0)},
+		{name: "sub below zero", got: usd(1, 0).Sub(usd(1, 500_000_000)), want: usd(0, -500_000_000)},
+		{name: "neg", got: usd(1, 250_000_000).Neg(), want: usd(-1, -250_000_000)},
+		{name: "abs", got: usd(-1, -250_000_000).Abs(), want: usd(1, 250_000_000)},
+		{name: "mul by whole units", got: usd(2, 500_000_000).Mul(Units(3)), want: usd(7, 500_000_000)},
+		{name: "mul by a fraction rounds half away from zero", got: usd(0, 1).Mul(Unit / 2), want: usd(0, 1)},
+		{name: "mul of a negative amount rounds away from zero", got: usd(0, -1).Mul(Unit / 2), want: usd(0, -1)},
+		{name: "div rounds to the nano", got: usd(1, 0).Div(Units(3)), want: usd(0, 333_333_333)},
+		{name: "div rounds half up", got: usd(2, 0).Div(Units(3)), want: usd(0, 666_666_667)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %+v, want %+v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneyCmpAndSign(t *testing.T) {
+	tests := []struct {
+		name     string
+		m, o     Money
+		wantCmp  int
+		wantSign int
+	}{
+		{name: "less", m: Money{Units: 1}, o: Money{Units: 1, Nanos: 1}, wantCmp: -1, wantSign: 1},
+		{name: "equal", m: Money{Units: 2}, o: Money{Units: 2}, wantCmp: 0, wantSign: 1},
+		{name: "greater", m: Money{Nanos: -1}, o: Money{Units: -1}, wantCmp: 1, wantSign: -1},
+		{name: "zero", m: Money{}, o: Money{}, wantCmp: 0, wantSign: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.Cmp(tt.o); got != tt.wantCmp {
+				t.Errorf("%+v.Cmp(%+v) = %d, want %d", tt.m, tt.o, got, tt.wantCmp)
+			}
+			if got := tt.m.Sign(); got != tt.wantSign {
+				t.Errorf("%+v.Sign() = %d, want %d", tt.m, got, tt.wantSign)
+			}
+		})
+	}
+}
+
+func TestMoneySameCurrency(t *testing.T) {
+	tests := []struct {
+		name string
+		m, o Money
+		want bool
+	}{
+		{name: "same currency", m: Money{CurrencyCode: "EUR", Units: 1}, o: Money{CurrencyCode: "EUR", Units: 2}, want: true},
+		{name: "other currency", m: Money{CurrencyCode: "EUR", Units: 1}, o: Money{CurrencyCode: "USD", Units: 1}, want: false},
+		{name: "zero of no currency", m: Money{}, o: Money{CurrencyCode: "USD", Units: 1}, want: true},
+		{name: "amount of no currency", m: Money{Units: 1}, o: Money{CurrencyCode: "USD", Units: 1}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.SameCurrency(tt.o); got != tt.want {
+				t.Errorf("%+v.SameCurrency(%+v) = %v, want %v", tt.m, tt.o, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	tests := []struct {
+		money Money
+		want  string
+	}{
+		{money: Money{CurrencyCode: "USD", Units: 12, Nanos: 500_000_000}, want: "12.50 USD"},
+		{money: Money{CurrencyCode: "USD", Units: 12}, want: "12.00 USD"},
+		{money: Money{CurrencyCode: "EUR", Units: -1, Nanos: -5}, want: "-1.000000005 EUR"},
+		{money: Money{Nanos: -500_000_000}, want: "-0.50"},
+		{money: Money{}, want: "0.00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.money.String(); got != tt.want {
+				t.Errorf("%+v.String() = %q, want %q", tt.money, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddToTotals(t *testing.T) {
+	tests := []struct {
+		name    string
+		totals  []Money
+		amounts []Money
+		want    []Money
+	}{
+		{
+			name:    "adds up per currency in currency order",
+			amounts: []Money{{CurrencyCode: "USD", Units: 1}, {CurrencyCode: "EUR", Units: 2}, {CurrencyCode: "USD", Units: 3}},
+			want:    []Money{{CurrencyCode: "EUR", Units: 2}, {CurrencyCode: "USD", Units: 4}},
+		},
+		{
+			name:    "skips zero amounts",
+			totals:  []Money{{CurrencyCode: "USD", Units: 1}},
+			amounts: []Money{{CurrencyCode: "EUR"}},
+			want:    []Money{{CurrencyCode: "USD", Units: 1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.totals
+			for _, amount := range tt.amounts {
+				got = AddToTotals(got, amount)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AddToTotals() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// PriceChange is a change of a product's own price, either already made or
+// scheduled to take effect later.
+type PriceChange struct {
+	ID        uuid.UUID `json:"id"`
+	ProductID uuid.UUID `json:"product_id"`
+	// OldPrice is the price the change replaced, filled in when it takes
+	// effect; zero for the first price of a product.
+	OldPrice Money `json:"old_price"`
+	NewPrice Money `json:"new_price"`
+	// EffectiveAt is when the change took or takes effect.
+	EffectiveAt time.Time `json:"effective_at"`
+	// Actor is who made or scheduled the change.
+	Actor     string            `json:"actor"`
+	Status    PriceChangeStatus `json:"status"`
+	CreatedAt time.Time         `json:"created_at"`
+	// AppliedAt is when the new price was written to the product; it trails
+	// EffectiveAt of a scheduled change by up to the interval of the job
+	// applying them.
+	AppliedAt time.Time `json:"applied_at"`
+}
+
+type PriceChangeStatus int
+
+const (
+	PriceChangeScheduled PriceChangeStatus = iota
+	PriceChangeApplied
+	PriceChangeCancelled
+)
+
+func (s PriceChangeStatus) String() string {
+	switch s {
+	case PriceChangeScheduled:
+		return "scheduled"
+	case PriceChangeApplied:
+		return "applied"
+	case PriceChangeCancelled:
+		return "cancelled"
+	}
+	return "unknown"
+}
@@ -0,0 +1,97 @@
+package model
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"math/big"
+	"time"
+)
+
+// PriceList sets the prices of products in one currency over a period,
+// optionally only for one group of customers.
+type PriceList struct {
+	ID           uuid.UUID `json:"id"`
+	Name         string    `json:"name"`
+	CurrencyCode string    `json:"currency_code"`
+	// CustomerGroup restricts the list to one group of customers, such as
+	// "wholesale"; empty for every customer.
+	CustomerGroup string `json:"customer_group"`
+	// EffectiveFrom and EffectiveTo bound the period the list applies in;
+	// EffectiveTo is exclusive and zero for no end.
+	EffectiveFrom time.Time         `json:"effective_from"`
+	EffectiveTo   time.Time         `json:"effective_to"`
+	Prices        []*PriceListPrice `json:"prices"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+}
+
+// EffectiveAt reports whether the list applies at t.
+func (l *PriceList) EffectiveAt(t time.Time) bool {
+	return !t.Before(l.EffectiveFrom) && (l.EffectiveTo.IsZero() || t.Before(l.EffectiveTo))
+}
+
+// Price returns the price of a product on the list, if it has one.
+func (l *PriceList) Price(productID uuid.UUID) (Money, bool) {
+	for _, price := range l.Prices {
+		if price.ProductID == productID {
+			return price.Price, true
+		}
+	}
+	return Money{}, false
+}
+
+// PriceListPrice is the price of one product on a price list, in the
+// currency of the list.
+type PriceListPrice struct {
+	ProductID uuid.UUID `json:"product_id"`
+	Price     Money     `json:"price"`
+}
+
+// ExchangeRate is what one unit of FromCurrency buys in ToCurrency from
+// EffectiveFrom on, until a later rate for the pair takes over.
+type ExchangeRate struct {
+	FromCurrency string `json:"from_currency"`
+	ToCurrency   string `json:"to_currency"`
+	// Rate is a decimal number, such as "1.0842".
+	Rate          string    `json:"rate"`
+	EffectiveFrom time.Time `json:"effective_from"`
+}
+
+// Convert changes an amount in FromCurrency to ToCurrency, rounded half away
+// from zero to the nano.
+func (r *ExchangeRate) Convert(amount Money) (Money, error) {
+	rate, ok := new(big.Rat).SetString(r.Rate)
+	if !ok {
+		return Money{}, fmt.Errorf("invalid exchange rate %q", r.Rate)
+	}
+	converted := new(big.Rat).Mul(new(big.Rat).SetInt(amount.TotalNanos()), rate)
+	return MoneyFromNanos(r.ToCurrency, roundNanos(converted)), nil
+}
+
+// Inverse is the rate converting the other way, exact to nine decimals.
+func (r *ExchangeRate) Inverse() (*ExchangeRate, error) {
+	rate, ok := new(big.Rat).SetString(r.Rate)
+	if !ok || rate.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid exchange rate %q", r.Rate)
+	}
+	return &ExchangeRate{
+		FromCurrency:  r.ToCurrency,
+		ToCurrency:    r.FromCurrency,
+		Rate:          new(big.Rat).Inv(rate).FloatString(9),
+		EffectiveFrom: r.EffectiveFrom,
+	}, nil
+}
+
+// EffectivePrice is the price a product sells at in a currency on a date, and
+// where it comes from.
+type EffectivePrice struct {
+	ProductID uuid.UUID `json:"product_id"`
+	Price     Money     `json:"price"`
+	// PriceListID is the list the price is taken from; nil when it is the
+	// product's own price.
+	PriceListID uuid.UUID `json:"price_list_id"`
+	// SourcePrice is the price before conversion, and ExchangeRate the rate
+	// it was converted at; nil when the price needed no conversion.
+	SourcePrice  Money         `json:"source_price"`
+	ExchangeRate *ExchangeRate `json:"exchange_rate"`
+}
@@ -1,12 +1,79 @@
 package model
 
-import "github.com/google/uuid"
+import (
+	"fmt"
+	"github.com/google/uuid"
+)
 
 type Product struct {
 	ID          uuid.UUID `json:"id"`
 	Name        string    `json:"name"`
 	Description string    `json:"description"`
 	CategoryID  uuid.UUID `json:"category_id"`
-	Price       float64   `json:"price"`
+	Price       Money     `json:"price"`
 	SKU         string    `json:"sku"`
+	// Serialized products are tracked unit by unit: every movement of their
+	// stock names the serial numbers of the units it moves.
+	Serialized bool `json:"serialized"`
+	// Length, Width and Height are the dimensions of one unit in metres and
+	// Weight its weight in kilograms; zero when unknown.
+	Length float64 `json:"length"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+	Weight float64 `json:"weight"`
+	// CostingMethod values the stock of the product taken out of inventory.
+	CostingMethod CostingMethod `json:"costing_method"`
+	// BaseUnit is the unit stock of the product is counted in, such as
+	// "each". Inventory items, lots, bins, reservations and dimensions are in
+	// base units.
+	BaseUnit string `json:"base_unit"`
+	// Units are the other units the product is bought or moved in, such as a
+	// case of 12.
+	Units []*UnitOfMeasure `json:"units"`
+	// QuantityPrecision is the number of decimals stock of the product is
+	// counted in, up to QuantityDecimals. Zero keeps it to whole units.
+	QuantityPrecision int `json:"quantity_precision"`
+}
+
+// DefaultBaseUnit is the base unit of products that do not name one.
+const DefaultBaseUnit = "each"
+
+// UnitOfMeasure is an alternate unit of a product holding Factor base units,
+// which may be a fraction, such as a gram of a product counted in kilograms.
+type UnitOfMeasure struct {
+	Name   string   `json:"name"`
+	Factor Quantity `json:"factor"`
+}
+
+// Volume is the volume of one unit in cubic metres.
+func (p *Product) Volume() float64 {
+	return p.Length * p.Width * p.Height
+}
+
+// UnitFactor returns the number of base units in one unit of the product. An
+// empty unit is the base unit.
+func (p *Product) UnitFactor(unit string) (Quantity, bool) {
+	if unit == "" || unit == p.BaseUnit {
+		return Unit, true
+	}
+	for _, u := range p.Units {
+		if u.Name == unit {
+			return u.Factor, true
+		}
+	}
+	return 0, false
+}
+
+// ToBaseQuantity converts a quantity in one of the units of the product to
+// base units.
+func (p *Product) ToBaseQuantity(quantity Quantity, unit string) (Quantity, error) {
+	factor, ok := p.UnitFactor(unit)
+	if !ok {
+		return 0, fmt.Errorf("product %s has no unit %q", p.ID, unit)
+	}
+	base, err := quantity.Mul(factor)
+	if err != nil {
+		return 0, fmt.Errorf("%s %s of product %s in base units: %w", quantity, unit, p.ID, err)
+	}
+	return base, nil
 }
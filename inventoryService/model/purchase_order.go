@@ -0,0 +1,69 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type PurchaseOrder struct {
+	ID         uuid.UUID            `json:"id"`
+	SupplierID uuid.UUID            `json:"supplier_id"`
+	Status     PurchaseOrderStatus  `json:"status"`
+	Notes      string               `json:"notes"`
+	Lines      []*PurchaseOrderLine `json:"lines"`
+	CreatedAt  time.Time            `json:"created_at"`
+	UpdatedAt  time.Time            `json:"updated_at"`
+	// SubmittedAt is when the order was sent to the supplier; zero for drafts.
+	SubmittedAt time.Time `json:"submitted_at"`
+	// ReceivingReceiptID is the goods receipt being posted against the order,
+	// if any. No other receipt or status change is accepted until it is done.
+	ReceivingReceiptID uuid.UUID `json:"receiving_receipt_id"`
+}
+
+// Total is the cost of the lines of the order, which share a currency.
+func (o *PurchaseOrder) Total() Money {
+	var total Money
+	for _, line := range o.Lines {
+		total = total.Add(line.UnitCost.Mul(line.Quantity))
+	}
+	return total
+}
+
+// PurchaseOrderLine orders a quantity of a product in one of its units, at a
+// cost per unit of it; the received quantity is in the same unit. An empty
+// unit is the base unit of the product.
+type PurchaseOrderLine struct {
+	ID               uuid.UUID `json:"id"`
+	ProductID        uuid.UUID `json:"product_id"`
+	WarehouseID      uuid.UUID `json:"warehouse_id"`
+	Quantity         Quantity  `json:"quantity"`
+	Unit             string    `json:"unit"`
+	UnitCost         Money     `json:"unit_cost"`
+	ReceivedQuantity Quantity  `json:"received_quantity"`
+}
+
+type PurchaseOrderStatus int
+
+const (
+	PurchaseOrderDraft PurchaseOrderStatus = iota
+	PurchaseOrderSubmitted
+	PurchaseOrderPartiallyReceived
+	PurchaseOrderReceived
+	PurchaseOrderCancelled
+)
+
+func (s PurchaseOrderStatus) String() string {
+	switch s {
+	case PurchaseOrderDraft:
+		return "draft"
+	case PurchaseOrderSubmitted:
+		return "submitted"
+	case PurchaseOrderPartiallyReceived:
+		return "partially received"
+	case PurchaseOrderReceived:
+		return "received"
+	case PurchaseOrderCancelled:
+		return "cancelled"
+	}
+	return "unknown"
+}
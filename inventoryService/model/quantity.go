@@ -0,0 +1,121 @@
+package model
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// QuantityDecimals is the number of decimals quantities are held to, and the
+// highest precision a product can be counted in.
+const QuantityDecimals = 6
+
+// Quantity is an amount of a product in its base unit, held like
+// time.Duration as a count of its smallest step, a millionth of a unit, so
+// that quantities add up and compare exactly. Use Units to make whole
+// quantities; Quantity(n) is n millionths.
+type Quantity int64
+
+// Unit is one whole base unit.
+const Unit Quantity = 1_000_000
+
+// Units makes a quantity of n whole base units.
+func Units(n int) Quantity {
+	return Quantity(n) * Unit
+}
+
+// ParseQuantity reads a quantity written as a decimal number with at most
+// QuantityDecimals decimals, such as "12.5".
+func ParseQuantity(s string) (Quantity, error) {
+	value, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+	if !ok {
+		return 0, fmt.Errorf("invalid quantity %q", s)
+	}
+	steps := new(big.Rat).Mul(value, new(big.Rat).SetInt64(int64(Unit)))
+	if !steps.IsInt() {
+		return 0, fmt.Errorf("quantity %q has more than %d decimals", s, QuantityDecimals)
+	}
+	if !steps.Num().IsInt64() {
+		return 0, fmt.Errorf("quantity %q is out of range", s)
+	}
+	return Quantity(steps.Num().Int64()), nil
+}
+
+// Mul returns the quantity times a factor, such as the base units in q cases
+// of factor base units each. It fails when the result has more than
+// QuantityDecimals decimals or is out of range.
+func (q Quantity) Mul(factor Quantity) (Quantity, error) {
+	product := new(big.Int).Mul(big.NewInt(int64(q)), big.NewInt(int64(factor)))
+	steps, rest := new(big.Int).QuoRem(product, big.NewInt(int64(Unit)), new(big.Int))
+	if rest.Sign() != 0 {
+		return 0, fmt.Errorf("%s times %s has more than %d decimals", q, factor, QuantityDecimals)
+	}
+	if !steps.IsInt64() {
+		return 0, fmt.Errorf("%s times %s is out of range", q, factor)
+	}
+	return Quantity(steps.Int64()), nil
+}
+
+// Whole reports whether the quantity is a whole number of units.
+func (q Quantity) Whole() bool {
+	return q%Unit == 0
+}
+
+// Int is the number of whole units in the quantity, rounded toward zero.
+func (q Quantity) Int() int {
+	return int(q / Unit)
+}
+
+// HasPrecision reports whether the quantity has at most decimals decimals.
+func (q Quantity) HasPrecision(decimals int) bool {
+	if decimals >= QuantityDecimals {
+		return true
+	}
+	step := Unit
+	for i := 0; i < decimals; i++ {
+		step /= 10
+	}
+	return q%step == 0
+}
+
+// Float64 is the quantity as a float, for volumes, weights and ratios that do
+// not need to be exact.
+func (q Quantity) Float64() float64 {
+	return float64(q) / float64(Unit)
+}
+
+// Rat is the exact value of the quantity.
+func (q Quantity) Rat() *big.Rat {
+	return big.NewRat(int64(q), int64(Unit))
+}
+
+// String writes the quantity as a decimal number without trailing zeros,
+// such as "12.5" or "3".
+func (q Quantity) String() string {
+	sign := ""
+	if q < 0 {
+		sign = "-"
+		q = -q
+	}
+	whole, frac := q/Unit, q%Unit
+	if frac == 0 {
+		return fmt.Sprintf("%s%d", sign, whole)
+	}
+	decimals := strings.TrimRight(fmt.Sprintf("%06d", frac), "0")
+	return fmt.Sprintf("%s%d.%s", sign, whole, decimals)
+}
+
+// MarshalJSON writes the quantity as a decimal number, such as 12.5.
+func (q Quantity) MarshalJSON() ([]byte, error) {
+	return []byte(q.String()), nil
+}
+
+// UnmarshalJSON reads a quantity written as a decimal number.
+func (q *Quantity) UnmarshalJSON(data []byte) error {
+	parsed, err := ParseQuantity(strings.Trim(string(data), `"`))
+	if err != nil {
+		return err
+	}
+	*q = parsed
+	return nil
+}
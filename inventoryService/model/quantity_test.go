@@ -0,0 +1,166 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseQuantity(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    Quantity
+		wantErr bool
+	}{
+		{s: "3", want: Units(3)},
+		{s: "12.5", want: 12_500_000},
+		{s: " 0.000001 ", want: 1},
+		{s: "-2.25", want: -2_250_000},
+		{s: "0.0000001", wantErr: true},
+		{s: "1e30", wantErr: true},
+		{s: "three", wantErr: true},
+		{s: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParseQuantity(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseQuantity(%q) error = %v, wantErr %v", tt.s, err, tt.wantErr)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("ParseQuantity(%q) = %d, want %d", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuantityString(t *testing.T) {
+	tests := []struct {
+		q    Quantity
+		want string
+	}{
+		{q: Units(3), want: "3"},
+		{q: 12_500_000, want: "12.5"},
+		{q: 1, want: "0.000001"},
+		{q: -2_250_000, want: "-2.25"},
+		{q: -500_000, want: "-0.5"},
+		{q: 0, want: "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.q.String(); got != tt.want {
+				t.Errorf("Quantity(%d).String() = %q, want %q", int64(tt.q), got, tt.want)
+			}
+			parsed, err := ParseQuantity(tt.want)
+			if err != nil || parsed != tt.q {
+				t.Errorf("ParseQuantity(%q) = %d, %v, want %d", tt.want, parsed, err, tt.q)
+			}
+		})
+	}
+}
+
+func TestQuantityHasPrecision(t *testing.T) {
+	tests := []struct {
+		name     string
+		q        Quantity
+		decimals int
+		want     bool
+	}{
+		{name: "whole in whole units", q: Units(4), decimals: 0, want: true},
+		{name: "fraction in whole units", q: 4_500_000, decimals: 0, want: false},
+		{name: "one decimal in one decimal", q: 4_500_000, decimals: 1, want: true},
+		{name: "two decimals in one decimal", q: 4_250_000, decimals: 1, want: false},
+		{name: "negative in two decimals", q: -4_250_000, decimals: 2, want: true},
+		{name: "smallest step in full precision", q: 1, decimals: QuantityDecimals, want: true},
+		{name: "beyond full precision", q: 1, decimals: QuantityDecimals + 2, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.q.HasPrecision(tt.decimals); got != tt.want {
+				t.Errorf("Quantity(%v).HasPrecision(%d) = %v, want %v", tt.q, tt.decimals, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuantityMul(t *testing.T) {
+	tests := []struct {
+		name    string
+		q       Quantity
+		factor  Quantity
+		want    Quantity
+		wantErr bool
+	}{
+		{name: "cases of twelve", q: Units(3), factor: Units(12), want: Units(36)},
+		{name: "half a case", q: Unit / 2, factor: Units(12), want: Units(6)},
+		{name: "grams in kilograms", q: Units(250), factor: Unit / 1000, want: Unit / 4},
+		{name: "negative", q: -Units(2), factor: 1_500_000, want: -Units(3)},
+		{name: "beyond the precision", q: 1, factor: Unit / 10, wantErr: true},
+		{name: "out of range", q: Units(1_000_000_000), factor: Units(1_000_000_000), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.q.Mul(tt.factor)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Quantity(%v).Mul(%v) error = %v, wantErr %v", tt.q, tt.factor, err, tt.wantErr)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("Quantity(%v).Mul(%v) = %v, want %v", tt.q, tt.factor, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuantityWholeAndInt(t *testing.T) {
+	tests := []struct {
+		q         Quantity
+		wantWhole bool
+		wantInt   int
+	}{
+		{q: Units(7), wantWhole: true, wantInt: 7},
+		{q: 7_900_000, wantWhole: false, wantInt: 7},
+		{q: -7_900_000, wantWhole: false, wantInt: -7},
+		{q: 0, wantWhole: true, wantInt: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.q.String(), func(t *testing.T) {
+			if got := tt.q.Whole(); got != tt.wantWhole {
+				t.Errorf("Quantity(%v).Whole() = %v, want %v", tt.q, got, tt.wantWhole)
+			}
+			if got := tt.q.Int(); got != tt.wantInt {
+				t.Errorf("Quantity(%v).Int() = %d, want %d", tt.q, got, tt.wantInt)
+			}
+		})
+	}
+}
+
+func TestQuantityJSON(t *testing.T) {
+	tests := []struct {
+		data    string
+		want    Quantity
+		wantErr bool
+	}{
+		{data: `12.5`, want: 12_500_000},
+		{data: `"12.5"`, want: 12_500_000},
+		{data: `3`, want: Units(3)},
+		{data: `0.0000001`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.data, func(t *testing.T) {
+			var got Quantity
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("json.Unmarshal(%s) error = %v, wantErr %v", tt.data, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("json.Unmarshal(%s) = %v, want %v", tt.data, got, tt.want)
+			}
+			data, err := json.Marshal(got)
+			if err != nil || string(data) != tt.want.String() {
+				t.Errorf("json.Marshal(%v) = %s, %v, want %s", got, data, err, tt.want.String())
+			}
+		})
+	}
+}
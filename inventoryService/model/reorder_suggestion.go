@@ -0,0 +1,21 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// ReorderSuggestion is raised for an inventory item whose quantity has fallen
+// to or below its reorder level. There is at most one open suggestion per
+// inventory item. PurchaseOrderID is set once the suggestion has been
+// converted into a draft purchase order.
+type ReorderSuggestion struct {
+	InventoryItemID   uuid.UUID `json:"inventory_item_id"`
+	ProductID         uuid.UUID `json:"product_id"`
+	WarehouseID       uuid.UUID `json:"warehouse_id"`
+	QuantityOnHand    Quantity  `json:"quantity_on_hand"`
+	ReorderLevel      Quantity  `json:"reorder_level"`
+	SuggestedQuantity Quantity  `json:"suggested_quantity"`
+	CreatedAt         time.Time `json:"created_at"`
+	PurchaseOrderID   uuid.UUID `json:"purchase_order_id"`
+}
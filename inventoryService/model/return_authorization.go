@@ -0,0 +1,80 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// ReturnAuthorization (RMA) lets a customer send back units of a product. The
+// units are received into WarehouseID and, once inspected, dispositioned.
+type ReturnAuthorization struct {
+	ID           uuid.UUID                 `json:"id"`
+	SalesOrderID uuid.UUID                 `json:"sales_order_id"`
+	Customer     string                    `json:"customer"`
+	ProductID    uuid.UUID                 `json:"product_id"`
+	WarehouseID  uuid.UUID                 `json:"warehouse_id"`
+	Quantity     Quantity                  `json:"quantity"`
+	Reason       string                    `json:"reason"`
+	Status       ReturnAuthorizationStatus `json:"status"`
+	Dispositions []*ReturnDisposition      `json:"dispositions"`
+	CreatedAt    time.Time                 `json:"created_at"`
+	UpdatedAt    time.Time                 `json:"updated_at"`
+	InspectedAt  time.Time                 `json:"inspected_at"`
+}
+
+// ReturnDisposition decides what happens to part of a return. Restocked units
+// are added to stock, refurbished and supplier-bound units are quarantined and
+// scrapped units do not enter stock at all.
+type ReturnDisposition struct {
+	Disposition     Disposition `json:"disposition"`
+	Quantity        Quantity    `json:"quantity"`
+	SupplierID      uuid.UUID   `json:"supplier_id"`
+	StockMovementID uuid.UUID   `json:"stock_movement_id"`
+	Notes           string      `json:"notes"`
+	// SerialNumbers are the units dispositioned when the product is
+	// serialized.
+	SerialNumbers []string `json:"serial_numbers"`
+}
+
+type ReturnAuthorizationStatus int
+
+const (
+	ReturnAuthorizationOpen ReturnAuthorizationStatus = iota
+	ReturnAuthorizationInspected
+	ReturnAuthorizationCancelled
+)
+
+func (s ReturnAuthorizationStatus) String() string {
+	switch s {
+	case ReturnAuthorizationOpen:
+		return "open"
+	case ReturnAuthorizationInspected:
+		return "inspected"
+	case ReturnAuthorizationCancelled:
+		return "cancelled"
+	}
+	return "unknown"
+}
+
+type Disposition int
+
+const (
+	DispositionRestock Disposition = iota
+	DispositionRefurbish
+	DispositionScrap
+	DispositionReturnToSupplier
+)
+
+func (d Disposition) String() string {
+	switch d {
+	case DispositionRestock:
+		return "restock"
+	case DispositionRefurbish:
+		return "refurbish"
+	case DispositionScrap:
+		return "scrap"
+	case DispositionReturnToSupplier:
+		return "return to supplier"
+	}
+	return "unknown"
+}
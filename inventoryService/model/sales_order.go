@@ -0,0 +1,68 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// SalesOrder promises stock to a customer. Stock is reserved when the order is
+// created and leaves the warehouses when it is shipped.
+type SalesOrder struct {
+	ID       uuid.UUID         `json:"id"`
+	Customer string            `json:"customer"`
+	Status   SalesOrderStatus  `json:"status"`
+	Notes    string            `json:"notes"`
+	Lines    []*SalesOrderLine `json:"lines"`
+	// ExpiresAt is when unshipped reservations are released; zero means
+	// they are held until the order is shipped or cancelled.
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	ShippedAt time.Time `json:"shipped_at"`
+}
+
+// SalesOrderLine asks for a quantity of a product, either from one warehouse
+// (non-nil WarehouseID) or from any. Allocations record where it was reserved.
+type SalesOrderLine struct {
+	ID          uuid.UUID               `json:"id"`
+	ProductID   uuid.UUID               `json:"product_id"`
+	WarehouseID uuid.UUID               `json:"warehouse_id"`
+	Quantity    Quantity                `json:"quantity"`
+	Allocations []*SalesOrderAllocation `json:"allocations"`
+}
+
+// SalesOrderAllocation is the part of a line reserved in one inventory item.
+// StockMovementID is chosen, along with SerialNumbers when the product is
+// serialized, before the allocation is shipped, and Shipped is set once its
+// movement has been posted.
+type SalesOrderAllocation struct {
+	InventoryItemID uuid.UUID `json:"inventory_item_id"`
+	WarehouseID     uuid.UUID `json:"warehouse_id"`
+	Quantity        Quantity  `json:"quantity"`
+	StockMovementID uuid.UUID `json:"stock_movement_id"`
+	SerialNumbers   []string  `json:"serial_numbers"`
+	Shipped         bool      `json:"shipped"`
+}
+
+type SalesOrderStatus int
+
+const (
+	SalesOrderReserved SalesOrderStatus = iota
+	SalesOrderShipped
+	SalesOrderCancelled
+	SalesOrderExpired
+)
+
+func (s SalesOrderStatus) String() string {
+	switch s {
+	case SalesOrderReserved:
+		return "reserved"
+	case SalesOrderShipped:
+		return "shipped"
+	case SalesOrderCancelled:
+		return "cancelled"
+	case SalesOrderExpired:
+		return "expired"
+	}
+	return "unknown"
+}
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Serial is one unit of a serialized product. Serial numbers are unique per
+// product.
+type Serial struct {
+	SerialNumber string    `json:"serial_number"`
+	ProductID    uuid.UUID `json:"product_id"`
+	// InventoryItemID is the item holding the unit; nil while it is in
+	// transit or after it has left stock.
+	InventoryItemID uuid.UUID `json:"inventory_item_id"`
+	// WarehouseID is where the unit is, where it is headed while in transit
+	// or where it was last when it has left stock.
+	WarehouseID     uuid.UUID    `json:"warehouse_id"`
+	Status          SerialStatus `json:"status"`
+	StockMovementID uuid.UUID    `json:"stock_movement_id"`
+	CreatedAt       time.Time    `json:"created_at"`
+	UpdatedAt       time.Time    `json:"updated_at"`
+}
+
+// SerialEvent records a movement of a unit and where it left the unit.
+type SerialEvent struct {
+	ID              uuid.UUID         `json:"id"`
+	SerialNumber    string            `json:"serial_number"`
+	ProductID       uuid.UUID         `json:"product_id"`
+	StockMovementID uuid.UUID         `json:"stock_movement_id"`
+	MovementType    StockMovementType `json:"movement_type"`
+	Status          SerialStatus      `json:"status"`
+	InventoryItemID uuid.UUID         `json:"inventory_item_id"`
+	WarehouseID     uuid.UUID         `json:"warehouse_id"`
+	Actor           string            `json:"actor"`
+	RecordedAt      time.Time         `json:"recorded_at"`
+}
+
+type SerialStatus int
+
+const (
+	SerialInStock SerialStatus = iota
+	SerialInTransit
+	SerialQuarantined
+	// SerialRemoved units have been shipped to a customer or written off.
+	SerialRemoved
+)
+
+func (s SerialStatus) String() string {
+	switch s {
+	case SerialInStock:
+		return "in stock"
+	case SerialInTransit:
+		return "in transit"
+	case SerialQuarantined:
+		return "quarantined"
+	case SerialRemoved:
+		return "removed"
+	}
+	return "unknown"
+}
@@ -0,0 +1,85 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// StockAdjustment corrects the quantity of an inventory item outside of any
+// order. Adjustments over the approval thresholds wait as pending until they
+// are approved or rejected; the others are applied straight away.
+type StockAdjustment struct {
+	ID              uuid.UUID `json:"id"`
+	InventoryItemID uuid.UUID `json:"inventory_item_id"`
+	ProductID       uuid.UUID `json:"product_id"`
+	WarehouseID     uuid.UUID `json:"warehouse_id"`
+	// LotID is the lot to adjust; nil to let a write-off draw from the lots
+	// expiring first.
+	LotID uuid.UUID `json:"lot_id"`
+	// SerialNumbers are the units found or written off when the product is
+	// serialized.
+	SerialNumbers []string `json:"serial_numbers"`
+	// QuantityChange is added to the quantity on hand; negative when stock is
+	// written off.
+	QuantityChange Quantity         `json:"quantity_change"`
+	Reason         AdjustmentReason `json:"reason"`
+	Notes          string           `json:"notes"`
+	// Value is the size of the change at the product's price when requested.
+	Value           Money            `json:"value"`
+	Status          AdjustmentStatus `json:"status"`
+	RequestedBy     string           `json:"requested_by"`
+	ReviewedBy      string           `json:"reviewed_by"`
+	ReviewNotes     string           `json:"review_notes"`
+	StockMovementID uuid.UUID        `json:"stock_movement_id"`
+	CreatedAt       time.Time        `json:"created_at"`
+	ReviewedAt      time.Time        `json:"reviewed_at"`
+}
+
+type AdjustmentReason int
+
+const (
+	AdjustmentReasonUnspecified AdjustmentReason = iota
+	AdjustmentDamage
+	AdjustmentTheft
+	AdjustmentCountCorrection
+	AdjustmentExpiry
+	AdjustmentFound
+)
+
+func (r AdjustmentReason) String() string {
+	switch r {
+	case AdjustmentReasonUnspecified:
+		return "unspecified"
+	case AdjustmentDamage:
+		return "damage"
+	case AdjustmentTheft:
+		return "theft"
+	case AdjustmentCountCorrection:
+		return "count correction"
+	case AdjustmentExpiry:
+		return "expiry"
+	case AdjustmentFound:
+		return "found"
+	}
+	return "unknown"
+}
+
+type AdjustmentStatus int
+
+const (
+	AdjustmentPending AdjustmentStatus = iota
+	AdjustmentApplied
+	AdjustmentRejected
+)
+
+func (s AdjustmentStatus) String() string {
+	switch s {
+	case AdjustmentPending:
+		return "pending"
+	case AdjustmentApplied:
+		return "applied"
+	case AdjustmentRejected:
+		return "rejected"
+	}
+	return "unknown"
+}
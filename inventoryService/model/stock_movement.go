@@ -9,10 +9,35 @@ type StockMovement struct {
 	ID                     uuid.UUID         `json:"id"`
 	InventoryItemID        uuid.UUID         `json:"inventory_item_id"`
 	Type                   StockMovementType `json:"type"`
-	Quantity               int               `json:"quantity"`
+	Quantity               Quantity          `json:"quantity"`
 	Date                   time.Time         `json:"date"`
 	SourceWarehouseID      uuid.UUID         `json:"source_warehouse_id"`
 	DestinationWarehouseID uuid.UUID         `json:"destination_warehouse_id"`
+	// LotID is the lot the units were added to or taken from; nil for stock
+	// not tracked by lot.
+	LotID uuid.UUID `json:"lot_id"`
+	// SerialNumbers are the units moved when the product is serialized, one
+	// per unit of Quantity.
+	SerialNumbers []string `json:"serial_numbers"`
+	// SourceBinID and DestinationBinID are the bins the units are taken from
+	// and put in, within the item's warehouse; nil for unplaced stock.
+	SourceBinID      uuid.UUID `json:"source_bin_id"`
+	DestinationBinID uuid.UUID `json:"destination_bin_id"`
+	// UnitCost is the cost of one unit added, or the average cost of the
+	// units taken out as valued by the product's costing method.
+	UnitCost Money `json:"unit_cost"`
+	// Cost is the value of the units moved, never negative; whether it
+	// enters or leaves stock follows from Type.
+	Cost Money `json:"cost"`
+	// Unit is the unit of the product the movement was entered in and
+	// UnitQuantity the quantity in it; Quantity and UnitCost are always in
+	// base units. Unit is empty for movements entered in base units.
+	Unit         string   `json:"unit"`
+	UnitQuantity Quantity `json:"unit_quantity"`
+	// Posted is set on movements that changed stock, lots, bins and cost
+	// layers. Movements journaled by CreateStockMovement are not posted and
+	// do not count towards stock or its value.
+	Posted bool `json:"posted"`
 }
 
 type StockMovementType int
@@ -21,4 +46,21 @@ const (
 	Addition StockMovementType = iota
 	Removal
 	Transfer
+	// Quarantine puts units into the quarantined quantity of an item, where
+	// they are held apart from sellable stock.
+	Quarantine
+	// Adjustment corrects the quantity of an item outside of any order. Its
+	// Quantity is signed: negative when stock is written off.
+	Adjustment
+	// Relocation moves units between bins of an item without changing its
+	// quantity.
+	Relocation
+
+	// numStockMovementTypes counts the types above; new types go before it.
+	numStockMovementTypes
 )
+
+// Valid reports whether t is one of the known movement types.
+func (t StockMovementType) Valid() bool {
+	return t >= Addition && t < numStockMovementTypes
+}
@@ -0,0 +1,17 @@
+package model
+
+import "github.com/google/uuid"
+
+// SupplierProduct records that a supplier sells a product, under which SKU and
+// on which terms. A product has at most one preferred supplier.
+type SupplierProduct struct {
+	ID                   uuid.UUID `json:"id"`
+	SupplierID           uuid.UUID `json:"supplier_id"`
+	ProductID            uuid.UUID `json:"product_id"`
+	SupplierSKU          string    `json:"supplier_sku"`
+	UnitCost             Money     `json:"unit_cost"`
+	MinimumOrderQuantity int       `json:"minimum_order_quantity"`
+	PackSize             int       `json:"pack_size"`
+	LeadTimeDays         int       `json:"lead_time_days"`
+	Preferred            bool      `json:"preferred"`
+}
@@ -0,0 +1,50 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// SupplierScorecard summarises how a supplier delivered on the goods receipts
+// recorded in [From, To). Rates are fractions between 0 and 1 and are zero when
+// there is nothing to measure them on.
+type SupplierScorecard struct {
+	SupplierID uuid.UUID `json:"supplier_id"`
+	From       time.Time `json:"from"`
+	To         time.Time `json:"to"`
+
+	PurchaseOrders   int      `json:"purchase_orders"`
+	Receipts         int      `json:"receipts"`
+	OrderedQuantity  Quantity `json:"ordered_quantity"`
+	ReceivedQuantity Quantity `json:"received_quantity"`
+	DamagedQuantity  Quantity `json:"damaged_quantity"`
+
+	// OnTimeRate is the share of receipt lines delivered within the lead
+	// time promised in the supplier's catalog. Lines of products without a
+	// catalog entry are not counted.
+	OnTimeRate float64 `json:"on_time_rate"`
+	// FillRate is the share of the ordered quantity that has been received,
+	// over the orders that had a receipt in the window.
+	FillRate float64 `json:"fill_rate"`
+	// AverageLeadTimeDays is the mean time from submitting an order to
+	// receiving it, and AveragePromisedLeadTimeDays the mean catalog lead
+	// time of the same lines.
+	AverageLeadTimeDays         float64 `json:"average_lead_time_days"`
+	AveragePromisedLeadTimeDays float64 `json:"average_promised_lead_time_days"`
+	// DamageRate is the share of received units that arrived damaged.
+	DamageRate float64 `json:"damage_rate"`
+	// Score combines the rates into a single figure between 0 and 1 used to
+	// rank suppliers.
+	Score float64 `json:"score"`
+}
+
+// SupplierRankingMetric selects what suppliers are ranked by.
+type SupplierRankingMetric int
+
+const (
+	RankByScore SupplierRankingMetric = iota
+	RankByOnTimeRate
+	RankByFillRate
+	RankByLeadTime
+	RankByDamageRate
+)
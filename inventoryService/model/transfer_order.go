@@ -0,0 +1,98 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// TransferOrder moves stock between two warehouses in two steps. Shipping
+// takes the stock out of the source warehouse and puts it in transit;
+// receiving, possibly in several deliveries, adds it to the destination.
+type TransferOrder struct {
+	ID                     uuid.UUID              `json:"id"`
+	SourceWarehouseID      uuid.UUID              `json:"source_warehouse_id"`
+	DestinationWarehouseID uuid.UUID              `json:"destination_warehouse_id"`
+	Status                 TransferOrderStatus    `json:"status"`
+	Notes                  string                 `json:"notes"`
+	Lines                  []*TransferOrderLine   `json:"lines"`
+	Receipts               []*TransferReceiptLine `json:"receipts"`
+	CreatedAt              time.Time              `json:"created_at"`
+	UpdatedAt              time.Time              `json:"updated_at"`
+	ShippedAt              time.Time              `json:"shipped_at"`
+	ReceivedAt             time.Time              `json:"received_at"`
+	// ReceivingID is the receipt being posted against the order, if any. No
+	// other receipt or status change is accepted until it is done.
+	ReceivingID uuid.UUID `json:"receiving_id"`
+}
+
+// TransferOrderLine is the transfer of one product. Until the order is
+// received, ShippedQuantity minus ReceivedQuantity is in transit. Once it is,
+// DiscrepancyQuantity is ReceivedQuantity minus ShippedQuantity: negative when
+// units went missing on the way and positive when more arrived than shipped.
+type TransferOrderLine struct {
+	ID                  uuid.UUID `json:"id"`
+	ProductID           uuid.UUID `json:"product_id"`
+	Quantity            Quantity  `json:"quantity"`
+	ShippedQuantity     Quantity  `json:"shipped_quantity"`
+	ReceivedQuantity    Quantity  `json:"received_quantity"`
+	DiscrepancyQuantity Quantity  `json:"discrepancy_quantity"`
+	ShipmentMovementID  uuid.UUID `json:"shipment_movement_id"`
+	// SerialNumbers are the units shipped when the product is serialized.
+	SerialNumbers []string `json:"serial_numbers"`
+}
+
+// InTransit returns the shipped quantity of the line not received yet.
+func (l *TransferOrderLine) InTransit() Quantity {
+	return max(l.ShippedQuantity-l.ReceivedQuantity, 0)
+}
+
+// TransferReceiptLine records units of a line arriving at the destination.
+// It is saved with the id of its stock movement before the movement is posted,
+// and Posted is set once the units are in stock and counted as received.
+type TransferReceiptLine struct {
+	ID              uuid.UUID `json:"id"`
+	LineID          uuid.UUID `json:"line_id"`
+	Quantity        Quantity  `json:"quantity"`
+	ReceivedAt      time.Time `json:"received_at"`
+	StockMovementID uuid.UUID `json:"stock_movement_id"`
+	Notes           string    `json:"notes"`
+	SerialNumbers   []string  `json:"serial_numbers"`
+	Posted          bool      `json:"posted"`
+}
+
+// InTransitStock is the part of a transfer order line currently in transit.
+type InTransitStock struct {
+	TransferOrderID        uuid.UUID `json:"transfer_order_id"`
+	LineID                 uuid.UUID `json:"line_id"`
+	ProductID              uuid.UUID `json:"product_id"`
+	SourceWarehouseID      uuid.UUID `json:"source_warehouse_id"`
+	DestinationWarehouseID uuid.UUID `json:"destination_warehouse_id"`
+	Quantity               Quantity  `json:"quantity"`
+	ShippedAt              time.Time `json:"shipped_at"`
+}
+
+type TransferOrderStatus int
+
+const (
+	TransferOrderDraft TransferOrderStatus = iota
+	TransferOrderInTransit
+	TransferOrderPartiallyReceived
+	TransferOrderReceived
+	TransferOrderCancelled
+)
+
+func (s TransferOrderStatus) String() string {
+	switch s {
+	case TransferOrderDraft:
+		return "draft"
+	case TransferOrderInTransit:
+		return "in transit"
+	case TransferOrderPartiallyReceived:
+		return "partially received"
+	case TransferOrderReceived:
+		return "received"
+	case TransferOrderCancelled:
+		return "cancelled"
+	}
+	return "unknown"
+}
@@ -0,0 +1,83 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// CostLayer is a batch of an inventory item's units received at one unit
+// cost. Stock taken out of the item consumes its layers in the order set by
+// the costing method of the product.
+type CostLayer struct {
+	ID              uuid.UUID `json:"id"`
+	InventoryItemID uuid.UUID `json:"inventory_item_id"`
+	// StockMovementID is the movement that received the units; under
+	// CostingMovingAverage, the last one merged into the layer.
+	StockMovementID   uuid.UUID `json:"stock_movement_id"`
+	UnitCost          Money     `json:"unit_cost"`
+	ReceivedQuantity  Quantity  `json:"received_quantity"`
+	RemainingQuantity Quantity  `json:"remaining_quantity"`
+	ReceivedAt        time.Time `json:"received_at"`
+}
+
+// CostingMethod decides the cost of units taken out of stock.
+type CostingMethod int
+
+const (
+	// CostingFIFO takes units from the oldest layer first.
+	CostingFIFO CostingMethod = iota
+	// CostingLIFO takes units from the newest layer first.
+	CostingLIFO
+	// CostingMovingAverage keeps a single layer whose unit cost is averaged
+	// again on every receipt.
+	CostingMovingAverage
+)
+
+func (m CostingMethod) String() string {
+	switch m {
+	case CostingFIFO:
+		return "FIFO"
+	case CostingLIFO:
+		return "LIFO"
+	case CostingMovingAverage:
+		return "moving average"
+	}
+	return "unknown"
+}
+
+// InventoryValuation is the value of stock on hand at a date and the cost of
+// the goods removed up to it.
+type InventoryValuation struct {
+	AsOf time.Time `json:"as_of"`
+	// Since is the start of the period CostOfGoodsRemoved covers; zero for
+	// all time.
+	Since    time.Time        `json:"since"`
+	Lines    []*ValuationLine `json:"lines"`
+	Quantity Quantity         `json:"quantity"`
+	// Value and CostOfGoodsRemoved hold one total per currency, as stock
+	// valued in different currencies does not add up.
+	Value              []Money `json:"value"`
+	CostOfGoodsRemoved []Money `json:"cost_of_goods_removed"`
+}
+
+// ValuationLine values the stock of one inventory item.
+type ValuationLine struct {
+	InventoryItemID uuid.UUID     `json:"inventory_item_id"`
+	ProductID       uuid.UUID     `json:"product_id"`
+	WarehouseID     uuid.UUID     `json:"warehouse_id"`
+	CategoryID      uuid.UUID     `json:"category_id"`
+	CostingMethod   CostingMethod `json:"costing_method"`
+	Quantity        Quantity      `json:"quantity"`
+	Value           Money         `json:"value"`
+	// CostOfGoodsRemoved is the cost of units removed or written off; units
+	// transferred to another warehouse keep their value.
+	CostOfGoodsRemoved Money `json:"cost_of_goods_removed"`
+}
+
+// AverageUnitCost is the value of a unit on hand, or zero without stock.
+func (l *ValuationLine) AverageUnitCost() Money {
+	if l.Quantity == 0 {
+		return Money{CurrencyCode: l.Value.CurrencyCode}
+	}
+	return l.Value.Div(l.Quantity)
+}
@@ -6,4 +6,8 @@ type Warehouse struct {
 	ID       uuid.UUID `json:"id"`
 	Name     string    `json:"name"`
 	Location string    `json:"location"`
+	// Capacity limits the stock held in the warehouse, including stock in
+	// transit to it; each bin may have a capacity of its own.
+	Capacity       Capacity       `json:"capacity"`
+	CapacityPolicy CapacityPolicy `json:"capacity_policy"`
 }
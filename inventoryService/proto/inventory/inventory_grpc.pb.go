@@ -20,36 +20,122 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	InventoryService_CreateProduct_FullMethodName       = "/inventory.InventoryService/CreateProduct"
-	InventoryService_GetProduct_FullMethodName          = "/inventory.InventoryService/GetProduct"
-	InventoryService_UpdateProduct_FullMethodName       = "/inventory.InventoryService/UpdateProduct"
-	InventoryService_DeleteProduct_FullMethodName       = "/inventory.InventoryService/DeleteProduct"
-	InventoryService_CreateCategory_FullMethodName      = "/inventory.InventoryService/CreateCategory"
-	InventoryService_GetCategory_FullMethodName         = "/inventory.InventoryService/GetCategory"
-	InventoryService_UpdateCategory_FullMethodName      = "/inventory.InventoryService/UpdateCategory"
-	InventoryService_DeleteCategory_FullMethodName      = "/inventory.InventoryService/DeleteCategory"
-	InventoryService_CreateInventoryItem_FullMethodName = "/inventory.InventoryService/CreateInventoryItem"
-	InventoryService_GetInventoryItem_FullMethodName    = "/inventory.InventoryService/GetInventoryItem"
-	InventoryService_UpdateInventoryItem_FullMethodName = "/inventory.InventoryService/UpdateInventoryItem"
-	InventoryService_DeleteInventoryItem_FullMethodName = "/inventory.InventoryService/DeleteInventoryItem"
-	InventoryService_CreateWarehouse_FullMethodName     = "/inventory.InventoryService/CreateWarehouse"
-	InventoryService_GetWarehouse_FullMethodName        = "/inventory.InventoryService/GetWarehouse"
-	InventoryService_UpdateWarehouse_FullMethodName     = "/inventory.InventoryService/UpdateWarehouse"
-	InventoryService_DeleteWarehouse_FullMethodName     = "/inventory.InventoryService/DeleteWarehouse"
-	InventoryService_CreateSupplier_FullMethodName      = "/inventory.InventoryService/CreateSupplier"
-	InventoryService_GetSupplier_FullMethodName         = "/inventory.InventoryService/GetSupplier"
-	InventoryService_UpdateSupplier_FullMethodName      = "/inventory.InventoryService/UpdateSupplier"
-	InventoryService_DeleteSupplier_FullMethodName      = "/inventory.InventoryService/DeleteSupplier"
-	InventoryService_CreateStockMovement_FullMethodName = "/inventory.InventoryService/CreateStockMovement"
-	InventoryService_GetStockMovement_FullMethodName    = "/inventory.InventoryService/GetStockMovement"
-	InventoryService_UpdateStockMovement_FullMethodName = "/inventory.InventoryService/UpdateStockMovement"
-	InventoryService_DeleteStockMovement_FullMethodName = "/inventory.InventoryService/DeleteStockMovement"
-	InventoryService_ListProducts_FullMethodName        = "/inventory.InventoryService/ListProducts"
-	InventoryService_ListCategories_FullMethodName      = "/inventory.InventoryService/ListCategories"
-	InventoryService_ListInventoryItems_FullMethodName  = "/inventory.InventoryService/ListInventoryItems"
-	InventoryService_ListWarehouses_FullMethodName      = "/inventory.InventoryService/ListWarehouses"
-	InventoryService_ListSuppliers_FullMethodName       = "/inventory.InventoryService/ListSuppliers"
-	InventoryService_ListStockMovements_FullMethodName  = "/inventory.InventoryService/ListStockMovements"
+	InventoryService_CreateProduct_FullMethodName                      = "/inventory.InventoryService/CreateProduct"
+	InventoryService_GetProduct_FullMethodName                         = "/inventory.InventoryService/GetProduct"
+	InventoryService_UpdateProduct_FullMethodName                      = "/inventory.InventoryService/UpdateProduct"
+	InventoryService_DeleteProduct_FullMethodName                      = "/inventory.InventoryService/DeleteProduct"
+	InventoryService_CreateCategory_FullMethodName                     = "/inventory.InventoryService/CreateCategory"
+	InventoryService_GetCategory_FullMethodName                        = "/inventory.InventoryService/GetCategory"
+	InventoryService_UpdateCategory_FullMethodName                     = "/inventory.InventoryService/UpdateCategory"
+	InventoryService_DeleteCategory_FullMethodName                     = "/inventory.InventoryService/DeleteCategory"
+	InventoryService_CreateInventoryItem_FullMethodName                = "/inventory.InventoryService/CreateInventoryItem"
+	InventoryService_GetInventoryItem_FullMethodName                   = "/inventory.InventoryService/GetInventoryItem"
+	InventoryService_UpdateInventoryItem_FullMethodName                = "/inventory.InventoryService/UpdateInventoryItem"
+	InventoryService_DeleteInventoryItem_FullMethodName                = "/inventory.InventoryService/DeleteInventoryItem"
+	InventoryService_CreateWarehouse_FullMethodName                    = "/inventory.InventoryService/CreateWarehouse"
+	InventoryService_GetWarehouse_FullMethodName                       = "/inventory.InventoryService/GetWarehouse"
+	InventoryService_UpdateWarehouse_FullMethodName                    = "/inventory.InventoryService/UpdateWarehouse"
+	InventoryService_DeleteWarehouse_FullMethodName                    = "/inventory.InventoryService/DeleteWarehouse"
+	InventoryService_CreateSupplier_FullMethodName                     = "/inventory.InventoryService/CreateSupplier"
+	InventoryService_GetSupplier_FullMethodName                        = "/inventory.InventoryService/GetSupplier"
+	InventoryService_UpdateSupplier_FullMethodName                     = "/inventory.InventoryService/UpdateSupplier"
+	InventoryService_DeleteSupplier_FullMethodName                     = "/inventory.InventoryService/DeleteSupplier"
+	InventoryService_CreateStockMovement_FullMethodName                = "/inventory.InventoryService/CreateStockMovement"
+	InventoryService_GetStockMovement_FullMethodName                   = "/inventory.InventoryService/GetStockMovement"
+	InventoryService_UpdateStockMovement_FullMethodName                = "/inventory.InventoryService/UpdateStockMovement"
+	InventoryService_DeleteStockMovement_FullMethodName                = "/inventory.InventoryService/DeleteStockMovement"
+	InventoryService_ListProducts_FullMethodName                       = "/inventory.InventoryService/ListProducts"
+	InventoryService_ListCategories_FullMethodName                     = "/inventory.InventoryService/ListCategories"
+	InventoryService_ListInventoryItems_FullMethodName                 = "/inventory.InventoryService/ListInventoryItems"
+	InventoryService_ListWarehouses_FullMethodName                     = "/inventory.InventoryService/ListWarehouses"
+	InventoryService_ListSuppliers_FullMethodName                      = "/inventory.InventoryService/ListSuppliers"
+	InventoryService_ListStockMovements_FullMethodName                 = "/inventory.InventoryService/ListStockMovements"
+	InventoryService_ListAuditEvents_FullMethodName                    = "/inventory.InventoryService/ListAuditEvents"
+	InventoryService_GetEntityHistory_FullMethodName                   = "/inventory.InventoryService/GetEntityHistory"
+	InventoryService_ListReorderSuggestions_FullMethodName             = "/inventory.InventoryService/ListReorderSuggestions"
+	InventoryService_StreamReorderAlerts_FullMethodName                = "/inventory.InventoryService/StreamReorderAlerts"
+	InventoryService_CreatePurchaseOrder_FullMethodName                = "/inventory.InventoryService/CreatePurchaseOrder"
+	InventoryService_GetPurchaseOrder_FullMethodName                   = "/inventory.InventoryService/GetPurchaseOrder"
+	InventoryService_UpdatePurchaseOrder_FullMethodName                = "/inventory.InventoryService/UpdatePurchaseOrder"
+	InventoryService_DeletePurchaseOrder_FullMethodName                = "/inventory.InventoryService/DeletePurchaseOrder"
+	InventoryService_ListPurchaseOrders_FullMethodName                 = "/inventory.InventoryService/ListPurchaseOrders"
+	InventoryService_SubmitPurchaseOrder_FullMethodName                = "/inventory.InventoryService/SubmitPurchaseOrder"
+	InventoryService_CancelPurchaseOrder_FullMethodName                = "/inventory.InventoryService/CancelPurchaseOrder"
+	InventoryService_CreatePurchaseOrderFromSuggestions_FullMethodName = "/inventory.InventoryService/CreatePurchaseOrderFromSuggestions"
+	InventoryService_ReceiveGoods_FullMethodName                       = "/inventory.InventoryService/ReceiveGoods"
+	InventoryService_GetGoodsReceipt_FullMethodName                    = "/inventory.InventoryService/GetGoodsReceipt"
+	InventoryService_ListGoodsReceipts_FullMethodName                  = "/inventory.InventoryService/ListGoodsReceipts"
+	InventoryService_CreateSupplierProduct_FullMethodName              = "/inventory.InventoryService/CreateSupplierProduct"
+	InventoryService_GetSupplierProduct_FullMethodName                 = "/inventory.InventoryService/GetSupplierProduct"
+	InventoryService_UpdateSupplierProduct_FullMethodName              = "/inventory.InventoryService/UpdateSupplierProduct"
+	InventoryService_DeleteSupplierProduct_FullMethodName              = "/inventory.InventoryService/DeleteSupplierProduct"
+	InventoryService_ListSuppliersForProduct_FullMethodName            = "/inventory.InventoryService/ListSuppliersForProduct"
+	InventoryService_ListProductsForSupplier_FullMethodName            = "/inventory.InventoryService/ListProductsForSupplier"
+	InventoryService_GetSupplierScorecard_FullMethodName               = "/inventory.InventoryService/GetSupplierScorecard"
+	InventoryService_RankSuppliers_FullMethodName                      = "/inventory.InventoryService/RankSuppliers"
+	InventoryService_CreateSalesOrder_FullMethodName                   = "/inventory.InventoryService/CreateSalesOrder"
+	InventoryService_GetSalesOrder_FullMethodName                      = "/inventory.InventoryService/GetSalesOrder"
+	InventoryService_ListSalesOrders_FullMethodName                    = "/inventory.InventoryService/ListSalesOrders"
+	InventoryService_CancelSalesOrder_FullMethodName                   = "/inventory.InventoryService/CancelSalesOrder"
+	InventoryService_ShipSalesOrder_FullMethodName                     = "/inventory.InventoryService/ShipSalesOrder"
+	InventoryService_CreateReturnAuthorization_FullMethodName          = "/inventory.InventoryService/CreateReturnAuthorization"
+	InventoryService_GetReturnAuthorization_FullMethodName             = "/inventory.InventoryService/GetReturnAuthorization"
+	InventoryService_ListReturnAuthorizations_FullMethodName           = "/inventory.InventoryService/ListReturnAuthorizations"
+	InventoryService_InspectReturnAuthorization_FullMethodName         = "/inventory.InventoryService/InspectReturnAuthorization"
+	InventoryService_CancelReturnAuthorization_FullMethodName          = "/inventory.InventoryService/CancelReturnAuthorization"
+	InventoryService_CreateTransferOrder_FullMethodName                = "/inventory.InventoryService/CreateTransferOrder"
+	InventoryService_GetTransferOrder_FullMethodName                   = "/inventory.InventoryService/GetTransferOrder"
+	InventoryService_ListTransferOrders_FullMethodName                 = "/inventory.InventoryService/ListTransferOrders"
+	InventoryService_ShipTransferOrder_FullMethodName                  = "/inventory.InventoryService/ShipTransferOrder"
+	InventoryService_ReceiveTransferOrder_FullMethodName               = "/inventory.InventoryService/ReceiveTransferOrder"
+	InventoryService_CancelTransferOrder_FullMethodName                = "/inventory.InventoryService/CancelTransferOrder"
+	InventoryService_ListInTransitStock_FullMethodName                 = "/inventory.InventoryService/ListInTransitStock"
+	InventoryService_AdjustStock_FullMethodName                        = "/inventory.InventoryService/AdjustStock"
+	InventoryService_GetStockAdjustment_FullMethodName                 = "/inventory.InventoryService/GetStockAdjustment"
+	InventoryService_ListStockAdjustments_FullMethodName               = "/inventory.InventoryService/ListStockAdjustments"
+	InventoryService_ApproveStockAdjustment_FullMethodName             = "/inventory.InventoryService/ApproveStockAdjustment"
+	InventoryService_RejectStockAdjustment_FullMethodName              = "/inventory.InventoryService/RejectStockAdjustment"
+	InventoryService_CreateCountSession_FullMethodName                 = "/inventory.InventoryService/CreateCountSession"
+	InventoryService_GetCountSession_FullMethodName                    = "/inventory.InventoryService/GetCountSession"
+	InventoryService_ListCountSessions_FullMethodName                  = "/inventory.InventoryService/ListCountSessions"
+	InventoryService_RecordCounts_FullMethodName                       = "/inventory.InventoryService/RecordCounts"
+	InventoryService_SubmitCountSession_FullMethodName                 = "/inventory.InventoryService/SubmitCountSession"
+	InventoryService_ApproveCountSession_FullMethodName                = "/inventory.InventoryService/ApproveCountSession"
+	InventoryService_CancelCountSession_FullMethodName                 = "/inventory.InventoryService/CancelCountSession"
+	InventoryService_CreateCountPlan_FullMethodName                    = "/inventory.InventoryService/CreateCountPlan"
+	InventoryService_GetCountPlan_FullMethodName                       = "/inventory.InventoryService/GetCountPlan"
+	InventoryService_ListCountPlans_FullMethodName                     = "/inventory.InventoryService/ListCountPlans"
+	InventoryService_UpdateCountPlan_FullMethodName                    = "/inventory.InventoryService/UpdateCountPlan"
+	InventoryService_DeleteCountPlan_FullMethodName                    = "/inventory.InventoryService/DeleteCountPlan"
+	InventoryService_RunCountPlan_FullMethodName                       = "/inventory.InventoryService/RunCountPlan"
+	InventoryService_ListCountPlanItems_FullMethodName                 = "/inventory.InventoryService/ListCountPlanItems"
+	InventoryService_CreateLot_FullMethodName                          = "/inventory.InventoryService/CreateLot"
+	InventoryService_GetLot_FullMethodName                             = "/inventory.InventoryService/GetLot"
+	InventoryService_ListLots_FullMethodName                           = "/inventory.InventoryService/ListLots"
+	InventoryService_ListExpiringLots_FullMethodName                   = "/inventory.InventoryService/ListExpiringLots"
+	InventoryService_LookupSerial_FullMethodName                       = "/inventory.InventoryService/LookupSerial"
+	InventoryService_GetSerialHistory_FullMethodName                   = "/inventory.InventoryService/GetSerialHistory"
+	InventoryService_CreateLocation_FullMethodName                     = "/inventory.InventoryService/CreateLocation"
+	InventoryService_GetLocation_FullMethodName                        = "/inventory.InventoryService/GetLocation"
+	InventoryService_ListLocations_FullMethodName                      = "/inventory.InventoryService/ListLocations"
+	InventoryService_UpdateLocation_FullMethodName                     = "/inventory.InventoryService/UpdateLocation"
+	InventoryService_DeleteLocation_FullMethodName                     = "/inventory.InventoryService/DeleteLocation"
+	InventoryService_ListBinStock_FullMethodName                       = "/inventory.InventoryService/ListBinStock"
+	InventoryService_MoveBinStock_FullMethodName                       = "/inventory.InventoryService/MoveBinStock"
+	InventoryService_SuggestPutaway_FullMethodName                     = "/inventory.InventoryService/SuggestPutaway"
+	InventoryService_GetWarehouseUtilization_FullMethodName            = "/inventory.InventoryService/GetWarehouseUtilization"
+	InventoryService_ListCostLayers_FullMethodName                     = "/inventory.InventoryService/ListCostLayers"
+	InventoryService_GetInventoryValuation_FullMethodName              = "/inventory.InventoryService/GetInventoryValuation"
+	InventoryService_CreatePriceList_FullMethodName                    = "/inventory.InventoryService/CreatePriceList"
+	InventoryService_GetPriceList_FullMethodName                       = "/inventory.InventoryService/GetPriceList"
+	InventoryService_ListPriceLists_FullMethodName                     = "/inventory.InventoryService/ListPriceLists"
+	InventoryService_UpdatePriceList_FullMethodName                    = "/inventory.InventoryService/UpdatePriceList"
+	InventoryService_DeletePriceList_FullMethodName                    = "/inventory.InventoryService/DeletePriceList"
+	InventoryService_GetEffectivePrice_FullMethodName                  = "/inventory.InventoryService/GetEffectivePrice"
+	InventoryService_ListExchangeRates_FullMethodName                  = "/inventory.InventoryService/ListExchangeRates"
+	InventoryService_SchedulePriceChange_FullMethodName                = "/inventory.InventoryService/SchedulePriceChange"
+	InventoryService_CancelPriceChange_FullMethodName                  = "/inventory.InventoryService/CancelPriceChange"
+	InventoryService_GetPriceHistory_FullMethodName                    = "/inventory.InventoryService/GetPriceHistory"
 )
 
 // InventoryServiceClient is the client API for InventoryService service.
@@ -86,6 +172,92 @@ type InventoryServiceClient interface {
 	ListWarehouses(ctx context.Context, in *ListWarehousesRequest, opts ...grpc.CallOption) (*ListWarehousesResponse, error)
 	ListSuppliers(ctx context.Context, in *ListSuppliersRequest, opts ...grpc.CallOption) (*ListSuppliersResponse, error)
 	ListStockMovements(ctx context.Context, in *ListStockMovementsRequest, opts ...grpc.CallOption) (*ListStockMovementsResponse, error)
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error)
+	GetEntityHistory(ctx context.Context, in *GetEntityHistoryRequest, opts ...grpc.CallOption) (*GetEntityHistoryResponse, error)
+	ListReorderSuggestions(ctx context.Context, in *ListReorderSuggestionsRequest, opts ...grpc.CallOption) (*ListReorderSuggestionsResponse, error)
+	StreamReorderAlerts(ctx context.Context, in *StreamReorderAlertsRequest, opts ...grpc.CallOption) (InventoryService_StreamReorderAlertsClient, error)
+	CreatePurchaseOrder(ctx context.Context, in *CreatePurchaseOrderRequest, opts ...grpc.CallOption) (*PurchaseOrder, error)
+	GetPurchaseOrder(ctx context.Context, in *GetPurchaseOrderRequest, opts ...grpc.CallOption) (*PurchaseOrder, error)
+	UpdatePurchaseOrder(ctx context.Context, in *UpdatePurchaseOrderRequest, opts ...grpc.CallOption) (*PurchaseOrder, error)
+	DeletePurchaseOrder(ctx context.Context, in *DeletePurchaseOrderRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListPurchaseOrders(ctx context.Context, in *ListPurchaseOrdersRequest, opts ...grpc.CallOption) (*ListPurchaseOrdersResponse, error)
+	SubmitPurchaseOrder(ctx context.Context, in *SubmitPurchaseOrderRequest, opts ...grpc.CallOption) (*PurchaseOrder, error)
+	CancelPurchaseOrder(ctx context.Context, in *CancelPurchaseOrderRequest, opts ...grpc.CallOption) (*PurchaseOrder, error)
+	CreatePurchaseOrderFromSuggestions(ctx context.Context, in *CreatePurchaseOrderFromSuggestionsRequest, opts ...grpc.CallOption) (*PurchaseOrder, error)
+	ReceiveGoods(ctx context.Context, in *ReceiveGoodsRequest, opts ...grpc.CallOption) (*ReceiveGoodsResponse, error)
+	GetGoodsReceipt(ctx context.Context, in *GetGoodsReceiptRequest, opts ...grpc.CallOption) (*GoodsReceipt, error)
+	ListGoodsReceipts(ctx context.Context, in *ListGoodsReceiptsRequest, opts ...grpc.CallOption) (*ListGoodsReceiptsResponse, error)
+	CreateSupplierProduct(ctx context.Context, in *CreateSupplierProductRequest, opts ...grpc.CallOption) (*SupplierProduct, error)
+	GetSupplierProduct(ctx context.Context, in *GetSupplierProductRequest, opts ...grpc.CallOption) (*SupplierProduct, error)
+	UpdateSupplierProduct(ctx context.Context, in *UpdateSupplierProductRequest, opts ...grpc.CallOption) (*SupplierProduct, error)
+	DeleteSupplierProduct(ctx context.Context, in *DeleteSupplierProductRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListSuppliersForProduct(ctx context.Context, in *ListSuppliersForProductRequest, opts ...grpc.CallOption) (*ListSupplierProductsResponse, error)
+	ListProductsForSupplier(ctx context.Context, in *ListProductsForSupplierRequest, opts ...grpc.CallOption) (*ListSupplierProductsResponse, error)
+	GetSupplierScorecard(ctx context.Context, in *GetSupplierScorecardRequest, opts ...grpc.CallOption) (*SupplierScorecard, error)
+	RankSuppliers(ctx context.Context, in *RankSuppliersRequest, opts ...grpc.CallOption) (*RankSuppliersResponse, error)
+	CreateSalesOrder(ctx context.Context, in *CreateSalesOrderRequest, opts ...grpc.CallOption) (*SalesOrder, error)
+	GetSalesOrder(ctx context.Context, in *GetSalesOrderRequest, opts ...grpc.CallOption) (*SalesOrder, error)
+	ListSalesOrders(ctx context.Context, in *ListSalesOrdersRequest, opts ...grpc.CallOption) (*ListSalesOrdersResponse, error)
+	CancelSalesOrder(ctx context.Context, in *CancelSalesOrderRequest, opts ...grpc.CallOption) (*SalesOrder, error)
+	ShipSalesOrder(ctx context.Context, in *ShipSalesOrderRequest, opts ...grpc.CallOption) (*SalesOrder, error)
+	CreateReturnAuthorization(ctx context.Context, in *CreateReturnAuthorizationRequest, opts ...grpc.CallOption) (*ReturnAuthorization, error)
+	GetReturnAuthorization(ctx context.Context, in *GetReturnAuthorizationRequest, opts ...grpc.CallOption) (*ReturnAuthorization, error)
+	ListReturnAuthorizations(ctx context.Context, in *ListReturnAuthorizationsRequest, opts ...grpc.CallOption) (*ListReturnAuthorizationsResponse, error)
+	InspectReturnAuthorization(ctx context.Context, in *InspectReturnAuthorizationRequest, opts ...grpc.CallOption) (*ReturnAuthorization, error)
+	CancelReturnAuthorization(ctx context.Context, in *CancelReturnAuthorizationRequest, opts ...grpc.CallOption) (*ReturnAuthorization, error)
+	CreateTransferOrder(ctx context.Context, in *CreateTransferOrderRequest, opts ...grpc.CallOption) (*TransferOrder, error)
+	GetTransferOrder(ctx context.Context, in *GetTransferOrderRequest, opts ...grpc.CallOption) (*TransferOrder, error)
+	ListTransferOrders(ctx context.Context, in *ListTransferOrdersRequest, opts ...grpc.CallOption) (*ListTransferOrdersResponse, error)
+	ShipTransferOrder(ctx context.Context, in *ShipTransferOrderRequest, opts ...grpc.CallOption) (*TransferOrder, error)
+	ReceiveTransferOrder(ctx context.Context, in *ReceiveTransferOrderRequest, opts ...grpc.CallOption) (*TransferOrder, error)
+	CancelTransferOrder(ctx context.Context, in *CancelTransferOrderRequest, opts ...grpc.CallOption) (*TransferOrder, error)
+	ListInTransitStock(ctx context.Context, in *ListInTransitStockRequest, opts ...grpc.CallOption) (*ListInTransitStockResponse, error)
+	AdjustStock(ctx context.Context, in *AdjustStockRequest, opts ...grpc.CallOption) (*StockAdjustment, error)
+	GetStockAdjustment(ctx context.Context, in *GetStockAdjustmentRequest, opts ...grpc.CallOption) (*StockAdjustment, error)
+	ListStockAdjustments(ctx context.Context, in *ListStockAdjustmentsRequest, opts ...grpc.CallOption) (*ListStockAdjustmentsResponse, error)
+	ApproveStockAdjustment(ctx context.Context, in *ApproveStockAdjustmentRequest, opts ...grpc.CallOption) (*StockAdjustment, error)
+	RejectStockAdjustment(ctx context.Context, in *RejectStockAdjustmentRequest, opts ...grpc.CallOption) (*StockAdjustment, error)
+	CreateCountSession(ctx context.Context, in *CreateCountSessionRequest, opts ...grpc.CallOption) (*CountSession, error)
+	GetCountSession(ctx context.Context, in *GetCountSessionRequest, opts ...grpc.CallOption) (*CountSession, error)
+	ListCountSessions(ctx context.Context, in *ListCountSessionsRequest, opts ...grpc.CallOption) (*ListCountSessionsResponse, error)
+	RecordCounts(ctx context.Context, in *RecordCountsRequest, opts ...grpc.CallOption) (*CountSession, error)
+	SubmitCountSession(ctx context.Context, in *SubmitCountSessionRequest, opts ...grpc.CallOption) (*CountSession, error)
+	ApproveCountSession(ctx context.Context, in *ApproveCountSessionRequest, opts ...grpc.CallOption) (*CountSession, error)
+	CancelCountSession(ctx context.Context, in *CancelCountSessionRequest, opts ...grpc.CallOption) (*CountSession, error)
+	CreateCountPlan(ctx context.Context, in *CreateCountPlanRequest, opts ...grpc.CallOption) (*CountPlan, error)
+	GetCountPlan(ctx context.Context, in *GetCountPlanRequest, opts ...grpc.CallOption) (*CountPlan, error)
+	ListCountPlans(ctx context.Context, in *ListCountPlansRequest, opts ...grpc.CallOption) (*ListCountPlansResponse, error)
+	UpdateCountPlan(ctx context.Context, in *UpdateCountPlanRequest, opts ...grpc.CallOption) (*CountPlan, error)
+	DeleteCountPlan(ctx context.Context, in *DeleteCountPlanRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RunCountPlan(ctx context.Context, in *RunCountPlanRequest, opts ...grpc.CallOption) (*RunCountPlanResponse, error)
+	ListCountPlanItems(ctx context.Context, in *ListCountPlanItemsRequest, opts ...grpc.CallOption) (*ListCountPlanItemsResponse, error)
+	CreateLot(ctx context.Context, in *CreateLotRequest, opts ...grpc.CallOption) (*Lot, error)
+	GetLot(ctx context.Context, in *GetLotRequest, opts ...grpc.CallOption) (*Lot, error)
+	ListLots(ctx context.Context, in *ListLotsRequest, opts ...grpc.CallOption) (*ListLotsResponse, error)
+	ListExpiringLots(ctx context.Context, in *ListExpiringLotsRequest, opts ...grpc.CallOption) (*ListLotsResponse, error)
+	LookupSerial(ctx context.Context, in *LookupSerialRequest, opts ...grpc.CallOption) (*Serial, error)
+	GetSerialHistory(ctx context.Context, in *GetSerialHistoryRequest, opts ...grpc.CallOption) (*GetSerialHistoryResponse, error)
+	CreateLocation(ctx context.Context, in *CreateLocationRequest, opts ...grpc.CallOption) (*Location, error)
+	GetLocation(ctx context.Context, in *GetLocationRequest, opts ...grpc.CallOption) (*Location, error)
+	ListLocations(ctx context.Context, in *ListLocationsRequest, opts ...grpc.CallOption) (*ListLocationsResponse, error)
+	UpdateLocation(ctx context.Context, in *UpdateLocationRequest, opts ...grpc.CallOption) (*Location, error)
+	DeleteLocation(ctx context.Context, in *DeleteLocationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListBinStock(ctx context.Context, in *ListBinStockRequest, opts ...grpc.CallOption) (*ListBinStockResponse, error)
+	MoveBinStock(ctx context.Context, in *MoveBinStockRequest, opts ...grpc.CallOption) (*StockMovement, error)
+	SuggestPutaway(ctx context.Context, in *SuggestPutawayRequest, opts ...grpc.CallOption) (*SuggestPutawayResponse, error)
+	GetWarehouseUtilization(ctx context.Context, in *GetWarehouseUtilizationRequest, opts ...grpc.CallOption) (*WarehouseUtilization, error)
+	ListCostLayers(ctx context.Context, in *ListCostLayersRequest, opts ...grpc.CallOption) (*ListCostLayersResponse, error)
+	GetInventoryValuation(ctx context.Context, in *GetInventoryValuationRequest, opts ...grpc.CallOption) (*InventoryValuation, error)
+	CreatePriceList(ctx context.Context, in *CreatePriceListRequest, opts ...grpc.CallOption) (*PriceList, error)
+	GetPriceList(ctx context.Context, in *GetPriceListRequest, opts ...grpc.CallOption) (*PriceList, error)
+	ListPriceLists(ctx context.Context, in *ListPriceListsRequest, opts ...grpc.CallOption) (*ListPriceListsResponse, error)
+	UpdatePriceList(ctx context.Context, in *UpdatePriceListRequest, opts ...grpc.CallOption) (*PriceList, error)
+	DeletePriceList(ctx context.Context, in *DeletePriceListRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetEffectivePrice(ctx context.Context, in *GetEffectivePriceRequest, opts ...grpc.CallOption) (*EffectivePrice, error)
+	ListExchangeRates(ctx context.Context, in *ListExchangeRatesRequest, opts ...grpc.CallOption) (*ListExchangeRatesResponse, error)
+	SchedulePriceChange(ctx context.Context, in *SchedulePriceChangeRequest, opts ...grpc.CallOption) (*PriceChange, error)
+	CancelPriceChange(ctx context.Context, in *CancelPriceChangeRequest, opts ...grpc.CallOption) (*PriceChange, error)
+	GetPriceHistory(ctx context.Context, in *GetPriceHistoryRequest, opts ...grpc.CallOption) (*GetPriceHistoryResponse, error)
 }
 
 type inventoryServiceClient struct {
@@ -366,686 +538,3378 @@ func (c *inventoryServiceClient) ListStockMovements(ctx context.Context, in *Lis
 	return out, nil
 }
 
-// InventoryServiceServer is the server API for InventoryService service.
-// All implementations must embed UnimplementedInventoryServiceServer
-// for forward compatibility
-type InventoryServiceServer interface {
-	CreateProduct(context.Context, *CreateProductRequest) (*Product, error)
-	GetProduct(context.Context, *GetProductRequest) (*Product, error)
-	UpdateProduct(context.Context, *UpdateProductRequest) (*Product, error)
-	DeleteProduct(context.Context, *DeleteProductRequest) (*emptypb.Empty, error)
-	CreateCategory(context.Context, *CreateCategoryRequest) (*Category, error)
-	GetCategory(context.Context, *GetCategoryRequest) (*Category, error)
-	UpdateCategory(context.Context, *UpdateCategoryRequest) (*Category, error)
-	DeleteCategory(context.Context, *DeleteCategoryRequest) (*emptypb.Empty, error)
-	CreateInventoryItem(context.Context, *CreateInventoryItemRequest) (*InventoryItem, error)
-	GetInventoryItem(context.Context, *GetInventoryItemRequest) (*InventoryItem, error)
-	UpdateInventoryItem(context.Context, *UpdateInventoryItemRequest) (*InventoryItem, error)
-	DeleteInventoryItem(context.Context, *DeleteInventoryItemRequest) (*emptypb.Empty, error)
-	CreateWarehouse(context.Context, *CreateWarehouseRequest) (*Warehouse, error)
-	GetWarehouse(context.Context, *GetWarehouseRequest) (*Warehouse, error)
-	UpdateWarehouse(context.Context, *UpdateWarehouseRequest) (*Warehouse, error)
-	DeleteWarehouse(context.Context, *DeleteWarehouseRequest) (*emptypb.Empty, error)
-	CreateSupplier(context.Context, *CreateSupplierRequest) (*Supplier, error)
-	GetSupplier(context.Context, *GetSupplierRequest) (*Supplier, error)
-	UpdateSupplier(context.Context, *UpdateSupplierRequest) (*Supplier, error)
-	DeleteSupplier(context.Context, *DeleteSupplierRequest) (*emptypb.Empty, error)
-	CreateStockMovement(context.Context, *CreateStockMovementRequest) (*StockMovement, error)
-	GetStockMovement(context.Context, *GetStockMovementRequest) (*StockMovement, error)
-	UpdateStockMovement(context.Context, *UpdateStockMovementRequest) (*StockMovement, error)
-	DeleteStockMovement(context.Context, *DeleteStockMovementRequest) (*emptypb.Empty, error)
-	ListProducts(context.Context, *ListProductsRequest) (*ListProductsResponse, error)
-	ListCategories(context.Context, *ListCategoriesRequest) (*ListCategoriesResponse, error)
-	ListInventoryItems(context.Context, *ListInventoryItemsRequest) (*ListInventoryItemsResponse, error)
-	ListWarehouses(context.Context, *ListWarehousesRequest) (*ListWarehousesResponse, error)
-	ListSuppliers(context.Context, *ListSuppliersRequest) (*ListSuppliersResponse, error)
-	ListStockMovements(context.Context, *ListStockMovementsRequest) (*ListStockMovementsResponse, error)
-	mustEmbedUnimplementedInventoryServiceServer()
+func (c *inventoryServiceClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error) {
+	out := new(ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListAuditEvents_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-// UnimplementedInventoryServiceServer must be embedded to have forward compatible implementations.
-type UnimplementedInventoryServiceServer struct {
+func (c *inventoryServiceClient) GetEntityHistory(ctx context.Context, in *GetEntityHistoryRequest, opts ...grpc.CallOption) (*GetEntityHistoryResponse, error) {
+	out := new(GetEntityHistoryResponse)
+	err := c.cc.Invoke(ctx, InventoryService_GetEntityHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (UnimplementedInventoryServiceServer) CreateProduct(context.Context, *CreateProductRequest) (*Product, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateProduct not implemented")
+func (c *inventoryServiceClient) ListReorderSuggestions(ctx context.Context, in *ListReorderSuggestionsRequest, opts ...grpc.CallOption) (*ListReorderSuggestionsResponse, error) {
+	out := new(ListReorderSuggestionsResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListReorderSuggestions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedInventoryServiceServer) GetProduct(context.Context, *GetProductRequest) (*Product, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetProduct not implemented")
+
+func (c *inventoryServiceClient) StreamReorderAlerts(ctx context.Context, in *StreamReorderAlertsRequest, opts ...grpc.CallOption) (InventoryService_StreamReorderAlertsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &InventoryService_ServiceDesc.Streams[0], InventoryService_StreamReorderAlerts_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &inventoryServiceStreamReorderAlertsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
-func (UnimplementedInventoryServiceServer) UpdateProduct(context.Context, *UpdateProductRequest) (*Product, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateProduct not implemented")
+
+type InventoryService_StreamReorderAlertsClient interface {
+	Recv() (*ReorderSuggestion, error)
+	grpc.ClientStream
 }
-func (UnimplementedInventoryServiceServer) DeleteProduct(context.Context, *DeleteProductRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteProduct not implemented")
+
+type inventoryServiceStreamReorderAlertsClient struct {
+	grpc.ClientStream
 }
-func (UnimplementedInventoryServiceServer) CreateCategory(context.Context, *CreateCategoryRequest) (*Category, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateCategory not implemented")
+
+func (x *inventoryServiceStreamReorderAlertsClient) Recv() (*ReorderSuggestion, error) {
+	m := new(ReorderSuggestion)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
-func (UnimplementedInventoryServiceServer) GetCategory(context.Context, *GetCategoryRequest) (*Category, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetCategory not implemented")
+
+func (c *inventoryServiceClient) CreatePurchaseOrder(ctx context.Context, in *CreatePurchaseOrderRequest, opts ...grpc.CallOption) (*PurchaseOrder, error) {
+	out := new(PurchaseOrder)
+	err := c.cc.Invoke(ctx, InventoryService_CreatePurchaseOrder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedInventoryServiceServer) UpdateCategory(context.Context, *UpdateCategoryRequest) (*Category, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateCategory not implemented")
+
+func (c *inventoryServiceClient) GetPurchaseOrder(ctx context.Context, in *GetPurchaseOrderRequest, opts ...grpc.CallOption) (*PurchaseOrder, error) {
+	out := new(PurchaseOrder)
+	err := c.cc.Invoke(ctx, InventoryService_GetPurchaseOrder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedInventoryServiceServer) DeleteCategory(context.Context, *DeleteCategoryRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteCategory not implemented")
+
+func (c *inventoryServiceClient) UpdatePurchaseOrder(ctx context.Context, in *UpdatePurchaseOrderRequest, opts ...grpc.CallOption) (*PurchaseOrder, error) {
+	out := new(PurchaseOrder)
+	err := c.cc.Invoke(ctx, InventoryService_UpdatePurchaseOrder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedInventoryServiceServer) CreateInventoryItem(context.Context, *CreateInventoryItemRequest) (*InventoryItem, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateInventoryItem not implemented")
+
+func (c *inventoryServiceClient) DeletePurchaseOrder(ctx context.Context, in *DeletePurchaseOrderRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, InventoryService_DeletePurchaseOrder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedInventoryServiceServer) GetInventoryItem(context.Context, *GetInventoryItemRequest) (*InventoryItem, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetInventoryItem not implemented")
+
+func (c *inventoryServiceClient) ListPurchaseOrders(ctx context.Context, in *ListPurchaseOrdersRequest, opts ...grpc.CallOption) (*ListPurchaseOrdersResponse, error) {
+	out := new(ListPurchaseOrdersResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListPurchaseOrders_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedInventoryServiceServer) UpdateInventoryItem(context.Context, *UpdateInventoryItemRequest) (*InventoryItem, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateInventoryItem not implemented")
+
+func (c *inventoryServiceClient) SubmitPurchaseOrder(ctx context.Context, in *SubmitPurchaseOrderRequest, opts ...grpc.CallOption) (*PurchaseOrder, error) {
+	out := new(PurchaseOrder)
+	err := c.cc.Invoke(ctx, InventoryService_SubmitPurchaseOrder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedInventoryServiceServer) DeleteInventoryItem(context.Context, *DeleteInventoryItemRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteInventoryItem not implemented")
+
+func (c *inventoryServiceClient) CancelPurchaseOrder(ctx context.Context, in *CancelPurchaseOrderRequest, opts ...grpc.CallOption) (*PurchaseOrder, error) {
+	out := new(PurchaseOrder)
+	err := c.cc.Invoke(ctx, InventoryService_CancelPurchaseOrder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedInventoryServiceServer) CreateWarehouse(context.Context, *CreateWarehouseRequest) (*Warehouse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateWarehouse not implemented")
+
+func (c *inventoryServiceClient) CreatePurchaseOrderFromSuggestions(ctx context.Context, in *CreatePurchaseOrderFromSuggestionsRequest, opts ...grpc.CallOption) (*PurchaseOrder, error) {
+	out := new(PurchaseOrder)
+	err := c.cc.Invoke(ctx, InventoryService_CreatePurchaseOrderFromSuggestions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedInventoryServiceServer) GetWarehouse(context.Context, *GetWarehouseRequest) (*Warehouse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetWarehouse not implemented")
+
+func (c *inventoryServiceClient) ReceiveGoods(ctx context.Context, in *ReceiveGoodsRequest, opts ...grpc.CallOption) (*ReceiveGoodsResponse, error) {
+	out := new(ReceiveGoodsResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ReceiveGoods_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedInventoryServiceServer) UpdateWarehouse(context.Context, *UpdateWarehouseRequest) (*Warehouse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateWarehouse not implemented")
+
+func (c *inventoryServiceClient) GetGoodsReceipt(ctx context.Context, in *GetGoodsReceiptRequest, opts ...grpc.CallOption) (*GoodsReceipt, error) {
+	out := new(GoodsReceipt)
+	err := c.cc.Invoke(ctx, InventoryService_GetGoodsReceipt_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedInventoryServiceServer) DeleteWarehouse(context.Context, *DeleteWarehouseRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteWarehouse not implemented")
+
+func (c *inventoryServiceClient) ListGoodsReceipts(ctx context.Context, in *ListGoodsReceiptsRequest, opts ...grpc.CallOption) (*ListGoodsReceiptsResponse, error) {
+	out := new(ListGoodsReceiptsResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListGoodsReceipts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedInventoryServiceServer) CreateSupplier(context.Context, *CreateSupplierRequest) (*Supplier, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateSupplier not implemented")
+
+func (c *inventoryServiceClient) CreateSupplierProduct(ctx context.Context, in *CreateSupplierProductRequest, opts ...grpc.CallOption) (*SupplierProduct, error) {
+	out := new(SupplierProduct)
+	err := c.cc.Invoke(ctx, InventoryService_CreateSupplierProduct_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedInventoryServiceServer) GetSupplier(context.Context, *GetSupplierRequest) (*Supplier, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetSupplier not implemented")
+
+func (c *inventoryServiceClient) GetSupplierProduct(ctx context.Context, in *GetSupplierProductRequest, opts ...grpc.CallOption) (*SupplierProduct, error) {
+	out := new(SupplierProduct)
+	err := c.cc.Invoke(ctx, InventoryService_GetSupplierProduct_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedInventoryServiceServer) UpdateSupplier(context.Context, *UpdateSupplierRequest) (*Supplier, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateSupplier not implemented")
+
+func (c *inventoryServiceClient) UpdateSupplierProduct(ctx context.Context, in *UpdateSupplierProductRequest, opts ...grpc.CallOption) (*SupplierProduct, error) {
+	out := new(SupplierProduct)
+	err := c.cc.Invoke(ctx, InventoryService_UpdateSupplierProduct_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) DeleteSupplierProduct(ctx context.Context, in *DeleteSupplierProductRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, InventoryService_DeleteSupplierProduct_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListSuppliersForProduct(ctx context.Context, in *ListSuppliersForProductRequest, opts ...grpc.CallOption) (*ListSupplierProductsResponse, error) {
+	out := new(ListSupplierProductsResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListSuppliersForProduct_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListProductsForSupplier(ctx context.Context, in *ListProductsForSupplierRequest, opts ...grpc.CallOption) (*ListSupplierProductsResponse, error) {
+	out := new(ListSupplierProductsResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListProductsForSupplier_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) GetSupplierScorecard(ctx context.Context, in *GetSupplierScorecardRequest, opts ...grpc.CallOption) (*SupplierScorecard, error) {
+	out := new(SupplierScorecard)
+	err := c.cc.Invoke(ctx, InventoryService_GetSupplierScorecard_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) RankSuppliers(ctx context.Context, in *RankSuppliersRequest, opts ...grpc.CallOption) (*RankSuppliersResponse, error) {
+	out := new(RankSuppliersResponse)
+	err := c.cc.Invoke(ctx, InventoryService_RankSuppliers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) CreateSalesOrder(ctx context.Context, in *CreateSalesOrderRequest, opts ...grpc.CallOption) (*SalesOrder, error) {
+	out := new(SalesOrder)
+	err := c.cc.Invoke(ctx, InventoryService_CreateSalesOrder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) GetSalesOrder(ctx context.Context, in *GetSalesOrderRequest, opts ...grpc.CallOption) (*SalesOrder, error) {
+	out := new(SalesOrder)
+	err := c.cc.Invoke(ctx, InventoryService_GetSalesOrder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListSalesOrders(ctx context.Context, in *ListSalesOrdersRequest, opts ...grpc.CallOption) (*ListSalesOrdersResponse, error) {
+	out := new(ListSalesOrdersResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListSalesOrders_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) CancelSalesOrder(ctx context.Context, in *CancelSalesOrderRequest, opts ...grpc.CallOption) (*SalesOrder, error) {
+	out := new(SalesOrder)
+	err := c.cc.Invoke(ctx, InventoryService_CancelSalesOrder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ShipSalesOrder(ctx context.Context, in *ShipSalesOrderRequest, opts ...grpc.CallOption) (*SalesOrder, error) {
+	out := new(SalesOrder)
+	err := c.cc.Invoke(ctx, InventoryService_ShipSalesOrder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) CreateReturnAuthorization(ctx context.Context, in *CreateReturnAuthorizationRequest, opts ...grpc.CallOption) (*ReturnAuthorization, error) {
+	out := new(ReturnAuthorization)
+	err := c.cc.Invoke(ctx, InventoryService_CreateReturnAuthorization_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) GetReturnAuthorization(ctx context.Context, in *GetReturnAuthorizationRequest, opts ...grpc.CallOption) (*ReturnAuthorization, error) {
+	out := new(ReturnAuthorization)
+	err := c.cc.Invoke(ctx, InventoryService_GetReturnAuthorization_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListReturnAuthorizations(ctx context.Context, in *ListReturnAuthorizationsRequest, opts ...grpc.CallOption) (*ListReturnAuthorizationsResponse, error) {
+	out := new(ListReturnAuthorizationsResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListReturnAuthorizations_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) InspectReturnAuthorization(ctx context.Context, in *InspectReturnAuthorizationRequest, opts ...grpc.CallOption) (*ReturnAuthorization, error) {
+	out := new(ReturnAuthorization)
+	err := c.cc.Invoke(ctx, InventoryService_InspectReturnAuthorization_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) CancelReturnAuthorization(ctx context.Context, in *CancelReturnAuthorizationRequest, opts ...grpc.CallOption) (*ReturnAuthorization, error) {
+	out := new(ReturnAuthorization)
+	err := c.cc.Invoke(ctx, InventoryService_CancelReturnAuthorization_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) CreateTransferOrder(ctx context.Context, in *CreateTransferOrderRequest, opts ...grpc.CallOption) (*TransferOrder, error) {
+	out := new(TransferOrder)
+	err := c.cc.Invoke(ctx, InventoryService_CreateTransferOrder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) GetTransferOrder(ctx context.Context, in *GetTransferOrderRequest, opts ...grpc.CallOption) (*TransferOrder, error) {
+	out := new(TransferOrder)
+	err := c.cc.Invoke(ctx, InventoryService_GetTransferOrder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListTransferOrders(ctx context.Context, in *ListTransferOrdersRequest, opts ...grpc.CallOption) (*ListTransferOrdersResponse, error) {
+	out := new(ListTransferOrdersResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListTransferOrders_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ShipTransferOrder(ctx context.Context, in *ShipTransferOrderRequest, opts ...grpc.CallOption) (*TransferOrder, error) {
+	out := new(TransferOrder)
+	err := c.cc.Invoke(ctx, InventoryService_ShipTransferOrder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ReceiveTransferOrder(ctx context.Context, in *ReceiveTransferOrderRequest, opts ...grpc.CallOption) (*TransferOrder, error) {
+	out := new(TransferOrder)
+	err := c.cc.Invoke(ctx, InventoryService_ReceiveTransferOrder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) CancelTransferOrder(ctx context.Context, in *CancelTransferOrderRequest, opts ...grpc.CallOption) (*TransferOrder, error) {
+	out := new(TransferOrder)
+	err := c.cc.Invoke(ctx, InventoryService_CancelTransferOrder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListInTransitStock(ctx context.Context, in *ListInTransitStockRequest, opts ...grpc.CallOption) (*ListInTransitStockResponse, error) {
+	out := new(ListInTransitStockResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListInTransitStock_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) AdjustStock(ctx context.Context, in *AdjustStockRequest, opts ...grpc.CallOption) (*StockAdjustment, error) {
+	out := new(StockAdjustment)
+	err := c.cc.Invoke(ctx, InventoryService_AdjustStock_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) GetStockAdjustment(ctx context.Context, in *GetStockAdjustmentRequest, opts ...grpc.CallOption) (*StockAdjustment, error) {
+	out := new(StockAdjustment)
+	err := c.cc.Invoke(ctx, InventoryService_GetStockAdjustment_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListStockAdjustments(ctx context.Context, in *ListStockAdjustmentsRequest, opts ...grpc.CallOption) (*ListStockAdjustmentsResponse, error) {
+	out := new(ListStockAdjustmentsResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListStockAdjustments_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ApproveStockAdjustment(ctx context.Context, in *ApproveStockAdjustmentRequest, opts ...grpc.CallOption) (*StockAdjustment, error) {
+	out := new(StockAdjustment)
+	err := c.cc.Invoke(ctx, InventoryService_ApproveStockAdjustment_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) RejectStockAdjustment(ctx context.Context, in *RejectStockAdjustmentRequest, opts ...grpc.CallOption) (*StockAdjustment, error) {
+	out := new(StockAdjustment)
+	err := c.cc.Invoke(ctx, InventoryService_RejectStockAdjustment_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) CreateCountSession(ctx context.Context, in *CreateCountSessionRequest, opts ...grpc.CallOption) (*CountSession, error) {
+	out := new(CountSession)
+	err := c.cc.Invoke(ctx, InventoryService_CreateCountSession_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) GetCountSession(ctx context.Context, in *GetCountSessionRequest, opts ...grpc.CallOption) (*CountSession, error) {
+	out := new(CountSession)
+	err := c.cc.Invoke(ctx, InventoryService_GetCountSession_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListCountSessions(ctx context.Context, in *ListCountSessionsRequest, opts ...grpc.CallOption) (*ListCountSessionsResponse, error) {
+	out := new(ListCountSessionsResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListCountSessions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) RecordCounts(ctx context.Context, in *RecordCountsRequest, opts ...grpc.CallOption) (*CountSession, error) {
+	out := new(CountSession)
+	err := c.cc.Invoke(ctx, InventoryService_RecordCounts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) SubmitCountSession(ctx context.Context, in *SubmitCountSessionRequest, opts ...grpc.CallOption) (*CountSession, error) {
+	out := new(CountSession)
+	err := c.cc.Invoke(ctx, InventoryService_SubmitCountSession_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ApproveCountSession(ctx context.Context, in *ApproveCountSessionRequest, opts ...grpc.CallOption) (*CountSession, error) {
+	out := new(CountSession)
+	err := c.cc.Invoke(ctx, InventoryService_ApproveCountSession_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) CancelCountSession(ctx context.Context, in *CancelCountSessionRequest, opts ...grpc.CallOption) (*CountSession, error) {
+	out := new(CountSession)
+	err := c.cc.Invoke(ctx, InventoryService_CancelCountSession_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) CreateCountPlan(ctx context.Context, in *CreateCountPlanRequest, opts ...grpc.CallOption) (*CountPlan, error) {
+	out := new(CountPlan)
+	err := c.cc.Invoke(ctx, InventoryService_CreateCountPlan_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) GetCountPlan(ctx context.Context, in *GetCountPlanRequest, opts ...grpc.CallOption) (*CountPlan, error) {
+	out := new(CountPlan)
+	err := c.cc.Invoke(ctx, InventoryService_GetCountPlan_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListCountPlans(ctx context.Context, in *ListCountPlansRequest, opts ...grpc.CallOption) (*ListCountPlansResponse, error) {
+	out := new(ListCountPlansResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListCountPlans_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) UpdateCountPlan(ctx context.Context, in *UpdateCountPlanRequest, opts ...grpc.CallOption) (*CountPlan, error) {
+	out := new(CountPlan)
+	err := c.cc.Invoke(ctx, InventoryService_UpdateCountPlan_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) DeleteCountPlan(ctx context.Context, in *DeleteCountPlanRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, InventoryService_DeleteCountPlan_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) RunCountPlan(ctx context.Context, in *RunCountPlanRequest, opts ...grpc.CallOption) (*RunCountPlanResponse, error) {
+	out := new(RunCountPlanResponse)
+	err := c.cc.Invoke(ctx, InventoryService_RunCountPlan_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListCountPlanItems(ctx context.Context, in *ListCountPlanItemsRequest, opts ...grpc.CallOption) (*ListCountPlanItemsResponse, error) {
+	out := new(ListCountPlanItemsResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListCountPlanItems_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) CreateLot(ctx context.Context, in *CreateLotRequest, opts ...grpc.CallOption) (*Lot, error) {
+	out := new(Lot)
+	err := c.cc.Invoke(ctx, InventoryService_CreateLot_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) GetLot(ctx context.Context, in *GetLotRequest, opts ...grpc.CallOption) (*Lot, error) {
+	out := new(Lot)
+	err := c.cc.Invoke(ctx, InventoryService_GetLot_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListLots(ctx context.Context, in *ListLotsRequest, opts ...grpc.CallOption) (*ListLotsResponse, error) {
+	out := new(ListLotsResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListLots_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListExpiringLots(ctx context.Context, in *ListExpiringLotsRequest, opts ...grpc.CallOption) (*ListLotsResponse, error) {
+	out := new(ListLotsResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListExpiringLots_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) LookupSerial(ctx context.Context, in *LookupSerialRequest, opts ...grpc.CallOption) (*Serial, error) {
+	out := new(Serial)
+	err := c.cc.Invoke(ctx, InventoryService_LookupSerial_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) GetSerialHistory(ctx context.Context, in *GetSerialHistoryRequest, opts ...grpc.CallOption) (*GetSerialHistoryResponse, error) {
+	out := new(GetSerialHistoryResponse)
+	err := c.cc.Invoke(ctx, InventoryService_GetSerialHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) CreateLocation(ctx context.Context, in *CreateLocationRequest, opts ...grpc.CallOption) (*Location, error) {
+	out := new(Location)
+	err := c.cc.Invoke(ctx, InventoryService_CreateLocation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) GetLocation(ctx context.Context, in *GetLocationRequest, opts ...grpc.CallOption) (*Location, error) {
+	out := new(Location)
+	err := c.cc.Invoke(ctx, InventoryService_GetLocation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListLocations(ctx context.Context, in *ListLocationsRequest, opts ...grpc.CallOption) (*ListLocationsResponse, error) {
+	out := new(ListLocationsResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListLocations_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) UpdateLocation(ctx context.Context, in *UpdateLocationRequest, opts ...grpc.CallOption) (*Location, error) {
+	out := new(Location)
+	err := c.cc.Invoke(ctx, InventoryService_UpdateLocation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) DeleteLocation(ctx context.Context, in *DeleteLocationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, InventoryService_DeleteLocation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListBinStock(ctx context.Context, in *ListBinStockRequest, opts ...grpc.CallOption) (*ListBinStockResponse, error) {
+	out := new(ListBinStockResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListBinStock_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) MoveBinStock(ctx context.Context, in *MoveBinStockRequest, opts ...grpc.CallOption) (*StockMovement, error) {
+	out := new(StockMovement)
+	err := c.cc.Invoke(ctx, InventoryService_MoveBinStock_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) SuggestPutaway(ctx context.Context, in *SuggestPutawayRequest, opts ...grpc.CallOption) (*SuggestPutawayResponse, error) {
+	out := new(SuggestPutawayResponse)
+	err := c.cc.Invoke(ctx, InventoryService_SuggestPutaway_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) GetWarehouseUtilization(ctx context.Context, in *GetWarehouseUtilizationRequest, opts ...grpc.CallOption) (*WarehouseUtilization, error) {
+	out := new(WarehouseUtilization)
+	err := c.cc.Invoke(ctx, InventoryService_GetWarehouseUtilization_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListCostLayers(ctx context.Context, in *ListCostLayersRequest, opts ...grpc.CallOption) (*ListCostLayersResponse, error) {
+	out := new(ListCostLayersResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListCostLayers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) GetInventoryValuation(ctx context.Context, in *GetInventoryValuationRequest, opts ...grpc.CallOption) (*InventoryValuation, error) {
+	out := new(InventoryValuation)
+	err := c.cc.Invoke(ctx, InventoryService_GetInventoryValuation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) CreatePriceList(ctx context.Context, in *CreatePriceListRequest, opts ...grpc.CallOption) (*PriceList, error) {
+	out := new(PriceList)
+	err := c.cc.Invoke(ctx, InventoryService_CreatePriceList_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) GetPriceList(ctx context.Context, in *GetPriceListRequest, opts ...grpc.CallOption) (*PriceList, error) {
+	out := new(PriceList)
+	err := c.cc.Invoke(ctx, InventoryService_GetPriceList_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListPriceLists(ctx context.Context, in *ListPriceListsRequest, opts ...grpc.CallOption) (*ListPriceListsResponse, error) {
+	out := new(ListPriceListsResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListPriceLists_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) UpdatePriceList(ctx context.Context, in *UpdatePriceListRequest, opts ...grpc.CallOption) (*PriceList, error) {
+	out := new(PriceList)
+	err := c.cc.Invoke(ctx, InventoryService_UpdatePriceList_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) DeletePriceList(ctx context.Context, in *DeletePriceListRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, InventoryService_DeletePriceList_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) GetEffectivePrice(ctx context.Context, in *GetEffectivePriceRequest, opts ...grpc.CallOption) (*EffectivePrice, error) {
+	out := new(EffectivePrice)
+	err := c.cc.Invoke(ctx, InventoryService_GetEffectivePrice_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListExchangeRates(ctx context.Context, in *ListExchangeRatesRequest, opts ...grpc.CallOption) (*ListExchangeRatesResponse, error) {
+	out := new(ListExchangeRatesResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListExchangeRates_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) SchedulePriceChange(ctx context.Context, in *SchedulePriceChangeRequest, opts ...grpc.CallOption) (*PriceChange, error) {
+	out := new(PriceChange)
+	err := c.cc.Invoke(ctx, InventoryService_SchedulePriceChange_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) CancelPriceChange(ctx context.Context, in *CancelPriceChangeRequest, opts ...grpc.CallOption) (*PriceChange, error) {
+	out := new(PriceChange)
+	err := c.cc.Invoke(ctx, InventoryService_CancelPriceChange_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) GetPriceHistory(ctx context.Context, in *GetPriceHistoryRequest, opts ...grpc.CallOption) (*GetPriceHistoryResponse, error) {
+	out := new(GetPriceHistoryResponse)
+	err := c.cc.Invoke(ctx, InventoryService_GetPriceHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// InventoryServiceServer is the server API for InventoryService service.
+// All implementations must embed UnimplementedInventoryServiceServer
+// for forward compatibility
+type InventoryServiceServer interface {
+	CreateProduct(context.Context, *CreateProductRequest) (*Product, error)
+	GetProduct(context.Context, *GetProductRequest) (*Product, error)
+	UpdateProduct(context.Context, *UpdateProductRequest) (*Product, error)
+	DeleteProduct(context.Context, *DeleteProductRequest) (*emptypb.Empty, error)
+	CreateCategory(context.Context, *CreateCategoryRequest) (*Category, error)
+	GetCategory(context.Context, *GetCategoryRequest) (*Category, error)
+	UpdateCategory(context.Context, *UpdateCategoryRequest) (*Category, error)
+	DeleteCategory(context.Context, *DeleteCategoryRequest) (*emptypb.Empty, error)
+	CreateInventoryItem(context.Context, *CreateInventoryItemRequest) (*InventoryItem, error)
+	GetInventoryItem(context.Context, *GetInventoryItemRequest) (*InventoryItem, error)
+	UpdateInventoryItem(context.Context, *UpdateInventoryItemRequest) (*InventoryItem, error)
+	DeleteInventoryItem(context.Context, *DeleteInventoryItemRequest) (*emptypb.Empty, error)
+	CreateWarehouse(context.Context, *CreateWarehouseRequest) (*Warehouse, error)
+	GetWarehouse(context.Context, *GetWarehouseRequest) (*Warehouse, error)
+	UpdateWarehouse(context.Context, *UpdateWarehouseRequest) (*Warehouse, error)
+	DeleteWarehouse(context.Context, *DeleteWarehouseRequest) (*emptypb.Empty, error)
+	CreateSupplier(context.Context, *CreateSupplierRequest) (*Supplier, error)
+	GetSupplier(context.Context, *GetSupplierRequest) (*Supplier, error)
+	UpdateSupplier(context.Context, *UpdateSupplierRequest) (*Supplier, error)
+	DeleteSupplier(context.Context, *DeleteSupplierRequest) (*emptypb.Empty, error)
+	CreateStockMovement(context.Context, *CreateStockMovementRequest) (*StockMovement, error)
+	GetStockMovement(context.Context, *GetStockMovementRequest) (*StockMovement, error)
+	UpdateStockMovement(context.Context, *UpdateStockMovementRequest) (*StockMovement, error)
+	DeleteStockMovement(context.Context, *DeleteStockMovementRequest) (*emptypb.Empty, error)
+	ListProducts(context.Context, *ListProductsRequest) (*ListProductsResponse, error)
+	ListCategories(context.Context, *ListCategoriesRequest) (*ListCategoriesResponse, error)
+	ListInventoryItems(context.Context, *ListInventoryItemsRequest) (*ListInventoryItemsResponse, error)
+	ListWarehouses(context.Context, *ListWarehousesRequest) (*ListWarehousesResponse, error)
+	ListSuppliers(context.Context, *ListSuppliersRequest) (*ListSuppliersResponse, error)
+	ListStockMovements(context.Context, *ListStockMovementsRequest) (*ListStockMovementsResponse, error)
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error)
+	GetEntityHistory(context.Context, *GetEntityHistoryRequest) (*GetEntityHistoryResponse, error)
+	ListReorderSuggestions(context.Context, *ListReorderSuggestionsRequest) (*ListReorderSuggestionsResponse, error)
+	StreamReorderAlerts(*StreamReorderAlertsRequest, InventoryService_StreamReorderAlertsServer) error
+	CreatePurchaseOrder(context.Context, *CreatePurchaseOrderRequest) (*PurchaseOrder, error)
+	GetPurchaseOrder(context.Context, *GetPurchaseOrderRequest) (*PurchaseOrder, error)
+	UpdatePurchaseOrder(context.Context, *UpdatePurchaseOrderRequest) (*PurchaseOrder, error)
+	DeletePurchaseOrder(context.Context, *DeletePurchaseOrderRequest) (*emptypb.Empty, error)
+	ListPurchaseOrders(context.Context, *ListPurchaseOrdersRequest) (*ListPurchaseOrdersResponse, error)
+	SubmitPurchaseOrder(context.Context, *SubmitPurchaseOrderRequest) (*PurchaseOrder, error)
+	CancelPurchaseOrder(context.Context, *CancelPurchaseOrderRequest) (*PurchaseOrder, error)
+	CreatePurchaseOrderFromSuggestions(context.Context, *CreatePurchaseOrderFromSuggestionsRequest) (*PurchaseOrder, error)
+	ReceiveGoods(context.Context, *ReceiveGoodsRequest) (*ReceiveGoodsResponse, error)
+	GetGoodsReceipt(context.Context, *GetGoodsReceiptRequest) (*GoodsReceipt, error)
+	ListGoodsReceipts(context.Context, *ListGoodsReceiptsRequest) (*ListGoodsReceiptsResponse, error)
+	CreateSupplierProduct(context.Context, *CreateSupplierProductRequest) (*SupplierProduct, error)
+	GetSupplierProduct(context.Context, *GetSupplierProductRequest) (*SupplierProduct, error)
+	UpdateSupplierProduct(context.Context, *UpdateSupplierProductRequest) (*SupplierProduct, error)
+	DeleteSupplierProduct(context.Context, *DeleteSupplierProductRequest) (*emptypb.Empty, error)
+	ListSuppliersForProduct(context.Context, *ListSuppliersForProductRequest) (*ListSupplierProductsResponse, error)
+	ListProductsForSupplier(context.Context, *ListProductsForSupplierRequest) (*ListSupplierProductsResponse, error)
+	GetSupplierScorecard(context.Context, *GetSupplierScorecardRequest) (*SupplierScorecard, error)
+	RankSuppliers(context.Context, *RankSuppliersRequest) (*RankSuppliersResponse, error)
+	CreateSalesOrder(context.Context, *CreateSalesOrderRequest) (*SalesOrder, error)
+	GetSalesOrder(context.Context, *GetSalesOrderRequest) (*SalesOrder, error)
+	ListSalesOrders(context.Context, *ListSalesOrdersRequest) (*ListSalesOrdersResponse, error)
+	CancelSalesOrder(context.Context, *CancelSalesOrderRequest) (*SalesOrder, error)
+	ShipSalesOrder(context.Context, *ShipSalesOrderRequest) (*SalesOrder, error)
+	CreateReturnAuthorization(context.Context, *CreateReturnAuthorizationRequest) (*ReturnAuthorization, error)
+	GetReturnAuthorization(context.Context, *GetReturnAuthorizationRequest) (*ReturnAuthorization, error)
+	ListReturnAuthorizations(context.Context, *ListReturnAuthorizationsRequest) (*ListReturnAuthorizationsResponse, error)
+	InspectReturnAuthorization(context.Context, *InspectReturnAuthorizationRequest) (*ReturnAuthorization, error)
+	CancelReturnAuthorization(context.Context, *CancelReturnAuthorizationRequest) (*ReturnAuthorization, error)
+	CreateTransferOrder(context.Context, *CreateTransferOrderRequest) (*TransferOrder, error)
+	GetTransferOrder(context.Context, *GetTransferOrderRequest) (*TransferOrder, error)
+	ListTransferOrders(context.Context, *ListTransferOrdersRequest) (*ListTransferOrdersResponse, error)
+	ShipTransferOrder(context.Context, *ShipTransferOrderRequest) (*TransferOrder, error)
+	ReceiveTransferOrder(context.Context, *ReceiveTransferOrderRequest) (*TransferOrder, error)
+	CancelTransferOrder(context.Context, *CancelTransferOrderRequest) (*TransferOrder, error)
+	ListInTransitStock(context.Context, *ListInTransitStockRequest) (*ListInTransitStockResponse, error)
+	AdjustStock(context.Context, *AdjustStockRequest) (*StockAdjustment, error)
+	GetStockAdjustment(context.Context, *GetStockAdjustmentRequest) (*StockAdjustment, error)
+	ListStockAdjustments(context.Context, *ListStockAdjustmentsRequest) (*ListStockAdjustmentsResponse, error)
+	ApproveStockAdjustment(context.Context, *ApproveStockAdjustmentRequest) (*StockAdjustment, error)
+	RejectStockAdjustment(context.Context, *RejectStockAdjustmentRequest) (*StockAdjustment, error)
+	CreateCountSession(context.Context, *CreateCountSessionRequest) (*CountSession, error)
+	GetCountSession(context.Context, *GetCountSessionRequest) (*CountSession, error)
+	ListCountSessions(context.Context, *ListCountSessionsRequest) (*ListCountSessionsResponse, error)
+	RecordCounts(context.Context, *RecordCountsRequest) (*CountSession, error)
+	SubmitCountSession(context.Context, *SubmitCountSessionRequest) (*CountSession, error)
+	ApproveCountSession(context.Context, *ApproveCountSessionRequest) (*CountSession, error)
+	CancelCountSession(context.Context, *CancelCountSessionRequest) (*CountSession, error)
+	CreateCountPlan(context.Context, *CreateCountPlanRequest) (*CountPlan, error)
+	GetCountPlan(context.Context, *GetCountPlanRequest) (*CountPlan, error)
+	ListCountPlans(context.Context, *ListCountPlansRequest) (*ListCountPlansResponse, error)
+	UpdateCountPlan(context.Context, *UpdateCountPlanRequest) (*CountPlan, error)
+	DeleteCountPlan(context.Context, *DeleteCountPlanRequest) (*emptypb.Empty, error)
+	RunCountPlan(context.Context, *RunCountPlanRequest) (*RunCountPlanResponse, error)
+	ListCountPlanItems(context.Context, *ListCountPlanItemsRequest) (*ListCountPlanItemsResponse, error)
+	CreateLot(context.Context, *CreateLotRequest) (*Lot, error)
+	GetLot(context.Context, *GetLotRequest) (*Lot, error)
+	ListLots(context.Context, *ListLotsRequest) (*ListLotsResponse, error)
+	ListExpiringLots(context.Context, *ListExpiringLotsRequest) (*ListLotsResponse, error)
+	LookupSerial(context.Context, *LookupSerialRequest) (*Serial, error)
+	GetSerialHistory(context.Context, *GetSerialHistoryRequest) (*GetSerialHistoryResponse, error)
+	CreateLocation(context.Context, *CreateLocationRequest) (*Location, error)
+	GetLocation(context.Context, *GetLocationRequest) (*Location, error)
+	ListLocations(context.Context, *ListLocationsRequest) (*ListLocationsResponse, error)
+	UpdateLocation(context.Context, *UpdateLocationRequest) (*Location, error)
+	DeleteLocation(context.Context, *DeleteLocationRequest) (*emptypb.Empty, error)
+	ListBinStock(context.Context, *ListBinStockRequest) (*ListBinStockResponse, error)
+	MoveBinStock(context.Context, *MoveBinStockRequest) (*StockMovement, error)
+	SuggestPutaway(context.Context, *SuggestPutawayRequest) (*SuggestPutawayResponse, error)
+	GetWarehouseUtilization(context.Context, *GetWarehouseUtilizationRequest) (*WarehouseUtilization, error)
+	ListCostLayers(context.Context, *ListCostLayersRequest) (*ListCostLayersResponse, error)
+	GetInventoryValuation(context.Context, *GetInventoryValuationRequest) (*InventoryValuation, error)
+	CreatePriceList(context.Context, *CreatePriceListRequest) (*PriceList, error)
+	GetPriceList(context.Context, *GetPriceListRequest) (*PriceList, error)
+	ListPriceLists(context.Context, *ListPriceListsRequest) (*ListPriceListsResponse, error)
+	UpdatePriceList(context.Context, *UpdatePriceListRequest) (*PriceList, error)
+	DeletePriceList(context.Context, *DeletePriceListRequest) (*emptypb.Empty, error)
+	GetEffectivePrice(context.Context, *GetEffectivePriceRequest) (*EffectivePrice, error)
+	ListExchangeRates(context.Context, *ListExchangeRatesRequest) (*ListExchangeRatesResponse, error)
+	SchedulePriceChange(context.Context, *SchedulePriceChangeRequest) (*PriceChange, error)
+	CancelPriceChange(context.Context, *CancelPriceChangeRequest) (*PriceChange, error)
+	GetPriceHistory(context.Context, *GetPriceHistoryRequest) (*GetPriceHistoryResponse, error)
+	mustEmbedUnimplementedInventoryServiceServer()
+}
+
+// UnimplementedInventoryServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedInventoryServiceServer struct {
+}
+
+func (UnimplementedInventoryServiceServer) CreateProduct(context.Context, *CreateProductRequest) (*Product, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateProduct not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetProduct(context.Context, *GetProductRequest) (*Product, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProduct not implemented")
+}
+func (UnimplementedInventoryServiceServer) UpdateProduct(context.Context, *UpdateProductRequest) (*Product, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateProduct not implemented")
+}
+func (UnimplementedInventoryServiceServer) DeleteProduct(context.Context, *DeleteProductRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteProduct not implemented")
+}
+func (UnimplementedInventoryServiceServer) CreateCategory(context.Context, *CreateCategoryRequest) (*Category, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCategory not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetCategory(context.Context, *GetCategoryRequest) (*Category, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCategory not implemented")
+}
+func (UnimplementedInventoryServiceServer) UpdateCategory(context.Context, *UpdateCategoryRequest) (*Category, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCategory not implemented")
+}
+func (UnimplementedInventoryServiceServer) DeleteCategory(context.Context, *DeleteCategoryRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCategory not implemented")
+}
+func (UnimplementedInventoryServiceServer) CreateInventoryItem(context.Context, *CreateInventoryItemRequest) (*InventoryItem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateInventoryItem not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetInventoryItem(context.Context, *GetInventoryItemRequest) (*InventoryItem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInventoryItem not implemented")
+}
+func (UnimplementedInventoryServiceServer) UpdateInventoryItem(context.Context, *UpdateInventoryItemRequest) (*InventoryItem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateInventoryItem not implemented")
+}
+func (UnimplementedInventoryServiceServer) DeleteInventoryItem(context.Context, *DeleteInventoryItemRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteInventoryItem not implemented")
+}
+func (UnimplementedInventoryServiceServer) CreateWarehouse(context.Context, *CreateWarehouseRequest) (*Warehouse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWarehouse not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetWarehouse(context.Context, *GetWarehouseRequest) (*Warehouse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWarehouse not implemented")
+}
+func (UnimplementedInventoryServiceServer) UpdateWarehouse(context.Context, *UpdateWarehouseRequest) (*Warehouse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateWarehouse not implemented")
+}
+func (UnimplementedInventoryServiceServer) DeleteWarehouse(context.Context, *DeleteWarehouseRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWarehouse not implemented")
+}
+func (UnimplementedInventoryServiceServer) CreateSupplier(context.Context, *CreateSupplierRequest) (*Supplier, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSupplier not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetSupplier(context.Context, *GetSupplierRequest) (*Supplier, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSupplier not implemented")
+}
+func (UnimplementedInventoryServiceServer) UpdateSupplier(context.Context, *UpdateSupplierRequest) (*Supplier, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSupplier not implemented")
+}
+func (UnimplementedInventoryServiceServer) DeleteSupplier(context.Context, *DeleteSupplierRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSupplier not implemented")
+}
+func (UnimplementedInventoryServiceServer) CreateStockMovement(context.Context, *CreateStockMovementRequest) (*StockMovement, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateStockMovement not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetStockMovement(context.Context, *GetStockMovementRequest) (*StockMovement, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStockMovement not implemented")
+}
+func (UnimplementedInventoryServiceServer) UpdateStockMovement(context.Context, *UpdateStockMovementRequest) (*StockMovement, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateStockMovement not implemented")
+}
+func (UnimplementedInventoryServiceServer) DeleteStockMovement(context.Context, *DeleteStockMovementRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteStockMovement not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListProducts(context.Context, *ListProductsRequest) (*ListProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProducts not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListCategories(context.Context, *ListCategoriesRequest) (*ListCategoriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCategories not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListInventoryItems(context.Context, *ListInventoryItemsRequest) (*ListInventoryItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInventoryItems not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListWarehouses(context.Context, *ListWarehousesRequest) (*ListWarehousesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWarehouses not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListSuppliers(context.Context, *ListSuppliersRequest) (*ListSuppliersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSuppliers not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListStockMovements(context.Context, *ListStockMovementsRequest) (*ListStockMovementsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStockMovements not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetEntityHistory(context.Context, *GetEntityHistoryRequest) (*GetEntityHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEntityHistory not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListReorderSuggestions(context.Context, *ListReorderSuggestionsRequest) (*ListReorderSuggestionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReorderSuggestions not implemented")
+}
+func (UnimplementedInventoryServiceServer) StreamReorderAlerts(*StreamReorderAlertsRequest, InventoryService_StreamReorderAlertsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamReorderAlerts not implemented")
+}
+func (UnimplementedInventoryServiceServer) CreatePurchaseOrder(context.Context, *CreatePurchaseOrderRequest) (*PurchaseOrder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePurchaseOrder not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetPurchaseOrder(context.Context, *GetPurchaseOrderRequest) (*PurchaseOrder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPurchaseOrder not implemented")
+}
+func (UnimplementedInventoryServiceServer) UpdatePurchaseOrder(context.Context, *UpdatePurchaseOrderRequest) (*PurchaseOrder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePurchaseOrder not implemented")
+}
+func (UnimplementedInventoryServiceServer) DeletePurchaseOrder(context.Context, *DeletePurchaseOrderRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePurchaseOrder not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListPurchaseOrders(context.Context, *ListPurchaseOrdersRequest) (*ListPurchaseOrdersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPurchaseOrders not implemented")
+}
+func (UnimplementedInventoryServiceServer) SubmitPurchaseOrder(context.Context, *SubmitPurchaseOrderRequest) (*PurchaseOrder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitPurchaseOrder not implemented")
+}
+func (UnimplementedInventoryServiceServer) CancelPurchaseOrder(context.Context, *CancelPurchaseOrderRequest) (*PurchaseOrder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelPurchaseOrder not implemented")
+}
+func (UnimplementedInventoryServiceServer) CreatePurchaseOrderFromSuggestions(context.Context, *CreatePurchaseOrderFromSuggestionsRequest) (*PurchaseOrder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePurchaseOrderFromSuggestions not implemented")
+}
+func (UnimplementedInventoryServiceServer) ReceiveGoods(context.Context, *ReceiveGoodsRequest) (*ReceiveGoodsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReceiveGoods not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetGoodsReceipt(context.Context, *GetGoodsReceiptRequest) (*GoodsReceipt, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGoodsReceipt not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListGoodsReceipts(context.Context, *ListGoodsReceiptsRequest) (*ListGoodsReceiptsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGoodsReceipts not implemented")
+}
+func (UnimplementedInventoryServiceServer) CreateSupplierProduct(context.Context, *CreateSupplierProductRequest) (*SupplierProduct, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSupplierProduct not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetSupplierProduct(context.Context, *GetSupplierProductRequest) (*SupplierProduct, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSupplierProduct not implemented")
+}
+func (UnimplementedInventoryServiceServer) UpdateSupplierProduct(context.Context, *UpdateSupplierProductRequest) (*SupplierProduct, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSupplierProduct not implemented")
+}
+func (UnimplementedInventoryServiceServer) DeleteSupplierProduct(context.Context, *DeleteSupplierProductRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSupplierProduct not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListSuppliersForProduct(context.Context, *ListSuppliersForProductRequest) (*ListSupplierProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSuppliersForProduct not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListProductsForSupplier(context.Context, *ListProductsForSupplierRequest) (*ListSupplierProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProductsForSupplier not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetSupplierScorecard(context.Context, *GetSupplierScorecardRequest) (*SupplierScorecard, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSupplierScorecard not implemented")
+}
+func (UnimplementedInventoryServiceServer) RankSuppliers(context.Context, *RankSuppliersRequest) (*RankSuppliersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RankSuppliers not implemented")
+}
+func (UnimplementedInventoryServiceServer) CreateSalesOrder(context.Context, *CreateSalesOrderRequest) (*SalesOrder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSalesOrder not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetSalesOrder(context.Context, *GetSalesOrderRequest) (*SalesOrder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSalesOrder not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListSalesOrders(context.Context, *ListSalesOrdersRequest) (*ListSalesOrdersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSalesOrders not implemented")
+}
+func (UnimplementedInventoryServiceServer) CancelSalesOrder(context.Context, *CancelSalesOrderRequest) (*SalesOrder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelSalesOrder not implemented")
+}
+func (UnimplementedInventoryServiceServer) ShipSalesOrder(context.Context, *ShipSalesOrderRequest) (*SalesOrder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShipSalesOrder not implemented")
+}
+func (UnimplementedInventoryServiceServer) CreateReturnAuthorization(context.Context, *CreateReturnAuthorizationRequest) (*ReturnAuthorization, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateReturnAuthorization not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetReturnAuthorization(context.Context, *GetReturnAuthorizationRequest) (*ReturnAuthorization, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReturnAuthorization not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListReturnAuthorizations(context.Context, *ListReturnAuthorizationsRequest) (*ListReturnAuthorizationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReturnAuthorizations not implemented")
+}
+func (UnimplementedInventoryServiceServer) InspectReturnAuthorization(context.Context, *InspectReturnAuthorizationRequest) (*ReturnAuthorization, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InspectReturnAuthorization not implemented")
+}
+func (UnimplementedInventoryServiceServer) CancelReturnAuthorization(context.Context, *CancelReturnAuthorizationRequest) (*ReturnAuthorization, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelReturnAuthorization not implemented")
+}
+func (UnimplementedInventoryServiceServer) CreateTransferOrder(context.Context, *CreateTransferOrderRequest) (*TransferOrder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTransferOrder not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetTransferOrder(context.Context, *GetTransferOrderRequest) (*TransferOrder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransferOrder not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListTransferOrders(context.Context, *ListTransferOrdersRequest) (*ListTransferOrdersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTransferOrders not implemented")
+}
+func (UnimplementedInventoryServiceServer) ShipTransferOrder(context.Context, *ShipTransferOrderRequest) (*TransferOrder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShipTransferOrder not implemented")
+}
+func (UnimplementedInventoryServiceServer) ReceiveTransferOrder(context.Context, *ReceiveTransferOrderRequest) (*TransferOrder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReceiveTransferOrder not implemented")
+}
+func (UnimplementedInventoryServiceServer) CancelTransferOrder(context.Context, *CancelTransferOrderRequest) (*TransferOrder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelTransferOrder not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListInTransitStock(context.Context, *ListInTransitStockRequest) (*ListInTransitStockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInTransitStock not implemented")
+}
+func (UnimplementedInventoryServiceServer) AdjustStock(context.Context, *AdjustStockRequest) (*StockAdjustment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdjustStock not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetStockAdjustment(context.Context, *GetStockAdjustmentRequest) (*StockAdjustment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStockAdjustment not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListStockAdjustments(context.Context, *ListStockAdjustmentsRequest) (*ListStockAdjustmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStockAdjustments not implemented")
+}
+func (UnimplementedInventoryServiceServer) ApproveStockAdjustment(context.Context, *ApproveStockAdjustmentRequest) (*StockAdjustment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveStockAdjustment not implemented")
+}
+func (UnimplementedInventoryServiceServer) RejectStockAdjustment(context.Context, *RejectStockAdjustmentRequest) (*StockAdjustment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectStockAdjustment not implemented")
+}
+func (UnimplementedInventoryServiceServer) CreateCountSession(context.Context, *CreateCountSessionRequest) (*CountSession, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCountSession not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetCountSession(context.Context, *GetCountSessionRequest) (*CountSession, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCountSession not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListCountSessions(context.Context, *ListCountSessionsRequest) (*ListCountSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCountSessions not implemented")
+}
+func (UnimplementedInventoryServiceServer) RecordCounts(context.Context, *RecordCountsRequest) (*CountSession, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordCounts not implemented")
+}
+func (UnimplementedInventoryServiceServer) SubmitCountSession(context.Context, *SubmitCountSessionRequest) (*CountSession, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitCountSession not implemented")
+}
+func (UnimplementedInventoryServiceServer) ApproveCountSession(context.Context, *ApproveCountSessionRequest) (*CountSession, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveCountSession not implemented")
+}
+func (UnimplementedInventoryServiceServer) CancelCountSession(context.Context, *CancelCountSessionRequest) (*CountSession, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelCountSession not implemented")
+}
+func (UnimplementedInventoryServiceServer) CreateCountPlan(context.Context, *CreateCountPlanRequest) (*CountPlan, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCountPlan not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetCountPlan(context.Context, *GetCountPlanRequest) (*CountPlan, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCountPlan not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListCountPlans(context.Context, *ListCountPlansRequest) (*ListCountPlansResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCountPlans not implemented")
+}
+func (UnimplementedInventoryServiceServer) UpdateCountPlan(context.Context, *UpdateCountPlanRequest) (*CountPlan, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCountPlan not implemented")
+}
+func (UnimplementedInventoryServiceServer) DeleteCountPlan(context.Context, *DeleteCountPlanRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCountPlan not implemented")
+}
+func (UnimplementedInventoryServiceServer) RunCountPlan(context.Context, *RunCountPlanRequest) (*RunCountPlanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunCountPlan not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListCountPlanItems(context.Context, *ListCountPlanItemsRequest) (*ListCountPlanItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCountPlanItems not implemented")
+}
+func (UnimplementedInventoryServiceServer) CreateLot(context.Context, *CreateLotRequest) (*Lot, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateLot not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetLot(context.Context, *GetLotRequest) (*Lot, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLot not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListLots(context.Context, *ListLotsRequest) (*ListLotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLots not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListExpiringLots(context.Context, *ListExpiringLotsRequest) (*ListLotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExpiringLots not implemented")
+}
+func (UnimplementedInventoryServiceServer) LookupSerial(context.Context, *LookupSerialRequest) (*Serial, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LookupSerial not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetSerialHistory(context.Context, *GetSerialHistoryRequest) (*GetSerialHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSerialHistory not implemented")
+}
+func (UnimplementedInventoryServiceServer) CreateLocation(context.Context, *CreateLocationRequest) (*Location, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateLocation not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetLocation(context.Context, *GetLocationRequest) (*Location, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLocation not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListLocations(context.Context, *ListLocationsRequest) (*ListLocationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLocations not implemented")
+}
+func (UnimplementedInventoryServiceServer) UpdateLocation(context.Context, *UpdateLocationRequest) (*Location, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLocation not implemented")
+}
+func (UnimplementedInventoryServiceServer) DeleteLocation(context.Context, *DeleteLocationRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteLocation not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListBinStock(context.Context, *ListBinStockRequest) (*ListBinStockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBinStock not implemented")
+}
+func (UnimplementedInventoryServiceServer) MoveBinStock(context.Context, *MoveBinStockRequest) (*StockMovement, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveBinStock not implemented")
+}
+func (UnimplementedInventoryServiceServer) SuggestPutaway(context.Context, *SuggestPutawayRequest) (*SuggestPutawayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuggestPutaway not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetWarehouseUtilization(context.Context, *GetWarehouseUtilizationRequest) (*WarehouseUtilization, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWarehouseUtilization not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListCostLayers(context.Context, *ListCostLayersRequest) (*ListCostLayersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCostLayers not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetInventoryValuation(context.Context, *GetInventoryValuationRequest) (*InventoryValuation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInventoryValuation not implemented")
+}
+func (UnimplementedInventoryServiceServer) CreatePriceList(context.Context, *CreatePriceListRequest) (*PriceList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePriceList not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetPriceList(context.Context, *GetPriceListRequest) (*PriceList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPriceList not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListPriceLists(context.Context, *ListPriceListsRequest) (*ListPriceListsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPriceLists not implemented")
+}
+func (UnimplementedInventoryServiceServer) UpdatePriceList(context.Context, *UpdatePriceListRequest) (*PriceList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePriceList not implemented")
+}
+func (UnimplementedInventoryServiceServer) DeletePriceList(context.Context, *DeletePriceListRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePriceList not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetEffectivePrice(context.Context, *GetEffectivePriceRequest) (*EffectivePrice, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEffectivePrice not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListExchangeRates(context.Context, *ListExchangeRatesRequest) (*ListExchangeRatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExchangeRates not implemented")
+}
+func (UnimplementedInventoryServiceServer) SchedulePriceChange(context.Context, *SchedulePriceChangeRequest) (*PriceChange, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SchedulePriceChange not implemented")
+}
+func (UnimplementedInventoryServiceServer) CancelPriceChange(context.Context, *CancelPriceChangeRequest) (*PriceChange, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelPriceChange not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetPriceHistory(context.Context, *GetPriceHistoryRequest) (*GetPriceHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPriceHistory not implemented")
+}
+func (UnimplementedInventoryServiceServer) mustEmbedUnimplementedInventoryServiceServer() {}
+
+// UnsafeInventoryServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to InventoryServiceServer will
+// result in compilation errors.
+type UnsafeInventoryServiceServer interface {
+	mustEmbedUnimplementedInventoryServiceServer()
+}
+
+func RegisterInventoryServiceServer(s grpc.ServiceRegistrar, srv InventoryServiceServer) {
+	s.RegisterService(&InventoryService_ServiceDesc, srv)
+}
+
+func _InventoryService_CreateProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CreateProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CreateProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CreateProduct(ctx, req.(*CreateProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_GetProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetProduct(ctx, req.(*GetProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_UpdateProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).UpdateProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_UpdateProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).UpdateProduct(ctx, req.(*UpdateProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_DeleteProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).DeleteProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_DeleteProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).DeleteProduct(ctx, req.(*DeleteProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_CreateCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CreateCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CreateCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CreateCategory(ctx, req.(*CreateCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_GetCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetCategory(ctx, req.(*GetCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_UpdateCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).UpdateCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_UpdateCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).UpdateCategory(ctx, req.(*UpdateCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_DeleteCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).DeleteCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_DeleteCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).DeleteCategory(ctx, req.(*DeleteCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_CreateInventoryItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateInventoryItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CreateInventoryItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CreateInventoryItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CreateInventoryItem(ctx, req.(*CreateInventoryItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_GetInventoryItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInventoryItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetInventoryItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetInventoryItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetInventoryItem(ctx, req.(*GetInventoryItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_UpdateInventoryItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateInventoryItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).UpdateInventoryItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_UpdateInventoryItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).UpdateInventoryItem(ctx, req.(*UpdateInventoryItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_DeleteInventoryItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteInventoryItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).DeleteInventoryItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_DeleteInventoryItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).DeleteInventoryItem(ctx, req.(*DeleteInventoryItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_CreateWarehouse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWarehouseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CreateWarehouse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CreateWarehouse_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CreateWarehouse(ctx, req.(*CreateWarehouseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_GetWarehouse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWarehouseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetWarehouse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetWarehouse_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetWarehouse(ctx, req.(*GetWarehouseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_UpdateWarehouse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateWarehouseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).UpdateWarehouse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_UpdateWarehouse_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).UpdateWarehouse(ctx, req.(*UpdateWarehouseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_DeleteWarehouse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWarehouseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).DeleteWarehouse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_DeleteWarehouse_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).DeleteWarehouse(ctx, req.(*DeleteWarehouseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_CreateSupplier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSupplierRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CreateSupplier(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CreateSupplier_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CreateSupplier(ctx, req.(*CreateSupplierRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_GetSupplier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSupplierRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetSupplier(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetSupplier_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetSupplier(ctx, req.(*GetSupplierRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_UpdateSupplier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSupplierRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).UpdateSupplier(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_UpdateSupplier_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).UpdateSupplier(ctx, req.(*UpdateSupplierRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_DeleteSupplier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSupplierRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).DeleteSupplier(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_DeleteSupplier_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).DeleteSupplier(ctx, req.(*DeleteSupplierRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_CreateStockMovement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateStockMovementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CreateStockMovement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CreateStockMovement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CreateStockMovement(ctx, req.(*CreateStockMovementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_GetStockMovement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStockMovementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetStockMovement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetStockMovement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetStockMovement(ctx, req.(*GetStockMovementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_UpdateStockMovement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateStockMovementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).UpdateStockMovement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_UpdateStockMovement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).UpdateStockMovement(ctx, req.(*UpdateStockMovementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_DeleteStockMovement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteStockMovementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).DeleteStockMovement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_DeleteStockMovement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).DeleteStockMovement(ctx, req.(*DeleteStockMovementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ListProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListProducts(ctx, req.(*ListProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ListCategories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCategoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListCategories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListCategories_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListCategories(ctx, req.(*ListCategoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ListInventoryItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInventoryItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListInventoryItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListInventoryItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListInventoryItems(ctx, req.(*ListInventoryItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ListWarehouses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWarehousesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListWarehouses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListWarehouses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListWarehouses(ctx, req.(*ListWarehousesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ListSuppliers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSuppliersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListSuppliers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListSuppliers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListSuppliers(ctx, req.(*ListSuppliersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ListStockMovements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStockMovementsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListStockMovements(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListStockMovements_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListStockMovements(ctx, req.(*ListStockMovementsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListAuditEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_GetEntityHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEntityHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetEntityHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetEntityHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetEntityHistory(ctx, req.(*GetEntityHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ListReorderSuggestions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReorderSuggestionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListReorderSuggestions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListReorderSuggestions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListReorderSuggestions(ctx, req.(*ListReorderSuggestionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_StreamReorderAlerts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamReorderAlertsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(InventoryServiceServer).StreamReorderAlerts(m, &inventoryServiceStreamReorderAlertsServer{stream})
+}
+
+type InventoryService_StreamReorderAlertsServer interface {
+	Send(*ReorderSuggestion) error
+	grpc.ServerStream
+}
+
+type inventoryServiceStreamReorderAlertsServer struct {
+	grpc.ServerStream
+}
+
+func (x *inventoryServiceStreamReorderAlertsServer) Send(m *ReorderSuggestion) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _InventoryService_CreatePurchaseOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePurchaseOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CreatePurchaseOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CreatePurchaseOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CreatePurchaseOrder(ctx, req.(*CreatePurchaseOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_GetPurchaseOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPurchaseOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetPurchaseOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetPurchaseOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetPurchaseOrder(ctx, req.(*GetPurchaseOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_UpdatePurchaseOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePurchaseOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).UpdatePurchaseOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_UpdatePurchaseOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).UpdatePurchaseOrder(ctx, req.(*UpdatePurchaseOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_DeletePurchaseOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePurchaseOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).DeletePurchaseOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_DeletePurchaseOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).DeletePurchaseOrder(ctx, req.(*DeletePurchaseOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ListPurchaseOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPurchaseOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListPurchaseOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListPurchaseOrders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListPurchaseOrders(ctx, req.(*ListPurchaseOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_SubmitPurchaseOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitPurchaseOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).SubmitPurchaseOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_SubmitPurchaseOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).SubmitPurchaseOrder(ctx, req.(*SubmitPurchaseOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_CancelPurchaseOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelPurchaseOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CancelPurchaseOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CancelPurchaseOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CancelPurchaseOrder(ctx, req.(*CancelPurchaseOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_CreatePurchaseOrderFromSuggestions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePurchaseOrderFromSuggestionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CreatePurchaseOrderFromSuggestions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CreatePurchaseOrderFromSuggestions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CreatePurchaseOrderFromSuggestions(ctx, req.(*CreatePurchaseOrderFromSuggestionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ReceiveGoods_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReceiveGoodsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ReceiveGoods(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ReceiveGoods_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ReceiveGoods(ctx, req.(*ReceiveGoodsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_GetGoodsReceipt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGoodsReceiptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetGoodsReceipt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetGoodsReceipt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetGoodsReceipt(ctx, req.(*GetGoodsReceiptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ListGoodsReceipts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGoodsReceiptsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListGoodsReceipts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListGoodsReceipts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListGoodsReceipts(ctx, req.(*ListGoodsReceiptsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_CreateSupplierProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSupplierProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CreateSupplierProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CreateSupplierProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CreateSupplierProduct(ctx, req.(*CreateSupplierProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_GetSupplierProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSupplierProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetSupplierProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetSupplierProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetSupplierProduct(ctx, req.(*GetSupplierProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_UpdateSupplierProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSupplierProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).UpdateSupplierProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_UpdateSupplierProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).UpdateSupplierProduct(ctx, req.(*UpdateSupplierProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_DeleteSupplierProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSupplierProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).DeleteSupplierProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_DeleteSupplierProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).DeleteSupplierProduct(ctx, req.(*DeleteSupplierProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ListSuppliersForProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSuppliersForProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListSuppliersForProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListSuppliersForProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListSuppliersForProduct(ctx, req.(*ListSuppliersForProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ListProductsForSupplier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProductsForSupplierRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListProductsForSupplier(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListProductsForSupplier_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListProductsForSupplier(ctx, req.(*ListProductsForSupplierRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_GetSupplierScorecard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSupplierScorecardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetSupplierScorecard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetSupplierScorecard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetSupplierScorecard(ctx, req.(*GetSupplierScorecardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_RankSuppliers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RankSuppliersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).RankSuppliers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_RankSuppliers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).RankSuppliers(ctx, req.(*RankSuppliersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_CreateSalesOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSalesOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CreateSalesOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CreateSalesOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CreateSalesOrder(ctx, req.(*CreateSalesOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_GetSalesOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSalesOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetSalesOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetSalesOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetSalesOrder(ctx, req.(*GetSalesOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ListSalesOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSalesOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListSalesOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListSalesOrders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListSalesOrders(ctx, req.(*ListSalesOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_CancelSalesOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelSalesOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CancelSalesOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CancelSalesOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CancelSalesOrder(ctx, req.(*CancelSalesOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ShipSalesOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShipSalesOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ShipSalesOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ShipSalesOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ShipSalesOrder(ctx, req.(*ShipSalesOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_CreateReturnAuthorization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateReturnAuthorizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CreateReturnAuthorization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CreateReturnAuthorization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CreateReturnAuthorization(ctx, req.(*CreateReturnAuthorizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_GetReturnAuthorization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReturnAuthorizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetReturnAuthorization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetReturnAuthorization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetReturnAuthorization(ctx, req.(*GetReturnAuthorizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ListReturnAuthorizations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReturnAuthorizationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListReturnAuthorizations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListReturnAuthorizations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListReturnAuthorizations(ctx, req.(*ListReturnAuthorizationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_InspectReturnAuthorization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InspectReturnAuthorizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).InspectReturnAuthorization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_InspectReturnAuthorization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).InspectReturnAuthorization(ctx, req.(*InspectReturnAuthorizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_CancelReturnAuthorization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelReturnAuthorizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CancelReturnAuthorization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CancelReturnAuthorization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CancelReturnAuthorization(ctx, req.(*CancelReturnAuthorizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_CreateTransferOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTransferOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CreateTransferOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CreateTransferOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CreateTransferOrder(ctx, req.(*CreateTransferOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_GetTransferOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransferOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetTransferOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetTransferOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetTransferOrder(ctx, req.(*GetTransferOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ListTransferOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTransferOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListTransferOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListTransferOrders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListTransferOrders(ctx, req.(*ListTransferOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ShipTransferOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShipTransferOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ShipTransferOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ShipTransferOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ShipTransferOrder(ctx, req.(*ShipTransferOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ReceiveTransferOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReceiveTransferOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ReceiveTransferOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ReceiveTransferOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ReceiveTransferOrder(ctx, req.(*ReceiveTransferOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_CancelTransferOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelTransferOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CancelTransferOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CancelTransferOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CancelTransferOrder(ctx, req.(*CancelTransferOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ListInTransitStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInTransitStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListInTransitStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListInTransitStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListInTransitStock(ctx, req.(*ListInTransitStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_AdjustStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdjustStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).AdjustStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_AdjustStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).AdjustStock(ctx, req.(*AdjustStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_GetStockAdjustment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStockAdjustmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetStockAdjustment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetStockAdjustment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetStockAdjustment(ctx, req.(*GetStockAdjustmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ListStockAdjustments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStockAdjustmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListStockAdjustments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListStockAdjustments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListStockAdjustments(ctx, req.(*ListStockAdjustmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedInventoryServiceServer) DeleteSupplier(context.Context, *DeleteSupplierRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteSupplier not implemented")
+
+func _InventoryService_ApproveStockAdjustment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveStockAdjustmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ApproveStockAdjustment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ApproveStockAdjustment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ApproveStockAdjustment(ctx, req.(*ApproveStockAdjustmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedInventoryServiceServer) CreateStockMovement(context.Context, *CreateStockMovementRequest) (*StockMovement, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateStockMovement not implemented")
+
+func _InventoryService_RejectStockAdjustment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectStockAdjustmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).RejectStockAdjustment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_RejectStockAdjustment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).RejectStockAdjustment(ctx, req.(*RejectStockAdjustmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedInventoryServiceServer) GetStockMovement(context.Context, *GetStockMovementRequest) (*StockMovement, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetStockMovement not implemented")
+
+func _InventoryService_CreateCountSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCountSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CreateCountSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CreateCountSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CreateCountSession(ctx, req.(*CreateCountSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedInventoryServiceServer) UpdateStockMovement(context.Context, *UpdateStockMovementRequest) (*StockMovement, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateStockMovement not implemented")
+
+func _InventoryService_GetCountSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCountSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetCountSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetCountSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetCountSession(ctx, req.(*GetCountSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedInventoryServiceServer) DeleteStockMovement(context.Context, *DeleteStockMovementRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteStockMovement not implemented")
+
+func _InventoryService_ListCountSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCountSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListCountSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListCountSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListCountSessions(ctx, req.(*ListCountSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedInventoryServiceServer) ListProducts(context.Context, *ListProductsRequest) (*ListProductsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListProducts not implemented")
+
+func _InventoryService_RecordCounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordCountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).RecordCounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_RecordCounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).RecordCounts(ctx, req.(*RecordCountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedInventoryServiceServer) ListCategories(context.Context, *ListCategoriesRequest) (*ListCategoriesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListCategories not implemented")
+
+func _InventoryService_SubmitCountSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitCountSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).SubmitCountSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_SubmitCountSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).SubmitCountSession(ctx, req.(*SubmitCountSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedInventoryServiceServer) ListInventoryItems(context.Context, *ListInventoryItemsRequest) (*ListInventoryItemsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListInventoryItems not implemented")
+
+func _InventoryService_ApproveCountSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveCountSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ApproveCountSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ApproveCountSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ApproveCountSession(ctx, req.(*ApproveCountSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedInventoryServiceServer) ListWarehouses(context.Context, *ListWarehousesRequest) (*ListWarehousesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListWarehouses not implemented")
+
+func _InventoryService_CancelCountSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelCountSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CancelCountSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CancelCountSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CancelCountSession(ctx, req.(*CancelCountSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedInventoryServiceServer) ListSuppliers(context.Context, *ListSuppliersRequest) (*ListSuppliersResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListSuppliers not implemented")
+
+func _InventoryService_CreateCountPlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCountPlanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CreateCountPlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CreateCountPlan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CreateCountPlan(ctx, req.(*CreateCountPlanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedInventoryServiceServer) ListStockMovements(context.Context, *ListStockMovementsRequest) (*ListStockMovementsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListStockMovements not implemented")
+
+func _InventoryService_GetCountPlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCountPlanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetCountPlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetCountPlan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetCountPlan(ctx, req.(*GetCountPlanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedInventoryServiceServer) mustEmbedUnimplementedInventoryServiceServer() {}
 
-// UnsafeInventoryServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to InventoryServiceServer will
-// result in compilation errors.
-type UnsafeInventoryServiceServer interface {
-	mustEmbedUnimplementedInventoryServiceServer()
+func _InventoryService_ListCountPlans_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCountPlansRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListCountPlans(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListCountPlans_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListCountPlans(ctx, req.(*ListCountPlansRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterInventoryServiceServer(s grpc.ServiceRegistrar, srv InventoryServiceServer) {
-	s.RegisterService(&InventoryService_ServiceDesc, srv)
+func _InventoryService_UpdateCountPlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCountPlanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).UpdateCountPlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_UpdateCountPlan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).UpdateCountPlan(ctx, req.(*UpdateCountPlanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_CreateProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateProductRequest)
+func _InventoryService_DeleteCountPlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCountPlanRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).CreateProduct(ctx, in)
+		return srv.(InventoryServiceServer).DeleteCountPlan(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_CreateProduct_FullMethodName,
+		FullMethod: InventoryService_DeleteCountPlan_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).CreateProduct(ctx, req.(*CreateProductRequest))
+		return srv.(InventoryServiceServer).DeleteCountPlan(ctx, req.(*DeleteCountPlanRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_GetProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetProductRequest)
+func _InventoryService_RunCountPlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunCountPlanRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).GetProduct(ctx, in)
+		return srv.(InventoryServiceServer).RunCountPlan(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_GetProduct_FullMethodName,
+		FullMethod: InventoryService_RunCountPlan_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).GetProduct(ctx, req.(*GetProductRequest))
+		return srv.(InventoryServiceServer).RunCountPlan(ctx, req.(*RunCountPlanRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_UpdateProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateProductRequest)
+func _InventoryService_ListCountPlanItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCountPlanItemsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).UpdateProduct(ctx, in)
+		return srv.(InventoryServiceServer).ListCountPlanItems(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_UpdateProduct_FullMethodName,
+		FullMethod: InventoryService_ListCountPlanItems_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).UpdateProduct(ctx, req.(*UpdateProductRequest))
+		return srv.(InventoryServiceServer).ListCountPlanItems(ctx, req.(*ListCountPlanItemsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_DeleteProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteProductRequest)
+func _InventoryService_CreateLot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateLotRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).DeleteProduct(ctx, in)
+		return srv.(InventoryServiceServer).CreateLot(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_DeleteProduct_FullMethodName,
+		FullMethod: InventoryService_CreateLot_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).DeleteProduct(ctx, req.(*DeleteProductRequest))
+		return srv.(InventoryServiceServer).CreateLot(ctx, req.(*CreateLotRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_CreateCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateCategoryRequest)
+func _InventoryService_GetLot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLotRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).CreateCategory(ctx, in)
+		return srv.(InventoryServiceServer).GetLot(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_CreateCategory_FullMethodName,
+		FullMethod: InventoryService_GetLot_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).CreateCategory(ctx, req.(*CreateCategoryRequest))
+		return srv.(InventoryServiceServer).GetLot(ctx, req.(*GetLotRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_GetCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetCategoryRequest)
+func _InventoryService_ListLots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLotsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).GetCategory(ctx, in)
+		return srv.(InventoryServiceServer).ListLots(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_GetCategory_FullMethodName,
+		FullMethod: InventoryService_ListLots_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).GetCategory(ctx, req.(*GetCategoryRequest))
+		return srv.(InventoryServiceServer).ListLots(ctx, req.(*ListLotsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_UpdateCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateCategoryRequest)
+func _InventoryService_ListExpiringLots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExpiringLotsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).UpdateCategory(ctx, in)
+		return srv.(InventoryServiceServer).ListExpiringLots(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_UpdateCategory_FullMethodName,
+		FullMethod: InventoryService_ListExpiringLots_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).UpdateCategory(ctx, req.(*UpdateCategoryRequest))
+		return srv.(InventoryServiceServer).ListExpiringLots(ctx, req.(*ListExpiringLotsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_DeleteCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteCategoryRequest)
+func _InventoryService_LookupSerial_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LookupSerialRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).DeleteCategory(ctx, in)
+		return srv.(InventoryServiceServer).LookupSerial(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_DeleteCategory_FullMethodName,
+		FullMethod: InventoryService_LookupSerial_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).DeleteCategory(ctx, req.(*DeleteCategoryRequest))
+		return srv.(InventoryServiceServer).LookupSerial(ctx, req.(*LookupSerialRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_CreateInventoryItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateInventoryItemRequest)
+func _InventoryService_GetSerialHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSerialHistoryRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).CreateInventoryItem(ctx, in)
+		return srv.(InventoryServiceServer).GetSerialHistory(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_CreateInventoryItem_FullMethodName,
+		FullMethod: InventoryService_GetSerialHistory_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).CreateInventoryItem(ctx, req.(*CreateInventoryItemRequest))
+		return srv.(InventoryServiceServer).GetSerialHistory(ctx, req.(*GetSerialHistoryRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_GetInventoryItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetInventoryItemRequest)
+func _InventoryService_CreateLocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateLocationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).GetInventoryItem(ctx, in)
+		return srv.(InventoryServiceServer).CreateLocation(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_GetInventoryItem_FullMethodName,
+		FullMethod: InventoryService_CreateLocation_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).GetInventoryItem(ctx, req.(*GetInventoryItemRequest))
+		return srv.(InventoryServiceServer).CreateLocation(ctx, req.(*CreateLocationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_UpdateInventoryItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateInventoryItemRequest)
+func _InventoryService_GetLocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLocationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).UpdateInventoryItem(ctx, in)
+		return srv.(InventoryServiceServer).GetLocation(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_UpdateInventoryItem_FullMethodName,
+		FullMethod: InventoryService_GetLocation_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).UpdateInventoryItem(ctx, req.(*UpdateInventoryItemRequest))
+		return srv.(InventoryServiceServer).GetLocation(ctx, req.(*GetLocationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_DeleteInventoryItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteInventoryItemRequest)
+func _InventoryService_ListLocations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLocationsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).DeleteInventoryItem(ctx, in)
+		return srv.(InventoryServiceServer).ListLocations(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_DeleteInventoryItem_FullMethodName,
+		FullMethod: InventoryService_ListLocations_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).DeleteInventoryItem(ctx, req.(*DeleteInventoryItemRequest))
+		return srv.(InventoryServiceServer).ListLocations(ctx, req.(*ListLocationsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_CreateWarehouse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateWarehouseRequest)
+func _InventoryService_UpdateLocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLocationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).CreateWarehouse(ctx, in)
+		return srv.(InventoryServiceServer).UpdateLocation(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_CreateWarehouse_FullMethodName,
+		FullMethod: InventoryService_UpdateLocation_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).CreateWarehouse(ctx, req.(*CreateWarehouseRequest))
+		return srv.(InventoryServiceServer).UpdateLocation(ctx, req.(*UpdateLocationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_GetWarehouse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetWarehouseRequest)
+func _InventoryService_DeleteLocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteLocationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).GetWarehouse(ctx, in)
+		return srv.(InventoryServiceServer).DeleteLocation(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_GetWarehouse_FullMethodName,
+		FullMethod: InventoryService_DeleteLocation_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).GetWarehouse(ctx, req.(*GetWarehouseRequest))
+		return srv.(InventoryServiceServer).DeleteLocation(ctx, req.(*DeleteLocationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_UpdateWarehouse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateWarehouseRequest)
+func _InventoryService_ListBinStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBinStockRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).UpdateWarehouse(ctx, in)
+		return srv.(InventoryServiceServer).ListBinStock(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_UpdateWarehouse_FullMethodName,
+		FullMethod: InventoryService_ListBinStock_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).UpdateWarehouse(ctx, req.(*UpdateWarehouseRequest))
+		return srv.(InventoryServiceServer).ListBinStock(ctx, req.(*ListBinStockRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_DeleteWarehouse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteWarehouseRequest)
+func _InventoryService_MoveBinStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveBinStockRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).DeleteWarehouse(ctx, in)
+		return srv.(InventoryServiceServer).MoveBinStock(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_DeleteWarehouse_FullMethodName,
+		FullMethod: InventoryService_MoveBinStock_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).DeleteWarehouse(ctx, req.(*DeleteWarehouseRequest))
+		return srv.(InventoryServiceServer).MoveBinStock(ctx, req.(*MoveBinStockRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_CreateSupplier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateSupplierRequest)
+func _InventoryService_SuggestPutaway_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestPutawayRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).CreateSupplier(ctx, in)
+		return srv.(InventoryServiceServer).SuggestPutaway(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_CreateSupplier_FullMethodName,
+		FullMethod: InventoryService_SuggestPutaway_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).CreateSupplier(ctx, req.(*CreateSupplierRequest))
+		return srv.(InventoryServiceServer).SuggestPutaway(ctx, req.(*SuggestPutawayRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_GetSupplier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetSupplierRequest)
+func _InventoryService_GetWarehouseUtilization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWarehouseUtilizationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).GetSupplier(ctx, in)
+		return srv.(InventoryServiceServer).GetWarehouseUtilization(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_GetSupplier_FullMethodName,
+		FullMethod: InventoryService_GetWarehouseUtilization_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).GetSupplier(ctx, req.(*GetSupplierRequest))
+		return srv.(InventoryServiceServer).GetWarehouseUtilization(ctx, req.(*GetWarehouseUtilizationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_UpdateSupplier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateSupplierRequest)
+func _InventoryService_ListCostLayers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCostLayersRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).UpdateSupplier(ctx, in)
+		return srv.(InventoryServiceServer).ListCostLayers(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_UpdateSupplier_FullMethodName,
+		FullMethod: InventoryService_ListCostLayers_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).UpdateSupplier(ctx, req.(*UpdateSupplierRequest))
+		return srv.(InventoryServiceServer).ListCostLayers(ctx, req.(*ListCostLayersRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_DeleteSupplier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteSupplierRequest)
+func _InventoryService_GetInventoryValuation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInventoryValuationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).DeleteSupplier(ctx, in)
+		return srv.(InventoryServiceServer).GetInventoryValuation(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_DeleteSupplier_FullMethodName,
+		FullMethod: InventoryService_GetInventoryValuation_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).DeleteSupplier(ctx, req.(*DeleteSupplierRequest))
+		return srv.(InventoryServiceServer).GetInventoryValuation(ctx, req.(*GetInventoryValuationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_CreateStockMovement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateStockMovementRequest)
+func _InventoryService_CreatePriceList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePriceListRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).CreateStockMovement(ctx, in)
+		return srv.(InventoryServiceServer).CreatePriceList(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_CreateStockMovement_FullMethodName,
+		FullMethod: InventoryService_CreatePriceList_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).CreateStockMovement(ctx, req.(*CreateStockMovementRequest))
+		return srv.(InventoryServiceServer).CreatePriceList(ctx, req.(*CreatePriceListRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_GetStockMovement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetStockMovementRequest)
+func _InventoryService_GetPriceList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPriceListRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).GetStockMovement(ctx, in)
+		return srv.(InventoryServiceServer).GetPriceList(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_GetStockMovement_FullMethodName,
+		FullMethod: InventoryService_GetPriceList_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).GetStockMovement(ctx, req.(*GetStockMovementRequest))
+		return srv.(InventoryServiceServer).GetPriceList(ctx, req.(*GetPriceListRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_UpdateStockMovement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateStockMovementRequest)
+func _InventoryService_ListPriceLists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPriceListsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).UpdateStockMovement(ctx, in)
+		return srv.(InventoryServiceServer).ListPriceLists(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_UpdateStockMovement_FullMethodName,
+		FullMethod: InventoryService_ListPriceLists_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).UpdateStockMovement(ctx, req.(*UpdateStockMovementRequest))
+		return srv.(InventoryServiceServer).ListPriceLists(ctx, req.(*ListPriceListsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_DeleteStockMovement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteStockMovementRequest)
+func _InventoryService_UpdatePriceList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePriceListRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).DeleteStockMovement(ctx, in)
+		return srv.(InventoryServiceServer).UpdatePriceList(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_DeleteStockMovement_FullMethodName,
+		FullMethod: InventoryService_UpdatePriceList_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).DeleteStockMovement(ctx, req.(*DeleteStockMovementRequest))
+		return srv.(InventoryServiceServer).UpdatePriceList(ctx, req.(*UpdatePriceListRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_ListProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListProductsRequest)
+func _InventoryService_DeletePriceList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePriceListRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).ListProducts(ctx, in)
+		return srv.(InventoryServiceServer).DeletePriceList(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_ListProducts_FullMethodName,
+		FullMethod: InventoryService_DeletePriceList_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).ListProducts(ctx, req.(*ListProductsRequest))
+		return srv.(InventoryServiceServer).DeletePriceList(ctx, req.(*DeletePriceListRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_ListCategories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListCategoriesRequest)
+func _InventoryService_GetEffectivePrice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEffectivePriceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).ListCategories(ctx, in)
+		return srv.(InventoryServiceServer).GetEffectivePrice(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_ListCategories_FullMethodName,
+		FullMethod: InventoryService_GetEffectivePrice_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).ListCategories(ctx, req.(*ListCategoriesRequest))
+		return srv.(InventoryServiceServer).GetEffectivePrice(ctx, req.(*GetEffectivePriceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_ListInventoryItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListInventoryItemsRequest)
+func _InventoryService_ListExchangeRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExchangeRatesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).ListInventoryItems(ctx, in)
+		return srv.(InventoryServiceServer).ListExchangeRates(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_ListInventoryItems_FullMethodName,
+		FullMethod: InventoryService_ListExchangeRates_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).ListInventoryItems(ctx, req.(*ListInventoryItemsRequest))
+		return srv.(InventoryServiceServer).ListExchangeRates(ctx, req.(*ListExchangeRatesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_ListWarehouses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListWarehousesRequest)
+func _InventoryService_SchedulePriceChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SchedulePriceChangeRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).ListWarehouses(ctx, in)
+		return srv.(InventoryServiceServer).SchedulePriceChange(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_ListWarehouses_FullMethodName,
+		FullMethod: InventoryService_SchedulePriceChange_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).ListWarehouses(ctx, req.(*ListWarehousesRequest))
+		return srv.(InventoryServiceServer).SchedulePriceChange(ctx, req.(*SchedulePriceChangeRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_ListSuppliers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListSuppliersRequest)
+func _InventoryService_CancelPriceChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelPriceChangeRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).ListSuppliers(ctx, in)
+		return srv.(InventoryServiceServer).CancelPriceChange(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_ListSuppliers_FullMethodName,
+		FullMethod: InventoryService_CancelPriceChange_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).ListSuppliers(ctx, req.(*ListSuppliersRequest))
+		return srv.(InventoryServiceServer).CancelPriceChange(ctx, req.(*CancelPriceChangeRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_ListStockMovements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListStockMovementsRequest)
+func _InventoryService_GetPriceHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPriceHistoryRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).ListStockMovements(ctx, in)
+		return srv.(InventoryServiceServer).GetPriceHistory(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_ListStockMovements_FullMethodName,
+		FullMethod: InventoryService_GetPriceHistory_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).ListStockMovements(ctx, req.(*ListStockMovementsRequest))
+		return srv.(InventoryServiceServer).GetPriceHistory(ctx, req.(*GetPriceHistoryRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1177,7 +4041,353 @@ var InventoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListStockMovements",
 			Handler:    _InventoryService_ListStockMovements_Handler,
 		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _InventoryService_ListAuditEvents_Handler,
+		},
+		{
+			MethodName: "GetEntityHistory",
+			Handler:    _InventoryService_GetEntityHistory_Handler,
+		},
+		{
+			MethodName: "ListReorderSuggestions",
+			Handler:    _InventoryService_ListReorderSuggestions_Handler,
+		},
+		{
+			MethodName: "CreatePurchaseOrder",
+			Handler:    _InventoryService_CreatePurchaseOrder_Handler,
+		},
+		{
+			MethodName: "GetPurchaseOrder",
+			Handler:    _InventoryService_GetPurchaseOrder_Handler,
+		},
+		{
+			MethodName: "UpdatePurchaseOrder",
+			Handler:    _InventoryService_UpdatePurchaseOrder_Handler,
+		},
+		{
+			MethodName: "DeletePurchaseOrder",
+			Handler:    _InventoryService_DeletePurchaseOrder_Handler,
+		},
+		{
+			MethodName: "ListPurchaseOrders",
+			Handler:    _InventoryService_ListPurchaseOrders_Handler,
+		},
+		{
+			MethodName: "SubmitPurchaseOrder",
+			Handler:    _InventoryService_SubmitPurchaseOrder_Handler,
+		},
+		{
+			MethodName: "CancelPurchaseOrder",
+			Handler:    _InventoryService_CancelPurchaseOrder_Handler,
+		},
+		{
+			MethodName: "CreatePurchaseOrderFromSuggestions",
+			Handler:    _InventoryService_CreatePurchaseOrderFromSuggestions_Handler,
+		},
+		{
+			MethodName: "ReceiveGoods",
+			Handler:    _InventoryService_ReceiveGoods_Handler,
+		},
+		{
+			MethodName: "GetGoodsReceipt",
+			Handler:    _InventoryService_GetGoodsReceipt_Handler,
+		},
+		{
+			MethodName: "ListGoodsReceipts",
+			Handler:    _InventoryService_ListGoodsReceipts_Handler,
+		},
+		{
+			MethodName: "CreateSupplierProduct",
+			Handler:    _InventoryService_CreateSupplierProduct_Handler,
+		},
+		{
+			MethodName: "GetSupplierProduct",
+			Handler:    _InventoryService_GetSupplierProduct_Handler,
+		},
+		{
+			MethodName: "UpdateSupplierProduct",
+			Handler:    _InventoryService_UpdateSupplierProduct_Handler,
+		},
+		{
+			MethodName: "DeleteSupplierProduct",
+			Handler:    _InventoryService_DeleteSupplierProduct_Handler,
+		},
+		{
+			MethodName: "ListSuppliersForProduct",
+			Handler:    _InventoryService_ListSuppliersForProduct_Handler,
+		},
+		{
+			MethodName: "ListProductsForSupplier",
+			Handler:    _InventoryService_ListProductsForSupplier_Handler,
+		},
+		{
+			MethodName: "GetSupplierScorecard",
+			Handler:    _InventoryService_GetSupplierScorecard_Handler,
+		},
+		{
+			MethodName: "RankSuppliers",
+			Handler:    _InventoryService_RankSuppliers_Handler,
+		},
+		{
+			MethodName: "CreateSalesOrder",
+			Handler:    _InventoryService_CreateSalesOrder_Handler,
+		},
+		{
+			MethodName: "GetSalesOrder",
+			Handler:    _InventoryService_GetSalesOrder_Handler,
+		},
+		{
+			MethodName: "ListSalesOrders",
+			Handler:    _InventoryService_ListSalesOrders_Handler,
+		},
+		{
+			MethodName: "CancelSalesOrder",
+			Handler:    _InventoryService_CancelSalesOrder_Handler,
+		},
+		{
+			MethodName: "ShipSalesOrder",
+			Handler:    _InventoryService_ShipSalesOrder_Handler,
+		},
+		{
+			MethodName: "CreateReturnAuthorization",
+			Handler:    _InventoryService_CreateReturnAuthorization_Handler,
+		},
+		{
+			MethodName: "GetReturnAuthorization",
+			Handler:    _InventoryService_GetReturnAuthorization_Handler,
+		},
+		{
+			MethodName: "ListReturnAuthorizations",
+			Handler:    _InventoryService_ListReturnAuthorizations_Handler,
+		},
+		{
+			MethodName: "InspectReturnAuthorization",
+			Handler:    _InventoryService_InspectReturnAuthorization_Handler,
+		},
+		{
+			MethodName: "CancelReturnAuthorization",
+			Handler:    _InventoryService_CancelReturnAuthorization_Handler,
+		},
+		{
+			MethodName: "CreateTransferOrder",
+			Handler:    _InventoryService_CreateTransferOrder_Handler,
+		},
+		{
+			MethodName: "GetTransferOrder",
+			Handler:    _InventoryService_GetTransferOrder_Handler,
+		},
+		{
+			MethodName: "ListTransferOrders",
+			Handler:    _InventoryService_ListTransferOrders_Handler,
+		},
+		{
+			MethodName: "ShipTransferOrder",
+			Handler:    _InventoryService_ShipTransferOrder_Handler,
+		},
+		{
+			MethodName: "ReceiveTransferOrder",
+			Handler:    _InventoryService_ReceiveTransferOrder_Handler,
+		},
+		{
+			MethodName: "CancelTransferOrder",
+			Handler:    _InventoryService_CancelTransferOrder_Handler,
+		},
+		{
+			MethodName: "ListInTransitStock",
+			Handler:    _InventoryService_ListInTransitStock_Handler,
+		},
+		{
+			MethodName: "AdjustStock",
+			Handler:    _InventoryService_AdjustStock_Handler,
+		},
+		{
+			MethodName: "GetStockAdjustment",
+			Handler:    _InventoryService_GetStockAdjustment_Handler,
+		},
+		{
+			MethodName: "ListStockAdjustments",
+			Handler:    _InventoryService_ListStockAdjustments_Handler,
+		},
+		{
+			MethodName: "ApproveStockAdjustment",
+			Handler:    _InventoryService_ApproveStockAdjustment_Handler,
+		},
+		{
+			MethodName: "RejectStockAdjustment",
+			Handler:    _InventoryService_RejectStockAdjustment_Handler,
+		},
+		{
+			MethodName: "CreateCountSession",
+			Handler:    _InventoryService_CreateCountSession_Handler,
+		},
+		{
+			MethodName: "GetCountSession",
+			Handler:    _InventoryService_GetCountSession_Handler,
+		},
+		{
+			MethodName: "ListCountSessions",
+			Handler:    _InventoryService_ListCountSessions_Handler,
+		},
+		{
+			MethodName: "RecordCounts",
+			Handler:    _InventoryService_RecordCounts_Handler,
+		},
+		{
+			MethodName: "SubmitCountSession",
+			Handler:    _InventoryService_SubmitCountSession_Handler,
+		},
+		{
+			MethodName: "ApproveCountSession",
+			Handler:    _InventoryService_ApproveCountSession_Handler,
+		},
+		{
+			MethodName: "CancelCountSession",
+			Handler:    _InventoryService_CancelCountSession_Handler,
+		},
+		{
+			MethodName: "CreateCountPlan",
+			Handler:    _InventoryService_CreateCountPlan_Handler,
+		},
+		{
+			MethodName: "GetCountPlan",
+			Handler:    _InventoryService_GetCountPlan_Handler,
+		},
+		{
+			MethodName: "ListCountPlans",
+			Handler:    _InventoryService_ListCountPlans_Handler,
+		},
+		{
+			MethodName: "UpdateCountPlan",
+			Handler:    _InventoryService_UpdateCountPlan_Handler,
+		},
+		{
+			MethodName: "DeleteCountPlan",
+			Handler:    _InventoryService_DeleteCountPlan_Handler,
+		},
+		{
+			MethodName: "RunCountPlan",
+			Handler:    _InventoryService_RunCountPlan_Handler,
+		},
+		{
+			MethodName: "ListCountPlanItems",
+			Handler:    _InventoryService_ListCountPlanItems_Handler,
+		},
+		{
+			MethodName: "CreateLot",
+			Handler:    _InventoryService_CreateLot_Handler,
+		},
+		{
+			MethodName: "GetLot",
+			Handler:    _InventoryService_GetLot_Handler,
+		},
+		{
+			MethodName: "ListLots",
+			Handler:    _InventoryService_ListLots_Handler,
+		},
+		{
+			MethodName: "ListExpiringLots",
+			Handler:    _InventoryService_ListExpiringLots_Handler,
+		},
+		{
+			MethodName: "LookupSerial",
+			Handler:    _InventoryService_LookupSerial_Handler,
+		},
+		{
+			MethodName: "GetSerialHistory",
+			Handler:    _InventoryService_GetSerialHistory_Handler,
+		},
+		{
+			MethodName: "CreateLocation",
+			Handler:    _InventoryService_CreateLocation_Handler,
+		},
+		{
+			MethodName: "GetLocation",
+			Handler:    _InventoryService_GetLocation_Handler,
+		},
+		{
+			MethodName: "ListLocations",
+			Handler:    _InventoryService_ListLocations_Handler,
+		},
+		{
+			MethodName: "UpdateLocation",
+			Handler:    _InventoryService_UpdateLocation_Handler,
+		},
+		{
+			MethodName: "DeleteLocation",
+			Handler:    _InventoryService_DeleteLocation_Handler,
+		},
+		{
+			MethodName: "ListBinStock",
+			Handler:    _InventoryService_ListBinStock_Handler,
+		},
+		{
+			MethodName: "MoveBinStock",
+			Handler:    _InventoryService_MoveBinStock_Handler,
+		},
+		{
+			MethodName: "SuggestPutaway",
+			Handler:    _InventoryService_SuggestPutaway_Handler,
+		},
+		{
+			MethodName: "GetWarehouseUtilization",
+			Handler:    _InventoryService_GetWarehouseUtilization_Handler,
+		},
+		{
+			MethodName: "ListCostLayers",
+			Handler:    _InventoryService_ListCostLayers_Handler,
+		},
+		{
+			MethodName: "GetInventoryValuation",
+			Handler:    _InventoryService_GetInventoryValuation_Handler,
+		},
+		{
+			MethodName: "CreatePriceList",
+			Handler:    _InventoryService_CreatePriceList_Handler,
+		},
+		{
+			MethodName: "GetPriceList",
+			Handler:    _InventoryService_GetPriceList_Handler,
+		},
+		{
+			MethodName: "ListPriceLists",
+			Handler:    _InventoryService_ListPriceLists_Handler,
+		},
+		{
+			MethodName: "UpdatePriceList",
+			Handler:    _InventoryService_UpdatePriceList_Handler,
+		},
+		{
+			MethodName: "DeletePriceList",
+			Handler:    _InventoryService_DeletePriceList_Handler,
+		},
+		{
+			MethodName: "GetEffectivePrice",
+			Handler:    _InventoryService_GetEffectivePrice_Handler,
+		},
+		{
+			MethodName: "ListExchangeRates",
+			Handler:    _InventoryService_ListExchangeRates_Handler,
+		},
+		{
+			MethodName: "SchedulePriceChange",
+			Handler:    _InventoryService_SchedulePriceChange_Handler,
+		},
+		{
+			MethodName: "CancelPriceChange",
+			Handler:    _InventoryService_CancelPriceChange_Handler,
+		},
+		{
+			MethodName: "GetPriceHistory",
+			Handler:    _InventoryService_GetPriceHistory_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamReorderAlerts",
+			Handler:       _InventoryService_StreamReorderAlerts_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/inventory.proto",
 }
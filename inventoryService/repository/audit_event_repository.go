@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"github.com/gocql/gocql"
+	"github.com/google/uuid"
+	"inventoryService/model"
+	"sort"
+	"strings"
+	"time"
+)
+
+type AuditEventRepository struct {
+	session *gocql.Session
+}
+
+func NewAuditEventRepository(session *gocql.Session) *AuditEventRepository {
+	return &AuditEventRepository{session: session}
+}
+
+// auditEventColumns are the columns of audit_events and of the tables that
+// hold the same events partitioned by actor and by day.
+const auditEventColumns = `entity_type, entity_id, event_time, id, action, actor, rpc, before, after`
+
+// auditDayWindow is how far back a listing that names neither an entity nor
+// an actor reaches when it gives no start time.
+const auditDayWindow = 31 * 24 * time.Hour
+
+// CreateAuditEvent appends an event to audit_events, partitioned by entity,
+// and to audit_events_by_actor and audit_events_by_day, so that every listing
+// reads a single partition or a bounded number of them.
+func (r *AuditEventRepository) CreateAuditEvent(ctx context.Context, event *model.AuditEvent) error {
+	values := []interface{}{string(event.EntityType), event.EntityID.String(), event.Timestamp, event.ID.String(), string(event.Action), event.Actor, event.RPC, event.Before, event.After}
+	batch := r.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	batch.Query(`INSERT INTO audit_events (`+auditEventColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`, values...)
+	batch.Query(`INSERT INTO audit_events_by_actor (`+auditEventColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`, values...)
+	batch.Query(`INSERT INTO audit_events_by_day (day, `+auditEventColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`, append([]interface{}{AuditDay(event.Timestamp)}, values...)...)
+	return r.session.ExecuteBatch(batch)
+}
+
+// AuditDay is the partition of audit_events_by_day holding events at t.
+func AuditDay(t time.Time) string {
+	return t.UTC().Format("2006-01-02")
+}
+
+// ListAuditEvents returns the events matching every non-zero field of filter,
+// newest first. It reads the entity's partition when filter names an entity
+// type and ID, the actor's partition when it names an actor, and otherwise
+// the partitions of the days from Start to End; without a Start, the days of
+// the last auditDayWindow before End.
+func (r *AuditEventRepository) ListAuditEvents(ctx context.Context, filter model.AuditEventFilter) ([]*model.AuditEvent, error) {
+	var timeConditions []string
+	var timeArgs []interface{}
+	if !filter.Start.IsZero() {
+		timeConditions = append(timeConditions, "event_time >= ?")
+		timeArgs = append(timeArgs, filter.Start)
+	}
+	if !filter.End.IsZero() {
+		timeConditions = append(timeConditions, "event_time <= ?")
+		timeArgs = append(timeArgs, filter.End)
+	}
+
+	var events []*model.AuditEvent
+	switch {
+	case filter.EntityType != "" && filter.EntityID != uuid.Nil:
+		conditions := append([]string{"entity_type = ?", "entity_id = ?"}, timeConditions...)
+		args := append([]interface{}{string(filter.EntityType), filter.EntityID.String()}, timeArgs...)
+		if err := r.scan(ctx, "audit_events", conditions, args, filter, &events); err != nil {
+			return nil, err
+		}
+	case filter.Actor != "":
+		conditions := append([]string{"actor = ?"}, timeConditions...)
+		args := append([]interface{}{filter.Actor}, timeArgs...)
+		if err := r.scan(ctx, "audit_events_by_actor", conditions, args, filter, &events); err != nil {
+			return nil, err
+		}
+	default:
+		end := filter.End
+		if end.IsZero() {
+			end = time.Now()
+		}
+		start := filter.Start
+		if start.IsZero() {
+			start = end.Add(-auditDayWindow)
+		}
+		for day := end.UTC(); !day.Before(start.UTC().Truncate(24 * time.Hour)); day = day.Add(-24 * time.Hour) {
+			conditions := append([]string{"day = ?"}, timeConditions...)
+			args := append([]interface{}{AuditDay(day)}, timeArgs...)
+			if err := r.scan(ctx, "audit_events_by_day", conditions, args, filter, &events); err != nil {
+				return nil, err
+			}
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp.After(events[j].Timestamp)
+	})
+	return events, nil
+}
+
+// scan appends the events of one partition of table that match filter.
+func (r *AuditEventRepository) scan(ctx context.Context, table string, conditions []string, args []interface{}, filter model.AuditEventFilter, events *[]*model.AuditEvent) error {
+	query := `SELECT ` + auditEventColumns + ` FROM ` + table + ` WHERE ` + strings.Join(conditions, " AND ")
+	iter := r.session.Query(query, args...).WithContext(ctx).Iter()
+	var entityType, entityID, id, action, actor, rpc, before, after string
+	var eventTime time.Time
+	for iter.Scan(&entityType, &entityID, &eventTime, &id, &action, &actor, &rpc, &before, &after) {
+		convertedEntityID, err := uuid.Parse(entityID)
+		if err != nil {
+			continue
+		}
+		convertedID, err := uuid.Parse(id)
+		if err != nil {
+			continue
+		}
+		event := &model.AuditEvent{
+			ID:         convertedID,
+			EntityType: model.EntityType(entityType),
+			EntityID:   convertedEntityID,
+			Action:     model.AuditAction(action),
+			Actor:      actor,
+			RPC:        rpc,
+			Before:     before,
+			After:      after,
+			Timestamp:  eventTime,
+		}
+		if filter.Matches(event) {
+			*events = append(*events, event)
+		}
+	}
+	return iter.Close()
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gocql/gocql"
+	"github.com/google/uuid"
+	"gopkg.in/inf.v0"
+	"inventoryService/model"
+)
+
+type BinStockRepository struct {
+	session *gocql.Session
+}
+
+func NewBinStockRepository(session *gocql.Session) *BinStockRepository {
+	return &BinStockRepository{session: session}
+}
+
+func (r *BinStockRepository) ListBinStockByInventoryItem(ctx context.Context, inventoryItemID string) ([]*model.BinStock, error) {
+	return scanBinStock(r.session.Query(`SELECT inventory_item_id, location_id, warehouse_id, quantity_decimal FROM bin_stock WHERE inventory_item_id = ?`,
+		inventoryItemID).WithContext(ctx).Iter())
+}
+
+func (r *BinStockRepository) ListBinStockByWarehouse(ctx context.Context, warehouseID string) ([]*model.BinStock, error) {
+	return scanBinStock(r.session.Query(`SELECT inventory_item_id, location_id, warehouse_id, quantity_decimal FROM bin_stock WHERE warehouse_id = ?`,
+		warehouseID).WithContext(ctx).Iter())
+}
+
+// AdjustBinQuantity adds delta to the quantity of an inventory item held in a
+// bin and returns the new quantity, refusing to take it below zero.
+func (r *BinStockRepository) AdjustBinQuantity(ctx context.Context, inventoryItemID, locationID, warehouseID string, delta model.Quantity) (model.Quantity, error) {
+	for attempt := 0; attempt < maxQuantityUpdateAttempts; attempt++ {
+		var quantity *inf.Dec
+		err := r.session.Query(`SELECT quantity_decimal FROM bin_stock WHERE inventory_item_id = ? AND location_id = ?`,
+			inventoryItemID, locationID).WithContext(ctx).Consistency(gocql.Quorum).Scan(&quantity)
+		if errors.Is(err, gocql.ErrNotFound) {
+			if delta < 0 {
+				return 0, ErrInsufficientStock
+			}
+			applied, err := r.session.Query(`INSERT INTO bin_stock (inventory_item_id, location_id, warehouse_id, quantity_decimal) VALUES (?, ?, ?, ?) IF NOT EXISTS`,
+				inventoryItemID, locationID, warehouseID, decimalFromQuantity(delta)).WithContext(ctx).MapScanCAS(map[string]interface{}{})
+			if err != nil {
+				return 0, err
+			}
+			if applied {
+				return delta, nil
+			}
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		updated := quantityFromDecimal(quantity) + delta
+		if updated < 0 {
+			return 0, ErrInsufficientStock
+		}
+		applied, err := r.session.Query(`UPDATE bin_stock SET quantity_decimal = ? WHERE inventory_item_id = ? AND location_id = ? IF quantity_decimal = ?`,
+			decimalFromQuantity(updated), inventoryItemID, locationID, quantity).WithContext(ctx).ScanCAS(&quantity)
+		if err != nil {
+			return 0, err
+		}
+		if applied {
+			return updated, nil
+		}
+	}
+	return 0, fmt.Errorf("quantity of inventory item %s in bin %s changed concurrently %d times", inventoryItemID, locationID, maxQuantityUpdateAttempts)
+}
+
+func scanBinStock(iter *gocql.Iter) ([]*model.BinStock, error) {
+	var stock []*model.BinStock
+	var itemIDStr, locationIDStr, warehouseIDStr string
+	var quantity *inf.Dec
+	for iter.Scan(&itemIDStr, &locationIDStr, &warehouseIDStr, &quantity) {
+		binStock := &model.BinStock{Quantity: quantityFromDecimal(quantity)}
+		binStock.InventoryItemID, _ = uuid.Parse(itemIDStr)
+		binStock.LocationID, _ = uuid.Parse(locationIDStr)
+		binStock.WarehouseID, _ = uuid.Parse(warehouseIDStr)
+		stock = append(stock, binStock)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return stock, nil
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"github.com/gocql/gocql"
+	"github.com/google/uuid"
+	"gopkg.in/inf.v0"
+	"inventoryService/model"
+	"time"
+)
+
+type CostLayerRepository struct {
+	session *gocql.Session
+}
+
+func NewCostLayerRepository(session *gocql.Session) *CostLayerRepository {
+	return &CostLayerRepository{session: session}
+}
+
+// SaveCostLayer writes a layer as is, creating it or restoring an earlier
+// state of it.
+func (r *CostLayerRepository) SaveCostLayer(ctx context.Context, layer *model.CostLayer) error {
+	return r.session.Query(`INSERT INTO cost_layers (inventory_item_id, received_at, id, stock_movement_id, unit_cost_amount, currency, received_quantity_decimal, remaining_quantity_decimal) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		layer.InventoryItemID.String(), layer.ReceivedAt, layer.ID.String(), layer.StockMovementID.String(), decimalFromMoney(layer.UnitCost), layer.UnitCost.CurrencyCode,
+		decimalFromQuantity(layer.ReceivedQuantity), decimalFromQuantity(layer.RemainingQuantity)).WithContext(ctx).Exec()
+}
+
+// ListCostLayers returns the layers of an inventory item, oldest first.
+func (r *CostLayerRepository) ListCostLayers(ctx context.Context, inventoryItemID string) ([]*model.CostLayer, error) {
+	var layers []*model.CostLayer
+	iter := r.session.Query(`SELECT inventory_item_id, received_at, id, stock_movement_id, unit_cost_amount, currency, received_quantity_decimal, remaining_quantity_decimal FROM cost_layers WHERE inventory_item_id = ?`,
+		inventoryItemID).WithContext(ctx).Consistency(gocql.Quorum).Iter()
+	var itemIDStr, idStr, movementIDStr, currency string
+	var receivedAt time.Time
+	var unitCost, receivedQuantity, remainingQuantity *inf.Dec
+	for iter.Scan(&itemIDStr, &receivedAt, &idStr, &movementIDStr, &unitCost, &currency, &receivedQuantity, &remainingQuantity) {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			continue
+		}
+		layer := &model.CostLayer{
+			ID:                id,
+			UnitCost:          moneyFromDecimal(unitCost, currency),
+			ReceivedQuantity:  quantityFromDecimal(receivedQuantity),
+			RemainingQuantity: quantityFromDecimal(remainingQuantity),
+			ReceivedAt:        receivedAt,
+		}
+		layer.InventoryItemID, _ = uuid.Parse(itemIDStr)
+		layer.StockMovementID, _ = uuid.Parse(movementIDStr)
+		layers = append(layers, layer)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return layers, nil
+}
+
+// UpdateCostLayer writes the new state of a layer if it is still in its
+// previous state, reporting whether it was.
+func (r *CostLayerRepository) UpdateCostLayer(ctx context.Context, layer, previous *model.CostLayer) (bool, error) {
+	return r.session.Query(`UPDATE cost_layers SET stock_movement_id = ?, unit_cost_amount = ?, currency = ?, received_quantity_decimal = ?, remaining_quantity_decimal = ? WHERE inventory_item_id = ? AND received_at = ? AND id = ? IF unit_cost_amount = ? AND remaining_quantity_decimal = ?`,
+		layer.StockMovementID.String(), decimalFromMoney(layer.UnitCost), layer.UnitCost.CurrencyCode, decimalFromQuantity(layer.ReceivedQuantity), decimalFromQuantity(layer.RemainingQuantity),
+		layer.InventoryItemID.String(), layer.ReceivedAt, layer.ID.String(),
+		decimalFromMoney(previous.UnitCost), decimalFromQuantity(previous.RemainingQuantity)).WithContext(ctx).MapScanCAS(map[string]interface{}{})
+}
+
+func (r *CostLayerRepository) DeleteCostLayer(ctx context.Context, layer *model.CostLayer) error {
+	return r.session.Query(`DELETE FROM cost_layers WHERE inventory_item_id = ? AND received_at = ? AND id = ?`,
+		layer.InventoryItemID.String(), layer.ReceivedAt, layer.ID.String()).WithContext(ctx).Exec()
+}
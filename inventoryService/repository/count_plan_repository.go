@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/gocql/gocql"
+	"github.com/google/uuid"
+	"inventoryService/model"
+	"time"
+)
+
+type CountPlanRepository struct {
+	session *gocql.Session
+}
+
+var ErrCountPlanNotFound = errors.New("count plan not found")
+
+func NewCountPlanRepository(session *gocql.Session) *CountPlanRepository {
+	return &CountPlanRepository{session: session}
+}
+
+func (r *CountPlanRepository) CreateCountPlan(ctx context.Context, plan *model.CountPlan) error {
+	return r.session.Query(`INSERT INTO count_plans (id, warehouse_id, name, class_a_interval_days, class_b_interval_days, class_c_interval_days, blind, active, created_at, updated_at, last_run_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		plan.ID.String(), plan.WarehouseID.String(), plan.Name, plan.ClassAIntervalDays, plan.ClassBIntervalDays, plan.ClassCIntervalDays,
+		plan.Blind, plan.Active, plan.CreatedAt, plan.UpdatedAt, plan.LastRunAt).WithContext(ctx).Exec()
+}
+
+func (r *CountPlanRepository) GetCountPlan(ctx context.Context, id string) (*model.CountPlan, error) {
+	var idStr, warehouseIDStr string
+	plan := &model.CountPlan{}
+	err := r.session.Query(`SELECT id, warehouse_id, name, class_a_interval_days, class_b_interval_days, class_c_interval_days, blind, active, created_at, updated_at, last_run_at FROM count_plans WHERE id = ? LIMIT 1`,
+		id).WithContext(ctx).Consistency(gocql.One).Scan(&idStr, &warehouseIDStr, &plan.Name, &plan.ClassAIntervalDays, &plan.ClassBIntervalDays,
+		&plan.ClassCIntervalDays, &plan.Blind, &plan.Active, &plan.CreatedAt, &plan.UpdatedAt, &plan.LastRunAt)
+	if err != nil {
+		if errors.Is(err, gocql.ErrNotFound) {
+			return nil, ErrCountPlanNotFound
+		}
+		return nil, err
+	}
+	plan.ID, _ = uuid.Parse(idStr)
+	plan.WarehouseID, _ = uuid.Parse(warehouseIDStr)
+	return plan, nil
+}
+
+func (r *CountPlanRepository) ListCountPlans(ctx context.Context) ([]*model.CountPlan, error) {
+	var plans []*model.CountPlan
+	iter := r.session.Query(`SELECT id, warehouse_id, name, class_a_interval_days, class_b_interval_days, class_c_interval_days, blind, active, created_at, updated_at, last_run_at FROM count_plans`).WithContext(ctx).Iter()
+	var idStr, warehouseIDStr, name string
+	var classA, classB, classC int
+	var blind, active bool
+	var createdAt, updatedAt, lastRunAt time.Time
+	for iter.Scan(&idStr, &warehouseIDStr, &name, &classA, &classB, &classC, &blind, &active, &createdAt, &updatedAt, &lastRunAt) {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			continue
+		}
+		warehouseID, _ := uuid.Parse(warehouseIDStr)
+		plans = append(plans, &model.CountPlan{
+			ID:                 id,
+			WarehouseID:        warehouseID,
+			Name:               name,
+			ClassAIntervalDays: classA,
+			ClassBIntervalDays: classB,
+			ClassCIntervalDays: classC,
+			Blind:              blind,
+			Active:             active,
+			CreatedAt:          createdAt,
+			UpdatedAt:          updatedAt,
+			LastRunAt:          lastRunAt,
+		})
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return plans, nil
+}
+
+func (r *CountPlanRepository) UpdateCountPlan(ctx context.Context, plan *model.CountPlan) error {
+	return r.session.Query(`UPDATE count_plans SET name = ?, class_a_interval_days = ?, class_b_interval_days = ?, class_c_interval_days = ?, blind = ?, active = ?, updated_at = ? WHERE id = ?`,
+		plan.Name, plan.ClassAIntervalDays, plan.ClassBIntervalDays, plan.ClassCIntervalDays, plan.Blind, plan.Active, plan.UpdatedAt, plan.ID.String()).WithContext(ctx).Exec()
+}
+
+func (r *CountPlanRepository) SetLastRunAt(ctx context.Context, id string, lastRunAt time.Time) error {
+	return r.session.Query(`UPDATE count_plans SET last_run_at = ? WHERE id = ?`, lastRunAt, id).WithContext(ctx).Exec()
+}
+
+func (r *CountPlanRepository) DeleteCountPlan(ctx context.Context, id string) error {
+	return r.session.Query(`DELETE FROM count_plans WHERE id = ?`, id).WithContext(ctx).Exec()
+}
@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/gocql/gocql"
+	"github.com/google/uuid"
+	"gopkg.in/inf.v0"
+	"inventoryService/model"
+	"time"
+)
+
+type CountSessionRepository struct {
+	session *gocql.Session
+}
+
+var ErrCountSessionNotFound = errors.New("count session not found")
+
+func NewCountSessionRepository(session *gocql.Session) *CountSessionRepository {
+	return &CountSessionRepository{session: session}
+}
+
+func (r *CountSessionRepository) CreateCountSession(ctx context.Context, countSession *model.CountSession) error {
+	batch := r.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	batch.Query(`INSERT INTO count_sessions (id, warehouse_id, category_id, plan_id, blind, status, notes, created_by, created_at, updated_at, submitted_at, approved_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		countSession.ID.String(), countSession.WarehouseID.String(), countSession.CategoryID.String(), countSession.PlanID.String(),
+		countSession.Blind, countSession.Status, countSession.Notes, countSession.CreatedBy, countSession.CreatedAt, countSession.UpdatedAt,
+		countSession.SubmittedAt, countSession.ApprovedAt)
+	for _, line := range countSession.Lines {
+		addCountLine(batch, countSession.ID, line)
+	}
+	return r.session.ExecuteBatch(batch)
+}
+
+func (r *CountSessionRepository) GetCountSession(ctx context.Context, id string) (*model.CountSession, error) {
+	var idStr, warehouseIDStr, categoryIDStr, planIDStr string
+	countSession := &model.CountSession{}
+	err := r.session.Query(`SELECT id, warehouse_id, category_id, plan_id, blind, status, notes, created_by, created_at, updated_at, submitted_at, approved_at FROM count_sessions WHERE id = ? LIMIT 1`,
+		id).WithContext(ctx).Consistency(gocql.One).Scan(&idStr, &warehouseIDStr, &categoryIDStr, &planIDStr, &countSession.Blind,
+		&countSession.Status, &countSession.Notes, &countSession.CreatedBy, &countSession.CreatedAt, &countSession.UpdatedAt,
+		&countSession.SubmittedAt, &countSession.ApprovedAt)
+	if err != nil {
+		if errors.Is(err, gocql.ErrNotFound) {
+			return nil, ErrCountSessionNotFound
+		}
+		return nil, err
+	}
+	countSession.ID, _ = uuid.Parse(idStr)
+	countSession.WarehouseID, _ = uuid.Parse(warehouseIDStr)
+	countSession.CategoryID, _ = uuid.Parse(categoryIDStr)
+	countSession.PlanID, _ = uuid.Parse(planIDStr)
+	countSession.Lines, err = r.listCountLines(ctx, idStr)
+	if err != nil {
+		return nil, err
+	}
+	return countSession, nil
+}
+
+func (r *CountSessionRepository) ListCountSessions(ctx context.Context) ([]*model.CountSession, error) {
+	var countSessions []*model.CountSession
+	iter := r.session.Query(`SELECT id, warehouse_id, category_id, plan_id, blind, status, notes, created_by, created_at, updated_at, submitted_at, approved_at FROM count_sessions`).WithContext(ctx).Iter()
+	var idStr, warehouseIDStr, categoryIDStr, planIDStr, notes, createdBy string
+	var blind bool
+	var sessionStatus model.CountSessionStatus
+	var createdAt, updatedAt, submittedAt, approvedAt time.Time
+	for iter.Scan(&idStr, &warehouseIDStr, &categoryIDStr, &planIDStr, &blind, &sessionStatus, &notes, &createdBy, &createdAt, &updatedAt, &submittedAt, &approvedAt) {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			continue
+		}
+		countSession := &model.CountSession{
+			ID:          id,
+			Blind:       blind,
+			Status:      sessionStatus,
+			Notes:       notes,
+			CreatedBy:   createdBy,
+			CreatedAt:   createdAt,
+			UpdatedAt:   updatedAt,
+			SubmittedAt: submittedAt,
+			ApprovedAt:  approvedAt,
+		}
+		countSession.WarehouseID, _ = uuid.Parse(warehouseIDStr)
+		countSession.CategoryID, _ = uuid.Parse(categoryIDStr)
+		countSession.PlanID, _ = uuid.Parse(planIDStr)
+		countSessions = append(countSessions, countSession)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	for _, countSession := range countSessions {
+		lines, err := r.listCountLines(ctx, countSession.ID.String())
+		if err != nil {
+			return nil, err
+		}
+		countSession.Lines = lines
+	}
+	return countSessions, nil
+}
+
+// SaveCountLines stores the given lines of a session.
+func (r *CountSessionRepository) SaveCountLines(ctx context.Context, countSessionID uuid.UUID, lines []*model.CountLine, updatedAt time.Time) error {
+	batch := r.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	batch.Query(`UPDATE count_sessions SET updated_at = ? WHERE id = ?`, updatedAt, countSessionID.String())
+	for _, line := range lines {
+		addCountLine(batch, countSessionID, line)
+	}
+	return r.session.ExecuteBatch(batch)
+}
+
+// SaveProgress stores the status and timestamps of a session together with
+// all of its lines.
+func (r *CountSessionRepository) SaveProgress(ctx context.Context, countSession *model.CountSession) error {
+	batch := r.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	batch.Query(`UPDATE count_sessions SET status = ?, updated_at = ?, submitted_at = ?, approved_at = ? WHERE id = ?`,
+		countSession.Status, countSession.UpdatedAt, countSession.SubmittedAt, countSession.ApprovedAt, countSession.ID.String())
+	for _, line := range countSession.Lines {
+		addCountLine(batch, countSession.ID, line)
+	}
+	return r.session.ExecuteBatch(batch)
+}
+
+func (r *CountSessionRepository) listCountLines(ctx context.Context, countSessionID string) ([]*model.CountLine, error) {
+	var lines []*model.CountLine
+	iter := r.session.Query(`SELECT inventory_item_id, product_id, expected_quantity_decimal, counted_quantity_decimal, counted, counted_by, counted_at, stock_adjustment_id FROM count_session_lines WHERE session_id = ?`,
+		countSessionID).WithContext(ctx).Iter()
+	var itemIDStr, productIDStr, countedBy, adjustmentIDStr string
+	var expected, countedQuantity *inf.Dec
+	var counted bool
+	var countedAt time.Time
+	for iter.Scan(&itemIDStr, &productIDStr, &expected, &countedQuantity, &counted, &countedBy, &countedAt, &adjustmentIDStr) {
+		itemID, err := uuid.Parse(itemIDStr)
+		if err != nil {
+			continue
+		}
+		line := &model.CountLine{
+			InventoryItemID:  itemID,
+			ExpectedQuantity: quantityFromDecimal(expected),
+			CountedQuantity:  quantityFromDecimal(countedQuantity),
+			Counted:          counted,
+			CountedBy:        countedBy,
+			CountedAt:        countedAt,
+		}
+		line.ProductID, _ = uuid.Parse(productIDStr)
+		line.StockAdjustmentID, _ = uuid.Parse(adjustmentIDStr)
+		lines = append(lines, line)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+func addCountLine(batch *gocql.Batch, countSessionID uuid.UUID, line *model.CountLine) {
+	batch.Query(`INSERT INTO count_session_lines (session_id, inventory_item_id, product_id, expected_quantity_decimal, counted_quantity_decimal, counted, counted_by, counted_at, stock_adjustment_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		countSessionID.String(), line.InventoryItemID.String(), line.ProductID.String(), decimalFromQuantity(line.ExpectedQuantity), decimalFromQuantity(line.CountedQuantity),
+		line.Counted, line.CountedBy, line.CountedAt, line.StockAdjustmentID.String())
+}
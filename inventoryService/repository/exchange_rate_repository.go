@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/gocql/gocql"
+	"gopkg.in/inf.v0"
+	"inventoryService/model"
+	"time"
+)
+
+type ExchangeRateRepository struct {
+	session *gocql.Session
+}
+
+var ErrExchangeRateNotFound = errors.New("exchange rate not found")
+
+func NewExchangeRateRepository(session *gocql.Session) *ExchangeRateRepository {
+	return &ExchangeRateRepository{session: session}
+}
+
+// SaveExchangeRate writes a rate, replacing the rate of the pair effective
+// from the same time.
+func (r *ExchangeRateRepository) SaveExchangeRate(ctx context.Context, rate *model.ExchangeRate) error {
+	value, ok := new(inf.Dec).SetString(rate.Rate)
+	if !ok {
+		return errors.New("invalid exchange rate " + rate.Rate)
+	}
+	return r.session.Query(`INSERT INTO exchange_rates (from_currency, to_currency, effective_from, rate) VALUES (?, ?, ?, ?)`,
+		rate.FromCurrency, rate.ToCurrency, rate.EffectiveFrom, value).WithContext(ctx).Exec()
+}
+
+// GetExchangeRate returns the rate of a pair of currencies in effect at t.
+func (r *ExchangeRateRepository) GetExchangeRate(ctx context.Context, fromCurrency, toCurrency string, t time.Time) (*model.ExchangeRate, error) {
+	rate := &model.ExchangeRate{FromCurrency: fromCurrency, ToCurrency: toCurrency}
+	var value *inf.Dec
+	err := r.session.Query(`SELECT effective_from, rate FROM exchange_rates WHERE from_currency = ? AND to_currency = ? AND effective_from <= ? LIMIT 1`,
+		fromCurrency, toCurrency, t).WithContext(ctx).Consistency(gocql.One).Scan(&rate.EffectiveFrom, &value)
+	if err != nil {
+		if errors.Is(err, gocql.ErrNotFound) {
+			return nil, ErrExchangeRateNotFound
+		}
+		return nil, err
+	}
+	rate.Rate = value.String()
+	return rate, nil
+}
+
+// ListExchangeRates returns every rate, newest first within each pair.
+func (r *ExchangeRateRepository) ListExchangeRates(ctx context.Context) ([]*model.ExchangeRate, error) {
+	var rates []*model.ExchangeRate
+	iter := r.session.Query(`SELECT from_currency, to_currency, effective_from, rate FROM exchange_rates`).WithContext(ctx).Iter()
+	var fromCurrency, toCurrency string
+	var effectiveFrom time.Time
+	var value *inf.Dec
+	for iter.Scan(&fromCurrency, &toCurrency, &effectiveFrom, &value) {
+		if value == nil {
+			continue
+		}
+		rates = append(rates, &model.ExchangeRate{
+			FromCurrency:  fromCurrency,
+			ToCurrency:    toCurrency,
+			Rate:          value.String(),
+			EffectiveFrom: effectiveFrom,
+		})
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
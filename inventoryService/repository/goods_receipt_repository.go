@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/gocql/gocql"
+	"github.com/google/uuid"
+	"gopkg.in/inf.v0"
+	"inventoryService/model"
+	"time"
+)
+
+type GoodsReceiptRepository struct {
+	session *gocql.Session
+}
+
+var ErrGoodsReceiptNotFound = errors.New("goods receipt not found")
+
+func NewGoodsReceiptRepository(session *gocql.Session) *GoodsReceiptRepository {
+	return &GoodsReceiptRepository{session: session}
+}
+
+func (r *GoodsReceiptRepository) CreateGoodsReceipt(ctx context.Context, receipt *model.GoodsReceipt) error {
+	batch := r.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	batch.Query(`INSERT INTO goods_receipts (id, purchase_order_id, supplier_id, received_at, notes) VALUES (?, ?, ?, ?, ?)`,
+		receipt.ID.String(), receipt.PurchaseOrderID.String(), receipt.SupplierID.String(), receipt.ReceivedAt, receipt.Notes)
+	for _, line := range receipt.Lines {
+		batch.Query(`INSERT INTO goods_receipt_lines (receipt_id, purchase_order_line_id, product_id, warehouse_id, received_quantity_decimal, damaged_quantity_decimal, accepted_quantity_decimal, variance_decimal, stock_movement_id, notes, serial_numbers, posted) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			receipt.ID.String(), line.PurchaseOrderLineID.String(), line.ProductID.String(), line.WarehouseID.String(),
+			decimalFromQuantity(line.ReceivedQuantity), decimalFromQuantity(line.DamagedQuantity), decimalFromQuantity(line.AcceptedQuantity), decimalFromQuantity(line.Variance), line.StockMovementID.String(), line.Notes, line.SerialNumbers, line.Posted)
+	}
+	return r.session.ExecuteBatch(batch)
+}
+
+// SaveLineReceived marks a line of a receipt as posted and records the new
+// received total of its purchase order line, together.
+func (r *GoodsReceiptRepository) SaveLineReceived(ctx context.Context, receipt *model.GoodsReceipt, line *model.GoodsReceiptLine, receivedQuantity model.Quantity) error {
+	batch := r.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	batch.Query(`UPDATE goods_receipt_lines SET posted = ? WHERE receipt_id = ? AND purchase_order_line_id = ?`,
+		true, receipt.ID.String(), line.PurchaseOrderLineID.String())
+	batch.Query(`UPDATE purchase_order_lines SET received_quantity_decimal = ? WHERE purchase_order_id = ? AND id = ?`,
+		decimalFromQuantity(receivedQuantity), receipt.PurchaseOrderID.String(), line.PurchaseOrderLineID.String())
+	return r.session.ExecuteBatch(batch)
+}
+
+func (r *GoodsReceiptRepository) GetGoodsReceipt(ctx context.Context, id string) (*model.GoodsReceipt, error) {
+	var idStr, purchaseOrderIDStr, supplierIDStr string
+	receipt := &model.GoodsReceipt{}
+	err := r.session.Query(`SELECT id, purchase_order_id, supplier_id, received_at, notes FROM goods_receipts WHERE id = ? LIMIT 1`,
+		id).WithContext(ctx).Consistency(gocql.One).Scan(&idStr, &purchaseOrderIDStr, &supplierIDStr, &receipt.ReceivedAt, &receipt.Notes)
+	if err != nil {
+		if errors.Is(err, gocql.ErrNotFound) {
+			return nil, ErrGoodsReceiptNotFound
+		}
+		return nil, err
+	}
+	receipt.ID, _ = uuid.Parse(idStr)
+	receipt.PurchaseOrderID, _ = uuid.Parse(purchaseOrderIDStr)
+	receipt.SupplierID, _ = uuid.Parse(supplierIDStr)
+	receipt.Lines, err = r.listGoodsReceiptLines(ctx, idStr)
+	if err != nil {
+		return nil, err
+	}
+	return receipt, nil
+}
+
+func (r *GoodsReceiptRepository) ListGoodsReceipts(ctx context.Context) ([]*model.GoodsReceipt, error) {
+	var receipts []*model.GoodsReceipt
+	iter := r.session.Query(`SELECT id, purchase_order_id, supplier_id, received_at, notes FROM goods_receipts`).WithContext(ctx).Iter()
+	var idStr, purchaseOrderIDStr, supplierIDStr, notes string
+	var receivedAt time.Time
+	for iter.Scan(&idStr, &purchaseOrderIDStr, &supplierIDStr, &receivedAt, &notes) {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			continue
+		}
+		purchaseOrderID, _ := uuid.Parse(purchaseOrderIDStr)
+		supplierID, _ := uuid.Parse(supplierIDStr)
+		receipts = append(receipts, &model.GoodsReceipt{
+			ID:              id,
+			PurchaseOrderID: purchaseOrderID,
+			SupplierID:      supplierID,
+			ReceivedAt:      receivedAt,
+			Notes:           notes,
+		})
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	for _, receipt := range receipts {
+		lines, err := r.listGoodsReceiptLines(ctx, receipt.ID.String())
+		if err != nil {
+			return nil, err
+		}
+		receipt.Lines = lines
+	}
+	return receipts, nil
+}
+
+func (r *GoodsReceiptRepository) listGoodsReceiptLines(ctx context.Context, receiptID string) ([]*model.GoodsReceiptLine, error) {
+	var lines []*model.GoodsReceiptLine
+	iter := r.session.Query(`SELECT purchase_order_line_id, product_id, warehouse_id, received_quantity_decimal, damaged_quantity_decimal, accepted_quantity_decimal, variance_decimal, stock_movement_id, notes, serial_numbers, posted FROM goods_receipt_lines WHERE receipt_id = ?`,
+		receiptID).WithContext(ctx).Iter()
+	var lineIDStr, productIDStr, warehouseIDStr, movementIDStr, notes string
+	var received, damaged, accepted, variance *inf.Dec
+	var serialNumbers []string
+	var posted bool
+	for iter.Scan(&lineIDStr, &productIDStr, &warehouseIDStr, &received, &damaged, &accepted, &variance, &movementIDStr, &notes, &serialNumbers, &posted) {
+		lineID, err := uuid.Parse(lineIDStr)
+		if err != nil {
+			continue
+		}
+		line := &model.GoodsReceiptLine{
+			PurchaseOrderLineID: lineID,
+			ReceivedQuantity:    quantityFromDecimal(received),
+			DamagedQuantity:     quantityFromDecimal(damaged),
+			AcceptedQuantity:    quantityFromDecimal(accepted),
+			Variance:            quantityFromDecimal(variance),
+			Notes:               notes,
+			SerialNumbers:       serialNumbers,
+			Posted:              posted,
+		}
+		line.ProductID, _ = uuid.Parse(productIDStr)
+		line.WarehouseID, _ = uuid.Parse(warehouseIDStr)
+		line.StockMovementID, _ = uuid.Parse(movementIDStr)
+		lines = append(lines, line)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
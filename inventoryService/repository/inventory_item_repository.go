@@ -3,8 +3,10 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/gocql/gocql"
 	"github.com/google/uuid"
+	"gopkg.in/inf.v0"
 	"inventoryService/model"
 )
 
@@ -16,24 +18,76 @@ func NewInventoryItemRepository(session *gocql.Session) *InventoryItemRepository
 	return &InventoryItemRepository{session: session}
 }
 
-func (r *InventoryItemRepository) CreateInventoryItem(ctx context.Context, item *model.InventoryItem) error {
-	return r.session.Query(`INSERT INTO inventory_items (id, product_id, warehouse_id, quantity, reorder_level, reorder_quantity) VALUES (?, ?, ?, ?, ?, ?)`,
-		item.ID.String(), item.ProductID.String(), item.WarehouseID.String(), item.Quantity, item.ReorderLevel, item.ReorderQuantity).WithContext(ctx).Exec()
+var ErrInventoryItemExists = errors.New("inventory item already exists")
+
+// CreateInventoryItem saves a new item. A product has one item per warehouse:
+// the pair is claimed for the item first, and when another item holds it
+// ErrInventoryItemExists is returned along with the id of that item.
+func (r *InventoryItemRepository) CreateInventoryItem(ctx context.Context, item *model.InventoryItem) (uuid.UUID, error) {
+	applied, holder, err := r.claimProductWarehouse(ctx, item)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if !applied {
+		return holder, ErrInventoryItemExists
+	}
+	return item.ID, r.session.Query(`INSERT INTO inventory_items (id, product_id, warehouse_id, quantity_decimal, reorder_level_decimal, reorder_quantity_decimal, reserved_quantity_decimal, quarantined_quantity_decimal) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		item.ID.String(), item.ProductID.String(), item.WarehouseID.String(), decimalFromQuantity(item.Quantity), decimalFromQuantity(item.ReorderLevel), decimalFromQuantity(item.ReorderQuantity), decimalFromQuantity(item.ReservedQuantity), decimalFromQuantity(item.QuarantinedQuantity)).WithContext(ctx).Exec()
+}
+
+// RestoreInventoryItem saves an empty item under an id already claimed for its
+// product and warehouse, unless the item exists. It finishes a creation that
+// claimed the pair but failed before saving the item.
+func (r *InventoryItemRepository) RestoreInventoryItem(ctx context.Context, item *model.InventoryItem) error {
+	_, err := r.session.Query(`INSERT INTO inventory_items (id, product_id, warehouse_id, quantity_decimal, reorder_level_decimal, reorder_quantity_decimal, reserved_quantity_decimal, quarantined_quantity_decimal) VALUES (?, ?, ?, ?, ?, ?, ?, ?) IF NOT EXISTS`,
+		item.ID.String(), item.ProductID.String(), item.WarehouseID.String(), decimalFromQuantity(0), decimalFromQuantity(0), decimalFromQuantity(0), decimalFromQuantity(0), decimalFromQuantity(0)).WithContext(ctx).MapScanCAS(map[string]interface{}{})
+	return err
+}
+
+// claimProductWarehouse records item as the item of its product in its
+// warehouse. When another item holds them it reports false and that item.
+func (r *InventoryItemRepository) claimProductWarehouse(ctx context.Context, item *model.InventoryItem) (bool, uuid.UUID, error) {
+	current := map[string]interface{}{}
+	applied, err := r.session.Query(`INSERT INTO inventory_items_by_product_warehouse (product_id, warehouse_id, inventory_item_id) VALUES (?, ?, ?) IF NOT EXISTS`,
+		item.ProductID.String(), item.WarehouseID.String(), item.ID.String()).WithContext(ctx).MapScanCAS(current)
+	if err != nil || applied {
+		return applied, uuid.Nil, err
+	}
+	holder, _ := current["inventory_item_id"].(gocql.UUID)
+	if uuid.UUID(holder) == item.ID {
+		return true, uuid.Nil, nil
+	}
+	return false, uuid.UUID(holder), nil
+}
+
+// releaseProductWarehouse drops the claim of item on its product and
+// warehouse, if it still holds them.
+func (r *InventoryItemRepository) releaseProductWarehouse(ctx context.Context, item *model.InventoryItem) error {
+	_, err := r.session.Query(`DELETE FROM inventory_items_by_product_warehouse WHERE product_id = ? AND warehouse_id = ? IF inventory_item_id = ?`,
+		item.ProductID.String(), item.WarehouseID.String(), item.ID.String()).WithContext(ctx).MapScanCAS(map[string]interface{}{})
+	return err
 }
 
 var ErrInventoryItemNotFound = errors.New("inventory item not found")
 
 func (r *InventoryItemRepository) GetInventoryItem(ctx context.Context, id string) (*model.InventoryItem, error) {
 	var idStr, productIdStr, warehouseIdStr string
-	item := &model.InventoryItem{}
-	err := r.session.Query(`SELECT id, product_id, warehouse_id, quantity, reorder_level, reorder_quantity FROM inventory_items WHERE id = ? LIMIT 1`,
-		id).WithContext(ctx).Consistency(gocql.One).Scan(&idStr, &productIdStr, &warehouseIdStr, &item.Quantity, &item.ReorderLevel, &item.ReorderQuantity)
+	var quantity, reorderLevel, reorderQuantity, reservedQuantity, quarantinedQuantity *inf.Dec
+	err := r.session.Query(`SELECT id, product_id, warehouse_id, quantity_decimal, reorder_level_decimal, reorder_quantity_decimal, reserved_quantity_decimal, quarantined_quantity_decimal FROM inventory_items WHERE id = ? LIMIT 1`,
+		id).WithContext(ctx).Consistency(gocql.One).Scan(&idStr, &productIdStr, &warehouseIdStr, &quantity, &reorderLevel, &reorderQuantity, &reservedQuantity, &quarantinedQuantity)
 	if err != nil {
 		if errors.Is(err, gocql.ErrNotFound) {
 			return nil, ErrInventoryItemNotFound
 		}
 		return nil, err
 	}
+	item := &model.InventoryItem{
+		Quantity:            quantityFromDecimal(quantity),
+		ReorderLevel:        quantityFromDecimal(reorderLevel),
+		ReorderQuantity:     quantityFromDecimal(reorderQuantity),
+		ReservedQuantity:    quantityFromDecimal(reservedQuantity),
+		QuarantinedQuantity: quantityFromDecimal(quarantinedQuantity),
+	}
 	item.ID, _ = uuid.Parse(idStr)
 	item.ProductID, _ = uuid.Parse(productIdStr)
 	item.WarehouseID, _ = uuid.Parse(warehouseIdStr)
@@ -42,10 +96,21 @@ func (r *InventoryItemRepository) GetInventoryItem(ctx context.Context, id strin
 
 func (r *InventoryItemRepository) ListInventoryItems(ctx context.Context) ([]*model.InventoryItem, error) {
 	var items []*model.InventoryItem
-	iter := r.session.Query(`SELECT id, product_id, warehouse_id, quantity, reorder_level, reorder_quantity FROM inventory_items`).WithContext(ctx).Iter()
-	var item model.InventoryItem
-	for iter.Scan(&item.ID, &item.ProductID, &item.WarehouseID, &item.Quantity, &item.ReorderLevel, &item.ReorderQuantity) {
-		items = append(items, &item)
+	iter := r.session.Query(`SELECT id, product_id, warehouse_id, quantity_decimal, reorder_level_decimal, reorder_quantity_decimal, reserved_quantity_decimal, quarantined_quantity_decimal FROM inventory_items`).WithContext(ctx).Iter()
+	var idStr, productIdStr, warehouseIdStr string
+	var quantity, reorderLevel, reorderQuantity, reservedQuantity, quarantinedQuantity *inf.Dec
+	for iter.Scan(&idStr, &productIdStr, &warehouseIdStr, &quantity, &reorderLevel, &reorderQuantity, &reservedQuantity, &quarantinedQuantity) {
+		item := &model.InventoryItem{
+			Quantity:            quantityFromDecimal(quantity),
+			ReorderLevel:        quantityFromDecimal(reorderLevel),
+			ReorderQuantity:     quantityFromDecimal(reorderQuantity),
+			ReservedQuantity:    quantityFromDecimal(reservedQuantity),
+			QuarantinedQuantity: quantityFromDecimal(quarantinedQuantity),
+		}
+		item.ID, _ = uuid.Parse(idStr)
+		item.ProductID, _ = uuid.Parse(productIdStr)
+		item.WarehouseID, _ = uuid.Parse(warehouseIdStr)
+		items = append(items, item)
 	}
 	if err := iter.Close(); err != nil {
 		return nil, err
@@ -53,11 +118,185 @@ func (r *InventoryItemRepository) ListInventoryItems(ctx context.Context) ([]*mo
 	return items, nil
 }
 
-func (r *InventoryItemRepository) UpdateInventoryItem(ctx context.Context, item *model.InventoryItem) error {
-	return r.session.Query(`UPDATE inventory_items SET product_id = ?, warehouse_id = ?, quantity = ?, reorder_level = ?, reorder_quantity = ? WHERE id = ?`,
-		item.ProductID.String(), item.WarehouseID.String(), item.Quantity, item.ReorderLevel, item.ReorderQuantity, item.ID.String()).WithContext(ctx).Exec()
+// UpdateInventoryItem saves the product, warehouse and reorder settings of an
+// item, as it was before. Its quantities are left alone; they change through
+// updateStock and the other compare-and-set updates only. A new product or
+// warehouse is claimed for the item first, returning ErrInventoryItemExists
+// when another item holds them, and the old pair is released afterwards.
+func (r *InventoryItemRepository) UpdateInventoryItem(ctx context.Context, before, item *model.InventoryItem) error {
+	moved := item.ProductID != before.ProductID || item.WarehouseID != before.WarehouseID
+	if moved {
+		applied, _, err := r.claimProductWarehouse(ctx, item)
+		if err != nil {
+			return err
+		}
+		if !applied {
+			return ErrInventoryItemExists
+		}
+	}
+	err := r.session.Query(`UPDATE inventory_items SET product_id = ?, warehouse_id = ?, reorder_level_decimal = ?, reorder_quantity_decimal = ? WHERE id = ?`,
+		item.ProductID.String(), item.WarehouseID.String(), decimalFromQuantity(item.ReorderLevel), decimalFromQuantity(item.ReorderQuantity), item.ID.String()).WithContext(ctx).Exec()
+	if err != nil || !moved {
+		return err
+	}
+	return r.releaseProductWarehouse(ctx, before)
+}
+
+// DeleteInventoryItem deletes an item and then releases its product and
+// warehouse for another item.
+func (r *InventoryItemRepository) DeleteInventoryItem(ctx context.Context, item *model.InventoryItem) error {
+	err := r.session.Query(`DELETE FROM inventory_items WHERE id = ?`, item.ID.String()).WithContext(ctx).Exec()
+	if err != nil {
+		return err
+	}
+	return r.releaseProductWarehouse(ctx, item)
+}
+
+// ListInventoryItemsByProduct returns the inventory items holding a product in
+// every warehouse.
+func (r *InventoryItemRepository) ListInventoryItemsByProduct(ctx context.Context, productID string) ([]*model.InventoryItem, error) {
+	var items []*model.InventoryItem
+	iter := r.session.Query(`SELECT id, product_id, warehouse_id, quantity_decimal, reorder_level_decimal, reorder_quantity_decimal, reserved_quantity_decimal, quarantined_quantity_decimal FROM inventory_items WHERE product_id = ? ALLOW FILTERING`,
+		productID).WithContext(ctx).Iter()
+	var idStr, productIdStr, warehouseIdStr string
+	var quantity, reorderLevel, reorderQuantity, reservedQuantity, quarantinedQuantity *inf.Dec
+	for iter.Scan(&idStr, &productIdStr, &warehouseIdStr, &quantity, &reorderLevel, &reorderQuantity, &reservedQuantity, &quarantinedQuantity) {
+		item := &model.InventoryItem{
+			Quantity:            quantityFromDecimal(quantity),
+			ReorderLevel:        quantityFromDecimal(reorderLevel),
+			ReorderQuantity:     quantityFromDecimal(reorderQuantity),
+			ReservedQuantity:    quantityFromDecimal(reservedQuantity),
+			QuarantinedQuantity: quantityFromDecimal(quarantinedQuantity),
+		}
+		item.ID, _ = uuid.Parse(idStr)
+		item.ProductID, _ = uuid.Parse(productIdStr)
+		item.WarehouseID, _ = uuid.Parse(warehouseIdStr)
+		items = append(items, item)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-func (r *InventoryItemRepository) DeleteInventoryItem(ctx context.Context, id string) error {
-	return r.session.Query(`DELETE FROM inventory_items WHERE id = ?`, id).WithContext(ctx).Exec()
+// FindInventoryItem returns the inventory item holding a product in a
+// warehouse.
+func (r *InventoryItemRepository) FindInventoryItem(ctx context.Context, productID, warehouseID string) (*model.InventoryItem, error) {
+	var idStr string
+	err := r.session.Query(`SELECT inventory_item_id FROM inventory_items_by_product_warehouse WHERE product_id = ? AND warehouse_id = ?`,
+		productID, warehouseID).WithContext(ctx).Scan(&idStr)
+	if err != nil {
+		if errors.Is(err, gocql.ErrNotFound) {
+			return nil, ErrInventoryItemNotFound
+		}
+		return nil, err
+	}
+	return r.GetInventoryItem(ctx, idStr)
+}
+
+var ErrInsufficientStock = errors.New("insufficient stock")
+
+const maxQuantityUpdateAttempts = 10
+
+// AdjustQuantity adds delta to the on-hand quantity of an item and returns the
+// new quantity. It refuses to take the quantity below what is reserved.
+func (r *InventoryItemRepository) AdjustQuantity(ctx context.Context, id string, delta model.Quantity) (model.Quantity, error) {
+	quantity, _, err := r.updateStock(ctx, id, func(quantity, reserved model.Quantity) (model.Quantity, model.Quantity, error) {
+		if quantity+delta < reserved || quantity+delta < 0 {
+			return 0, 0, ErrInsufficientStock
+		}
+		return quantity + delta, reserved, nil
+	})
+	return quantity, err
+}
+
+// AdjustReservation adds delta to the reserved quantity of an item and returns
+// the new reserved quantity. It refuses to reserve more than is on hand;
+// releasing more than is reserved leaves nothing reserved.
+func (r *InventoryItemRepository) AdjustReservation(ctx context.Context, id string, delta model.Quantity) (model.Quantity, error) {
+	_, reserved, err := r.updateStock(ctx, id, func(quantity, reserved model.Quantity) (model.Quantity, model.Quantity, error) {
+		updated := reserved + delta
+		if updated > quantity {
+			return 0, 0, ErrInsufficientStock
+		}
+		if updated < 0 {
+			updated = 0
+		}
+		return quantity, updated, nil
+	})
+	return reserved, err
+}
+
+// ConsumeReservation takes a reserved quantity out of stock, lowering both the
+// on-hand and the reserved quantity of an item.
+func (r *InventoryItemRepository) ConsumeReservation(ctx context.Context, id string, quantity model.Quantity) error {
+	_, _, err := r.updateStock(ctx, id, func(onHand, reserved model.Quantity) (model.Quantity, model.Quantity, error) {
+		if reserved < quantity || onHand < quantity {
+			return 0, 0, ErrInsufficientStock
+		}
+		return onHand - quantity, reserved - quantity, nil
+	})
+	return err
+}
+
+// AdjustQuarantine adds delta to the quarantined quantity of an item and
+// returns the new quarantined quantity, refusing to take it below zero.
+func (r *InventoryItemRepository) AdjustQuarantine(ctx context.Context, id string, delta model.Quantity) (model.Quantity, error) {
+	var quarantined *inf.Dec
+	err := r.session.Query(`SELECT quarantined_quantity_decimal FROM inventory_items WHERE id = ? LIMIT 1`,
+		id).WithContext(ctx).Consistency(gocql.Quorum).Scan(&quarantined)
+	if err != nil {
+		if errors.Is(err, gocql.ErrNotFound) {
+			return 0, ErrInventoryItemNotFound
+		}
+		return 0, err
+	}
+	for attempt := 0; attempt < maxQuantityUpdateAttempts; attempt++ {
+		updated := quantityFromDecimal(quarantined) + delta
+		if updated < 0 {
+			return 0, ErrInsufficientStock
+		}
+		applied, err := r.session.Query(`UPDATE inventory_items SET quarantined_quantity_decimal = ? WHERE id = ? IF quarantined_quantity_decimal = ?`,
+			decimalFromQuantity(updated), id, quarantined).WithContext(ctx).ScanCAS(&quarantined)
+		if err != nil {
+			return 0, err
+		}
+		if applied {
+			return updated, nil
+		}
+	}
+	return 0, fmt.Errorf("quarantined stock of inventory item %s changed concurrently %d times", id, maxQuantityUpdateAttempts)
+}
+
+// updateStock replaces the on-hand and reserved quantities of an item with the
+// result of change using a lightweight transaction, retrying with fresh values
+// when a concurrent update wins.
+func (r *InventoryItemRepository) updateStock(ctx context.Context, id string, change func(quantity, reserved model.Quantity) (model.Quantity, model.Quantity, error)) (model.Quantity, model.Quantity, error) {
+	// The columns are kept as read so that the transaction condition matches
+	// them exactly, including a null reserved quantity on items written before
+	// reservations existed.
+	var quantity, reserved *inf.Dec
+	err := r.session.Query(`SELECT quantity_decimal, reserved_quantity_decimal FROM inventory_items WHERE id = ? LIMIT 1`,
+		id).WithContext(ctx).Consistency(gocql.Quorum).Scan(&quantity, &reserved)
+	if err != nil {
+		if errors.Is(err, gocql.ErrNotFound) {
+			return 0, 0, ErrInventoryItemNotFound
+		}
+		return 0, 0, err
+	}
+	for attempt := 0; attempt < maxQuantityUpdateAttempts; attempt++ {
+		updatedQuantity, updatedReserved, err := change(quantityFromDecimal(quantity), quantityFromDecimal(reserved))
+		if err != nil {
+			return 0, 0, err
+		}
+		applied, err := r.session.Query(`UPDATE inventory_items SET quantity_decimal = ?, reserved_quantity_decimal = ? WHERE id = ? IF quantity_decimal = ? AND reserved_quantity_decimal = ?`,
+			decimalFromQuantity(updatedQuantity), decimalFromQuantity(updatedReserved), id, quantity, reserved).WithContext(ctx).ScanCAS(&quantity, &reserved)
+		if err != nil {
+			return 0, 0, err
+		}
+		if applied {
+			return updatedQuantity, updatedReserved, nil
+		}
+	}
+	return 0, 0, fmt.Errorf("stock of inventory item %s changed concurrently %d times", id, maxQuantityUpdateAttempts)
 }
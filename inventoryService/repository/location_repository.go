@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/gocql/gocql"
+	"github.com/google/uuid"
+	"gopkg.in/inf.v0"
+	"inventoryService/model"
+	"time"
+)
+
+type LocationRepository struct {
+	session *gocql.Session
+}
+
+var ErrLocationNotFound = errors.New("location not found")
+
+func NewLocationRepository(session *gocql.Session) *LocationRepository {
+	return &LocationRepository{session: session}
+}
+
+func (r *LocationRepository) CreateLocation(ctx context.Context, location *model.Location) error {
+	return r.session.Query(`INSERT INTO locations (id, warehouse_id, parent_id, type, code, name, path, max_units_decimal, max_volume, max_weight, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		location.ID.String(), location.WarehouseID.String(), location.ParentID.String(), location.Type, location.Code, location.Name,
+		location.Path, decimalFromQuantity(location.Capacity.MaxUnits), location.Capacity.MaxVolume, location.Capacity.MaxWeight, location.CreatedAt, location.UpdatedAt).WithContext(ctx).Exec()
+}
+
+func (r *LocationRepository) GetLocation(ctx context.Context, id string) (*model.Location, error) {
+	var idStr, warehouseIDStr, parentIDStr string
+	var maxUnits *inf.Dec
+	location := &model.Location{}
+	err := r.session.Query(`SELECT id, warehouse_id, parent_id, type, code, name, path, max_units_decimal, max_volume, max_weight, created_at, updated_at FROM locations WHERE id = ? LIMIT 1`,
+		id).WithContext(ctx).Consistency(gocql.One).Scan(&idStr, &warehouseIDStr, &parentIDStr, &location.Type, &location.Code, &location.Name,
+		&location.Path, &maxUnits, &location.Capacity.MaxVolume, &location.Capacity.MaxWeight, &location.CreatedAt, &location.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, gocql.ErrNotFound) {
+			return nil, ErrLocationNotFound
+		}
+		return nil, err
+	}
+	location.ID, _ = uuid.Parse(idStr)
+	location.WarehouseID, _ = uuid.Parse(warehouseIDStr)
+	location.ParentID, _ = uuid.Parse(parentIDStr)
+	location.Capacity.MaxUnits = quantityFromDecimal(maxUnits)
+	return location, nil
+}
+
+func (r *LocationRepository) ListLocationsByWarehouse(ctx context.Context, warehouseID string) ([]*model.Location, error) {
+	var locations []*model.Location
+	iter := r.session.Query(`SELECT id, warehouse_id, parent_id, type, code, name, path, max_units_decimal, max_volume, max_weight, created_at, updated_at FROM locations WHERE warehouse_id = ?`,
+		warehouseID).WithContext(ctx).Iter()
+	var idStr, warehouseIDStr, parentIDStr, code, name, path string
+	var locationType model.LocationType
+	var capacity model.Capacity
+	var maxUnits *inf.Dec
+	var createdAt, updatedAt time.Time
+	for iter.Scan(&idStr, &warehouseIDStr, &parentIDStr, &locationType, &code, &name, &path, &maxUnits, &capacity.MaxVolume, &capacity.MaxWeight, &createdAt, &updatedAt) {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			continue
+		}
+		capacity.MaxUnits = quantityFromDecimal(maxUnits)
+		location := &model.Location{
+			ID:        id,
+			Type:      locationType,
+			Code:      code,
+			Name:      name,
+			Path:      path,
+			Capacity:  capacity,
+			CreatedAt: createdAt,
+			UpdatedAt: updatedAt,
+		}
+		location.WarehouseID, _ = uuid.Parse(warehouseIDStr)
+		location.ParentID, _ = uuid.Parse(parentIDStr)
+		locations = append(locations, location)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return locations, nil
+}
+
+func (r *LocationRepository) UpdateLocation(ctx context.Context, location *model.Location) error {
+	return r.session.Query(`UPDATE locations SET code = ?, name = ?, path = ?, max_units_decimal = ?, max_volume = ?, max_weight = ?, updated_at = ? WHERE id = ?`,
+		location.Code, location.Name, location.Path, decimalFromQuantity(location.Capacity.MaxUnits), location.Capacity.MaxVolume, location.Capacity.MaxWeight,
+		location.UpdatedAt, location.ID.String()).WithContext(ctx).Exec()
+}
+
+func (r *LocationRepository) DeleteLocation(ctx context.Context, id string) error {
+	return r.session.Query(`DELETE FROM locations WHERE id = ?`, id).WithContext(ctx).Exec()
+}
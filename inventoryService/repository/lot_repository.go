@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gocql/gocql"
+	"github.com/google/uuid"
+	"gopkg.in/inf.v0"
+	"inventoryService/model"
+	"time"
+)
+
+type LotRepository struct {
+	session *gocql.Session
+}
+
+var ErrLotNotFound = errors.New("lot not found")
+
+func NewLotRepository(session *gocql.Session) *LotRepository {
+	return &LotRepository{session: session}
+}
+
+func (r *LotRepository) CreateLot(ctx context.Context, lot *model.Lot) error {
+	return r.session.Query(`INSERT INTO lots (id, inventory_item_id, product_id, warehouse_id, lot_number, manufacture_date, expiry_date, supplier_id, quantity_decimal, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		lot.ID.String(), lot.InventoryItemID.String(), lot.ProductID.String(), lot.WarehouseID.String(), lot.LotNumber,
+		lot.ManufactureDate, lot.ExpiryDate, lot.SupplierID.String(), decimalFromQuantity(lot.Quantity), lot.CreatedAt).WithContext(ctx).Exec()
+}
+
+func (r *LotRepository) GetLot(ctx context.Context, id string) (*model.Lot, error) {
+	var idStr, itemIDStr, productIDStr, warehouseIDStr, supplierIDStr string
+	var quantity *inf.Dec
+	lot := &model.Lot{}
+	err := r.session.Query(`SELECT id, inventory_item_id, product_id, warehouse_id, lot_number, manufacture_date, expiry_date, supplier_id, quantity_decimal, created_at FROM lots WHERE id = ? LIMIT 1`,
+		id).WithContext(ctx).Consistency(gocql.One).Scan(&idStr, &itemIDStr, &productIDStr, &warehouseIDStr, &lot.LotNumber,
+		&lot.ManufactureDate, &lot.ExpiryDate, &supplierIDStr, &quantity, &lot.CreatedAt)
+	if err != nil {
+		if errors.Is(err, gocql.ErrNotFound) {
+			return nil, ErrLotNotFound
+		}
+		return nil, err
+	}
+	lot.ID, _ = uuid.Parse(idStr)
+	lot.Quantity = quantityFromDecimal(quantity)
+	lot.InventoryItemID, _ = uuid.Parse(itemIDStr)
+	lot.ProductID, _ = uuid.Parse(productIDStr)
+	lot.WarehouseID, _ = uuid.Parse(warehouseIDStr)
+	lot.SupplierID, _ = uuid.Parse(supplierIDStr)
+	return lot, nil
+}
+
+func (r *LotRepository) ListLots(ctx context.Context) ([]*model.Lot, error) {
+	return scanLots(r.session.Query(`SELECT id, inventory_item_id, product_id, warehouse_id, lot_number, manufacture_date, expiry_date, supplier_id, quantity_decimal, created_at FROM lots`).WithContext(ctx).Iter())
+}
+
+func (r *LotRepository) ListLotsByInventoryItem(ctx context.Context, inventoryItemID string) ([]*model.Lot, error) {
+	return scanLots(r.session.Query(`SELECT id, inventory_item_id, product_id, warehouse_id, lot_number, manufacture_date, expiry_date, supplier_id, quantity_decimal, created_at FROM lots WHERE inventory_item_id = ?`,
+		inventoryItemID).WithContext(ctx).Iter())
+}
+
+func (r *LotRepository) DeleteLot(ctx context.Context, id string) error {
+	return r.session.Query(`DELETE FROM lots WHERE id = ?`, id).WithContext(ctx).Exec()
+}
+
+// AdjustLotQuantity adds delta to the quantity of a lot and returns the new
+// quantity, refusing to take it below zero.
+func (r *LotRepository) AdjustLotQuantity(ctx context.Context, id string, delta model.Quantity) (model.Quantity, error) {
+	var quantity *inf.Dec
+	err := r.session.Query(`SELECT quantity_decimal FROM lots WHERE id = ? LIMIT 1`,
+		id).WithContext(ctx).Consistency(gocql.Quorum).Scan(&quantity)
+	if err != nil {
+		if errors.Is(err, gocql.ErrNotFound) {
+			return 0, ErrLotNotFound
+		}
+		return 0, err
+	}
+	for attempt := 0; attempt < maxQuantityUpdateAttempts; attempt++ {
+		updated := quantityFromDecimal(quantity) + delta
+		if updated < 0 {
+			return 0, ErrInsufficientStock
+		}
+		applied, err := r.session.Query(`UPDATE lots SET quantity_decimal = ? WHERE id = ? IF quantity_decimal = ?`,
+			decimalFromQuantity(updated), id, quantity).WithContext(ctx).ScanCAS(&quantity)
+		if err != nil {
+			return 0, err
+		}
+		if applied {
+			return updated, nil
+		}
+	}
+	return 0, fmt.Errorf("quantity of lot %s changed concurrently %d times", id, maxQuantityUpdateAttempts)
+}
+
+func scanLots(iter *gocql.Iter) ([]*model.Lot, error) {
+	var lots []*model.Lot
+	var idStr, itemIDStr, productIDStr, warehouseIDStr, lotNumber, supplierIDStr string
+	var manufactureDate, expiryDate, createdAt time.Time
+	var quantity *inf.Dec
+	for iter.Scan(&idStr, &itemIDStr, &productIDStr, &warehouseIDStr, &lotNumber, &manufactureDate, &expiryDate, &supplierIDStr, &quantity, &createdAt) {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			continue
+		}
+		lot := &model.Lot{
+			ID:              id,
+			LotNumber:       lotNumber,
+			ManufactureDate: manufactureDate,
+			ExpiryDate:      expiryDate,
+			Quantity:        quantityFromDecimal(quantity),
+			CreatedAt:       createdAt,
+		}
+		lot.InventoryItemID, _ = uuid.Parse(itemIDStr)
+		lot.ProductID, _ = uuid.Parse(productIDStr)
+		lot.WarehouseID, _ = uuid.Parse(warehouseIDStr)
+		lot.SupplierID, _ = uuid.Parse(supplierIDStr)
+		lots = append(lots, lot)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return lots, nil
+}
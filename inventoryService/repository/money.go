@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"gopkg.in/inf.v0"
+	"inventoryService/model"
+)
+
+// Money is stored as a decimal amount next to a text currency code column.
+// The amounts are written with nine decimals, the precision of model.Money.
+const moneyScale = 9
+
+func decimalFromMoney(m model.Money) *inf.Dec {
+	return inf.NewDecBig(m.TotalNanos(), moneyScale)
+}
+
+// moneyFromDecimal reads an amount back; a null amount is zero.
+func moneyFromDecimal(amount *inf.Dec, currencyCode string) model.Money {
+	if amount == nil {
+		return model.Money{CurrencyCode: currencyCode}
+	}
+	nanos := new(inf.Dec).Round(amount, moneyScale, inf.RoundHalfUp).UnscaledBig()
+	return model.MoneyFromNanos(currencyCode, nanos)
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/gocql/gocql"
+	"github.com/google/uuid"
+	"gopkg.in/inf.v0"
+	"inventoryService/model"
+	"time"
+)
+
+type PriceChangeRepository struct {
+	session *gocql.Session
+}
+
+var ErrPriceChangeNotFound = errors.New("price change not found")
+
+const priceChangeColumns = `product_id, effective_at, id, old_price_amount, old_currency, new_price_amount, new_currency, actor, status, created_at, applied_at`
+
+func NewPriceChangeRepository(session *gocql.Session) *PriceChangeRepository {
+	return &PriceChangeRepository{session: session}
+}
+
+func (r *PriceChangeRepository) CreatePriceChange(ctx context.Context, change *model.PriceChange) error {
+	return r.session.Query(`INSERT INTO price_changes (`+priceChangeColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		change.ProductID.String(), change.EffectiveAt, change.ID.String(),
+		decimalFromMoney(change.OldPrice), change.OldPrice.CurrencyCode, decimalFromMoney(change.NewPrice), change.NewPrice.CurrencyCode,
+		change.Actor, change.Status, change.CreatedAt, change.AppliedAt).WithContext(ctx).Exec()
+}
+
+func (r *PriceChangeRepository) GetPriceChange(ctx context.Context, id string) (*model.PriceChange, error) {
+	iter := r.session.Query(`SELECT `+priceChangeColumns+` FROM price_changes WHERE id = ? LIMIT 1`,
+		id).WithContext(ctx).Consistency(gocql.One).Iter()
+	changes, err := scanPriceChanges(iter)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return nil, ErrPriceChangeNotFound
+	}
+	return changes[0], nil
+}
+
+// ListPriceChangesByProduct returns the price changes of a product, latest
+// effective first.
+func (r *PriceChangeRepository) ListPriceChangesByProduct(ctx context.Context, productID string) ([]*model.PriceChange, error) {
+	iter := r.session.Query(`SELECT `+priceChangeColumns+` FROM price_changes WHERE product_id = ?`,
+		productID).WithContext(ctx).Iter()
+	return scanPriceChanges(iter)
+}
+
+func (r *PriceChangeRepository) ListPriceChangesByStatus(ctx context.Context, status model.PriceChangeStatus) ([]*model.PriceChange, error) {
+	iter := r.session.Query(`SELECT `+priceChangeColumns+` FROM price_changes WHERE status = ?`,
+		status).WithContext(ctx).Iter()
+	return scanPriceChanges(iter)
+}
+
+// UpdatePriceChangeStatus writes the status, old price and application time
+// of a change if it is still in status from, reporting whether it was, so
+// that a scheduled change is applied or cancelled only once.
+func (r *PriceChangeRepository) UpdatePriceChangeStatus(ctx context.Context, change *model.PriceChange, from model.PriceChangeStatus) (bool, error) {
+	return r.session.Query(`UPDATE price_changes SET status = ?, old_price_amount = ?, old_currency = ?, applied_at = ? WHERE product_id = ? AND effective_at = ? AND id = ? IF status = ?`,
+		change.Status, decimalFromMoney(change.OldPrice), change.OldPrice.CurrencyCode, change.AppliedAt,
+		change.ProductID.String(), change.EffectiveAt, change.ID.String(), from).WithContext(ctx).MapScanCAS(map[string]interface{}{})
+}
+
+func scanPriceChanges(iter *gocql.Iter) ([]*model.PriceChange, error) {
+	var changes []*model.PriceChange
+	var productIDStr, idStr, oldCurrency, newCurrency, actor string
+	var oldPrice, newPrice *inf.Dec
+	var status model.PriceChangeStatus
+	var effectiveAt, createdAt, appliedAt time.Time
+	for iter.Scan(&productIDStr, &effectiveAt, &idStr, &oldPrice, &oldCurrency, &newPrice, &newCurrency, &actor, &status, &createdAt, &appliedAt) {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			continue
+		}
+		productID, _ := uuid.Parse(productIDStr)
+		changes = append(changes, &model.PriceChange{
+			ID:          id,
+			ProductID:   productID,
+			OldPrice:    moneyFromDecimal(oldPrice, oldCurrency),
+			NewPrice:    moneyFromDecimal(newPrice, newCurrency),
+			EffectiveAt: effectiveAt,
+			Actor:       actor,
+			Status:      status,
+			CreatedAt:   createdAt,
+			AppliedAt:   appliedAt,
+		})
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/gocql/gocql"
+	"github.com/google/uuid"
+	"gopkg.in/inf.v0"
+	"inventoryService/model"
+	"time"
+)
+
+type PriceListRepository struct {
+	session *gocql.Session
+}
+
+var ErrPriceListNotFound = errors.New("price list not found")
+
+func NewPriceListRepository(session *gocql.Session) *PriceListRepository {
+	return &PriceListRepository{session: session}
+}
+
+func (r *PriceListRepository) CreatePriceList(ctx context.Context, list *model.PriceList) error {
+	batch := r.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	batch.Query(`INSERT INTO price_lists (id, name, currency, customer_group, effective_from, effective_to, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		list.ID.String(), list.Name, list.CurrencyCode, list.CustomerGroup, list.EffectiveFrom, list.EffectiveTo, list.CreatedAt, list.UpdatedAt)
+	for _, price := range list.Prices {
+		addPriceListPrice(batch, list.ID, price)
+	}
+	return r.session.ExecuteBatch(batch)
+}
+
+func (r *PriceListRepository) GetPriceList(ctx context.Context, id string) (*model.PriceList, error) {
+	var idStr string
+	list := &model.PriceList{}
+	err := r.session.Query(`SELECT id, name, currency, customer_group, effective_from, effective_to, created_at, updated_at FROM price_lists WHERE id = ? LIMIT 1`,
+		id).WithContext(ctx).Consistency(gocql.One).Scan(&idStr, &list.Name, &list.CurrencyCode, &list.CustomerGroup, &list.EffectiveFrom, &list.EffectiveTo, &list.CreatedAt, &list.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, gocql.ErrNotFound) {
+			return nil, ErrPriceListNotFound
+		}
+		return nil, err
+	}
+	list.ID, _ = uuid.Parse(idStr)
+	list.Prices, err = r.listPriceListPrices(ctx, idStr, list.CurrencyCode)
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *PriceListRepository) ListPriceLists(ctx context.Context) ([]*model.PriceList, error) {
+	var lists []*model.PriceList
+	iter := r.session.Query(`SELECT id, name, currency, customer_group, effective_from, effective_to, created_at, updated_at FROM price_lists`).WithContext(ctx).Iter()
+	var idStr, name, currency, customerGroup string
+	var effectiveFrom, effectiveTo, createdAt, updatedAt time.Time
+	for iter.Scan(&idStr, &name, &currency, &customerGroup, &effectiveFrom, &effectiveTo, &createdAt, &updatedAt) {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			continue
+		}
+		lists = append(lists, &model.PriceList{
+			ID:            id,
+			Name:          name,
+			CurrencyCode:  currency,
+			CustomerGroup: customerGroup,
+			EffectiveFrom: effectiveFrom,
+			EffectiveTo:   effectiveTo,
+			CreatedAt:     createdAt,
+			UpdatedAt:     updatedAt,
+		})
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	for _, list := range lists {
+		prices, err := r.listPriceListPrices(ctx, list.ID.String(), list.CurrencyCode)
+		if err != nil {
+			return nil, err
+		}
+		list.Prices = prices
+	}
+	return lists, nil
+}
+
+// UpdatePriceList replaces the header and every price of a list.
+func (r *PriceListRepository) UpdatePriceList(ctx context.Context, list *model.PriceList) error {
+	err := r.session.Query(`DELETE FROM price_list_prices WHERE price_list_id = ?`, list.ID.String()).WithContext(ctx).Exec()
+	if err != nil {
+		return err
+	}
+	batch := r.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	batch.Query(`UPDATE price_lists SET name = ?, currency = ?, customer_group = ?, effective_from = ?, effective_to = ?, updated_at = ? WHERE id = ?`,
+		list.Name, list.CurrencyCode, list.CustomerGroup, list.EffectiveFrom, list.EffectiveTo, list.UpdatedAt, list.ID.String())
+	for _, price := range list.Prices {
+		addPriceListPrice(batch, list.ID, price)
+	}
+	return r.session.ExecuteBatch(batch)
+}
+
+func (r *PriceListRepository) DeletePriceList(ctx context.Context, id string) error {
+	batch := r.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	batch.Query(`DELETE FROM price_list_prices WHERE price_list_id = ?`, id)
+	batch.Query(`DELETE FROM price_lists WHERE id = ?`, id)
+	return r.session.ExecuteBatch(batch)
+}
+
+func (r *PriceListRepository) listPriceListPrices(ctx context.Context, priceListID, currency string) ([]*model.PriceListPrice, error) {
+	var prices []*model.PriceListPrice
+	iter := r.session.Query(`SELECT product_id, price_amount FROM price_list_prices WHERE price_list_id = ?`,
+		priceListID).WithContext(ctx).Iter()
+	var productIDStr string
+	var amount *inf.Dec
+	for iter.Scan(&productIDStr, &amount) {
+		productID, err := uuid.Parse(productIDStr)
+		if err != nil {
+			continue
+		}
+		prices = append(prices, &model.PriceListPrice{
+			ProductID: productID,
+			Price:     moneyFromDecimal(amount, currency),
+		})
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return prices, nil
+}
+
+func addPriceListPrice(batch *gocql.Batch, priceListID uuid.UUID, price *model.PriceListPrice) {
+	batch.Query(`INSERT INTO price_list_prices (price_list_id, product_id, price_amount) VALUES (?, ?, ?)`,
+		priceListID.String(), price.ProductID.String(), decimalFromMoney(price.Price))
+}
@@ -5,8 +5,10 @@ import (
 	"errors"
 	"github.com/gocql/gocql"
 	"github.com/google/uuid"
+	"gopkg.in/inf.v0"
 	"inventoryService/model"
 	"log"
+	"sort"
 )
 
 type ProductRepository struct {
@@ -20,15 +22,22 @@ func NewProductRepository(session *gocql.Session) *ProductRepository {
 }
 
 func (r *ProductRepository) CreateProduct(ctx context.Context, product *model.Product) error {
-	return r.session.Query(`INSERT INTO products (id, name, description, category_id, price, sku) VALUES (?, ?, ?, ?, ?, ?)`,
-		product.ID.String(), product.Name, product.Description, product.CategoryID.String(), product.Price, product.SKU).WithContext(ctx).Exec()
+	return r.session.Query(`INSERT INTO products (id, name, description, category_id, price_amount, currency, sku, serialized, length, width, height, weight, costing_method, base_unit, unit_factors, quantity_precision) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		product.ID.String(), product.Name, product.Description, product.CategoryID.String(), decimalFromMoney(product.Price), product.Price.CurrencyCode, product.SKU, product.Serialized,
+		product.Length, product.Width, product.Height, product.Weight, product.CostingMethod, product.BaseUnit, unitsToMap(product.Units), product.QuantityPrecision).WithContext(ctx).Exec()
 }
 
 func (r *ProductRepository) GetProduct(ctx context.Context, id string) (*model.Product, error) {
 	product := &model.Product{}
-	var productID, categoryID string
-	if err := r.session.Query(`SELECT id, name, description, category_id, price, sku FROM products WHERE id = ? LIMIT 1`,
-		id).WithContext(ctx).Consistency(gocql.One).Scan(&productID, &product.Name, &product.Description, &categoryID, &product.Price, &product.SKU); err != nil {
+	var productID, categoryID, currency, baseUnit string
+	var price *inf.Dec
+	var units map[string]*inf.Dec
+	if err := r.session.Query(`SELECT id, name, description, category_id, price_amount, currency, sku, serialized, length, width, height, weight, costing_method, base_unit, unit_factors, quantity_precision FROM products WHERE id = ? LIMIT 1`,
+		id).WithContext(ctx).Consistency(gocql.One).Scan(&productID, &product.Name, &product.Description, &categoryID, &price, &currency, &product.SKU, &product.Serialized,
+		&product.Length, &product.Width, &product.Height, &product.Weight, &product.CostingMethod, &baseUnit, &units, &product.QuantityPrecision); err != nil {
+		if errors.Is(err, gocql.ErrNotFound) {
+			return nil, ErrProductNotFound
+		}
 		return nil, err
 	}
 	parsedUUID, err := uuid.Parse(productID)
@@ -37,6 +46,9 @@ func (r *ProductRepository) GetProduct(ctx context.Context, id string) (*model.P
 		return nil, err
 	}
 	product.ID = parsedUUID
+	product.Price = moneyFromDecimal(price, currency)
+	product.BaseUnit = baseUnitOrDefault(baseUnit)
+	product.Units = unitsFromMap(units)
 	catUUID, err := uuid.Parse(categoryID)
 	if err != nil {
 		log.Printf("Error parsing category UUID: %v", err)
@@ -49,10 +61,15 @@ func (r *ProductRepository) GetProduct(ctx context.Context, id string) (*model.P
 
 func (r *ProductRepository) ListProducts(ctx context.Context) ([]*model.Product, error) {
 	var products []*model.Product
-	iter := r.session.Query(`SELECT id, name, description, category_id, price, sku FROM products`).WithContext(ctx).Iter()
-	var id, categoryID, name, description, sku string
-	var price float64
-	for iter.Scan(&id, &name, &description, &categoryID, &price, &sku) {
+	iter := r.session.Query(`SELECT id, name, description, category_id, price_amount, currency, sku, serialized, length, width, height, weight, costing_method, base_unit, unit_factors, quantity_precision FROM products`).WithContext(ctx).Iter()
+	var id, categoryID, name, description, currency, sku, baseUnit string
+	var units map[string]*inf.Dec
+	var price *inf.Dec
+	var length, width, height, weight float64
+	var serialized bool
+	var costingMethod model.CostingMethod
+	var quantityPrecision int
+	for iter.Scan(&id, &name, &description, &categoryID, &price, &currency, &sku, &serialized, &length, &width, &height, &weight, &costingMethod, &baseUnit, &units, &quantityPrecision) {
 		convertedID, err := uuid.Parse(id)
 		if err != nil {
 			continue
@@ -62,12 +79,21 @@ func (r *ProductRepository) ListProducts(ctx context.Context) ([]*model.Product,
 			continue
 		}
 		product := &model.Product{
-			ID:          convertedID,
-			Name:        name,
-			Description: description,
-			CategoryID:  convertedCategoryID,
-			Price:       price,
-			SKU:         sku,
+			ID:                convertedID,
+			Name:              name,
+			Description:       description,
+			CategoryID:        convertedCategoryID,
+			Price:             moneyFromDecimal(price, currency),
+			SKU:               sku,
+			Serialized:        serialized,
+			Length:            length,
+			Width:             width,
+			Height:            height,
+			Weight:            weight,
+			CostingMethod:     costingMethod,
+			BaseUnit:          baseUnitOrDefault(baseUnit),
+			Units:             unitsFromMap(units),
+			QuantityPrecision: quantityPrecision,
 		}
 		products = append(products, product)
 	}
@@ -78,8 +104,16 @@ func (r *ProductRepository) ListProducts(ctx context.Context) ([]*model.Product,
 }
 
 func (r *ProductRepository) UpdateProduct(ctx context.Context, product *model.Product) error {
-	return r.session.Query(`UPDATE products SET name = ?, description = ?, category_id = ?, price = ?, sku = ? WHERE id = ?`,
-		product.Name, product.Description, product.CategoryID.String(), product.Price, product.SKU, product.ID.String()).WithContext(ctx).Exec()
+	return r.session.Query(`UPDATE products SET name = ?, description = ?, category_id = ?, price_amount = ?, currency = ?, sku = ?, serialized = ?, length = ?, width = ?, height = ?, weight = ?, costing_method = ?, base_unit = ?, unit_factors = ?, quantity_precision = ? WHERE id = ?`,
+		product.Name, product.Description, product.CategoryID.String(), decimalFromMoney(product.Price), product.Price.CurrencyCode, product.SKU, product.Serialized,
+		product.Length, product.Width, product.Height, product.Weight, product.CostingMethod, product.BaseUnit, unitsToMap(product.Units), product.QuantityPrecision, product.ID.String()).WithContext(ctx).Exec()
+}
+
+// UpdateProductPrice changes only the price of a product, leaving changes made
+// to its other fields in the meantime alone.
+func (r *ProductRepository) UpdateProductPrice(ctx context.Context, id string, price model.Money) error {
+	return r.session.Query(`UPDATE products SET price_amount = ?, currency = ? WHERE id = ?`,
+		decimalFromMoney(price), price.CurrencyCode, id).WithContext(ctx).Exec()
 }
 
 func (r *ProductRepository) DeleteProduct(ctx context.Context, id string) error {
@@ -130,3 +164,36 @@ func (r *ProductRepository) ProductExists(ctx context.Context, id string) (bool,
 	}
 	return count > 0, nil
 }
+
+// baseUnitOrDefault gives products stored before they had units of measure
+// the default base unit.
+func baseUnitOrDefault(baseUnit string) string {
+	if baseUnit == "" {
+		return model.DefaultBaseUnit
+	}
+	return baseUnit
+}
+
+// Alternate units are stored as a map of unit name to decimal factor.
+func unitsToMap(units []*model.UnitOfMeasure) map[string]*inf.Dec {
+	m := make(map[string]*inf.Dec, len(units))
+	for _, unit := range units {
+		m[unit.Name] = decimalFromQuantity(unit.Factor)
+	}
+	return m
+}
+
+// unitsFromMap reads alternate units back, smallest first.
+func unitsFromMap(m map[string]*inf.Dec) []*model.UnitOfMeasure {
+	var units []*model.UnitOfMeasure
+	for name, factor := range m {
+		units = append(units, &model.UnitOfMeasure{Name: name, Factor: quantityFromDecimal(factor)})
+	}
+	sort.Slice(units, func(i, j int) bool {
+		if units[i].Factor != units[j].Factor {
+			return units[i].Factor < units[j].Factor
+		}
+		return units[i].Name < units[j].Name
+	})
+	return units
+}
@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/gocql/gocql"
+	"github.com/google/uuid"
+	"gopkg.in/inf.v0"
+	"inventoryService/model"
+	"time"
+)
+
+type PurchaseOrderRepository struct {
+	session *gocql.Session
+}
+
+var ErrPurchaseOrderNotFound = errors.New("purchase order not found")
+
+func NewPurchaseOrderRepository(session *gocql.Session) *PurchaseOrderRepository {
+	return &PurchaseOrderRepository{session: session}
+}
+
+func (r *PurchaseOrderRepository) CreatePurchaseOrder(ctx context.Context, order *model.PurchaseOrder) error {
+	batch := r.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	batch.Query(`INSERT INTO purchase_orders (id, supplier_id, status, notes, created_at, updated_at, submitted_at) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		order.ID.String(), order.SupplierID.String(), order.Status, order.Notes, order.CreatedAt, order.UpdatedAt, order.SubmittedAt)
+	for _, line := range order.Lines {
+		addPurchaseOrderLine(batch, order.ID, line)
+	}
+	return r.session.ExecuteBatch(batch)
+}
+
+func (r *PurchaseOrderRepository) GetPurchaseOrder(ctx context.Context, id string) (*model.PurchaseOrder, error) {
+	var idStr, supplierIDStr, receivingIDStr string
+	order := &model.PurchaseOrder{}
+	err := r.session.Query(`SELECT id, supplier_id, status, notes, created_at, updated_at, submitted_at, receiving_receipt_id FROM purchase_orders WHERE id = ? LIMIT 1`,
+		id).WithContext(ctx).Consistency(gocql.One).Scan(&idStr, &supplierIDStr, &order.Status, &order.Notes, &order.CreatedAt, &order.UpdatedAt, &order.SubmittedAt, &receivingIDStr)
+	if err != nil {
+		if errors.Is(err, gocql.ErrNotFound) {
+			return nil, ErrPurchaseOrderNotFound
+		}
+		return nil, err
+	}
+	order.ID, _ = uuid.Parse(idStr)
+	order.SupplierID, _ = uuid.Parse(supplierIDStr)
+	order.ReceivingReceiptID, _ = uuid.Parse(receivingIDStr)
+	order.Lines, err = r.listPurchaseOrderLines(ctx, idStr)
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+func (r *PurchaseOrderRepository) ListPurchaseOrders(ctx context.Context) ([]*model.PurchaseOrder, error) {
+	var orders []*model.PurchaseOrder
+	iter := r.session.Query(`SELECT id, supplier_id, status, notes, created_at, updated_at, submitted_at, receiving_receipt_id FROM purchase_orders`).WithContext(ctx).Iter()
+	var idStr, supplierIDStr, notes, receivingIDStr string
+	var orderStatus model.PurchaseOrderStatus
+	var createdAt, updatedAt, submittedAt time.Time
+	for iter.Scan(&idStr, &supplierIDStr, &orderStatus, &notes, &createdAt, &updatedAt, &submittedAt, &receivingIDStr) {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			continue
+		}
+		supplierID, _ := uuid.Parse(supplierIDStr)
+		receivingID, _ := uuid.Parse(receivingIDStr)
+		orders = append(orders, &model.PurchaseOrder{
+			ID:                 id,
+			SupplierID:         supplierID,
+			Status:             orderStatus,
+			Notes:              notes,
+			CreatedAt:          createdAt,
+			UpdatedAt:          updatedAt,
+			SubmittedAt:        submittedAt,
+			ReceivingReceiptID: receivingID,
+		})
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	for _, order := range orders {
+		lines, err := r.listPurchaseOrderLines(ctx, order.ID.String())
+		if err != nil {
+			return nil, err
+		}
+		order.Lines = lines
+	}
+	return orders, nil
+}
+
+// UpdatePurchaseOrder replaces the header and every line of an order. The
+// status is left alone; it only changes through UpdatePurchaseOrderStatus,
+// MarkSubmitted and the receipt claim.
+func (r *PurchaseOrderRepository) UpdatePurchaseOrder(ctx context.Context, order *model.PurchaseOrder) error {
+	err := r.session.Query(`DELETE FROM purchase_order_lines WHERE purchase_order_id = ?`, order.ID.String()).WithContext(ctx).Exec()
+	if err != nil {
+		return err
+	}
+	batch := r.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	batch.Query(`UPDATE purchase_orders SET supplier_id = ?, notes = ?, updated_at = ? WHERE id = ?`,
+		order.SupplierID.String(), order.Notes, order.UpdatedAt, order.ID.String())
+	for _, line := range order.Lines {
+		addPurchaseOrderLine(batch, order.ID, line)
+	}
+	return r.session.ExecuteBatch(batch)
+}
+
+// UpdatePurchaseOrderStatus moves an order from one status to another. It
+// reports false, changing nothing, when the order is no longer in from or
+// goods are being received against it.
+func (r *PurchaseOrderRepository) UpdatePurchaseOrderStatus(ctx context.Context, id string, from, to model.PurchaseOrderStatus, updatedAt time.Time) (bool, error) {
+	return r.session.Query(`UPDATE purchase_orders SET status = ?, updated_at = ? WHERE id = ? IF status = ? AND receiving_receipt_id = null`,
+		to, updatedAt, id, from).WithContext(ctx).MapScanCAS(map[string]interface{}{})
+}
+
+// MarkSubmitted moves a draft order to submitted and records when it was
+// sent. It reports false when the order is no longer a draft.
+func (r *PurchaseOrderRepository) MarkSubmitted(ctx context.Context, id string, submittedAt time.Time) (bool, error) {
+	return r.session.Query(`UPDATE purchase_orders SET status = ?, submitted_at = ?, updated_at = ? WHERE id = ? IF status = ?`,
+		model.PurchaseOrderSubmitted, submittedAt, submittedAt, id, model.PurchaseOrderDraft).WithContext(ctx).MapScanCAS(map[string]interface{}{})
+}
+
+// ClaimReceipt holds an order in the given status for a goods receipt. When
+// the order has moved on or is held by another receipt it reports false and
+// the receipt holding it, if any.
+func (r *PurchaseOrderRepository) ClaimReceipt(ctx context.Context, id string, status model.PurchaseOrderStatus, receiptID uuid.UUID) (bool, uuid.UUID, error) {
+	current := map[string]interface{}{}
+	applied, err := r.session.Query(`UPDATE purchase_orders SET receiving_receipt_id = ? WHERE id = ? IF status = ? AND receiving_receipt_id = null`,
+		receiptID.String(), id, status).WithContext(ctx).MapScanCAS(current)
+	if err != nil || applied {
+		return applied, uuid.Nil, err
+	}
+	holder, _ := current["receiving_receipt_id"].(gocql.UUID)
+	return false, uuid.UUID(holder), nil
+}
+
+// CompleteReceipt sets the status an order is left in by a goods receipt and
+// releases the receipt's hold on it. It reports false when the receipt does
+// not hold the order.
+func (r *PurchaseOrderRepository) CompleteReceipt(ctx context.Context, id string, receiptID uuid.UUID, status model.PurchaseOrderStatus, updatedAt time.Time) (bool, error) {
+	return r.session.Query(`UPDATE purchase_orders SET status = ?, updated_at = ?, receiving_receipt_id = null WHERE id = ? IF receiving_receipt_id = ?`,
+		status, updatedAt, id, receiptID.String()).WithContext(ctx).MapScanCAS(map[string]interface{}{})
+}
+
+// ReleaseReceipt drops a receipt's hold on an order without changing it.
+func (r *PurchaseOrderRepository) ReleaseReceipt(ctx context.Context, id string, receiptID uuid.UUID) error {
+	_, err := r.session.Query(`UPDATE purchase_orders SET receiving_receipt_id = null WHERE id = ? IF receiving_receipt_id = ?`,
+		id, receiptID.String()).WithContext(ctx).MapScanCAS(map[string]interface{}{})
+	return err
+}
+
+func (r *PurchaseOrderRepository) DeletePurchaseOrder(ctx context.Context, id string) error {
+	batch := r.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	batch.Query(`DELETE FROM purchase_order_lines WHERE purchase_order_id = ?`, id)
+	batch.Query(`DELETE FROM purchase_orders WHERE id = ?`, id)
+	return r.session.ExecuteBatch(batch)
+}
+
+func (r *PurchaseOrderRepository) listPurchaseOrderLines(ctx context.Context, purchaseOrderID string) ([]*model.PurchaseOrderLine, error) {
+	var lines []*model.PurchaseOrderLine
+	iter := r.session.Query(`SELECT id, product_id, warehouse_id, quantity_decimal, unit, unit_cost_amount, currency, received_quantity_decimal FROM purchase_order_lines WHERE purchase_order_id = ?`,
+		purchaseOrderID).WithContext(ctx).Iter()
+	var idStr, productIDStr, warehouseIDStr, unit, currency string
+	var unitCost, quantity, receivedQuantity *inf.Dec
+	for iter.Scan(&idStr, &productIDStr, &warehouseIDStr, &quantity, &unit, &unitCost, &currency, &receivedQuantity) {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			continue
+		}
+		productID, _ := uuid.Parse(productIDStr)
+		warehouseID, _ := uuid.Parse(warehouseIDStr)
+		lines = append(lines, &model.PurchaseOrderLine{
+			ID:               id,
+			ProductID:        productID,
+			WarehouseID:      warehouseID,
+			Quantity:         quantityFromDecimal(quantity),
+			Unit:             unit,
+			UnitCost:         moneyFromDecimal(unitCost, currency),
+			ReceivedQuantity: quantityFromDecimal(receivedQuantity),
+		})
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+func addPurchaseOrderLine(batch *gocql.Batch, purchaseOrderID uuid.UUID, line *model.PurchaseOrderLine) {
+	batch.Query(`INSERT INTO purchase_order_lines (purchase_order_id, id, product_id, warehouse_id, quantity_decimal, unit, unit_cost_amount, currency, received_quantity_decimal) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		purchaseOrderID.String(), line.ID.String(), line.ProductID.String(), line.WarehouseID.String(), decimalFromQuantity(line.Quantity), line.Unit, decimalFromMoney(line.UnitCost), line.UnitCost.CurrencyCode, decimalFromQuantity(line.ReceivedQuantity))
+}
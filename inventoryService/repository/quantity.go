@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"gopkg.in/inf.v0"
+	"inventoryService/model"
+)
+
+// Quantities are stored as decimals with the six decimals of model.Quantity,
+// in columns named after the integer columns they replace with a _decimal
+// suffix.
+func decimalFromQuantity(q model.Quantity) *inf.Dec {
+	return inf.NewDec(int64(q), model.QuantityDecimals)
+}
+
+// quantityFromDecimal reads a quantity back; a null quantity is zero.
+func quantityFromDecimal(value *inf.Dec) model.Quantity {
+	if value == nil {
+		return 0
+	}
+	return model.Quantity(new(inf.Dec).Round(value, model.QuantityDecimals, inf.RoundHalfUp).UnscaledBig().Int64())
+}
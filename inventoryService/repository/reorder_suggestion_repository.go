@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/gocql/gocql"
+	"github.com/google/uuid"
+	"gopkg.in/inf.v0"
+	"inventoryService/model"
+	"time"
+)
+
+type ReorderSuggestionRepository struct {
+	session *gocql.Session
+}
+
+var ErrReorderSuggestionNotFound = errors.New("reorder suggestion not found")
+
+func NewReorderSuggestionRepository(session *gocql.Session) *ReorderSuggestionRepository {
+	return &ReorderSuggestionRepository{session: session}
+}
+
+func (r *ReorderSuggestionRepository) SaveReorderSuggestion(ctx context.Context, suggestion *model.ReorderSuggestion) error {
+	return r.session.Query(`INSERT INTO reorder_suggestions (inventory_item_id, product_id, warehouse_id, quantity_on_hand_decimal, reorder_level_decimal, suggested_quantity_decimal, created_at, purchase_order_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		suggestion.InventoryItemID.String(), suggestion.ProductID.String(), suggestion.WarehouseID.String(), decimalFromQuantity(suggestion.QuantityOnHand), decimalFromQuantity(suggestion.ReorderLevel), decimalFromQuantity(suggestion.SuggestedQuantity), suggestion.CreatedAt, suggestion.PurchaseOrderID.String()).WithContext(ctx).Exec()
+}
+
+func (r *ReorderSuggestionRepository) GetReorderSuggestion(ctx context.Context, inventoryItemID string) (*model.ReorderSuggestion, error) {
+	var itemIDStr, productIDStr, warehouseIDStr, purchaseOrderIDStr string
+	var quantityOnHand, reorderLevel, suggestedQuantity *inf.Dec
+	suggestion := &model.ReorderSuggestion{}
+	err := r.session.Query(`SELECT inventory_item_id, product_id, warehouse_id, quantity_on_hand_decimal, reorder_level_decimal, suggested_quantity_decimal, created_at, purchase_order_id FROM reorder_suggestions WHERE inventory_item_id = ? LIMIT 1`,
+		inventoryItemID).WithContext(ctx).Consistency(gocql.One).Scan(&itemIDStr, &productIDStr, &warehouseIDStr, &quantityOnHand, &reorderLevel, &suggestedQuantity, &suggestion.CreatedAt, &purchaseOrderIDStr)
+	if err != nil {
+		if errors.Is(err, gocql.ErrNotFound) {
+			return nil, ErrReorderSuggestionNotFound
+		}
+		return nil, err
+	}
+	suggestion.InventoryItemID, _ = uuid.Parse(itemIDStr)
+	suggestion.ProductID, _ = uuid.Parse(productIDStr)
+	suggestion.WarehouseID, _ = uuid.Parse(warehouseIDStr)
+	suggestion.PurchaseOrderID, _ = uuid.Parse(purchaseOrderIDStr)
+	suggestion.QuantityOnHand = quantityFromDecimal(quantityOnHand)
+	suggestion.ReorderLevel = quantityFromDecimal(reorderLevel)
+	suggestion.SuggestedQuantity = quantityFromDecimal(suggestedQuantity)
+	return suggestion, nil
+}
+
+func (r *ReorderSuggestionRepository) ListReorderSuggestions(ctx context.Context) ([]*model.ReorderSuggestion, error) {
+	var suggestions []*model.ReorderSuggestion
+	iter := r.session.Query(`SELECT inventory_item_id, product_id, warehouse_id, quantity_on_hand_decimal, reorder_level_decimal, suggested_quantity_decimal, created_at, purchase_order_id FROM reorder_suggestions`).WithContext(ctx).Iter()
+	var itemIDStr, productIDStr, warehouseIDStr, purchaseOrderIDStr string
+	var quantityOnHand, reorderLevel, suggestedQuantity *inf.Dec
+	var createdAt time.Time
+	for iter.Scan(&itemIDStr, &productIDStr, &warehouseIDStr, &quantityOnHand, &reorderLevel, &suggestedQuantity, &createdAt, &purchaseOrderIDStr) {
+		itemID, err := uuid.Parse(itemIDStr)
+		if err != nil {
+			continue
+		}
+		productID, _ := uuid.Parse(productIDStr)
+		warehouseID, _ := uuid.Parse(warehouseIDStr)
+		purchaseOrderID, _ := uuid.Parse(purchaseOrderIDStr)
+		suggestions = append(suggestions, &model.ReorderSuggestion{
+			InventoryItemID:   itemID,
+			ProductID:         productID,
+			WarehouseID:       warehouseID,
+			QuantityOnHand:    quantityFromDecimal(quantityOnHand),
+			ReorderLevel:      quantityFromDecimal(reorderLevel),
+			SuggestedQuantity: quantityFromDecimal(suggestedQuantity),
+			CreatedAt:         createdAt,
+			PurchaseOrderID:   purchaseOrderID,
+		})
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return suggestions, nil
+}
+
+func (r *ReorderSuggestionRepository) DeleteReorderSuggestion(ctx context.Context, inventoryItemID string) error {
+	return r.session.Query(`DELETE FROM reorder_suggestions WHERE inventory_item_id = ?`, inventoryItemID).WithContext(ctx).Exec()
+}
+
+func (r *ReorderSuggestionRepository) SetPurchaseOrder(ctx context.Context, inventoryItemID string, purchaseOrderID uuid.UUID) error {
+	return r.session.Query(`UPDATE reorder_suggestions SET purchase_order_id = ? WHERE inventory_item_id = ?`,
+		purchaseOrderID.String(), inventoryItemID).WithContext(ctx).Exec()
+}
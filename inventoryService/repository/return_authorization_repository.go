@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gocql/gocql"
+	"github.com/google/uuid"
+	"gopkg.in/inf.v0"
+	"inventoryService/model"
+	"time"
+)
+
+type ReturnAuthorizationRepository struct {
+	session *gocql.Session
+}
+
+var ErrReturnAuthorizationNotFound = errors.New("return authorization not found")
+
+// ErrReturnLimitExceeded is returned when more units would be returned against
+// a sales order than it shipped.
+var ErrReturnLimitExceeded = errors.New("return limit exceeded")
+
+func NewReturnAuthorizationRepository(session *gocql.Session) *ReturnAuthorizationRepository {
+	return &ReturnAuthorizationRepository{session: session}
+}
+
+func (r *ReturnAuthorizationRepository) CreateReturnAuthorization(ctx context.Context, rma *model.ReturnAuthorization) error {
+	return r.session.Query(`INSERT INTO return_authorizations (id, sales_order_id, customer, product_id, warehouse_id, quantity_decimal, reason, status, created_at, updated_at, inspected_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		rma.ID.String(), rma.SalesOrderID.String(), rma.Customer, rma.ProductID.String(), rma.WarehouseID.String(), decimalFromQuantity(rma.Quantity), rma.Reason,
+		rma.Status, rma.CreatedAt, rma.UpdatedAt, rma.InspectedAt).WithContext(ctx).Exec()
+}
+
+func (r *ReturnAuthorizationRepository) GetReturnAuthorization(ctx context.Context, id string) (*model.ReturnAuthorization, error) {
+	var idStr, salesOrderIDStr, productIDStr, warehouseIDStr string
+	var quantity *inf.Dec
+	rma := &model.ReturnAuthorization{}
+	err := r.session.Query(`SELECT id, sales_order_id, customer, product_id, warehouse_id, quantity_decimal, reason, status, created_at, updated_at, inspected_at FROM return_authorizations WHERE id = ? LIMIT 1`,
+		id).WithContext(ctx).Consistency(gocql.One).Scan(&idStr, &salesOrderIDStr, &rma.Customer, &productIDStr, &warehouseIDStr, &quantity, &rma.Reason,
+		&rma.Status, &rma.CreatedAt, &rma.UpdatedAt, &rma.InspectedAt)
+	if err != nil {
+		if errors.Is(err, gocql.ErrNotFound) {
+			return nil, ErrReturnAuthorizationNotFound
+		}
+		return nil, err
+	}
+	rma.ID, _ = uuid.Parse(idStr)
+	rma.SalesOrderID, _ = uuid.Parse(salesOrderIDStr)
+	rma.ProductID, _ = uuid.Parse(productIDStr)
+	rma.WarehouseID, _ = uuid.Parse(warehouseIDStr)
+	rma.Quantity = quantityFromDecimal(quantity)
+	rma.Dispositions, err = r.listReturnDispositions(ctx, idStr)
+	if err != nil {
+		return nil, err
+	}
+	return rma, nil
+}
+
+func (r *ReturnAuthorizationRepository) ListReturnAuthorizations(ctx context.Context) ([]*model.ReturnAuthorization, error) {
+	var rmas []*model.ReturnAuthorization
+	iter := r.session.Query(`SELECT id, sales_order_id, customer, product_id, warehouse_id, quantity_decimal, reason, status, created_at, updated_at, inspected_at FROM return_authorizations`).WithContext(ctx).Iter()
+	var idStr, salesOrderIDStr, customer, productIDStr, warehouseIDStr, reason string
+	var quantity *inf.Dec
+	var rmaStatus model.ReturnAuthorizationStatus
+	var createdAt, updatedAt, inspectedAt time.Time
+	for iter.Scan(&idStr, &salesOrderIDStr, &customer, &productIDStr, &warehouseIDStr, &quantity, &reason, &rmaStatus, &createdAt, &updatedAt, &inspectedAt) {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			continue
+		}
+		rma := &model.ReturnAuthorization{
+			ID:          id,
+			Customer:    customer,
+			Quantity:    quantityFromDecimal(quantity),
+			Reason:      reason,
+			Status:      rmaStatus,
+			CreatedAt:   createdAt,
+			UpdatedAt:   updatedAt,
+			InspectedAt: inspectedAt,
+		}
+		rma.SalesOrderID, _ = uuid.Parse(salesOrderIDStr)
+		rma.ProductID, _ = uuid.Parse(productIDStr)
+		rma.WarehouseID, _ = uuid.Parse(warehouseIDStr)
+		rmas = append(rmas, rma)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	for _, rma := range rmas {
+		dispositions, err := r.listReturnDispositions(ctx, rma.ID.String())
+		if err != nil {
+			return nil, err
+		}
+		rma.Dispositions = dispositions
+	}
+	return rmas, nil
+}
+
+// UpdateReturnAuthorizationStatus moves a return from one status to another.
+// It reports false, changing nothing, when the return is no longer in from.
+func (r *ReturnAuthorizationRepository) UpdateReturnAuthorizationStatus(ctx context.Context, id string, from, to model.ReturnAuthorizationStatus) (bool, error) {
+	var current model.ReturnAuthorizationStatus
+	return r.session.Query(`UPDATE return_authorizations SET status = ? WHERE id = ? IF status = ?`,
+		to, id, from).WithContext(ctx).ScanCAS(&current)
+}
+
+// UpdateReturnAuthorizationTimes records when a return last changed and when
+// it was inspected.
+func (r *ReturnAuthorizationRepository) UpdateReturnAuthorizationTimes(ctx context.Context, rma *model.ReturnAuthorization) error {
+	return r.session.Query(`UPDATE return_authorizations SET updated_at = ?, inspected_at = ? WHERE id = ?`,
+		rma.UpdatedAt, rma.InspectedAt, rma.ID.String()).WithContext(ctx).Exec()
+}
+
+// SaveDispositions replaces the dispositions of a return.
+func (r *ReturnAuthorizationRepository) SaveDispositions(ctx context.Context, rma *model.ReturnAuthorization) error {
+	err := r.session.Query(`DELETE FROM return_dispositions WHERE return_id = ?`, rma.ID.String()).WithContext(ctx).Exec()
+	if err != nil {
+		return err
+	}
+	batch := r.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	for _, disposition := range rma.Dispositions {
+		batch.Query(`INSERT INTO return_dispositions (return_id, disposition, quantity_decimal, supplier_id, stock_movement_id, notes, serial_numbers) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			rma.ID.String(), disposition.Disposition, decimalFromQuantity(disposition.Quantity), disposition.SupplierID.String(), disposition.StockMovementID.String(), disposition.Notes, disposition.SerialNumbers)
+	}
+	return r.session.ExecuteBatch(batch)
+}
+
+// AdjustReturnedQuantity adds delta to the units of a product returned against
+// a sales order and returns the new total. It refuses to go above limit with
+// ErrReturnLimitExceeded and the current total. The first time a product is
+// returned against an order, count gives the units returned so far.
+func (r *ReturnAuthorizationRepository) AdjustReturnedQuantity(ctx context.Context, salesOrderID, productID string, delta, limit model.Quantity, count func() (model.Quantity, error)) (model.Quantity, error) {
+	var returned *inf.Dec
+	err := r.session.Query(`SELECT returned_quantity_decimal FROM sales_order_returns WHERE sales_order_id = ? AND product_id = ? LIMIT 1`,
+		salesOrderID, productID).WithContext(ctx).Consistency(gocql.Quorum).Scan(&returned)
+	if errors.Is(err, gocql.ErrNotFound) {
+		counted, err := count()
+		if err != nil {
+			return 0, err
+		}
+		existing := map[string]interface{}{}
+		applied, err := r.session.Query(`INSERT INTO sales_order_returns (sales_order_id, product_id, returned_quantity_decimal) VALUES (?, ?, ?) IF NOT EXISTS`,
+			salesOrderID, productID, decimalFromQuantity(counted)).WithContext(ctx).MapScanCAS(existing)
+		if err != nil {
+			return 0, err
+		}
+		returned = decimalFromQuantity(counted)
+		if !applied {
+			returned, _ = existing["returned_quantity_decimal"].(*inf.Dec)
+		}
+	} else if err != nil {
+		return 0, err
+	}
+	for attempt := 0; attempt < maxQuantityUpdateAttempts; attempt++ {
+		current := quantityFromDecimal(returned)
+		updated := current + delta
+		if updated > limit && delta > 0 {
+			return current, ErrReturnLimitExceeded
+		}
+		if updated < 0 {
+			updated = 0
+		}
+		applied, err := r.session.Query(`UPDATE sales_order_returns SET returned_quantity_decimal = ? WHERE sales_order_id = ? AND product_id = ? IF returned_quantity_decimal = ?`,
+			decimalFromQuantity(updated), salesOrderID, productID, returned).WithContext(ctx).ScanCAS(&returned)
+		if err != nil {
+			return 0, err
+		}
+		if applied {
+			return updated, nil
+		}
+	}
+	return 0, fmt.Errorf("returns of product %s on sales order %s changed concurrently %d times", productID, salesOrderID, maxQuantityUpdateAttempts)
+}
+
+func (r *ReturnAuthorizationRepository) listReturnDispositions(ctx context.Context, returnID string) ([]*model.ReturnDisposition, error) {
+	var dispositions []*model.ReturnDisposition
+	iter := r.session.Query(`SELECT disposition, quantity_decimal, supplier_id, stock_movement_id, notes, serial_numbers FROM return_dispositions WHERE return_id = ?`,
+		returnID).WithContext(ctx).Iter()
+	var disposition model.Disposition
+	var quantity *inf.Dec
+	var supplierIDStr, movementIDStr, notes string
+	var serialNumbers []string
+	for iter.Scan(&disposition, &quantity, &supplierIDStr, &movementIDStr, &notes, &serialNumbers) {
+		d := &model.ReturnDisposition{Disposition: disposition, Quantity: quantityFromDecimal(quantity), Notes: notes, SerialNumbers: serialNumbers}
+		d.SupplierID, _ = uuid.Parse(supplierIDStr)
+		d.StockMovementID, _ = uuid.Parse(movementIDStr)
+		dispositions = append(dispositions, d)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return dispositions, nil
+}
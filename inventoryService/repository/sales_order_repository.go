@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/gocql/gocql"
+	"github.com/google/uuid"
+	"gopkg.in/inf.v0"
+	"inventoryService/model"
+	"time"
+)
+
+type SalesOrderRepository struct {
+	session *gocql.Session
+}
+
+var ErrSalesOrderNotFound = errors.New("sales order not found")
+
+func NewSalesOrderRepository(session *gocql.Session) *SalesOrderRepository {
+	return &SalesOrderRepository{session: session}
+}
+
+func (r *SalesOrderRepository) CreateSalesOrder(ctx context.Context, order *model.SalesOrder) error {
+	batch := r.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	batch.Query(`INSERT INTO sales_orders (id, customer, status, notes, expires_at, created_at, updated_at, shipped_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		order.ID.String(), order.Customer, order.Status, order.Notes, order.ExpiresAt, order.CreatedAt, order.UpdatedAt, order.ShippedAt)
+	for _, line := range order.Lines {
+		batch.Query(`INSERT INTO sales_order_lines (sales_order_id, id, product_id, warehouse_id, quantity_decimal) VALUES (?, ?, ?, ?, ?)`,
+			order.ID.String(), line.ID.String(), line.ProductID.String(), line.WarehouseID.String(), decimalFromQuantity(line.Quantity))
+		for _, allocation := range line.Allocations {
+			addSalesOrderAllocation(batch, order.ID, line.ID, allocation)
+		}
+	}
+	return r.session.ExecuteBatch(batch)
+}
+
+func (r *SalesOrderRepository) GetSalesOrder(ctx context.Context, id string) (*model.SalesOrder, error) {
+	var idStr string
+	order := &model.SalesOrder{}
+	err := r.session.Query(`SELECT id, customer, status, notes, expires_at, created_at, updated_at, shipped_at FROM sales_orders WHERE id = ? LIMIT 1`,
+		id).WithContext(ctx).Consistency(gocql.One).Scan(&idStr, &order.Customer, &order.Status, &order.Notes, &order.ExpiresAt, &order.CreatedAt, &order.UpdatedAt, &order.ShippedAt)
+	if err != nil {
+		if errors.Is(err, gocql.ErrNotFound) {
+			return nil, ErrSalesOrderNotFound
+		}
+		return nil, err
+	}
+	order.ID, _ = uuid.Parse(idStr)
+	order.Lines, err = r.listSalesOrderLines(ctx, idStr)
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+func (r *SalesOrderRepository) ListSalesOrders(ctx context.Context) ([]*model.SalesOrder, error) {
+	var orders []*model.SalesOrder
+	iter := r.session.Query(`SELECT id, customer, status, notes, expires_at, created_at, updated_at, shipped_at FROM sales_orders`).WithContext(ctx).Iter()
+	var idStr, customer, notes string
+	var orderStatus model.SalesOrderStatus
+	var expiresAt, createdAt, updatedAt, shippedAt time.Time
+	for iter.Scan(&idStr, &customer, &orderStatus, &notes, &expiresAt, &createdAt, &updatedAt, &shippedAt) {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			continue
+		}
+		orders = append(orders, &model.SalesOrder{
+			ID:        id,
+			Customer:  customer,
+			Status:    orderStatus,
+			Notes:     notes,
+			ExpiresAt: expiresAt,
+			CreatedAt: createdAt,
+			UpdatedAt: updatedAt,
+			ShippedAt: shippedAt,
+		})
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	for _, order := range orders {
+		lines, err := r.listSalesOrderLines(ctx, order.ID.String())
+		if err != nil {
+			return nil, err
+		}
+		order.Lines = lines
+	}
+	return orders, nil
+}
+
+// UpdateSalesOrderStatus moves an order from one status to another. It
+// reports false, changing nothing, when the order is no longer in from.
+func (r *SalesOrderRepository) UpdateSalesOrderStatus(ctx context.Context, id string, from, to model.SalesOrderStatus, updatedAt time.Time) (bool, error) {
+	var current model.SalesOrderStatus
+	return r.session.Query(`UPDATE sales_orders SET status = ?, updated_at = ? WHERE id = ? IF status = ?`,
+		to, updatedAt, id, from).WithContext(ctx).ScanCAS(&current)
+}
+
+// SaveShipment stores the shipping time of an order together with the stock
+// movements of its allocations and whether they have been shipped. The status is changed separately, by
+// UpdateSalesOrderStatus.
+func (r *SalesOrderRepository) SaveShipment(ctx context.Context, order *model.SalesOrder) error {
+	batch := r.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	batch.Query(`UPDATE sales_orders SET shipped_at = ? WHERE id = ?`,
+		order.ShippedAt, order.ID.String())
+	for _, line := range order.Lines {
+		for _, allocation := range line.Allocations {
+			addSalesOrderAllocation(batch, order.ID, line.ID, allocation)
+		}
+	}
+	return r.session.ExecuteBatch(batch)
+}
+
+func (r *SalesOrderRepository) listSalesOrderLines(ctx context.Context, salesOrderID string) ([]*model.SalesOrderLine, error) {
+	var lines []*model.SalesOrderLine
+	linesByID := make(map[uuid.UUID]*model.SalesOrderLine)
+	iter := r.session.Query(`SELECT id, product_id, warehouse_id, quantity_decimal FROM sales_order_lines WHERE sales_order_id = ?`,
+		salesOrderID).WithContext(ctx).Iter()
+	var idStr, productIDStr, warehouseIDStr string
+	var quantity *inf.Dec
+	for iter.Scan(&idStr, &productIDStr, &warehouseIDStr, &quantity) {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			continue
+		}
+		productID, _ := uuid.Parse(productIDStr)
+		warehouseID, _ := uuid.Parse(warehouseIDStr)
+		line := &model.SalesOrderLine{ID: id, ProductID: productID, WarehouseID: warehouseID, Quantity: quantityFromDecimal(quantity)}
+		lines = append(lines, line)
+		linesByID[id] = line
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+
+	iter = r.session.Query(`SELECT line_id, inventory_item_id, warehouse_id, quantity_decimal, stock_movement_id, serial_numbers, shipped FROM sales_order_allocations WHERE sales_order_id = ?`,
+		salesOrderID).WithContext(ctx).Iter()
+	var lineIDStr, itemIDStr, movementIDStr string
+	var serialNumbers []string
+	var shipped bool
+	for iter.Scan(&lineIDStr, &itemIDStr, &warehouseIDStr, &quantity, &movementIDStr, &serialNumbers, &shipped) {
+		lineID, _ := uuid.Parse(lineIDStr)
+		line, ok := linesByID[lineID]
+		if !ok {
+			continue
+		}
+		allocation := &model.SalesOrderAllocation{Quantity: quantityFromDecimal(quantity), SerialNumbers: serialNumbers, Shipped: shipped}
+		allocation.InventoryItemID, _ = uuid.Parse(itemIDStr)
+		allocation.WarehouseID, _ = uuid.Parse(warehouseIDStr)
+		allocation.StockMovementID, _ = uuid.Parse(movementIDStr)
+		line.Allocations = append(line.Allocations, allocation)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+func addSalesOrderAllocation(batch *gocql.Batch, salesOrderID, lineID uuid.UUID, allocation *model.SalesOrderAllocation) {
+	batch.Query(`INSERT INTO sales_order_allocations (sales_order_id, line_id, inventory_item_id, warehouse_id, quantity_decimal, stock_movement_id, serial_numbers, shipped) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		salesOrderID.String(), lineID.String(), allocation.InventoryItemID.String(), allocation.WarehouseID.String(), decimalFromQuantity(allocation.Quantity), allocation.StockMovementID.String(), allocation.SerialNumbers, allocation.Shipped)
+}
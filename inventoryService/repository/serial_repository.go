@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/gocql/gocql"
+	"github.com/google/uuid"
+	"inventoryService/model"
+	"time"
+)
+
+type SerialRepository struct {
+	session *gocql.Session
+}
+
+var ErrSerialNotFound = errors.New("serial not found")
+
+func NewSerialRepository(session *gocql.Session) *SerialRepository {
+	return &SerialRepository{session: session}
+}
+
+func (r *SerialRepository) GetSerial(ctx context.Context, productID, serialNumber string) (*model.Serial, error) {
+	serials, err := scanSerials(r.session.Query(`SELECT serial_number, product_id, inventory_item_id, warehouse_id, status, stock_movement_id, created_at, updated_at FROM serials WHERE serial_number = ? AND product_id = ?`,
+		serialNumber, productID).WithContext(ctx).Consistency(gocql.Quorum).Iter())
+	if err != nil {
+		return nil, err
+	}
+	if len(serials) == 0 {
+		return nil, ErrSerialNotFound
+	}
+	return serials[0], nil
+}
+
+// ListSerialsByNumber returns the units of every product carrying a serial
+// number.
+func (r *SerialRepository) ListSerialsByNumber(ctx context.Context, serialNumber string) ([]*model.Serial, error) {
+	return scanSerials(r.session.Query(`SELECT serial_number, product_id, inventory_item_id, warehouse_id, status, stock_movement_id, created_at, updated_at FROM serials WHERE serial_number = ?`,
+		serialNumber).WithContext(ctx).Iter())
+}
+
+// MoveSerial saves the new state of a unit provided it is still in the state
+// previous was read in, or still unknown when previous is nil. It reports
+// whether the unit was saved.
+func (r *SerialRepository) MoveSerial(ctx context.Context, serial, previous *model.Serial) (bool, error) {
+	if previous == nil {
+		return r.session.Query(`INSERT INTO serials (serial_number, product_id, inventory_item_id, warehouse_id, status, stock_movement_id, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?) IF NOT EXISTS`,
+			serial.SerialNumber, serial.ProductID.String(), serial.InventoryItemID.String(), serial.WarehouseID.String(), serial.Status,
+			serial.StockMovementID.String(), serial.CreatedAt, serial.UpdatedAt).WithContext(ctx).MapScanCAS(map[string]interface{}{})
+	}
+	var updatedAt time.Time
+	return r.session.Query(`UPDATE serials SET inventory_item_id = ?, warehouse_id = ?, status = ?, stock_movement_id = ?, updated_at = ? WHERE serial_number = ? AND product_id = ? IF updated_at = ?`,
+		serial.InventoryItemID.String(), serial.WarehouseID.String(), serial.Status, serial.StockMovementID.String(), serial.UpdatedAt,
+		serial.SerialNumber, serial.ProductID.String(), previous.UpdatedAt).WithContext(ctx).ScanCAS(&updatedAt)
+}
+
+// RestoreSerial puts a unit back in the state it was read in before a
+// movement that could not be completed.
+func (r *SerialRepository) RestoreSerial(ctx context.Context, serial *model.Serial) error {
+	return r.session.Query(`INSERT INTO serials (serial_number, product_id, inventory_item_id, warehouse_id, status, stock_movement_id, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		serial.SerialNumber, serial.ProductID.String(), serial.InventoryItemID.String(), serial.WarehouseID.String(), serial.Status,
+		serial.StockMovementID.String(), serial.CreatedAt, serial.UpdatedAt).WithContext(ctx).Exec()
+}
+
+func (r *SerialRepository) DeleteSerial(ctx context.Context, productID, serialNumber string) error {
+	return r.session.Query(`DELETE FROM serials WHERE serial_number = ? AND product_id = ?`,
+		serialNumber, productID).WithContext(ctx).Exec()
+}
+
+func (r *SerialRepository) CreateSerialEvent(ctx context.Context, event *model.SerialEvent) error {
+	return r.session.Query(`INSERT INTO serial_events (product_id, serial_number, recorded_at, id, stock_movement_id, movement_type, status, inventory_item_id, warehouse_id, actor) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		event.ProductID.String(), event.SerialNumber, event.RecordedAt, event.ID.String(), event.StockMovementID.String(), event.MovementType,
+		event.Status, event.InventoryItemID.String(), event.WarehouseID.String(), event.Actor).WithContext(ctx).Exec()
+}
+
+// ListSerialEvents returns the history of a unit, oldest first.
+func (r *SerialRepository) ListSerialEvents(ctx context.Context, productID, serialNumber string) ([]*model.SerialEvent, error) {
+	var events []*model.SerialEvent
+	iter := r.session.Query(`SELECT product_id, serial_number, recorded_at, id, stock_movement_id, movement_type, status, inventory_item_id, warehouse_id, actor FROM serial_events WHERE product_id = ? AND serial_number = ?`,
+		productID, serialNumber).WithContext(ctx).Iter()
+	var productIDStr, number, idStr, movementIDStr, itemIDStr, warehouseIDStr, actor string
+	var recordedAt time.Time
+	var movementType model.StockMovementType
+	var status model.SerialStatus
+	for iter.Scan(&productIDStr, &number, &recordedAt, &idStr, &movementIDStr, &movementType, &status, &itemIDStr, &warehouseIDStr, &actor) {
+		event := &model.SerialEvent{
+			SerialNumber: number,
+			MovementType: movementType,
+			Status:       status,
+			Actor:        actor,
+			RecordedAt:   recordedAt,
+		}
+		event.ID, _ = uuid.Parse(idStr)
+		event.ProductID, _ = uuid.Parse(productIDStr)
+		event.StockMovementID, _ = uuid.Parse(movementIDStr)
+		event.InventoryItemID, _ = uuid.Parse(itemIDStr)
+		event.WarehouseID, _ = uuid.Parse(warehouseIDStr)
+		events = append(events, event)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func scanSerials(iter *gocql.Iter) ([]*model.Serial, error) {
+	var serials []*model.Serial
+	var number, productIDStr, itemIDStr, warehouseIDStr, movementIDStr string
+	var status model.SerialStatus
+	var createdAt, updatedAt time.Time
+	for iter.Scan(&number, &productIDStr, &itemIDStr, &warehouseIDStr, &status, &movementIDStr, &createdAt, &updatedAt) {
+		serial := &model.Serial{
+			SerialNumber: number,
+			Status:       status,
+			CreatedAt:    createdAt,
+			UpdatedAt:    updatedAt,
+		}
+		serial.ProductID, _ = uuid.Parse(productIDStr)
+		serial.InventoryItemID, _ = uuid.Parse(itemIDStr)
+		serial.WarehouseID, _ = uuid.Parse(warehouseIDStr)
+		serial.StockMovementID, _ = uuid.Parse(movementIDStr)
+		serials = append(serials, serial)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return serials, nil
+}
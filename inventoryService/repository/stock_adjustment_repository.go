@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/gocql/gocql"
+	"github.com/google/uuid"
+	"gopkg.in/inf.v0"
+	"inventoryService/model"
+	"time"
+)
+
+type StockAdjustmentRepository struct {
+	session *gocql.Session
+}
+
+var ErrStockAdjustmentNotFound = errors.New("stock adjustment not found")
+
+func NewStockAdjustmentRepository(session *gocql.Session) *StockAdjustmentRepository {
+	return &StockAdjustmentRepository{session: session}
+}
+
+func (r *StockAdjustmentRepository) CreateStockAdjustment(ctx context.Context, adjustment *model.StockAdjustment) error {
+	return r.session.Query(`INSERT INTO stock_adjustments (id, inventory_item_id, product_id, warehouse_id, lot_id, serial_numbers, quantity_change_decimal, reason, notes, value_amount, currency, status, requested_by, reviewed_by, review_notes, stock_movement_id, created_at, reviewed_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		adjustment.ID.String(), adjustment.InventoryItemID.String(), adjustment.ProductID.String(), adjustment.WarehouseID.String(), adjustment.LotID.String(), adjustment.SerialNumbers,
+		decimalFromQuantity(adjustment.QuantityChange), adjustment.Reason, adjustment.Notes, decimalFromMoney(adjustment.Value), adjustment.Value.CurrencyCode, adjustment.Status, adjustment.RequestedBy,
+		adjustment.ReviewedBy, adjustment.ReviewNotes, adjustment.StockMovementID.String(), adjustment.CreatedAt, adjustment.ReviewedAt).WithContext(ctx).Exec()
+}
+
+func (r *StockAdjustmentRepository) GetStockAdjustment(ctx context.Context, id string) (*model.StockAdjustment, error) {
+	var idStr, itemIDStr, productIDStr, warehouseIDStr, lotIDStr, movementIDStr, currency string
+	var value, quantityChange *inf.Dec
+	adjustment := &model.StockAdjustment{}
+	err := r.session.Query(`SELECT id, inventory_item_id, product_id, warehouse_id, lot_id, serial_numbers, quantity_change_decimal, reason, notes, value_amount, currency, status, requested_by, reviewed_by, review_notes, stock_movement_id, created_at, reviewed_at FROM stock_adjustments WHERE id = ? LIMIT 1`,
+		id).WithContext(ctx).Consistency(gocql.One).Scan(&idStr, &itemIDStr, &productIDStr, &warehouseIDStr, &lotIDStr, &adjustment.SerialNumbers,
+		&quantityChange, &adjustment.Reason, &adjustment.Notes, &value, &currency, &adjustment.Status, &adjustment.RequestedBy,
+		&adjustment.ReviewedBy, &adjustment.ReviewNotes, &movementIDStr, &adjustment.CreatedAt, &adjustment.ReviewedAt)
+	if err != nil {
+		if errors.Is(err, gocql.ErrNotFound) {
+			return nil, ErrStockAdjustmentNotFound
+		}
+		return nil, err
+	}
+	adjustment.ID, _ = uuid.Parse(idStr)
+	adjustment.InventoryItemID, _ = uuid.Parse(itemIDStr)
+	adjustment.ProductID, _ = uuid.Parse(productIDStr)
+	adjustment.WarehouseID, _ = uuid.Parse(warehouseIDStr)
+	adjustment.LotID, _ = uuid.Parse(lotIDStr)
+	adjustment.StockMovementID, _ = uuid.Parse(movementIDStr)
+	adjustment.QuantityChange = quantityFromDecimal(quantityChange)
+	adjustment.Value = moneyFromDecimal(value, currency)
+	return adjustment, nil
+}
+
+func (r *StockAdjustmentRepository) ListStockAdjustments(ctx context.Context) ([]*model.StockAdjustment, error) {
+	var adjustments []*model.StockAdjustment
+	iter := r.session.Query(`SELECT id, inventory_item_id, product_id, warehouse_id, lot_id, serial_numbers, quantity_change_decimal, reason, notes, value_amount, currency, status, requested_by, reviewed_by, review_notes, stock_movement_id, created_at, reviewed_at FROM stock_adjustments`).WithContext(ctx).Iter()
+	var idStr, itemIDStr, productIDStr, warehouseIDStr, lotIDStr, movementIDStr, notes, currency, requestedBy, reviewedBy, reviewNotes string
+	var quantityChange *inf.Dec
+	var reason model.AdjustmentReason
+	var value *inf.Dec
+	var adjustmentStatus model.AdjustmentStatus
+	var createdAt, reviewedAt time.Time
+	var serialNumbers []string
+	for iter.Scan(&idStr, &itemIDStr, &productIDStr, &warehouseIDStr, &lotIDStr, &serialNumbers, &quantityChange, &reason, &notes, &value, &currency, &adjustmentStatus,
+		&requestedBy, &reviewedBy, &reviewNotes, &movementIDStr, &createdAt, &reviewedAt) {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			continue
+		}
+		adjustment := &model.StockAdjustment{
+			ID:             id,
+			QuantityChange: quantityFromDecimal(quantityChange),
+			Reason:         reason,
+			Notes:          notes,
+			Value:          moneyFromDecimal(value, currency),
+			Status:         adjustmentStatus,
+			RequestedBy:    requestedBy,
+			ReviewedBy:     reviewedBy,
+			ReviewNotes:    reviewNotes,
+			CreatedAt:      createdAt,
+			ReviewedAt:     reviewedAt,
+			SerialNumbers:  serialNumbers,
+		}
+		adjustment.InventoryItemID, _ = uuid.Parse(itemIDStr)
+		adjustment.ProductID, _ = uuid.Parse(productIDStr)
+		adjustment.WarehouseID, _ = uuid.Parse(warehouseIDStr)
+		adjustment.LotID, _ = uuid.Parse(lotIDStr)
+		adjustment.StockMovementID, _ = uuid.Parse(movementIDStr)
+		adjustments = append(adjustments, adjustment)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return adjustments, nil
+}
+
+// ClaimStockAdjustment moves a pending adjustment to status, reporting false
+// when it is no longer pending so that two reviewers cannot both act on it.
+func (r *StockAdjustmentRepository) ClaimStockAdjustment(ctx context.Context, id string, status model.AdjustmentStatus) (bool, error) {
+	var current model.AdjustmentStatus
+	return r.session.Query(`UPDATE stock_adjustments SET status = ? WHERE id = ? IF status = ?`,
+		status, id, model.AdjustmentPending).WithContext(ctx).ScanCAS(&current)
+}
+
+// ReleaseStockAdjustment returns an adjustment claimed with status to pending.
+func (r *StockAdjustmentRepository) ReleaseStockAdjustment(ctx context.Context, id string, status model.AdjustmentStatus) error {
+	var current model.AdjustmentStatus
+	_, err := r.session.Query(`UPDATE stock_adjustments SET status = ? WHERE id = ? IF status = ?`,
+		model.AdjustmentPending, id, status).WithContext(ctx).ScanCAS(&current)
+	return err
+}
+
+func (r *StockAdjustmentRepository) SaveReview(ctx context.Context, adjustment *model.StockAdjustment) error {
+	return r.session.Query(`UPDATE stock_adjustments SET status = ?, reviewed_by = ?, review_notes = ?, reviewed_at = ?, stock_movement_id = ? WHERE id = ?`,
+		adjustment.Status, adjustment.ReviewedBy, adjustment.ReviewNotes, adjustment.ReviewedAt, adjustment.StockMovementID.String(), adjustment.ID.String()).WithContext(ctx).Exec()
+}
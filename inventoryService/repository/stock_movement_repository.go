@@ -2,9 +2,11 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/gocql/gocql"
 	"github.com/google/uuid"
+	"gopkg.in/inf.v0"
 	"inventoryService/model"
 	"time"
 )
@@ -13,49 +15,75 @@ type StockMovementRepository struct {
 	session *gocql.Session
 }
 
+var ErrStockMovementNotFound = errors.New("stock movement not found")
+
 func NewStockMovementRepository(session *gocql.Session) *StockMovementRepository {
 	return &StockMovementRepository{session: session}
 }
 
 func (r *StockMovementRepository) CreateStockMovement(ctx context.Context, movement *model.StockMovement) error {
-	return r.session.Query(`INSERT INTO stock_movements (id, inventory_item_id, type, quantity, date, source_warehouse_id, destination_warehouse_id) VALUES (?, ?, ?, ?, ?, ?, ?)`,
-		movement.ID.String(), movement.InventoryItemID.String(), movement.Type, movement.Quantity, movement.Date, movement.SourceWarehouseID.String(), movement.DestinationWarehouseID.String()).WithContext(ctx).Exec()
+	return r.session.Query(`INSERT INTO stock_movements (id, inventory_item_id, type, quantity_decimal, date, source_warehouse_id, destination_warehouse_id, lot_id, serial_numbers, source_bin_id, destination_bin_id, unit_cost_amount, cost_amount, currency, unit, unit_quantity_decimal, posted) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		movement.ID.String(), movement.InventoryItemID.String(), movement.Type, decimalFromQuantity(movement.Quantity), movement.Date, movement.SourceWarehouseID.String(), movement.DestinationWarehouseID.String(), movement.LotID.String(), movement.SerialNumbers, movement.SourceBinID.String(), movement.DestinationBinID.String(),
+		decimalFromMoney(movement.UnitCost), decimalFromMoney(movement.Cost), movement.UnitCost.CurrencyCode, movement.Unit, decimalFromQuantity(movement.UnitQuantity), movement.Posted).WithContext(ctx).Exec()
 }
 
 func (r *StockMovementRepository) GetStockMovement(ctx context.Context, id string) (*model.StockMovement, error) {
-	var idStr, inventoryItemIdStr, sourceWarehouseIdStr, destinationWarehouseIdStr string
+	var idStr, inventoryItemIdStr, sourceWarehouseIdStr, destinationWarehouseIdStr, lotIdStr, sourceBinIdStr, destinationBinIdStr, currency string
+	var unitCost, cost, quantity, unitQuantity *inf.Dec
 	movement := &model.StockMovement{}
-	if err := r.session.Query(`SELECT id, inventory_item_id, type, quantity, date, source_warehouse_id, destination_warehouse_id FROM stock_movements WHERE id = ? LIMIT 1`,
-		id).WithContext(ctx).Consistency(gocql.One).Scan(&idStr, &inventoryItemIdStr, &movement.Type, &movement.Quantity, &movement.Date, &sourceWarehouseIdStr, &destinationWarehouseIdStr); err != nil {
+	if err := r.session.Query(`SELECT id, inventory_item_id, type, quantity_decimal, date, source_warehouse_id, destination_warehouse_id, lot_id, serial_numbers, source_bin_id, destination_bin_id, unit_cost_amount, cost_amount, currency, unit, unit_quantity_decimal, posted FROM stock_movements WHERE id = ? LIMIT 1`,
+		id).WithContext(ctx).Consistency(gocql.One).Scan(&idStr, &inventoryItemIdStr, &movement.Type, &quantity, &movement.Date, &sourceWarehouseIdStr, &destinationWarehouseIdStr, &lotIdStr, &movement.SerialNumbers, &sourceBinIdStr, &destinationBinIdStr,
+		&unitCost, &cost, &currency, &movement.Unit, &unitQuantity, &movement.Posted); err != nil {
+		if errors.Is(err, gocql.ErrNotFound) {
+			return nil, ErrStockMovementNotFound
+		}
 		return nil, err
 	}
 	movement.ID, _ = uuid.Parse(idStr)
 	movement.InventoryItemID, _ = uuid.Parse(inventoryItemIdStr)
 	movement.SourceWarehouseID, _ = uuid.Parse(sourceWarehouseIdStr)
 	movement.DestinationWarehouseID, _ = uuid.Parse(destinationWarehouseIdStr)
+	movement.LotID, _ = uuid.Parse(lotIdStr)
+	movement.SourceBinID, _ = uuid.Parse(sourceBinIdStr)
+	movement.DestinationBinID, _ = uuid.Parse(destinationBinIdStr)
+	movement.UnitCost = moneyFromDecimal(unitCost, currency)
+	movement.Cost = moneyFromDecimal(cost, currency)
+	movement.Quantity = quantityFromDecimal(quantity)
+	movement.UnitQuantity = quantityFromDecimal(unitQuantity)
 	return movement, nil
 }
 
 func (r *StockMovementRepository) ListStockMovements(ctx context.Context) ([]*model.StockMovement, error) {
 	var movements []*model.StockMovement
-	iter := r.session.Query(`SELECT id, inventory_item_id, type, quantity, date, source_warehouse_id, destination_warehouse_id FROM stock_movements`).WithContext(ctx).Iter()
-	var idStr, inventoryItemIdStr, sourceWarehouseIdStr, destinationWarehouseIdStr string
+	iter := r.session.Query(`SELECT id, inventory_item_id, type, quantity_decimal, date, source_warehouse_id, destination_warehouse_id, lot_id, serial_numbers, source_bin_id, destination_bin_id, unit_cost_amount, cost_amount, currency, unit, unit_quantity_decimal, posted FROM stock_movements`).WithContext(ctx).Iter()
+	var idStr, inventoryItemIdStr, sourceWarehouseIdStr, destinationWarehouseIdStr, lotIdStr, sourceBinIdStr, destinationBinIdStr, currency, unit string
 	var movementTypeInt int
-	var quantity int
 	var date time.Time
-	for iter.Scan(&idStr, &inventoryItemIdStr, &movementTypeInt, &quantity, &date, &sourceWarehouseIdStr, &destinationWarehouseIdStr) {
-		if movementTypeInt < 0 || movementTypeInt > 2 {
+	var serialNumbers []string
+	var unitCost, cost, quantity, unitQuantity *inf.Dec
+	var posted bool
+	for iter.Scan(&idStr, &inventoryItemIdStr, &movementTypeInt, &quantity, &date, &sourceWarehouseIdStr, &destinationWarehouseIdStr, &lotIdStr, &serialNumbers, &sourceBinIdStr, &destinationBinIdStr, &unitCost, &cost, &currency, &unit, &unitQuantity, &posted) {
+		if !model.StockMovementType(movementTypeInt).Valid() {
 			return nil, fmt.Errorf("invalid stock movement type: %d", movementTypeInt)
 		}
 		movement := &model.StockMovement{
 			ID:                     uuid.MustParse(idStr),
 			InventoryItemID:        uuid.MustParse(inventoryItemIdStr),
 			Type:                   model.StockMovementType(movementTypeInt),
-			Quantity:               quantity,
+			Quantity:               quantityFromDecimal(quantity),
 			Date:                   date,
 			SourceWarehouseID:      uuid.MustParse(sourceWarehouseIdStr),
 			DestinationWarehouseID: uuid.MustParse(destinationWarehouseIdStr),
+			SerialNumbers:          serialNumbers,
+			UnitCost:               moneyFromDecimal(unitCost, currency),
+			Cost:                   moneyFromDecimal(cost, currency),
+			Unit:                   unit,
+			UnitQuantity:           quantityFromDecimal(unitQuantity),
+			Posted:                 posted,
 		}
+		movement.LotID, _ = uuid.Parse(lotIdStr)
+		movement.SourceBinID, _ = uuid.Parse(sourceBinIdStr)
+		movement.DestinationBinID, _ = uuid.Parse(destinationBinIdStr)
 		movements = append(movements, movement)
 	}
 	if err := iter.Close(); err != nil {
@@ -64,9 +92,12 @@ func (r *StockMovementRepository) ListStockMovements(ctx context.Context) ([]*mo
 	return movements, nil
 }
 
+// UpdateStockMovement rewrites a movement that was journaled without being
+// posted; posted movements are never rewritten.
 func (r *StockMovementRepository) UpdateStockMovement(ctx context.Context, movement *model.StockMovement) error {
-	return r.session.Query(`UPDATE stock_movements SET inventory_item_id = ?, type = ?, quantity = ?, date = ?, source_warehouse_id = ?, destination_warehouse_id = ? WHERE id = ?`,
-		movement.InventoryItemID.String(), movement.Type, movement.Quantity, movement.Date, movement.SourceWarehouseID.String(), movement.DestinationWarehouseID.String(), movement.ID.String()).WithContext(ctx).Exec()
+	return r.session.Query(`UPDATE stock_movements SET inventory_item_id = ?, type = ?, quantity_decimal = ?, date = ?, source_warehouse_id = ?, destination_warehouse_id = ?, lot_id = ?, serial_numbers = ?, source_bin_id = ?, destination_bin_id = ?, unit_cost_amount = ?, cost_amount = ?, currency = ?, unit = ?, unit_quantity_decimal = ? WHERE id = ?`,
+		movement.InventoryItemID.String(), movement.Type, decimalFromQuantity(movement.Quantity), movement.Date, movement.SourceWarehouseID.String(), movement.DestinationWarehouseID.String(), movement.LotID.String(), movement.SerialNumbers, movement.SourceBinID.String(), movement.DestinationBinID.String(),
+		decimalFromMoney(movement.UnitCost), decimalFromMoney(movement.Cost), movement.UnitCost.CurrencyCode, movement.Unit, decimalFromQuantity(movement.UnitQuantity), movement.ID.String()).WithContext(ctx).Exec()
 }
 
 func (r *StockMovementRepository) DeleteStockMovement(ctx context.Context, id string) error {
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/gocql/gocql"
+	"github.com/google/uuid"
+	"gopkg.in/inf.v0"
+	"inventoryService/model"
+)
+
+type SupplierProductRepository struct {
+	session *gocql.Session
+}
+
+var ErrSupplierProductNotFound = errors.New("supplier product not found")
+
+const supplierProductColumns = `id, supplier_id, product_id, supplier_sku, unit_cost_amount, currency, minimum_order_quantity, pack_size, lead_time_days, preferred`
+
+func NewSupplierProductRepository(session *gocql.Session) *SupplierProductRepository {
+	return &SupplierProductRepository{session: session}
+}
+
+func (r *SupplierProductRepository) CreateSupplierProduct(ctx context.Context, sp *model.SupplierProduct) error {
+	return r.session.Query(`INSERT INTO supplier_products (`+supplierProductColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		sp.ID.String(), sp.SupplierID.String(), sp.ProductID.String(), sp.SupplierSKU, decimalFromMoney(sp.UnitCost), sp.UnitCost.CurrencyCode,
+		sp.MinimumOrderQuantity, sp.PackSize, sp.LeadTimeDays, sp.Preferred).WithContext(ctx).Exec()
+}
+
+func (r *SupplierProductRepository) GetSupplierProduct(ctx context.Context, id string) (*model.SupplierProduct, error) {
+	iter := r.session.Query(`SELECT `+supplierProductColumns+` FROM supplier_products WHERE id = ? LIMIT 1`,
+		id).WithContext(ctx).Consistency(gocql.One).Iter()
+	supplierProducts, err := scanSupplierProducts(iter)
+	if err != nil {
+		return nil, err
+	}
+	if len(supplierProducts) == 0 {
+		return nil, ErrSupplierProductNotFound
+	}
+	return supplierProducts[0], nil
+}
+
+// ListSupplierProductsBySupplier returns the catalog of a supplier.
+func (r *SupplierProductRepository) ListSupplierProductsBySupplier(ctx context.Context, supplierID string) ([]*model.SupplierProduct, error) {
+	iter := r.session.Query(`SELECT `+supplierProductColumns+` FROM supplier_products WHERE supplier_id = ?`,
+		supplierID).WithContext(ctx).Iter()
+	return scanSupplierProducts(iter)
+}
+
+// ListSupplierProductsByProduct returns every supplier offer of a product.
+func (r *SupplierProductRepository) ListSupplierProductsByProduct(ctx context.Context, productID string) ([]*model.SupplierProduct, error) {
+	iter := r.session.Query(`SELECT `+supplierProductColumns+` FROM supplier_products WHERE product_id = ?`,
+		productID).WithContext(ctx).Iter()
+	return scanSupplierProducts(iter)
+}
+
+func (r *SupplierProductRepository) UpdateSupplierProduct(ctx context.Context, sp *model.SupplierProduct) error {
+	return r.session.Query(`UPDATE supplier_products SET supplier_sku = ?, unit_cost_amount = ?, currency = ?, minimum_order_quantity = ?, pack_size = ?, lead_time_days = ?, preferred = ? WHERE id = ?`,
+		sp.SupplierSKU, decimalFromMoney(sp.UnitCost), sp.UnitCost.CurrencyCode, sp.MinimumOrderQuantity, sp.PackSize, sp.LeadTimeDays, sp.Preferred, sp.ID.String()).WithContext(ctx).Exec()
+}
+
+func (r *SupplierProductRepository) SetPreferred(ctx context.Context, id string, preferred bool) error {
+	return r.session.Query(`UPDATE supplier_products SET preferred = ? WHERE id = ?`,
+		preferred, id).WithContext(ctx).Exec()
+}
+
+func (r *SupplierProductRepository) DeleteSupplierProduct(ctx context.Context, id string) error {
+	return r.session.Query(`DELETE FROM supplier_products WHERE id = ?`, id).WithContext(ctx).Exec()
+}
+
+func scanSupplierProducts(iter *gocql.Iter) ([]*model.SupplierProduct, error) {
+	var supplierProducts []*model.SupplierProduct
+	var idStr, supplierIDStr, productIDStr, supplierSKU, currency string
+	var unitCost *inf.Dec
+	var minimumOrderQuantity, packSize, leadTimeDays int
+	var preferred bool
+	for iter.Scan(&idStr, &supplierIDStr, &productIDStr, &supplierSKU, &unitCost, &currency, &minimumOrderQuantity, &packSize, &leadTimeDays, &preferred) {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			continue
+		}
+		supplierID, _ := uuid.Parse(supplierIDStr)
+		productID, _ := uuid.Parse(productIDStr)
+		supplierProducts = append(supplierProducts, &model.SupplierProduct{
+			ID:                   id,
+			SupplierID:           supplierID,
+			ProductID:            productID,
+			SupplierSKU:          supplierSKU,
+			UnitCost:             moneyFromDecimal(unitCost, currency),
+			MinimumOrderQuantity: minimumOrderQuantity,
+			PackSize:             packSize,
+			LeadTimeDays:         leadTimeDays,
+			Preferred:            preferred,
+		})
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return supplierProducts, nil
+}
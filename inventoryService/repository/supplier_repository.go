@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"github.com/gocql/gocql"
 	"github.com/google/uuid"
 	"inventoryService/model"
@@ -11,6 +12,8 @@ type SupplierRepository struct {
 	session *gocql.Session
 }
 
+var ErrSupplierNotFound = errors.New("supplier not found")
+
 func NewSupplierRepository(session *gocql.Session) *SupplierRepository {
 	return &SupplierRepository{session: session}
 }
@@ -25,6 +28,9 @@ func (r *SupplierRepository) GetSupplier(ctx context.Context, id string) (*model
 	supplier := &model.Supplier{}
 	if err := r.session.Query(`SELECT id, name, contact_info FROM suppliers WHERE id = ? LIMIT 1`,
 		id).WithContext(ctx).Consistency(gocql.One).Scan(&idStr, &supplier.Name, &supplier.ContactInfo); err != nil {
+		if errors.Is(err, gocql.ErrNotFound) {
+			return nil, ErrSupplierNotFound
+		}
 		return nil, err
 	}
 	supplier.ID, _ = uuid.Parse(idStr)
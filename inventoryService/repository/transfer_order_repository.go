@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/gocql/gocql"
+	"github.com/google/uuid"
+	"gopkg.in/inf.v0"
+	"inventoryService/model"
+	"time"
+)
+
+type TransferOrderRepository struct {
+	session *gocql.Session
+}
+
+var ErrTransferOrderNotFound = errors.New("transfer order not found")
+
+func NewTransferOrderRepository(session *gocql.Session) *TransferOrderRepository {
+	return &TransferOrderRepository{session: session}
+}
+
+func (r *TransferOrderRepository) CreateTransferOrder(ctx context.Context, order *model.TransferOrder) error {
+	batch := r.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	batch.Query(`INSERT INTO transfer_orders (id, source_warehouse_id, destination_warehouse_id, status, notes, created_at, updated_at, shipped_at, received_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		order.ID.String(), order.SourceWarehouseID.String(), order.DestinationWarehouseID.String(), order.Status, order.Notes,
+		order.CreatedAt, order.UpdatedAt, order.ShippedAt, order.ReceivedAt)
+	for _, line := range order.Lines {
+		addTransferOrderLine(batch, order.ID, line)
+	}
+	return r.session.ExecuteBatch(batch)
+}
+
+func (r *TransferOrderRepository) GetTransferOrder(ctx context.Context, id string) (*model.TransferOrder, error) {
+	var idStr, sourceIDStr, destinationIDStr, receivingIDStr string
+	order := &model.TransferOrder{}
+	err := r.session.Query(`SELECT id, source_warehouse_id, destination_warehouse_id, status, notes, created_at, updated_at, shipped_at, received_at, receiving_id FROM transfer_orders WHERE id = ? LIMIT 1`,
+		id).WithContext(ctx).Consistency(gocql.One).Scan(&idStr, &sourceIDStr, &destinationIDStr, &order.Status, &order.Notes,
+		&order.CreatedAt, &order.UpdatedAt, &order.ShippedAt, &order.ReceivedAt, &receivingIDStr)
+	if err != nil {
+		if errors.Is(err, gocql.ErrNotFound) {
+			return nil, ErrTransferOrderNotFound
+		}
+		return nil, err
+	}
+	order.ID, _ = uuid.Parse(idStr)
+	order.SourceWarehouseID, _ = uuid.Parse(sourceIDStr)
+	order.DestinationWarehouseID, _ = uuid.Parse(destinationIDStr)
+	order.ReceivingID, _ = uuid.Parse(receivingIDStr)
+	if err := r.loadDetails(ctx, order); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+func (r *TransferOrderRepository) ListTransferOrders(ctx context.Context) ([]*model.TransferOrder, error) {
+	var orders []*model.TransferOrder
+	iter := r.session.Query(`SELECT id, source_warehouse_id, destination_warehouse_id, status, notes, created_at, updated_at, shipped_at, received_at, receiving_id FROM transfer_orders`).WithContext(ctx).Iter()
+	var idStr, sourceIDStr, destinationIDStr, notes, receivingIDStr string
+	var orderStatus model.TransferOrderStatus
+	var createdAt, updatedAt, shippedAt, receivedAt time.Time
+	for iter.Scan(&idStr, &sourceIDStr, &destinationIDStr, &orderStatus, &notes, &createdAt, &updatedAt, &shippedAt, &receivedAt, &receivingIDStr) {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			continue
+		}
+		order := &model.TransferOrder{
+			ID:         id,
+			Status:     orderStatus,
+			Notes:      notes,
+			CreatedAt:  createdAt,
+			UpdatedAt:  updatedAt,
+			ShippedAt:  shippedAt,
+			ReceivedAt: receivedAt,
+		}
+		order.SourceWarehouseID, _ = uuid.Parse(sourceIDStr)
+		order.DestinationWarehouseID, _ = uuid.Parse(destinationIDStr)
+		order.ReceivingID, _ = uuid.Parse(receivingIDStr)
+		orders = append(orders, order)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	for _, order := range orders {
+		if err := r.loadDetails(ctx, order); err != nil {
+			return nil, err
+		}
+	}
+	return orders, nil
+}
+
+// UpdateTransferOrderStatus moves an order from one status to another. It
+// reports false, changing nothing, when the order is no longer in from or is
+// being received.
+func (r *TransferOrderRepository) UpdateTransferOrderStatus(ctx context.Context, id string, from, to model.TransferOrderStatus) (bool, error) {
+	return r.session.Query(`UPDATE transfer_orders SET status = ? WHERE id = ? IF status = ? AND receiving_id = null`,
+		to, id, from).WithContext(ctx).MapScanCAS(map[string]interface{}{})
+}
+
+// ClaimReceiving holds an order in the given status for one receipt, so that
+// no other receipt or status change is accepted until FinishReceiving. When
+// the order has moved on or is held by another receipt it reports false and
+// the receipt holding it, if any.
+func (r *TransferOrderRepository) ClaimReceiving(ctx context.Context, id string, status model.TransferOrderStatus, claimID uuid.UUID) (bool, uuid.UUID, error) {
+	current := map[string]interface{}{}
+	applied, err := r.session.Query(`UPDATE transfer_orders SET receiving_id = ? WHERE id = ? IF status = ? AND receiving_id = null`,
+		claimID.String(), id, status).WithContext(ctx).MapScanCAS(current)
+	if err != nil || applied {
+		return applied, uuid.Nil, err
+	}
+	holder, _ := current["receiving_id"].(gocql.UUID)
+	return false, uuid.UUID(holder), nil
+}
+
+// FinishReceiving sets the status an order is left in by a receipt and
+// releases the receipt's hold on it.
+func (r *TransferOrderRepository) FinishReceiving(ctx context.Context, id string, claimID uuid.UUID, status model.TransferOrderStatus) (bool, error) {
+	return r.session.Query(`UPDATE transfer_orders SET status = ?, receiving_id = null WHERE id = ? IF receiving_id = ?`,
+		status, id, claimID.String()).WithContext(ctx).MapScanCAS(map[string]interface{}{})
+}
+
+// SaveProgress stores the timestamps of an order together with its lines. The
+// status is changed separately, by UpdateTransferOrderStatus or
+// FinishReceiving.
+func (r *TransferOrderRepository) SaveProgress(ctx context.Context, order *model.TransferOrder) error {
+	batch := r.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	batch.Query(`UPDATE transfer_orders SET updated_at = ?, shipped_at = ?, received_at = ? WHERE id = ?`,
+		order.UpdatedAt, order.ShippedAt, order.ReceivedAt, order.ID.String())
+	for _, line := range order.Lines {
+		addTransferOrderLine(batch, order.ID, line)
+	}
+	return r.session.ExecuteBatch(batch)
+}
+
+// SaveReceipts stores new receipt lines of an order, not posted yet.
+func (r *TransferOrderRepository) SaveReceipts(ctx context.Context, order *model.TransferOrder, receipts []*model.TransferReceiptLine) error {
+	batch := r.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	for _, receipt := range receipts {
+		batch.Query(`INSERT INTO transfer_order_receipts (transfer_order_id, id, line_id, quantity_decimal, received_at, stock_movement_id, notes, serial_numbers, posted) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			order.ID.String(), receipt.ID.String(), receipt.LineID.String(), decimalFromQuantity(receipt.Quantity), receipt.ReceivedAt, receipt.StockMovementID.String(), receipt.Notes, receipt.SerialNumbers, false)
+	}
+	return r.session.ExecuteBatch(batch)
+}
+
+// SaveReceiptPosted marks a receipt line as posted and records the new
+// received total of its order line, together.
+func (r *TransferOrderRepository) SaveReceiptPosted(ctx context.Context, order *model.TransferOrder, receipt *model.TransferReceiptLine, receivedQuantity model.Quantity) error {
+	batch := r.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	batch.Query(`UPDATE transfer_order_receipts SET posted = ? WHERE transfer_order_id = ? AND id = ?`,
+		true, order.ID.String(), receipt.ID.String())
+	batch.Query(`UPDATE transfer_order_lines SET received_quantity_decimal = ? WHERE transfer_order_id = ? AND id = ?`,
+		decimalFromQuantity(receivedQuantity), order.ID.String(), receipt.LineID.String())
+	return r.session.ExecuteBatch(batch)
+}
+
+// DeleteReceipts removes receipt lines of an order that were never posted.
+func (r *TransferOrderRepository) DeleteReceipts(ctx context.Context, order *model.TransferOrder, receipts []*model.TransferReceiptLine) error {
+	batch := r.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	for _, receipt := range receipts {
+		batch.Query(`DELETE FROM transfer_order_receipts WHERE transfer_order_id = ? AND id = ?`, order.ID.String(), receipt.ID.String())
+	}
+	return r.session.ExecuteBatch(batch)
+}
+
+func (r *TransferOrderRepository) loadDetails(ctx context.Context, order *model.TransferOrder) error {
+	iter := r.session.Query(`SELECT id, product_id, quantity_decimal, shipped_quantity_decimal, received_quantity_decimal, discrepancy_quantity_decimal, shipment_movement_id, serial_numbers FROM transfer_order_lines WHERE transfer_order_id = ?`,
+		order.ID.String()).WithContext(ctx).Iter()
+	var idStr, productIDStr, movementIDStr string
+	var quantity, shipped, received, discrepancy *inf.Dec
+	var serialNumbers []string
+	for iter.Scan(&idStr, &productIDStr, &quantity, &shipped, &received, &discrepancy, &movementIDStr, &serialNumbers) {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			continue
+		}
+		line := &model.TransferOrderLine{
+			ID:                  id,
+			Quantity:            quantityFromDecimal(quantity),
+			ShippedQuantity:     quantityFromDecimal(shipped),
+			ReceivedQuantity:    quantityFromDecimal(received),
+			DiscrepancyQuantity: quantityFromDecimal(discrepancy),
+			SerialNumbers:       serialNumbers,
+		}
+		line.ProductID, _ = uuid.Parse(productIDStr)
+		line.ShipmentMovementID, _ = uuid.Parse(movementIDStr)
+		order.Lines = append(order.Lines, line)
+	}
+	if err := iter.Close(); err != nil {
+		return err
+	}
+
+	iter = r.session.Query(`SELECT id, line_id, quantity_decimal, received_at, stock_movement_id, notes, serial_numbers, posted FROM transfer_order_receipts WHERE transfer_order_id = ?`,
+		order.ID.String()).WithContext(ctx).Iter()
+	var lineIDStr, notes string
+	var receivedAt time.Time
+	var posted bool
+	for iter.Scan(&idStr, &lineIDStr, &quantity, &receivedAt, &movementIDStr, &notes, &serialNumbers, &posted) {
+		receipt := &model.TransferReceiptLine{Quantity: quantityFromDecimal(quantity), ReceivedAt: receivedAt, Notes: notes, SerialNumbers: serialNumbers, Posted: posted}
+		receipt.ID, _ = uuid.Parse(idStr)
+		receipt.LineID, _ = uuid.Parse(lineIDStr)
+		receipt.StockMovementID, _ = uuid.Parse(movementIDStr)
+		order.Receipts = append(order.Receipts, receipt)
+	}
+	return iter.Close()
+}
+
+func addTransferOrderLine(batch *gocql.Batch, transferOrderID uuid.UUID, line *model.TransferOrderLine) {
+	batch.Query(`INSERT INTO transfer_order_lines (transfer_order_id, id, product_id, quantity_decimal, shipped_quantity_decimal, received_quantity_decimal, discrepancy_quantity_decimal, shipment_movement_id, serial_numbers) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		transferOrderID.String(), line.ID.String(), line.ProductID.String(), decimalFromQuantity(line.Quantity), decimalFromQuantity(line.ShippedQuantity), decimalFromQuantity(line.ReceivedQuantity), decimalFromQuantity(line.DiscrepancyQuantity), line.ShipmentMovementID.String(), line.SerialNumbers)
+}
@@ -2,8 +2,10 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"github.com/gocql/gocql"
 	"github.com/google/uuid"
+	"gopkg.in/inf.v0"
 	"inventoryService/model"
 )
 
@@ -16,35 +18,50 @@ func NewWarehouseRepository(session *gocql.Session) *WarehouseRepository {
 }
 
 func (r *WarehouseRepository) CreateWarehouse(ctx context.Context, warehouse *model.Warehouse) error {
-	return r.session.Query(`INSERT INTO warehouses (id, name, location) VALUES (?, ?, ?)`,
-		warehouse.ID.String(), warehouse.Name, warehouse.Location).WithContext(ctx).Exec()
+	return r.session.Query(`INSERT INTO warehouses (id, name, location, max_units_decimal, max_volume, max_weight, capacity_policy) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		warehouse.ID.String(), warehouse.Name, warehouse.Location, decimalFromQuantity(warehouse.Capacity.MaxUnits), warehouse.Capacity.MaxVolume, warehouse.Capacity.MaxWeight,
+		warehouse.CapacityPolicy).WithContext(ctx).Exec()
 }
 
+var ErrWarehouseNotFound = errors.New("warehouse not found")
+
 func (r *WarehouseRepository) GetWarehouse(ctx context.Context, id string) (*model.Warehouse, error) {
 	var idStr string
+	var maxUnits *inf.Dec
 	warehouse := &model.Warehouse{}
-	if err := r.session.Query(`SELECT id, name, location FROM warehouses WHERE id = ? LIMIT 1`,
-		id).WithContext(ctx).Consistency(gocql.One).Scan(&idStr, &warehouse.Name, &warehouse.Location); err != nil {
+	if err := r.session.Query(`SELECT id, name, location, max_units_decimal, max_volume, max_weight, capacity_policy FROM warehouses WHERE id = ? LIMIT 1`,
+		id).WithContext(ctx).Consistency(gocql.One).Scan(&idStr, &warehouse.Name, &warehouse.Location, &maxUnits,
+		&warehouse.Capacity.MaxVolume, &warehouse.Capacity.MaxWeight, &warehouse.CapacityPolicy); err != nil {
+		if errors.Is(err, gocql.ErrNotFound) {
+			return nil, ErrWarehouseNotFound
+		}
 		return nil, err
 	}
 	warehouse.ID, _ = uuid.Parse(idStr)
+	warehouse.Capacity.MaxUnits = quantityFromDecimal(maxUnits)
 	return warehouse, nil
 }
 
 func (r *WarehouseRepository) ListWarehouses(ctx context.Context) ([]*model.Warehouse, error) {
 	var warehouses []*model.Warehouse
-	iter := r.session.Query(`SELECT id, name, location FROM warehouses`).WithContext(ctx).Iter()
+	iter := r.session.Query(`SELECT id, name, location, max_units_decimal, max_volume, max_weight, capacity_policy FROM warehouses`).WithContext(ctx).Iter()
 	var id string
 	var name, location string
-	for iter.Scan(&id, &name, &location) {
+	var capacity model.Capacity
+	var maxUnits *inf.Dec
+	var capacityPolicy model.CapacityPolicy
+	for iter.Scan(&id, &name, &location, &maxUnits, &capacity.MaxVolume, &capacity.MaxWeight, &capacityPolicy) {
 		convertedID, err := uuid.Parse(id)
 		if err != nil {
 			continue
 		}
+		capacity.MaxUnits = quantityFromDecimal(maxUnits)
 		warehouse := &model.Warehouse{
-			ID:       convertedID,
-			Name:     name,
-			Location: location,
+			ID:             convertedID,
+			Name:           name,
+			Location:       location,
+			Capacity:       capacity,
+			CapacityPolicy: capacityPolicy,
 		}
 		warehouses = append(warehouses, warehouse)
 	}
@@ -55,8 +72,9 @@ func (r *WarehouseRepository) ListWarehouses(ctx context.Context) ([]*model.Ware
 }
 
 func (r *WarehouseRepository) UpdateWarehouse(ctx context.Context, warehouse *model.Warehouse) error {
-	return r.session.Query(`UPDATE warehouses SET name = ?, location = ? WHERE id = ?`,
-		warehouse.Name, warehouse.Location, warehouse.ID.String()).WithContext(ctx).Exec()
+	return r.session.Query(`UPDATE warehouses SET name = ?, location = ?, max_units_decimal = ?, max_volume = ?, max_weight = ?, capacity_policy = ? WHERE id = ?`,
+		warehouse.Name, warehouse.Location, decimalFromQuantity(warehouse.Capacity.MaxUnits), warehouse.Capacity.MaxVolume, warehouse.Capacity.MaxWeight,
+		warehouse.CapacityPolicy, warehouse.ID.String()).WithContext(ctx).Exec()
 }
 
 func (r *WarehouseRepository) DeleteWarehouse(ctx context.Context, id string) error {
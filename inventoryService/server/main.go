@@ -1,14 +1,26 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"github.com/gocql/gocql"
 	"google.golang.org/grpc"
+	"gopkg.in/inf.v0"
+	"inventoryService/gateway"
 	"inventoryService/handler"
+	"inventoryService/interceptor"
+	"inventoryService/model"
 	pb "inventoryService/proto/inventory"
 	"inventoryService/repository"
 	"inventoryService/service"
 	"log"
 	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 func main() {
@@ -51,34 +63,1252 @@ func main() {
 		log.Fatalf("Failed to create index on 'name': %v", err)
 	}
 
+	err = addColumnIfMissing(session, cluster.Keyspace, "inventory_items", "reserved_quantity_decimal", "decimal")
+	if err != nil {
+		log.Fatalf("Failed to add column 'reserved_quantity_decimal': %v", err)
+	}
+
+	err = addColumnIfMissing(session, cluster.Keyspace, "inventory_items", "quarantined_quantity_decimal", "decimal")
+	if err != nil {
+		log.Fatalf("Failed to add column 'quarantined_quantity_decimal': %v", err)
+	}
+
+	cqlStatement = `CREATE TABLE IF NOT EXISTS schema_migrations (
+		name text PRIMARY KEY,
+		applied_at timestamp
+	)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create table 'schema_migrations': %v", err)
+	}
+
+	cqlStatement = `CREATE TABLE IF NOT EXISTS audit_events (
+		entity_type text,
+		entity_id uuid,
+		event_time timestamp,
+		id uuid,
+		action text,
+		actor text,
+		rpc text,
+		before text,
+		after text,
+		PRIMARY KEY ((entity_type, entity_id), event_time, id)
+	) WITH CLUSTERING ORDER BY (event_time DESC, id ASC)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create table 'audit_events': %v", err)
+	}
+
+	// Listings by actor and by time read these copies of audit_events
+	// instead of filtering all of it. Events recorded before they existed
+	// are copied over once, when they are created.
+	backfillAudit, err := tableMissing(session, cluster.Keyspace, "audit_events_by_day")
+	if err != nil {
+		log.Fatalf("Failed to look up table 'audit_events_by_day': %v", err)
+	}
+	cqlStatement = `CREATE TABLE IF NOT EXISTS audit_events_by_actor (
+		actor text,
+		event_time timestamp,
+		id uuid,
+		entity_type text,
+		entity_id uuid,
+		action text,
+		rpc text,
+		before text,
+		after text,
+		PRIMARY KEY ((actor), event_time, id)
+	) WITH CLUSTERING ORDER BY (event_time DESC, id ASC)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create table 'audit_events_by_actor': %v", err)
+	}
+
+	cqlStatement = `CREATE TABLE IF NOT EXISTS audit_events_by_day (
+		day text,
+		event_time timestamp,
+		id uuid,
+		entity_type text,
+		entity_id uuid,
+		action text,
+		actor text,
+		rpc text,
+		before text,
+		after text,
+		PRIMARY KEY ((day), event_time, id)
+	) WITH CLUSTERING ORDER BY (event_time DESC, id ASC)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create table 'audit_events_by_day': %v", err)
+	}
+	if backfillAudit {
+		if err := backfillAuditEvents(session); err != nil {
+			log.Fatalf("Failed to copy audit events: %v", err)
+		}
+	}
+
+	cqlStatement = `DROP INDEX IF EXISTS audit_events_actor_idx`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to drop index on 'actor': %v", err)
+	}
+
+	cqlStatement = `CREATE TABLE IF NOT EXISTS reorder_suggestions (
+		inventory_item_id uuid PRIMARY KEY,
+		product_id uuid,
+		warehouse_id uuid,
+		quantity_on_hand_decimal decimal,
+		reorder_level_decimal decimal,
+		suggested_quantity_decimal decimal,
+		created_at timestamp,
+		purchase_order_id uuid
+	)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create table 'reorder_suggestions': %v", err)
+	}
+
+	cqlStatement = `CREATE TABLE IF NOT EXISTS purchase_orders (
+		id uuid PRIMARY KEY,
+		supplier_id uuid,
+		status int,
+		notes text,
+		created_at timestamp,
+		updated_at timestamp,
+		submitted_at timestamp
+	)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create table 'purchase_orders': %v", err)
+	}
+
+	cqlStatement = `CREATE TABLE IF NOT EXISTS purchase_order_lines (
+		purchase_order_id uuid,
+		id uuid,
+		product_id uuid,
+		warehouse_id uuid,
+		quantity_decimal decimal,
+		unit_cost_amount decimal,
+		currency text,
+		received_quantity_decimal decimal,
+		PRIMARY KEY (purchase_order_id, id)
+	)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create table 'purchase_order_lines': %v", err)
+	}
+
+	cqlStatement = `CREATE TABLE IF NOT EXISTS goods_receipts (
+		id uuid PRIMARY KEY,
+		purchase_order_id uuid,
+		supplier_id uuid,
+		received_at timestamp,
+		notes text
+	)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create table 'goods_receipts': %v", err)
+	}
+
+	cqlStatement = `CREATE TABLE IF NOT EXISTS goods_receipt_lines (
+		receipt_id uuid,
+		purchase_order_line_id uuid,
+		product_id uuid,
+		warehouse_id uuid,
+		received_quantity_decimal decimal,
+		damaged_quantity_decimal decimal,
+		accepted_quantity_decimal decimal,
+		variance_decimal decimal,
+		stock_movement_id uuid,
+		notes text,
+		PRIMARY KEY (receipt_id, purchase_order_line_id)
+	)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create table 'goods_receipt_lines': %v", err)
+	}
+
+	cqlStatement = `CREATE TABLE IF NOT EXISTS supplier_products (
+		id uuid PRIMARY KEY,
+		supplier_id uuid,
+		product_id uuid,
+		supplier_sku text,
+		unit_cost_amount decimal,
+		currency text,
+		minimum_order_quantity int,
+		pack_size int,
+		lead_time_days int,
+		preferred boolean
+	)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create table 'supplier_products': %v", err)
+	}
+
+	cqlStatement = `CREATE INDEX IF NOT EXISTS ON supplier_products (supplier_id)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create index on 'supplier_id': %v", err)
+	}
+
+	cqlStatement = `CREATE INDEX IF NOT EXISTS ON supplier_products (product_id)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create index on 'product_id': %v", err)
+	}
+
+	cqlStatement = `CREATE TABLE IF NOT EXISTS sales_orders (
+		id uuid PRIMARY KEY,
+		customer text,
+		status int,
+		notes text,
+		expires_at timestamp,
+		created_at timestamp,
+		updated_at timestamp,
+		shipped_at timestamp
+	)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create table 'sales_orders': %v", err)
+	}
+
+	cqlStatement = `CREATE TABLE IF NOT EXISTS sales_order_lines (
+		sales_order_id uuid,
+		id uuid,
+		product_id uuid,
+		warehouse_id uuid,
+		quantity_decimal decimal,
+		PRIMARY KEY (sales_order_id, id)
+	)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create table 'sales_order_lines': %v", err)
+	}
+
+	cqlStatement = `CREATE TABLE IF NOT EXISTS sales_order_allocations (
+		sales_order_id uuid,
+		line_id uuid,
+		inventory_item_id uuid,
+		warehouse_id uuid,
+		quantity_decimal decimal,
+		stock_movement_id uuid,
+		PRIMARY KEY (sales_order_id, line_id, inventory_item_id)
+	)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create table 'sales_order_allocations': %v", err)
+	}
+
+	cqlStatement = `CREATE TABLE IF NOT EXISTS return_authorizations (
+		id uuid PRIMARY KEY,
+		sales_order_id uuid,
+		customer text,
+		product_id uuid,
+		warehouse_id uuid,
+		quantity_decimal decimal,
+		reason text,
+		status int,
+		created_at timestamp,
+		updated_at timestamp,
+		inspected_at timestamp
+	)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create table 'return_authorizations': %v", err)
+	}
+
+	cqlStatement = `CREATE TABLE IF NOT EXISTS return_dispositions (
+		return_id uuid,
+		disposition int,
+		quantity_decimal decimal,
+		supplier_id uuid,
+		stock_movement_id uuid,
+		notes text,
+		PRIMARY KEY (return_id, disposition)
+	)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create table 'return_dispositions': %v", err)
+	}
+
+	cqlStatement = `CREATE TABLE IF NOT EXISTS transfer_orders (
+		id uuid PRIMARY KEY,
+		source_warehouse_id uuid,
+		destination_warehouse_id uuid,
+		status int,
+		notes text,
+		created_at timestamp,
+		updated_at timestamp,
+		shipped_at timestamp,
+		received_at timestamp
+	)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create table 'transfer_orders': %v", err)
+	}
+
+	cqlStatement = `CREATE TABLE IF NOT EXISTS transfer_order_lines (
+		transfer_order_id uuid,
+		id uuid,
+		product_id uuid,
+		quantity_decimal decimal,
+		shipped_quantity_decimal decimal,
+		received_quantity_decimal decimal,
+		discrepancy_quantity_decimal decimal,
+		shipment_movement_id uuid,
+		PRIMARY KEY (transfer_order_id, id)
+	)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create table 'transfer_order_lines': %v", err)
+	}
+
+	cqlStatement = `CREATE TABLE IF NOT EXISTS transfer_order_receipts (
+		transfer_order_id uuid,
+		id uuid,
+		line_id uuid,
+		quantity_decimal decimal,
+		received_at timestamp,
+		stock_movement_id uuid,
+		notes text,
+		PRIMARY KEY (transfer_order_id, id)
+	)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create table 'transfer_order_receipts': %v", err)
+	}
+
+	cqlStatement = `CREATE TABLE IF NOT EXISTS stock_adjustments (
+		id uuid PRIMARY KEY,
+		inventory_item_id uuid,
+		product_id uuid,
+		warehouse_id uuid,
+		quantity_change_decimal decimal,
+		reason int,
+		notes text,
+		value_amount decimal,
+		currency text,
+		status int,
+		requested_by text,
+		reviewed_by text,
+		review_notes text,
+		stock_movement_id uuid,
+		created_at timestamp,
+		reviewed_at timestamp
+	)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create table 'stock_adjustments': %v", err)
+	}
+
+	cqlStatement = `CREATE TABLE IF NOT EXISTS count_sessions (
+		id uuid PRIMARY KEY,
+		warehouse_id uuid,
+		category_id uuid,
+		plan_id uuid,
+		blind boolean,
+		status int,
+		notes text,
+		created_by text,
+		created_at timestamp,
+		updated_at timestamp,
+		submitted_at timestamp,
+		approved_at timestamp
+	)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create table 'count_sessions': %v", err)
+	}
+
+	cqlStatement = `CREATE TABLE IF NOT EXISTS count_session_lines (
+		session_id uuid,
+		inventory_item_id uuid,
+		product_id uuid,
+		expected_quantity_decimal decimal,
+		counted_quantity_decimal decimal,
+		counted boolean,
+		counted_by text,
+		counted_at timestamp,
+		stock_adjustment_id uuid,
+		PRIMARY KEY (session_id, inventory_item_id)
+	)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create table 'count_session_lines': %v", err)
+	}
+
+	cqlStatement = `CREATE TABLE IF NOT EXISTS count_plans (
+		id uuid PRIMARY KEY,
+		warehouse_id uuid,
+		name text,
+		class_a_interval_days int,
+		class_b_interval_days int,
+		class_c_interval_days int,
+		blind boolean,
+		active boolean,
+		created_at timestamp,
+		updated_at timestamp,
+		last_run_at timestamp
+	)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create table 'count_plans': %v", err)
+	}
+
+	cqlStatement = `CREATE TABLE IF NOT EXISTS lots (
+		id uuid PRIMARY KEY,
+		inventory_item_id uuid,
+		product_id uuid,
+		warehouse_id uuid,
+		lot_number text,
+		manufacture_date timestamp,
+		expiry_date timestamp,
+		supplier_id uuid,
+		quantity_decimal decimal,
+		created_at timestamp
+	)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create table 'lots': %v", err)
+	}
+
+	cqlStatement = `CREATE INDEX IF NOT EXISTS ON lots (inventory_item_id)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create index on 'inventory_item_id': %v", err)
+	}
+
+	err = addColumnIfMissing(session, cluster.Keyspace, "stock_movements", "lot_id", "uuid")
+	if err != nil {
+		log.Fatalf("Failed to add column 'lot_id': %v", err)
+	}
+
+	err = addColumnIfMissing(session, cluster.Keyspace, "stock_adjustments", "lot_id", "uuid")
+	if err != nil {
+		log.Fatalf("Failed to add column 'lot_id': %v", err)
+	}
+
+	cqlStatement = `CREATE TABLE IF NOT EXISTS serials (
+		serial_number text,
+		product_id uuid,
+		inventory_item_id uuid,
+		warehouse_id uuid,
+		status int,
+		stock_movement_id uuid,
+		created_at timestamp,
+		updated_at timestamp,
+		PRIMARY KEY (serial_number, product_id)
+	)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create table 'serials': %v", err)
+	}
+
+	cqlStatement = `CREATE TABLE IF NOT EXISTS serial_events (
+		product_id uuid,
+		serial_number text,
+		recorded_at timestamp,
+		id uuid,
+		stock_movement_id uuid,
+		movement_type int,
+		status int,
+		inventory_item_id uuid,
+		warehouse_id uuid,
+		actor text,
+		PRIMARY KEY ((product_id, serial_number), recorded_at, id)
+	)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create table 'serial_events': %v", err)
+	}
+
+	err = addColumnIfMissing(session, cluster.Keyspace, "products", "serialized", "boolean")
+	if err != nil {
+		log.Fatalf("Failed to add column 'serialized': %v", err)
+	}
+
+	for _, table := range []string{"stock_movements", "goods_receipt_lines", "sales_order_allocations", "return_dispositions", "transfer_order_lines", "transfer_order_receipts", "stock_adjustments"} {
+		err = addColumnIfMissing(session, cluster.Keyspace, table, "serial_numbers", "list<text>")
+		if err != nil {
+			log.Fatalf("Failed to add column 'serial_numbers' to '%s': %v", table, err)
+		}
+	}
+
+	cqlStatement = `CREATE TABLE IF NOT EXISTS locations (
+		id uuid PRIMARY KEY,
+		warehouse_id uuid,
+		parent_id uuid,
+		type int,
+		code text,
+		name text,
+		path text,
+		created_at timestamp,
+		updated_at timestamp
+	)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create table 'locations': %v", err)
+	}
+
+	cqlStatement = `CREATE INDEX IF NOT EXISTS ON locations (warehouse_id)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create index on 'warehouse_id': %v", err)
+	}
+
+	cqlStatement = `CREATE TABLE IF NOT EXISTS bin_stock (
+		inventory_item_id uuid,
+		location_id uuid,
+		warehouse_id uuid,
+		quantity_decimal decimal,
+		PRIMARY KEY (inventory_item_id, location_id)
+	)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create table 'bin_stock': %v", err)
+	}
+
+	cqlStatement = `CREATE INDEX IF NOT EXISTS ON bin_stock (warehouse_id)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create index on 'warehouse_id': %v", err)
+	}
+
+	for _, table := range []string{"warehouses", "locations"} {
+		for _, column := range [][2]string{{"max_units_decimal", "decimal"}, {"max_volume", "double"}, {"max_weight", "double"}} {
+			err = addColumnIfMissing(session, cluster.Keyspace, table, column[0], column[1])
+			if err != nil {
+				log.Fatalf("Failed to add column '%s' to '%s': %v", column[0], table, err)
+			}
+		}
+	}
+
+	err = addColumnIfMissing(session, cluster.Keyspace, "warehouses", "capacity_policy", "int")
+	if err != nil {
+		log.Fatalf("Failed to add column 'capacity_policy': %v", err)
+	}
+
+	for _, column := range []string{"length", "width", "height", "weight"} {
+		err = addColumnIfMissing(session, cluster.Keyspace, "products", column, "double")
+		if err != nil {
+			log.Fatalf("Failed to add column '%s': %v", column, err)
+		}
+	}
+
+	for _, column := range []string{"source_bin_id", "destination_bin_id"} {
+		err = addColumnIfMissing(session, cluster.Keyspace, "stock_movements", column, "uuid")
+		if err != nil {
+			log.Fatalf("Failed to add column '%s': %v", column, err)
+		}
+	}
+
+	cqlStatement = `CREATE TABLE IF NOT EXISTS cost_layers (
+		inventory_item_id uuid,
+		received_at timestamp,
+		id uuid,
+		stock_movement_id uuid,
+		unit_cost_amount decimal,
+		currency text,
+		received_quantity_decimal decimal,
+		remaining_quantity_decimal decimal,
+		PRIMARY KEY (inventory_item_id, received_at, id)
+	)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create table 'cost_layers': %v", err)
+	}
+
+	for _, column := range [][2]string{{"unit_cost_amount", "decimal"}, {"cost_amount", "decimal"}, {"currency", "text"}} {
+		err = addColumnIfMissing(session, cluster.Keyspace, "stock_movements", column[0], column[1])
+		if err != nil {
+			log.Fatalf("Failed to add column '%s': %v", column[0], err)
+		}
+	}
+
+	err = addColumnIfMissing(session, cluster.Keyspace, "products", "costing_method", "int")
+	if err != nil {
+		log.Fatalf("Failed to add column 'costing_method': %v", err)
+	}
+
+	// Amounts of money stored before they carried a currency are taken to be
+	// in this one.
+	defaultCurrency := "USD"
+	if value := os.Getenv("DEFAULT_CURRENCY"); value != "" {
+		defaultCurrency = strings.ToUpper(value)
+	}
+
+	moneyColumns := []struct {
+		table    string
+		keys     []string
+		from, to string
+	}{
+		{"products", []string{"id"}, "price", "price_amount"},
+		{"purchase_order_lines", []string{"purchase_order_id", "id"}, "unit_cost", "unit_cost_amount"},
+		{"supplier_products", []string{"id"}, "unit_cost", "unit_cost_amount"},
+		{"stock_adjustments", []string{"id"}, "value", "value_amount"},
+		{"stock_movements", []string{"id"}, "unit_cost", "unit_cost_amount"},
+		{"stock_movements", []string{"id"}, "cost", "cost_amount"},
+		{"cost_layers", []string{"inventory_item_id", "received_at", "id"}, "unit_cost", "unit_cost_amount"},
+	}
+	err = runMigration(session, "decimal_money", func() error {
+		for _, column := range moneyColumns {
+			err := migrateMoneyColumn(session, cluster.Keyspace, column.table, column.keys, column.from, column.to, defaultCurrency)
+			if err != nil {
+				return fmt.Errorf("column '%s' of '%s': %w", column.from, column.table, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("Failed to migrate amounts of money: %v", err)
+	}
+
+	cqlStatement = `CREATE TABLE IF NOT EXISTS price_lists (
+		id uuid PRIMARY KEY,
+		name text,
+		currency text,
+		customer_group text,
+		effective_from timestamp,
+		effective_to timestamp,
+		created_at timestamp,
+		updated_at timestamp
+	)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create table 'price_lists': %v", err)
+	}
+
+	cqlStatement = `CREATE TABLE IF NOT EXISTS price_list_prices (
+		price_list_id uuid,
+		product_id uuid,
+		price_amount decimal,
+		PRIMARY KEY (price_list_id, product_id)
+	)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create table 'price_list_prices': %v", err)
+	}
+
+	cqlStatement = `CREATE TABLE IF NOT EXISTS exchange_rates (
+		from_currency text,
+		to_currency text,
+		effective_from timestamp,
+		rate decimal,
+		PRIMARY KEY ((from_currency, to_currency), effective_from)
+	) WITH CLUSTERING ORDER BY (effective_from DESC)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create table 'exchange_rates': %v", err)
+	}
+
+	cqlStatement = `CREATE TABLE IF NOT EXISTS price_changes (
+		product_id uuid,
+		effective_at timestamp,
+		id uuid,
+		old_price_amount decimal,
+		old_currency text,
+		new_price_amount decimal,
+		new_currency text,
+		actor text,
+		status int,
+		created_at timestamp,
+		applied_at timestamp,
+		PRIMARY KEY (product_id, effective_at, id)
+	) WITH CLUSTERING ORDER BY (effective_at DESC, id ASC)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create table 'price_changes': %v", err)
+	}
+
+	cqlStatement = `CREATE INDEX IF NOT EXISTS ON price_changes (id)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create index on 'id': %v", err)
+	}
+
+	cqlStatement = `CREATE INDEX IF NOT EXISTS ON price_changes (status)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create index on 'status': %v", err)
+	}
+
+	unitColumns := []struct {
+		table, column, cqlType string
+	}{
+		{"products", "base_unit", "text"},
+		{"products", "unit_factors", "map<text, decimal>"},
+		{"stock_movements", "unit", "text"},
+		{"stock_movements", "unit_quantity_decimal", "decimal"},
+		{"purchase_order_lines", "unit", "text"},
+	}
+	for _, column := range unitColumns {
+		err = addColumnIfMissing(session, cluster.Keyspace, column.table, column.column, column.cqlType)
+		if err != nil {
+			log.Fatalf("Failed to add column '%s' to '%s': %v", column.column, column.table, err)
+		}
+	}
+
+	err = runMigration(session, "decimal_unit_factors", func() error {
+		return migrateUnitFactors(session, cluster.Keyspace)
+	})
+	if err != nil {
+		log.Fatalf("Failed to migrate unit factors: %v", err)
+	}
+
+	err = addColumnIfMissing(session, cluster.Keyspace, "products", "quantity_precision", "int")
+	if err != nil {
+		log.Fatalf("Failed to add column 'quantity_precision': %v", err)
+	}
+
+	quantityColumns := []struct {
+		table   string
+		keys    []string
+		columns []string
+	}{
+		{"inventory_items", []string{"id"}, []string{"quantity", "reorder_level", "reorder_quantity", "reserved_quantity", "quarantined_quantity"}},
+		{"stock_movements", []string{"id"}, []string{"quantity", "unit_quantity"}},
+		{"lots", []string{"id"}, []string{"quantity"}},
+		{"bin_stock", []string{"inventory_item_id", "location_id"}, []string{"quantity"}},
+		{"cost_layers", []string{"inventory_item_id", "received_at", "id"}, []string{"received_quantity", "remaining_quantity"}},
+		{"warehouses", []string{"id"}, []string{"max_units"}},
+		{"locations", []string{"id"}, []string{"max_units"}},
+		{"reorder_suggestions", []string{"inventory_item_id"}, []string{"quantity_on_hand", "reorder_level", "suggested_quantity"}},
+		{"purchase_order_lines", []string{"purchase_order_id", "id"}, []string{"quantity", "received_quantity"}},
+		{"goods_receipt_lines", []string{"receipt_id", "purchase_order_line_id"}, []string{"received_quantity", "damaged_quantity", "accepted_quantity", "variance"}},
+		{"sales_order_lines", []string{"sales_order_id", "id"}, []string{"quantity"}},
+		{"sales_order_allocations", []string{"sales_order_id", "line_id", "inventory_item_id"}, []string{"quantity"}},
+		{"return_authorizations", []string{"id"}, []string{"quantity"}},
+		{"return_dispositions", []string{"return_id", "disposition"}, []string{"quantity"}},
+		{"transfer_order_lines", []string{"transfer_order_id", "id"}, []string{"quantity", "shipped_quantity", "received_quantity", "discrepancy_quantity"}},
+		{"transfer_order_receipts", []string{"transfer_order_id", "id"}, []string{"quantity"}},
+		{"stock_adjustments", []string{"id"}, []string{"quantity_change"}},
+		{"count_session_lines", []string{"session_id", "inventory_item_id"}, []string{"expected_quantity", "counted_quantity"}},
+	}
+	err = runMigration(session, "decimal_quantities", func() error {
+		for _, table := range quantityColumns {
+			for _, column := range table.columns {
+				err := migrateQuantityColumn(session, cluster.Keyspace, table.table, table.keys, column, column+"_decimal")
+				if err != nil {
+					return fmt.Errorf("column '%s' of '%s': %w", column, table.table, err)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("Failed to migrate quantities: %v", err)
+	}
+
+	err = addColumnIfMissing(session, cluster.Keyspace, "purchase_orders", "receiving_receipt_id", "uuid")
+	if err != nil {
+		log.Fatalf("Failed to add column 'receiving_receipt_id': %v", err)
+	}
+
+	err = addColumnIfMissing(session, cluster.Keyspace, "goods_receipt_lines", "posted", "boolean")
+	if err != nil {
+		log.Fatalf("Failed to add column 'posted': %v", err)
+	}
+
+	err = addColumnIfMissing(session, cluster.Keyspace, "sales_order_allocations", "shipped", "boolean")
+	if err != nil {
+		log.Fatalf("Failed to add column 'shipped': %v", err)
+	}
+	// Allocations used to get a stock movement only once they were shipped.
+	err = runMigration(session, "shipped_sales_order_allocations", func() error {
+		return markAllocationsShipped(session)
+	})
+	if err != nil {
+		log.Fatalf("Failed to mark sales order allocations shipped: %v", err)
+	}
+
+	err = addColumnIfMissing(session, cluster.Keyspace, "transfer_orders", "receiving_id", "uuid")
+	if err != nil {
+		log.Fatalf("Failed to add column 'receiving_id': %v", err)
+	}
+
+	err = addColumnIfMissing(session, cluster.Keyspace, "transfer_order_receipts", "posted", "boolean")
+	if err != nil {
+		log.Fatalf("Failed to add column 'posted': %v", err)
+	}
+	// Receipts used to be saved only once their movements were posted.
+	err = runMigration(session, "posted_transfer_receipts", func() error {
+		return markTransferReceiptsPosted(session)
+	})
+	if err != nil {
+		log.Fatalf("Failed to mark transfer receipts posted: %v", err)
+	}
+
+	err = addColumnIfMissing(session, cluster.Keyspace, "stock_movements", "posted", "boolean")
+	if err != nil {
+		log.Fatalf("Failed to add column 'posted': %v", err)
+	}
+	// Movements journaled before posted existed cannot be told apart; they
+	// are taken as posted, as the valuation did until then.
+	err = runMigration(session, "posted_stock_movements", func() error {
+		return markMovementsPosted(session)
+	})
+	if err != nil {
+		log.Fatalf("Failed to mark stock movements posted: %v", err)
+	}
+
+	cqlStatement = `CREATE TABLE IF NOT EXISTS sales_order_returns (
+		sales_order_id uuid,
+		product_id uuid,
+		returned_quantity_decimal decimal,
+		PRIMARY KEY (sales_order_id, product_id)
+	)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create table 'sales_order_returns': %v", err)
+	}
+
+	cqlStatement = `CREATE TABLE IF NOT EXISTS inventory_items_by_product_warehouse (
+		product_id uuid,
+		warehouse_id uuid,
+		inventory_item_id uuid,
+		PRIMARY KEY ((product_id, warehouse_id))
+	)`
+	err = session.Query(cqlStatement).Exec()
+	if err != nil {
+		log.Fatalf("Failed to create table 'inventory_items_by_product_warehouse': %v", err)
+	}
+	err = runMigration(session, "inventory_items_by_product_warehouse", func() error {
+		return indexInventoryItems(session)
+	})
+	if err != nil {
+		log.Fatalf("Failed to index inventory items: %v", err)
+	}
+
 	productRepo := repository.NewProductRepository(session)
 	categoryRepo := repository.NewCategoryRepository(session)
 	warehouseRepo := repository.NewWarehouseRepository(session)
 	inventoryItemRepo := repository.NewInventoryItemRepository(session)
 	stockMovementRepo := repository.NewStockMovementRepository(session)
 	supplierRepo := repository.NewSupplierRepository(session)
+	auditEventRepo := repository.NewAuditEventRepository(session)
+	reorderSuggestionRepo := repository.NewReorderSuggestionRepository(session)
+	purchaseOrderRepo := repository.NewPurchaseOrderRepository(session)
+	goodsReceiptRepo := repository.NewGoodsReceiptRepository(session)
+	supplierProductRepo := repository.NewSupplierProductRepository(session)
+	salesOrderRepo := repository.NewSalesOrderRepository(session)
+	returnAuthorizationRepo := repository.NewReturnAuthorizationRepository(session)
+	transferOrderRepo := repository.NewTransferOrderRepository(session)
+	stockAdjustmentRepo := repository.NewStockAdjustmentRepository(session)
+	countSessionRepo := repository.NewCountSessionRepository(session)
+	countPlanRepo := repository.NewCountPlanRepository(session)
+	lotRepo := repository.NewLotRepository(session)
+	serialRepo := repository.NewSerialRepository(session)
+	locationRepo := repository.NewLocationRepository(session)
+	binStockRepo := repository.NewBinStockRepository(session)
+	costLayerRepo := repository.NewCostLayerRepository(session)
+	priceListRepo := repository.NewPriceListRepository(session)
+	exchangeRateRepo := repository.NewExchangeRateRepository(session)
+	priceChangeRepo := repository.NewPriceChangeRepository(session)
+
+	// Adjustments changing more units or worth more than these wait for
+	// approval; unset thresholds are not applied.
+	var adjustmentThresholds service.AdjustmentThresholds
+	if value := os.Getenv("ADJUSTMENT_APPROVAL_QUANTITY"); value != "" {
+		adjustmentThresholds.Quantity, err = model.ParseQuantity(value)
+		if err != nil {
+			log.Fatalf("Invalid ADJUSTMENT_APPROVAL_QUANTITY: %v", err)
+		}
+	}
+	if value := os.Getenv("ADJUSTMENT_APPROVAL_VALUE"); value != "" {
+		adjustmentThresholds.Value, err = model.ParseMoney(value, defaultCurrency)
+		if err != nil {
+			log.Fatalf("Invalid ADJUSTMENT_APPROVAL_VALUE: %v", err)
+		}
+	}
+
+	auditService := service.NewAuditService(auditEventRepo)
+	replenishmentService := service.NewReplenishmentService(inventoryItemRepo, reorderSuggestionRepo)
+	priceChangeService := service.NewPriceChangeService(priceChangeRepo, productRepo, auditService)
+	productService := service.NewProductService(productRepo, categoryRepo, inventoryItemRepo, auditService, priceChangeService)
+	categoryService := service.NewCategoryService(categoryRepo, auditService)
+	warehouseService := service.NewWarehouseService(warehouseRepo, auditService)
+	capacityService := service.NewCapacityService(warehouseRepo, locationRepo, binStockRepo, inventoryItemRepo, productRepo, transferOrderRepo)
+	stockMovementService := service.NewStockMovementService(stockMovementRepo, inventoryItemRepo, lotRepo, serialRepo, binStockRepo, locationRepo, costLayerRepo, productRepo, capacityService, auditService, replenishmentService)
+	inventoryItemService := service.NewInventoryItemService(inventoryItemRepo, productRepo, warehouseRepo, lotRepo, binStockRepo, costLayerRepo, stockMovementService, auditService, replenishmentService)
+	supplierService := service.NewSupplierService(supplierRepo, auditService)
+	purchaseOrderService := service.NewPurchaseOrderService(purchaseOrderRepo, supplierRepo, productRepo, warehouseRepo, reorderSuggestionRepo, auditService)
+	goodsReceiptService := service.NewGoodsReceiptService(goodsReceiptRepo, purchaseOrderRepo, inventoryItemRepo, productRepo, stockMovementService, auditService)
+	supplierProductService := service.NewSupplierProductService(supplierProductRepo, supplierRepo, productRepo, auditService)
+	supplierScorecardService := service.NewSupplierScorecardService(supplierRepo, purchaseOrderRepo, goodsReceiptRepo, supplierProductRepo)
+	salesOrderService := service.NewSalesOrderService(salesOrderRepo, inventoryItemRepo, productRepo, warehouseRepo, serialRepo, stockMovementService, auditService)
+	returnAuthorizationService := service.NewReturnAuthorizationService(returnAuthorizationRepo, salesOrderRepo, productRepo, warehouseRepo, supplierRepo, inventoryItemRepo, stockMovementService, auditService)
+	transferOrderService := service.NewTransferOrderService(transferOrderRepo, inventoryItemRepo, productRepo, warehouseRepo, stockMovementService, auditService)
+	stockAdjustmentService := service.NewStockAdjustmentService(stockAdjustmentRepo, inventoryItemRepo, productRepo, stockMovementService, auditService, adjustmentThresholds)
+	countSessionService := service.NewCountSessionService(countSessionRepo, inventoryItemRepo, productRepo, categoryRepo, warehouseRepo, stockAdjustmentService, auditService)
+	countPlanService := service.NewCountPlanService(countPlanRepo, countSessionService, inventoryItemRepo, productRepo, stockMovementRepo, warehouseRepo, auditService)
+	lotService := service.NewLotService(lotRepo, inventoryItemRepo, supplierRepo, stockMovementService, auditService)
+	serialService := service.NewSerialService(serialRepo)
+	locationService := service.NewLocationService(locationRepo, warehouseRepo, binStockRepo, inventoryItemRepo, stockMovementService, capacityService, auditService)
+	valuationService := service.NewValuationService(stockMovementRepo, costLayerRepo, inventoryItemRepo, productRepo)
+	priceListService := service.NewPriceListService(priceListRepo, exchangeRateRepo, productRepo, auditService)
+	exchangeRateService := service.NewExchangeRateService(exchangeRateRepo)
 
-	productService := service.NewProductService(productRepo, categoryRepo)
-	categoryService := service.NewCategoryService(categoryRepo)
-	warehouseService := service.NewWarehouseService(warehouseRepo)
-	inventoryItemService := service.NewInventoryItemService(inventoryItemRepo, productRepo, warehouseRepo)
-	stockMovementService := service.NewStockMovementService(stockMovementRepo)
-	supplierService := service.NewSupplierService(supplierRepo)
+	// Exchange rates are kept up to date by loading a file of them at startup.
+	if path := os.Getenv("EXCHANGE_RATES_FILE"); path != "" {
+		_, err = exchangeRateService.LoadExchangeRates(context.Background(), path)
+		if err != nil {
+			log.Fatalf("Failed to load exchange rates: %v", err)
+		}
+	}
 
 	inventoryHandler := handler.NewInventoryHandler(
 		productService, categoryService, warehouseService,
 		inventoryItemService, stockMovementService, supplierService,
+		auditService, replenishmentService, purchaseOrderService,
+		goodsReceiptService, supplierProductService, supplierScorecardService,
+		salesOrderService, returnAuthorizationService, transferOrderService,
+		stockAdjustmentService, countSessionService, countPlanService,
+		lotService, serialService, locationService,
+		capacityService, valuationService, priceListService,
+		exchangeRateService, priceChangeService,
 	)
 
+	reorderScanInterval := 15 * time.Minute
+	if value := os.Getenv("REORDER_SCAN_INTERVAL"); value != "" {
+		reorderScanInterval, err = time.ParseDuration(value)
+		if err != nil {
+			log.Fatalf("Invalid REORDER_SCAN_INTERVAL: %v", err)
+		}
+	}
+	replenishmentService.Start(context.Background(), reorderScanInterval)
+
+	salesOrderExpiryInterval := time.Minute
+	if value := os.Getenv("SALES_ORDER_EXPIRY_INTERVAL"); value != "" {
+		salesOrderExpiryInterval, err = time.ParseDuration(value)
+		if err != nil {
+			log.Fatalf("Invalid SALES_ORDER_EXPIRY_INTERVAL: %v", err)
+		}
+	}
+	salesOrderService.Start(context.Background(), salesOrderExpiryInterval)
+
+	priceChangeInterval := time.Minute
+	if value := os.Getenv("PRICE_CHANGE_INTERVAL"); value != "" {
+		priceChangeInterval, err = time.ParseDuration(value)
+		if err != nil {
+			log.Fatalf("Invalid PRICE_CHANGE_INTERVAL: %v", err)
+		}
+	}
+	priceChangeService.Start(context.Background(), priceChangeInterval)
+
+	countPlanInterval := time.Hour
+	if value := os.Getenv("COUNT_PLAN_INTERVAL"); value != "" {
+		countPlanInterval, err = time.ParseDuration(value)
+		if err != nil {
+			log.Fatalf("Invalid COUNT_PLAN_INTERVAL: %v", err)
+		}
+	}
+	countPlanService.Start(context.Background(), countPlanInterval)
+
 	lis, err := net.Listen("tcp", ":50051")
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
-	s := grpc.NewServer()
+	authConfig, err := interceptor.LoadAuthConfig(os.Getenv("AUTH_CONFIG"))
+	if err != nil {
+		log.Fatalf("Failed to load auth config: %v", err)
+	}
+	rateLimitConfig, err := interceptor.LoadRateLimitConfig(os.Getenv("RATE_LIMIT_CONFIG"))
+	if err != nil {
+		log.Fatalf("Failed to load rate limit config: %v", err)
+	}
+	rateLimiter := interceptor.NewRateLimiter(rateLimitConfig)
+
+	deadlineConfig, err := interceptor.LoadDeadlineConfig(os.Getenv("DEADLINE_CONFIG"))
+	if err != nil {
+		log.Fatalf("Failed to load deadline config: %v", err)
+	}
+
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			interceptor.RecoveryUnaryServerInterceptor(),
+			interceptor.AuthUnaryServerInterceptor(authConfig),
+			rateLimiter.UnaryServerInterceptor(),
+			interceptor.DeadlineUnaryServerInterceptor(deadlineConfig),
+			interceptor.RetiredFieldsUnaryServerInterceptor(),
+		),
+		grpc.ChainStreamInterceptor(
+			interceptor.RecoveryStreamServerInterceptor(),
+			interceptor.AuthStreamServerInterceptor(authConfig),
+			rateLimiter.StreamServerInterceptor(),
+			interceptor.RetiredFieldsStreamServerInterceptor(),
+		),
+	)
 	pb.RegisterInventoryServiceServer(s, inventoryHandler)
 
-	log.Println("Server listening on :50051")
-	if err := s.Serve(lis); err != nil {
-		log.Fatalf("Failed to serve: %v", err)
+	go func() {
+		log.Println("Server listening on :50051")
+		if err := s.Serve(lis); err != nil {
+			log.Fatalf("Failed to serve: %v", err)
+		}
+	}()
+
+	gatewayHandler, err := gateway.NewHandler(context.Background(), "localhost:50051")
+	if err != nil {
+		log.Fatalf("Failed to create REST gateway: %v", err)
+	}
+
+	log.Println("REST gateway listening on :8080")
+	if err := http.ListenAndServe(":8080", gatewayHandler); err != nil {
+		log.Fatalf("Failed to serve REST gateway: %v", err)
+	}
+}
+
+// tableMissing reports whether a table has yet to be created.
+func tableMissing(session *gocql.Session, keyspace, table string) (bool, error) {
+	var name string
+	err := session.Query(`SELECT table_name FROM system_schema.tables WHERE keyspace_name = ? AND table_name = ?`,
+		keyspace, table).Scan(&name)
+	if errors.Is(err, gocql.ErrNotFound) {
+		return true, nil
+	}
+	return false, err
+}
+
+// backfillAuditEvents copies every event of audit_events into the tables
+// that hold them by actor and by day.
+func backfillAuditEvents(session *gocql.Session) error {
+	iter := session.Query(`SELECT entity_type, entity_id, event_time, id, action, actor, rpc, before, after FROM audit_events`).Iter()
+	var entityType, action, actor, rpc, before, after string
+	var entityID, id gocql.UUID
+	var eventTime time.Time
+	for iter.Scan(&entityType, &entityID, &eventTime, &id, &action, &actor, &rpc, &before, &after) {
+		batch := session.NewBatch(gocql.LoggedBatch)
+		batch.Query(`INSERT INTO audit_events_by_actor (entity_type, entity_id, event_time, id, action, actor, rpc, before, after) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			entityType, entityID, eventTime, id, action, actor, rpc, before, after)
+		batch.Query(`INSERT INTO audit_events_by_day (day, entity_type, entity_id, event_time, id, action, actor, rpc, before, after) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			repository.AuditDay(eventTime), entityType, entityID, eventTime, id, action, actor, rpc, before, after)
+		if err := session.ExecuteBatch(batch); err != nil {
+			iter.Close()
+			return err
+		}
+	}
+	return iter.Close()
+}
+
+// markMovementsPosted marks every stock movement journaled so far as posted.
+func markMovementsPosted(session *gocql.Session) error {
+	iter := session.Query(`SELECT id FROM stock_movements`).Iter()
+	var id gocql.UUID
+	for iter.Scan(&id) {
+		if err := session.Query(`UPDATE stock_movements SET posted = true WHERE id = ?`, id).Exec(); err != nil {
+			iter.Close()
+			return err
+		}
+	}
+	return iter.Close()
+}
+
+// indexInventoryItems records every inventory item under its product and
+// warehouse. Where two items already share them the first one found is kept
+// and the other is logged, to be merged by hand.
+func indexInventoryItems(session *gocql.Session) error {
+	iter := session.Query(`SELECT id, product_id, warehouse_id FROM inventory_items`).Iter()
+	var id, productID, warehouseID gocql.UUID
+	for iter.Scan(&id, &productID, &warehouseID) {
+		current := map[string]interface{}{}
+		applied, err := session.Query(`INSERT INTO inventory_items_by_product_warehouse (product_id, warehouse_id, inventory_item_id) VALUES (?, ?, ?) IF NOT EXISTS`,
+			productID, warehouseID, id).MapScanCAS(current)
+		if err != nil {
+			iter.Close()
+			return err
+		}
+		if holder, _ := current["inventory_item_id"].(gocql.UUID); !applied && holder != id {
+			log.Printf("Inventory items %s and %s both hold product %s in warehouse %s", holder, id, productID, warehouseID)
+		}
+	}
+	return iter.Close()
+}
+
+// markAllocationsShipped marks every sales order allocation with a stock
+// movement as shipped.
+func markAllocationsShipped(session *gocql.Session) error {
+	iter := session.Query(`SELECT sales_order_id, line_id, inventory_item_id, stock_movement_id FROM sales_order_allocations`).Iter()
+	var salesOrderID, lineID, itemID, movementID gocql.UUID
+	for iter.Scan(&salesOrderID, &lineID, &itemID, &movementID) {
+		if movementID == (gocql.UUID{}) {
+			continue
+		}
+		if err := session.Query(`UPDATE sales_order_allocations SET shipped = true WHERE sales_order_id = ? AND line_id = ? AND inventory_item_id = ?`, salesOrderID, lineID, itemID).Exec(); err != nil {
+			iter.Close()
+			return err
+		}
+	}
+	return iter.Close()
+}
+
+// markTransferReceiptsPosted marks every transfer receipt line saved so far as
+// posted.
+func markTransferReceiptsPosted(session *gocql.Session) error {
+	iter := session.Query(`SELECT transfer_order_id, id FROM transfer_order_receipts`).Iter()
+	var transferOrderID, id gocql.UUID
+	for iter.Scan(&transferOrderID, &id) {
+		if err := session.Query(`UPDATE transfer_order_receipts SET posted = true WHERE transfer_order_id = ? AND id = ?`, transferOrderID, id).Exec(); err != nil {
+			iter.Close()
+			return err
+		}
+	}
+	return iter.Close()
+}
+
+// runMigration runs migrate unless schema_migrations records that it has
+// run already, and records it once it succeeds, so that migrations which scan
+// whole tables run once rather than on every start.
+func runMigration(session *gocql.Session, name string, migrate func() error) error {
+	var appliedAt time.Time
+	err := session.Query(`SELECT applied_at FROM schema_migrations WHERE name = ?`, name).Scan(&appliedAt)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, gocql.ErrNotFound) {
+		return err
+	}
+	if err := migrate(); err != nil {
+		return err
+	}
+	log.Printf("Applied migration '%s'", name)
+	return session.Query(`INSERT INTO schema_migrations (name, applied_at) VALUES (?, ?)`, name, time.Now()).Exec()
+}
+
+// columnExists reports whether a table has a column.
+func columnExists(session *gocql.Session, keyspace, table, column string) (bool, error) {
+	var name string
+	err := session.Query(`SELECT column_name FROM system_schema.columns WHERE keyspace_name = ? AND table_name = ? AND column_name = ?`,
+		keyspace, table, column).Scan(&name)
+	if errors.Is(err, gocql.ErrNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// addColumnIfMissing adds a column to a table created before the column
+// existed. CREATE TABLE IF NOT EXISTS leaves such tables unchanged.
+func addColumnIfMissing(session *gocql.Session, keyspace, table, column, cqlType string) error {
+	exists, err := columnExists(session, keyspace, table, column)
+	if err != nil || exists {
+		return err
+	}
+	return session.Query(fmt.Sprintf(`ALTER TABLE %s ADD %s %s`, table, column, cqlType)).Exec()
+}
+
+// migrateMoneyColumn moves amounts of money from a double column, which rounds
+// them, to a decimal column next to it, with a currency column naming their
+// currency. Rows not migrated yet get their amount copied over, in currency
+// unless they name one already, so it is safe to resume after a failure.
+// Tables created without the double column have nothing to migrate.
+func migrateMoneyColumn(session *gocql.Session, keyspace, table string, keys []string, from, to, currency string) error {
+	if err := addColumnIfMissing(session, keyspace, table, to, "decimal"); err != nil {
+		return err
+	}
+	if err := addColumnIfMissing(session, keyspace, table, "currency", "text"); err != nil {
+		return err
+	}
+	legacy, err := columnExists(session, keyspace, table, from)
+	if err != nil || !legacy {
+		return err
+	}
+	conditions := make([]string, len(keys))
+	for i, key := range keys {
+		conditions[i] = key + " = ?"
+	}
+	update := fmt.Sprintf(`UPDATE %s SET %s = ?, currency = ? WHERE %s`, table, to, strings.Join(conditions, " AND "))
+
+	columns := append(append([]string{}, keys...), from, to, "currency")
+	iter := session.Query(fmt.Sprintf(`SELECT %s FROM %s`, strings.Join(columns, ", "), table)).Iter()
+	row := map[string]interface{}{}
+	for iter.MapScan(row) {
+		if migrated, _ := row[to].(*inf.Dec); migrated == nil {
+			value, _ := row[from].(float64)
+			amount, ok := new(inf.Dec).SetString(strconv.FormatFloat(value, 'f', -1, 64))
+			if !ok {
+				iter.Close()
+				return fmt.Errorf("cannot migrate amount %v", value)
+			}
+			amount.Round(amount, 9, inf.RoundHalfUp)
+			rowCurrency, _ := row["currency"].(string)
+			if rowCurrency == "" {
+				rowCurrency = currency
+			}
+			values := []interface{}{amount, rowCurrency}
+			for _, key := range keys {
+				values = append(values, row[key])
+			}
+			if err := session.Query(update, values...).Exec(); err != nil {
+				iter.Close()
+				return err
+			}
+		}
+		row = map[string]interface{}{}
+	}
+	return iter.Close()
+}
+
+// migrateUnitFactors copies the alternate units of products from the units
+// column, which held whole numbers of base units, to unit_factors. Products
+// whose units were copied already are left alone.
+func migrateUnitFactors(session *gocql.Session, keyspace string) error {
+	legacy, err := columnExists(session, keyspace, "products", "units")
+	if err != nil || !legacy {
+		return err
+	}
+	iter := session.Query(`SELECT id, units, unit_factors FROM products`).Iter()
+	var id gocql.UUID
+	var units map[string]int
+	var factors map[string]*inf.Dec
+	for iter.Scan(&id, &units, &factors) {
+		if len(units) == 0 || len(factors) > 0 {
+			continue
+		}
+		factors = make(map[string]*inf.Dec, len(units))
+		for name, factor := range units {
+			factors[name] = inf.NewDec(int64(model.Units(factor)), model.QuantityDecimals)
+		}
+		if err := session.Query(`UPDATE products SET unit_factors = ? WHERE id = ?`, factors, id).Exec(); err != nil {
+			iter.Close()
+			return err
+		}
+	}
+	return iter.Close()
+}
+
+// migrateQuantityColumn moves quantities from an int column, which only holds
+// whole units, to a decimal column next to it. Rows not migrated yet get their
+// quantity copied over, so it is safe to resume after a failure. Tables
+// created without the int column have nothing to migrate.
+func migrateQuantityColumn(session *gocql.Session, keyspace, table string, keys []string, from, to string) error {
+	if err := addColumnIfMissing(session, keyspace, table, to, "decimal"); err != nil {
+		return err
+	}
+	legacy, err := columnExists(session, keyspace, table, from)
+	if err != nil || !legacy {
+		return err
+	}
+	conditions := make([]string, len(keys))
+	for i, key := range keys {
+		conditions[i] = key + " = ?"
+	}
+	update := fmt.Sprintf(`UPDATE %s SET %s = ? WHERE %s`, table, to, strings.Join(conditions, " AND "))
+
+	columns := append(append([]string{}, keys...), from, to)
+	iter := session.Query(fmt.Sprintf(`SELECT %s FROM %s`, strings.Join(columns, ", "), table)).Iter()
+	row := map[string]interface{}{}
+	for iter.MapScan(row) {
+		migrated, _ := row[to].(*inf.Dec)
+		value, ok := row[from].(int)
+		if migrated == nil && ok {
+			values := []interface{}{inf.NewDec(int64(model.Units(value)), model.QuantityDecimals)}
+			for _, key := range keys {
+				values = append(values, row[key])
+			}
+			if err := session.Query(update, values...).Exec(); err != nil {
+				iter.Close()
+				return err
+			}
+		}
+		row = map[string]interface{}{}
 	}
+	return iter.Close()
 }
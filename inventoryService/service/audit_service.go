@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventoryService/interceptor"
+	"inventoryService/model"
+	"inventoryService/repository"
+	"log"
+	"time"
+)
+
+type AuditService struct {
+	repo *repository.AuditEventRepository
+}
+
+func NewAuditService(repo *repository.AuditEventRepository) *AuditService {
+	return &AuditService{repo: repo}
+}
+
+// Record appends an audit event for a mutation that has already been applied.
+// before and after are snapshotted as JSON; pass nil for the side that does
+// not exist. Failures are logged rather than returned so that a successful
+// mutation is never reported to the caller as failed; the log line holds the
+// whole event so that it can be restored into the log.
+func (s *AuditService) Record(ctx context.Context, entityType model.EntityType, entityID uuid.UUID, action model.AuditAction, before, after interface{}) {
+	event := &model.AuditEvent{
+		ID:         uuid.New(),
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     action,
+		Actor:      actorFromContext(ctx),
+		Timestamp:  time.Now().UTC(),
+	}
+	event.RPC, _ = grpc.Method(ctx)
+	event.Before = snapshot(before)
+	event.After = snapshot(after)
+
+	if err := s.repo.CreateAuditEvent(ctx, event); err != nil {
+		data, _ := json.Marshal(event)
+		log.Printf("Error recording audit event for %s %s: %v; lost event: %s", entityType, entityID, err, data)
+	}
+}
+
+func (s *AuditService) ListAuditEvents(ctx context.Context, filter model.AuditEventFilter) ([]*model.AuditEvent, error) {
+	events, err := s.repo.ListAuditEvents(ctx, filter)
+	if err != nil {
+		log.Printf("Error listing audit events: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing audit events: %v", err)
+	}
+	return events, nil
+}
+
+func (s *AuditService) GetEntityHistory(ctx context.Context, entityType model.EntityType, entityID uuid.UUID, start, end time.Time) ([]*model.AuditEvent, error) {
+	if entityType == "" || entityID == uuid.Nil {
+		return nil, status.Error(codes.InvalidArgument, "entity type and entity id are required")
+	}
+	return s.ListAuditEvents(ctx, model.AuditEventFilter{
+		EntityType: entityType,
+		EntityID:   entityID,
+		Start:      start,
+		End:        end,
+	})
+}
+
+func snapshot(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("Error marshalling audit snapshot: %v", err)
+		return ""
+	}
+	if string(data) == "null" {
+		return ""
+	}
+	return string(data)
+}
+
+// actorFromContext names the caller by the identity it authenticated as and,
+// for unauthenticated calls, by its client identity.
+func actorFromContext(ctx context.Context) string {
+	if identity, ok := interceptor.Identity(ctx); ok {
+		return identity
+	}
+	return interceptor.ClientID(ctx)
+}
@@ -0,0 +1,276 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"inventoryService/model"
+	"inventoryService/repository"
+	"log"
+	"sort"
+)
+
+// capacityWarningHeader carries the reason stock was let into a warehouse or
+// bin over capacity under CapacityWarn.
+const capacityWarningHeader = "x-capacity-warning"
+
+// CapacityService measures how full warehouses and bins are and checks that
+// incoming stock fits in them. Stock that does not fit is rejected or let in
+// with a warning, as the capacity policy of the warehouse says.
+type CapacityService struct {
+	warehouseRepo     *repository.WarehouseRepository
+	locationRepo      *repository.LocationRepository
+	binStockRepo      *repository.BinStockRepository
+	itemRepo          *repository.InventoryItemRepository
+	productRepo       *repository.ProductRepository
+	transferOrderRepo *repository.TransferOrderRepository
+}
+
+func NewCapacityService(
+	warehouseRepo *repository.WarehouseRepository,
+	locationRepo *repository.LocationRepository,
+	binStockRepo *repository.BinStockRepository,
+	itemRepo *repository.InventoryItemRepository,
+	productRepo *repository.ProductRepository,
+	transferOrderRepo *repository.TransferOrderRepository,
+) *CapacityService {
+	return &CapacityService{
+		warehouseRepo:     warehouseRepo,
+		locationRepo:      locationRepo,
+		binStockRepo:      binStockRepo,
+		itemRepo:          itemRepo,
+		productRepo:       productRepo,
+		transferOrderRepo: transferOrderRepo,
+	}
+}
+
+// GetWarehouseUtilization returns how full a warehouse is and how full each
+// of its bins is, bins ordered by path.
+func (s *CapacityService) GetWarehouseUtilization(ctx context.Context, warehouseID uuid.UUID) (*model.WarehouseUtilization, error) {
+	warehouse, err := s.getWarehouse(ctx, warehouseID)
+	if err != nil {
+		return nil, err
+	}
+	products, err := s.products(ctx)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.warehouseItems(ctx, warehouseID)
+	if err != nil {
+		return nil, err
+	}
+	load, err := s.warehouseLoad(ctx, warehouseID, items, products)
+	if err != nil {
+		return nil, err
+	}
+	binLoads, err := s.binLoads(ctx, warehouseID, items, products)
+	if err != nil {
+		return nil, err
+	}
+	locations, err := s.locationRepo.ListLocationsByWarehouse(ctx, warehouseID.String())
+	if err != nil {
+		log.Printf("Error listing locations: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing locations: %v", err)
+	}
+
+	utilization := &model.WarehouseUtilization{
+		WarehouseID: warehouse.ID,
+		Capacity:    warehouse.Capacity,
+		Load:        load,
+		FillRatio:   warehouse.Capacity.FillRatio(load),
+	}
+	for _, location := range locations {
+		if location.Type != model.LocationBin {
+			continue
+		}
+		binLoad := binLoads[location.ID]
+		utilization.Bins = append(utilization.Bins, &model.BinUtilization{
+			Location:  location,
+			Load:      binLoad,
+			FillRatio: location.Capacity.FillRatio(binLoad),
+		})
+	}
+	sort.Slice(utilization.Bins, func(i, j int) bool {
+		return utilization.Bins[i].Location.Path < utilization.Bins[j].Location.Path
+	})
+	return utilization, nil
+}
+
+// CheckWarehouse checks that units of product arriving in a warehouse fit in
+// it.
+func (s *CapacityService) CheckWarehouse(ctx context.Context, warehouseID uuid.UUID, product *model.Product, units model.Quantity) error {
+	warehouse, err := s.getWarehouse(ctx, warehouseID)
+	if err != nil {
+		return err
+	}
+	if warehouse.Capacity == (model.Capacity{}) {
+		return nil
+	}
+	products, err := s.products(ctx)
+	if err != nil {
+		return err
+	}
+	items, err := s.warehouseItems(ctx, warehouseID)
+	if err != nil {
+		return err
+	}
+	load, err := s.warehouseLoad(ctx, warehouseID, items, products)
+	if err != nil {
+		return err
+	}
+	load.Add(product, units)
+	return s.enforce(ctx, warehouse, "warehouse "+warehouse.Name, warehouse.Capacity.Exceeded(load))
+}
+
+// CheckBin checks that units of product put in a bin fit in it.
+func (s *CapacityService) CheckBin(ctx context.Context, bin *model.Location, product *model.Product, units model.Quantity) error {
+	if bin.Capacity == (model.Capacity{}) {
+		return nil
+	}
+	warehouse, err := s.getWarehouse(ctx, bin.WarehouseID)
+	if err != nil {
+		return err
+	}
+	products, err := s.products(ctx)
+	if err != nil {
+		return err
+	}
+	items, err := s.warehouseItems(ctx, bin.WarehouseID)
+	if err != nil {
+		return err
+	}
+	binLoads, err := s.binLoads(ctx, bin.WarehouseID, items, products)
+	if err != nil {
+		return err
+	}
+	load := binLoads[bin.ID]
+	load.Add(product, units)
+	return s.enforce(ctx, warehouse, "bin "+bin.Path, bin.Capacity.Exceeded(load))
+}
+
+// enforce applies the capacity policy of a warehouse to stock going over
+// capacity as described by exceeded, which is empty when the stock fits.
+// Outside of a request there is no header to carry the warning, so it is
+// only logged.
+func (s *CapacityService) enforce(ctx context.Context, warehouse *model.Warehouse, place, exceeded string) error {
+	if exceeded == "" {
+		return nil
+	}
+	if warehouse.CapacityPolicy == model.CapacityWarn {
+		warning := fmt.Sprintf("%s is over capacity: %s", place, exceeded)
+		log.Printf("Capacity warning: %s", warning)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(capacityWarningHeader, warning))
+		return nil
+	}
+	return status.Errorf(codes.FailedPrecondition, "%s would be over capacity: %s", place, exceeded)
+}
+
+func (s *CapacityService) getWarehouse(ctx context.Context, id uuid.UUID) (*model.Warehouse, error) {
+	warehouse, err := s.warehouseRepo.GetWarehouse(ctx, id.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrWarehouseNotFound) {
+			return nil, status.Errorf(codes.NotFound, "warehouse %s not found", id)
+		}
+		log.Printf("Error retrieving warehouse: %v", err)
+		return nil, status.Errorf(codes.Internal, "error retrieving warehouse: %v", err)
+	}
+	return warehouse, nil
+}
+
+// products returns every product by ID.
+func (s *CapacityService) products(ctx context.Context) (map[uuid.UUID]*model.Product, error) {
+	products, err := s.productRepo.ListProducts(ctx)
+	if err != nil {
+		log.Printf("Error listing products: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing products: %v", err)
+	}
+	byID := make(map[uuid.UUID]*model.Product, len(products))
+	for _, product := range products {
+		byID[product.ID] = product
+	}
+	return byID, nil
+}
+
+// warehouseItems returns the inventory items of a warehouse by ID.
+func (s *CapacityService) warehouseItems(ctx context.Context, warehouseID uuid.UUID) (map[uuid.UUID]*model.InventoryItem, error) {
+	items, err := s.itemRepo.ListInventoryItems(ctx)
+	if err != nil {
+		log.Printf("Error listing inventory items: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing inventory items: %v", err)
+	}
+	byID := make(map[uuid.UUID]*model.InventoryItem)
+	for _, item := range items {
+		if item.WarehouseID == warehouseID {
+			byID[item.ID] = item
+		}
+	}
+	return byID, nil
+}
+
+// warehouseLoad adds up the stock on hand and quarantined in a warehouse and
+// the stock in transit to it, which takes up room once it arrives.
+func (s *CapacityService) warehouseLoad(ctx context.Context, warehouseID uuid.UUID, items map[uuid.UUID]*model.InventoryItem, products map[uuid.UUID]*model.Product) (model.StorageLoad, error) {
+	var load model.StorageLoad
+	for _, item := range items {
+		load.Add(products[item.ProductID], item.Quantity+item.QuarantinedQuantity)
+	}
+	orders, err := s.transferOrderRepo.ListTransferOrders(ctx)
+	if err != nil {
+		log.Printf("Error listing transfer orders: %v", err)
+		return load, status.Errorf(codes.Internal, "error listing transfer orders: %v", err)
+	}
+	for _, order := range orders {
+		if order.DestinationWarehouseID != warehouseID {
+			continue
+		}
+		if order.Status != model.TransferOrderInTransit && order.Status != model.TransferOrderPartiallyReceived {
+			continue
+		}
+		for _, line := range order.Lines {
+			load.Add(products[line.ProductID], line.InTransit())
+		}
+	}
+	return load, nil
+}
+
+// binLoads adds up the stock held in each bin of a warehouse.
+func (s *CapacityService) binLoads(ctx context.Context, warehouseID uuid.UUID, items map[uuid.UUID]*model.InventoryItem, products map[uuid.UUID]*model.Product) (map[uuid.UUID]model.StorageLoad, error) {
+	bins, err := s.binStockRepo.ListBinStockByWarehouse(ctx, warehouseID.String())
+	if err != nil {
+		log.Printf("Error listing bin stock: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing bin stock: %v", err)
+	}
+	loads := make(map[uuid.UUID]model.StorageLoad)
+	for _, bin := range bins {
+		var product *model.Product
+		if item, ok := items[bin.InventoryItemID]; ok {
+			product = products[item.ProductID]
+		}
+		load := loads[bin.LocationID]
+		load.Add(product, bin.Quantity)
+		loads[bin.LocationID] = load
+	}
+	return loads, nil
+}
+
+// validateCapacity checks that no capacity limit is negative.
+func validateCapacity(capacity model.Capacity) error {
+	if capacity.MaxUnits < 0 || capacity.MaxVolume < 0 || capacity.MaxWeight < 0 {
+		return status.Error(codes.InvalidArgument, "capacity limits cannot be negative")
+	}
+	return nil
+}
+
+// validateWarehouseCapacity checks the capacity and capacity policy of a
+// warehouse.
+func validateWarehouseCapacity(warehouse *model.Warehouse) error {
+	if warehouse.CapacityPolicy < model.CapacityReject || warehouse.CapacityPolicy > model.CapacityWarn {
+		return status.Errorf(codes.InvalidArgument, "invalid capacity policy: %d", warehouse.CapacityPolicy)
+	}
+	return validateCapacity(warehouse.Capacity)
+}
@@ -12,11 +12,12 @@ import (
 )
 
 type CategoryService struct {
-	repo *repository.CategoryRepository
+	repo         *repository.CategoryRepository
+	auditService *AuditService
 }
 
-func NewCategoryService(repo *repository.CategoryRepository) *CategoryService {
-	return &CategoryService{repo: repo}
+func NewCategoryService(repo *repository.CategoryRepository, auditService *AuditService) *CategoryService {
+	return &CategoryService{repo: repo, auditService: auditService}
 }
 
 func (s *CategoryService) CreateCategory(ctx context.Context, category *model.Category) (*model.Category, error) {
@@ -32,6 +33,7 @@ func (s *CategoryService) CreateCategory(ctx context.Context, category *model.Ca
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "error creating category: %v", err)
 	}
+	s.auditService.Record(ctx, model.EntityCategory, category.ID, model.AuditCreate, nil, category)
 	return category, nil
 }
 
@@ -66,17 +68,28 @@ func (s *CategoryService) UpdateCategory(ctx context.Context, category *model.Ca
 	if exists {
 		return status.Error(codes.AlreadyExists, "another category with the same Name already exists")
 	}
+	before, err := s.repo.GetCategory(ctx, category.ID.String())
+	if err != nil && !errors.Is(err, repository.ErrCategoryNotFound) {
+		log.Printf("Error retrieving category: %v", err)
+		return status.Errorf(codes.Internal, "error retrieving category: %v", err)
+	}
 	err = s.repo.UpdateCategory(ctx, category)
 	if err != nil {
 		log.Printf("Error updating category: %v", err)
 		return status.Errorf(codes.Internal, "error updating category: %v", err)
 	}
+	s.auditService.Record(ctx, model.EntityCategory, category.ID, model.AuditUpdate, before, category)
 	log.Println("Category updated successfully:", category.ID)
 	return nil
 }
 
 func (s *CategoryService) DeleteCategory(ctx context.Context, id uuid.UUID) error {
-	err := s.repo.DeleteCategory(ctx, id.String())
+	before, err := s.repo.GetCategory(ctx, id.String())
+	if err != nil && !errors.Is(err, repository.ErrCategoryNotFound) {
+		log.Printf("Error retrieving category: %v", err)
+		return status.Errorf(codes.Internal, "error retrieving category: %v", err)
+	}
+	err = s.repo.DeleteCategory(ctx, id.String())
 	if err != nil {
 		if errors.Is(err, repository.ErrCategoryNotFound) {
 			return status.Error(codes.NotFound, "category not found")
@@ -84,6 +97,7 @@ func (s *CategoryService) DeleteCategory(ctx context.Context, id uuid.UUID) erro
 		log.Printf("Error deleting category: %v", err)
 		return status.Errorf(codes.Internal, "error deleting category: %v", err)
 	}
+	s.auditService.Record(ctx, model.EntityCategory, id, model.AuditDelete, before, nil)
 	log.Println("Category deleted successfully:", id)
 	return nil
 }
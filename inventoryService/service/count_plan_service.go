@@ -0,0 +1,349 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventoryService/model"
+	"inventoryService/repository"
+	"log"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// classACutoff and classBCutoff are the shares of a warehouse's usage
+	// value covered by its A items and by its A and B items together.
+	classACutoff = 0.80
+	classBCutoff = 0.95
+	// usageWindow is how far back usage is looked at to classify items.
+	usageWindow = 365 * 24 * time.Hour
+
+	defaultClassAIntervalDays = 30
+	defaultClassBIntervalDays = 90
+	defaultClassCIntervalDays = 180
+)
+
+// CountPlanService schedules cycle counts by ABC class, opening count
+// sessions for the items that are due.
+type CountPlanService struct {
+	repo                *repository.CountPlanRepository
+	countSessionService *CountSessionService
+	itemRepo            *repository.InventoryItemRepository
+	productRepo         *repository.ProductRepository
+	stockMovementRepo   *repository.StockMovementRepository
+	warehouseRepo       *repository.WarehouseRepository
+	auditService        *AuditService
+}
+
+func NewCountPlanService(
+	repo *repository.CountPlanRepository,
+	countSessionService *CountSessionService,
+	itemRepo *repository.InventoryItemRepository,
+	productRepo *repository.ProductRepository,
+	stockMovementRepo *repository.StockMovementRepository,
+	warehouseRepo *repository.WarehouseRepository,
+	auditService *AuditService,
+) *CountPlanService {
+	return &CountPlanService{
+		repo:                repo,
+		countSessionService: countSessionService,
+		itemRepo:            itemRepo,
+		productRepo:         productRepo,
+		stockMovementRepo:   stockMovementRepo,
+		warehouseRepo:       warehouseRepo,
+		auditService:        auditService,
+	}
+}
+
+// Start runs every active plan once per interval until ctx is done.
+func (s *CountPlanService) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.RunCountPlans(ctx); err != nil {
+					log.Printf("Error running count plans: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+func (s *CountPlanService) CreateCountPlan(ctx context.Context, plan *model.CountPlan) (*model.CountPlan, error) {
+	exists, err := s.warehouseRepo.WarehouseExists(ctx, plan.WarehouseID.String())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error checking warehouse existence: %v", err)
+	}
+	if !exists {
+		return nil, status.Errorf(codes.NotFound, "warehouse %s not found", plan.WarehouseID)
+	}
+	if err := validateCountPlan(plan); err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	plan.ID = uuid.New()
+	plan.CreatedAt = now
+	plan.UpdatedAt = now
+	plan.LastRunAt = time.Time{}
+	err = s.repo.CreateCountPlan(ctx, plan)
+	if err != nil {
+		log.Printf("Error creating count plan: %v", err)
+		return nil, status.Errorf(codes.Internal, "error creating count plan: %v", err)
+	}
+	s.auditService.Record(ctx, model.EntityCountPlan, plan.ID, model.AuditCreate, nil, plan)
+	log.Println("Count plan created successfully:", plan.ID)
+	return plan, nil
+}
+
+func (s *CountPlanService) GetCountPlan(ctx context.Context, id uuid.UUID) (*model.CountPlan, error) {
+	plan, err := s.repo.GetCountPlan(ctx, id.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrCountPlanNotFound) {
+			return nil, status.Error(codes.NotFound, "count plan not found")
+		}
+		log.Printf("Error retrieving count plan: %v", err)
+		return nil, status.Errorf(codes.Internal, "error retrieving count plan: %v", err)
+	}
+	return plan, nil
+}
+
+// ListCountPlans returns every plan, optionally restricted to a warehouse
+// (non-nil warehouseID).
+func (s *CountPlanService) ListCountPlans(ctx context.Context, warehouseID uuid.UUID) ([]*model.CountPlan, error) {
+	plans, err := s.repo.ListCountPlans(ctx)
+	if err != nil {
+		log.Printf("Error listing count plans: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing count plans: %v", err)
+	}
+	var filtered []*model.CountPlan
+	for _, plan := range plans {
+		if warehouseID != uuid.Nil && plan.WarehouseID != warehouseID {
+			continue
+		}
+		filtered = append(filtered, plan)
+	}
+	log.Println("Count plans listed successfully")
+	return filtered, nil
+}
+
+// UpdateCountPlan changes the name, intervals and flags of a plan. Its
+// warehouse cannot be changed.
+func (s *CountPlanService) UpdateCountPlan(ctx context.Context, plan *model.CountPlan) (*model.CountPlan, error) {
+	before, err := s.GetCountPlan(ctx, plan.ID)
+	if err != nil {
+		return nil, err
+	}
+	if plan.WarehouseID != uuid.Nil && plan.WarehouseID != before.WarehouseID {
+		return nil, status.Error(codes.InvalidArgument, "the warehouse of a count plan cannot be changed")
+	}
+	if err := validateCountPlan(plan); err != nil {
+		return nil, err
+	}
+	plan.WarehouseID = before.WarehouseID
+	plan.CreatedAt = before.CreatedAt
+	plan.LastRunAt = before.LastRunAt
+	plan.UpdatedAt = time.Now().UTC()
+	err = s.repo.UpdateCountPlan(ctx, plan)
+	if err != nil {
+		log.Printf("Error updating count plan: %v", err)
+		return nil, status.Errorf(codes.Internal, "error updating count plan: %v", err)
+	}
+	s.auditService.Record(ctx, model.EntityCountPlan, plan.ID, model.AuditUpdate, before, plan)
+	log.Println("Count plan updated successfully:", plan.ID)
+	return plan, nil
+}
+
+func (s *CountPlanService) DeleteCountPlan(ctx context.Context, id uuid.UUID) error {
+	before, err := s.GetCountPlan(ctx, id)
+	if err != nil {
+		return err
+	}
+	err = s.repo.DeleteCountPlan(ctx, id.String())
+	if err != nil {
+		log.Printf("Error deleting count plan: %v", err)
+		return status.Errorf(codes.Internal, "error deleting count plan: %v", err)
+	}
+	s.auditService.Record(ctx, model.EntityCountPlan, id, model.AuditDelete, before, nil)
+	log.Println("Count plan deleted successfully:", id)
+	return nil
+}
+
+// RunCountPlans runs every active plan.
+func (s *CountPlanService) RunCountPlans(ctx context.Context) error {
+	plans, err := s.repo.ListCountPlans(ctx)
+	if err != nil {
+		return err
+	}
+	for _, plan := range plans {
+		if !plan.Active {
+			continue
+		}
+		if _, err := s.run(ctx, plan); err != nil {
+			log.Printf("Error running count plan %s: %v", plan.ID, err)
+		}
+	}
+	return nil
+}
+
+// RunCountPlan opens a count session for the items of a plan that are due,
+// returning nil when none is.
+func (s *CountPlanService) RunCountPlan(ctx context.Context, id uuid.UUID) (*model.CountSession, error) {
+	plan, err := s.GetCountPlan(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.run(ctx, plan)
+}
+
+// ListCountPlanItems returns the ABC class and count schedule of every
+// inventory item in the warehouse of a plan, highest usage first.
+func (s *CountPlanService) ListCountPlanItems(ctx context.Context, id uuid.UUID) ([]*model.CountPlanItem, error) {
+	plan, err := s.GetCountPlan(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.schedule(ctx, plan)
+}
+
+func (s *CountPlanService) run(ctx context.Context, plan *model.CountPlan) (*model.CountSession, error) {
+	planItems, err := s.schedule(ctx, plan)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	var due []uuid.UUID
+	for _, planItem := range planItems {
+		if planItem.CountSessionID == uuid.Nil && !planItem.NextCountAt.After(now) {
+			due = append(due, planItem.InventoryItemID)
+		}
+	}
+
+	var countSession *model.CountSession
+	if len(due) > 0 {
+		countSession, err = s.countSessionService.CreateCountSession(ctx, &model.CountSession{
+			WarehouseID: plan.WarehouseID,
+			PlanID:      plan.ID,
+			Blind:       plan.Blind,
+			Notes:       fmt.Sprintf("Scheduled by count plan %s", plan.Name),
+		}, due)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := s.repo.SetLastRunAt(ctx, plan.ID.String(), now); err != nil {
+		log.Printf("Error updating last run of count plan %s: %v", plan.ID, err)
+	}
+	log.Printf("Count plan %s run: %d items due", plan.ID, len(due))
+	return countSession, nil
+}
+
+// schedule classifies the inventory items of a plan's warehouse by the value
+// of the units that left them over the usage window, items without usage
+// being C items, and works out when each is next due for a count.
+func (s *CountPlanService) schedule(ctx context.Context, plan *model.CountPlan) ([]*model.CountPlanItem, error) {
+	items, err := s.itemRepo.ListInventoryItems(ctx)
+	if err != nil {
+		log.Printf("Error listing inventory items: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing inventory items: %v", err)
+	}
+	products, err := s.productRepo.ListProducts(ctx)
+	if err != nil {
+		log.Printf("Error listing products: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing products: %v", err)
+	}
+	movements, err := s.stockMovementRepo.ListStockMovements(ctx)
+	if err != nil {
+		log.Printf("Error listing stock movements: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing stock movements: %v", err)
+	}
+	underCount, lastCounted, err := s.countSessionService.countHistory(ctx, plan.WarehouseID)
+	if err != nil {
+		return nil, err
+	}
+	// Usage values only rank items, so prices need not be exact.
+	prices := make(map[uuid.UUID]float64, len(products))
+	for _, product := range products {
+		prices[product.ID] = product.Price.Float64()
+	}
+
+	planItems := make(map[uuid.UUID]*model.CountPlanItem)
+	var ranked []*model.CountPlanItem
+	for _, item := range items {
+		if item.WarehouseID != plan.WarehouseID {
+			continue
+		}
+		planItem := &model.CountPlanItem{
+			InventoryItemID: item.ID,
+			ProductID:       item.ProductID,
+			Class:           model.ClassC,
+			LastCountedAt:   lastCounted[item.ID],
+			CountSessionID:  underCount[item.ID],
+		}
+		planItems[item.ID] = planItem
+		ranked = append(ranked, planItem)
+	}
+	since := time.Now().Add(-usageWindow)
+	total := 0.0
+	for _, movement := range movements {
+		if movement.Type != model.Removal && movement.Type != model.Transfer {
+			continue
+		}
+		planItem, ok := planItems[movement.InventoryItemID]
+		if !ok || movement.Date.Before(since) {
+			continue
+		}
+		value := movement.Quantity.Float64() * prices[planItem.ProductID]
+		planItem.UsageValue += value
+		total += value
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		return ranked[i].UsageValue > ranked[j].UsageValue
+	})
+	cumulative := 0.0
+	for _, planItem := range ranked {
+		if planItem.UsageValue > 0 {
+			share := cumulative / total
+			switch {
+			case share < classACutoff:
+				planItem.Class = model.ClassA
+			case share < classBCutoff:
+				planItem.Class = model.ClassB
+			}
+			cumulative += planItem.UsageValue
+		}
+		if !planItem.LastCountedAt.IsZero() {
+			planItem.NextCountAt = planItem.LastCountedAt.AddDate(0, 0, plan.IntervalDays(planItem.Class))
+		}
+	}
+	return ranked, nil
+}
+
+func validateCountPlan(plan *model.CountPlan) error {
+	plan.Name = strings.TrimSpace(plan.Name)
+	if plan.Name == "" {
+		return status.Error(codes.InvalidArgument, "name is required")
+	}
+	if plan.ClassAIntervalDays < 0 || plan.ClassBIntervalDays < 0 || plan.ClassCIntervalDays < 0 {
+		return status.Error(codes.InvalidArgument, "count intervals must not be negative")
+	}
+	if plan.ClassAIntervalDays == 0 {
+		plan.ClassAIntervalDays = defaultClassAIntervalDays
+	}
+	if plan.ClassBIntervalDays == 0 {
+		plan.ClassBIntervalDays = defaultClassBIntervalDays
+	}
+	if plan.ClassCIntervalDays == 0 {
+		plan.ClassCIntervalDays = defaultClassCIntervalDays
+	}
+	return nil
+}
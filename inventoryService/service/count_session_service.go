@@ -0,0 +1,411 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventoryService/model"
+	"inventoryService/repository"
+	"log"
+	"sort"
+	"time"
+)
+
+// CountSessionService runs physical counts of warehouse stock and posts the
+// variances they find as stock adjustments.
+type CountSessionService struct {
+	repo                   *repository.CountSessionRepository
+	itemRepo               *repository.InventoryItemRepository
+	productRepo            *repository.ProductRepository
+	categoryRepo           *repository.CategoryRepository
+	warehouseRepo          *repository.WarehouseRepository
+	stockAdjustmentService *StockAdjustmentService
+	auditService           *AuditService
+}
+
+func NewCountSessionService(
+	repo *repository.CountSessionRepository,
+	itemRepo *repository.InventoryItemRepository,
+	productRepo *repository.ProductRepository,
+	categoryRepo *repository.CategoryRepository,
+	warehouseRepo *repository.WarehouseRepository,
+	stockAdjustmentService *StockAdjustmentService,
+	auditService *AuditService,
+) *CountSessionService {
+	return &CountSessionService{
+		repo:                   repo,
+		itemRepo:               itemRepo,
+		productRepo:            productRepo,
+		categoryRepo:           categoryRepo,
+		warehouseRepo:          warehouseRepo,
+		stockAdjustmentService: stockAdjustmentService,
+		auditService:           auditService,
+	}
+}
+
+// CreateCountSession opens a count of a warehouse and snapshots the expected
+// quantity of every inventory item in scope. The scope is every item of the
+// warehouse, the items whose product is in countSession.CategoryID, or the
+// items listed in itemIDs. An item can only be in one unfinished session at a
+// time.
+func (s *CountSessionService) CreateCountSession(ctx context.Context, countSession *model.CountSession, itemIDs []uuid.UUID) (*model.CountSession, error) {
+	exists, err := s.warehouseRepo.WarehouseExists(ctx, countSession.WarehouseID.String())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error checking warehouse existence: %v", err)
+	}
+	if !exists {
+		return nil, status.Errorf(codes.NotFound, "warehouse %s not found", countSession.WarehouseID)
+	}
+	if countSession.CategoryID != uuid.Nil && len(itemIDs) > 0 {
+		return nil, status.Error(codes.InvalidArgument, "a count session is scoped by category or by item list, not both")
+	}
+
+	items, err := s.itemsInScope(ctx, countSession.WarehouseID, countSession.CategoryID, itemIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "no inventory items in scope")
+	}
+	underCount, _, err := s.countHistory(ctx, countSession.WarehouseID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	countSession.ID = uuid.New()
+	countSession.Status = model.CountSessionOpen
+	countSession.CreatedBy = actorFromContext(ctx)
+	countSession.CreatedAt = now
+	countSession.UpdatedAt = now
+	countSession.SubmittedAt = time.Time{}
+	countSession.ApprovedAt = time.Time{}
+	countSession.Lines = make([]*model.CountLine, len(items))
+	for i, item := range items {
+		if sessionID, ok := underCount[item.ID]; ok {
+			return nil, status.Errorf(codes.FailedPrecondition, "inventory item %s is already being counted in session %s", item.ID, sessionID)
+		}
+		countSession.Lines[i] = &model.CountLine{
+			InventoryItemID:  item.ID,
+			ProductID:        item.ProductID,
+			ExpectedQuantity: item.Quantity,
+		}
+	}
+
+	err = s.repo.CreateCountSession(ctx, countSession)
+	if err != nil {
+		log.Printf("Error creating count session: %v", err)
+		return nil, status.Errorf(codes.Internal, "error creating count session: %v", err)
+	}
+	s.auditService.Record(ctx, model.EntityCountSession, countSession.ID, model.AuditCreate, nil, countSession)
+	log.Println("Count session created successfully:", countSession.ID)
+	return countSession, nil
+}
+
+func (s *CountSessionService) GetCountSession(ctx context.Context, id uuid.UUID) (*model.CountSession, error) {
+	countSession, err := s.repo.GetCountSession(ctx, id.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrCountSessionNotFound) {
+			return nil, status.Error(codes.NotFound, "count session not found")
+		}
+		log.Printf("Error retrieving count session: %v", err)
+		return nil, status.Errorf(codes.Internal, "error retrieving count session: %v", err)
+	}
+	return countSession, nil
+}
+
+// ListCountSessions returns sessions newest first, optionally restricted to a
+// warehouse (non-nil warehouseID) and to a set of statuses (non-empty
+// statuses).
+func (s *CountSessionService) ListCountSessions(ctx context.Context, warehouseID uuid.UUID, statuses []model.CountSessionStatus) ([]*model.CountSession, error) {
+	countSessions, err := s.repo.ListCountSessions(ctx)
+	if err != nil {
+		log.Printf("Error listing count sessions: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing count sessions: %v", err)
+	}
+	var filtered []*model.CountSession
+	for _, countSession := range countSessions {
+		if warehouseID != uuid.Nil && countSession.WarehouseID != warehouseID {
+			continue
+		}
+		if len(statuses) > 0 && !containsCountSessionStatus(statuses, countSession.Status) {
+			continue
+		}
+		filtered = append(filtered, countSession)
+	}
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].CreatedAt.After(filtered[j].CreatedAt)
+	})
+	log.Println("Count sessions listed successfully")
+	return filtered, nil
+}
+
+// RecordCounts stores the quantities counted for items of an open session.
+// Counting an item again replaces its earlier count.
+func (s *CountSessionService) RecordCounts(ctx context.Context, id uuid.UUID, counts []*model.CountLine) (*model.CountSession, error) {
+	countSession, err := s.GetCountSession(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if countSession.Status != model.CountSessionOpen {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot record counts in a count session that is %s", countSession.Status)
+	}
+	if len(counts) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one count is required")
+	}
+	lines := make(map[uuid.UUID]*model.CountLine, len(countSession.Lines))
+	for _, line := range countSession.Lines {
+		lines[line.InventoryItemID] = line
+	}
+	for _, count := range counts {
+		line, ok := lines[count.InventoryItemID]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "inventory item %s is not part of count session %s", count.InventoryItemID, countSession.ID)
+		}
+		if count.CountedQuantity < 0 {
+			return nil, status.Error(codes.InvalidArgument, "counted quantity must not be negative")
+		}
+		product, err := s.productRepo.GetProduct(ctx, line.ProductID.String())
+		if err != nil {
+			log.Printf("Error retrieving product: %v", err)
+			return nil, status.Errorf(codes.Internal, "error retrieving product: %v", err)
+		}
+		if err := validateQuantity("counted quantity", count.CountedQuantity, product); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now().UTC()
+	actor := actorFromContext(ctx)
+	changed := make([]*model.CountLine, 0, len(counts))
+	for _, count := range counts {
+		line := lines[count.InventoryItemID]
+		line.CountedQuantity = count.CountedQuantity
+		line.Counted = true
+		line.CountedBy = actor
+		line.CountedAt = now
+		changed = append(changed, line)
+	}
+	countSession.UpdatedAt = now
+	err = s.repo.SaveCountLines(ctx, countSession.ID, changed, now)
+	if err != nil {
+		log.Printf("Error saving counts: %v", err)
+		return nil, status.Errorf(codes.Internal, "error saving counts: %v", err)
+	}
+	log.Printf("%d counts recorded in count session %s", len(changed), countSession.ID)
+	return countSession, nil
+}
+
+// SubmitCountSession closes counting once every item has been counted,
+// revealing the variances for review.
+func (s *CountSessionService) SubmitCountSession(ctx context.Context, id uuid.UUID) (*model.CountSession, error) {
+	countSession, err := s.GetCountSession(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if countSession.Status != model.CountSessionOpen {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot submit a count session that is %s", countSession.Status)
+	}
+	uncounted := 0
+	for _, line := range countSession.Lines {
+		if !line.Counted {
+			uncounted++
+		}
+	}
+	if uncounted > 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "%d of %d items have not been counted", uncounted, len(countSession.Lines))
+	}
+	before := copyCountSession(countSession)
+	countSession.Status = model.CountSessionSubmitted
+	countSession.UpdatedAt = time.Now().UTC()
+	countSession.SubmittedAt = countSession.UpdatedAt
+	return s.save(ctx, before, countSession)
+}
+
+// ApproveCountSession posts a count correction adjustment for every variance
+// of a submitted session. The variance is applied to the current quantity,
+// so movements posted since the session was opened are kept. Lines that
+// already have an adjustment are skipped, so an approval that failed part
+// way can be retried.
+func (s *CountSessionService) ApproveCountSession(ctx context.Context, id uuid.UUID, notes string) (*model.CountSession, error) {
+	countSession, err := s.GetCountSession(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if countSession.Status != model.CountSessionSubmitted {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot approve a count session that is %s", countSession.Status)
+	}
+	before := copyCountSession(countSession)
+
+	var approveErr error
+	for _, line := range countSession.Lines {
+		if line.Variance() == 0 || line.StockAdjustmentID != uuid.Nil {
+			continue
+		}
+		adjustment, err := s.stockAdjustmentService.AdjustStockApproved(ctx, &model.StockAdjustment{
+			InventoryItemID: line.InventoryItemID,
+			QuantityChange:  line.Variance(),
+			Reason:          model.AdjustmentCountCorrection,
+			Notes:           fmt.Sprintf("count session %s", countSession.ID),
+		}, notes)
+		if err != nil {
+			approveErr = err
+			break
+		}
+		line.StockAdjustmentID = adjustment.ID
+	}
+
+	countSession.UpdatedAt = time.Now().UTC()
+	if approveErr == nil {
+		countSession.Status = model.CountSessionApproved
+		countSession.ApprovedAt = countSession.UpdatedAt
+	}
+	saved, err := s.save(ctx, before, countSession)
+	if err != nil {
+		return nil, err
+	}
+	if approveErr != nil {
+		return nil, approveErr
+	}
+	return saved, nil
+}
+
+// CancelCountSession abandons a session before any of its variances has been
+// posted.
+func (s *CountSessionService) CancelCountSession(ctx context.Context, id uuid.UUID) (*model.CountSession, error) {
+	countSession, err := s.GetCountSession(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if countSession.Status != model.CountSessionOpen && countSession.Status != model.CountSessionSubmitted {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot cancel a count session that is %s", countSession.Status)
+	}
+	for _, line := range countSession.Lines {
+		if line.StockAdjustmentID != uuid.Nil {
+			return nil, status.Error(codes.FailedPrecondition, "cannot cancel a count session that has been partly approved; approve it again instead")
+		}
+	}
+	before := copyCountSession(countSession)
+	countSession.Status = model.CountSessionCancelled
+	countSession.UpdatedAt = time.Now().UTC()
+	return s.save(ctx, before, countSession)
+}
+
+func (s *CountSessionService) save(ctx context.Context, before, countSession *model.CountSession) (*model.CountSession, error) {
+	err := s.repo.SaveProgress(ctx, countSession)
+	if err != nil {
+		log.Printf("Error saving count session: %v", err)
+		return nil, status.Errorf(codes.Internal, "error saving count session: %v", err)
+	}
+	s.auditService.Record(ctx, model.EntityCountSession, countSession.ID, model.AuditUpdate, before, countSession)
+	log.Printf("Count session %s moved from %s to %s", countSession.ID, before.Status, countSession.Status)
+	return countSession, nil
+}
+
+// itemsInScope returns the inventory items of a warehouse, restricted to the
+// products of a category (non-nil categoryID) or to the given items.
+func (s *CountSessionService) itemsInScope(ctx context.Context, warehouseID, categoryID uuid.UUID, itemIDs []uuid.UUID) ([]*model.InventoryItem, error) {
+	items, err := s.itemRepo.ListInventoryItems(ctx)
+	if err != nil {
+		log.Printf("Error listing inventory items: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing inventory items: %v", err)
+	}
+	var products map[uuid.UUID]bool
+	if categoryID != uuid.Nil {
+		exists, err := s.categoryRepo.CategoryExists(ctx, categoryID.String())
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "error checking category existence: %v", err)
+		}
+		if !exists {
+			return nil, status.Errorf(codes.NotFound, "category %s not found", categoryID)
+		}
+		allProducts, err := s.productRepo.ListProducts(ctx)
+		if err != nil {
+			log.Printf("Error listing products: %v", err)
+			return nil, status.Errorf(codes.Internal, "error listing products: %v", err)
+		}
+		products = make(map[uuid.UUID]bool)
+		for _, product := range allProducts {
+			if product.CategoryID == categoryID {
+				products[product.ID] = true
+			}
+		}
+	}
+	var wanted map[uuid.UUID]bool
+	if len(itemIDs) > 0 {
+		wanted = make(map[uuid.UUID]bool, len(itemIDs))
+		for _, id := range itemIDs {
+			wanted[id] = true
+		}
+	}
+
+	var inScope []*model.InventoryItem
+	for _, item := range items {
+		if item.WarehouseID != warehouseID {
+			continue
+		}
+		if products != nil && !products[item.ProductID] {
+			continue
+		}
+		if wanted != nil {
+			if !wanted[item.ID] {
+				continue
+			}
+			delete(wanted, item.ID)
+		}
+		inScope = append(inScope, item)
+	}
+	for id := range wanted {
+		return nil, status.Errorf(codes.InvalidArgument, "inventory item %s is not held in warehouse %s", id, warehouseID)
+	}
+	return inScope, nil
+}
+
+// countHistory returns, for the items of a warehouse, the unfinished session
+// each is being counted in and when each was last counted in an approved
+// session.
+func (s *CountSessionService) countHistory(ctx context.Context, warehouseID uuid.UUID) (map[uuid.UUID]uuid.UUID, map[uuid.UUID]time.Time, error) {
+	countSessions, err := s.ListCountSessions(ctx, warehouseID, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	underCount := make(map[uuid.UUID]uuid.UUID)
+	lastCounted := make(map[uuid.UUID]time.Time)
+	for _, countSession := range countSessions {
+		for _, line := range countSession.Lines {
+			switch countSession.Status {
+			case model.CountSessionOpen, model.CountSessionSubmitted:
+				underCount[line.InventoryItemID] = countSession.ID
+			case model.CountSessionApproved:
+				if line.CountedAt.After(lastCounted[line.InventoryItemID]) {
+					lastCounted[line.InventoryItemID] = line.CountedAt
+				}
+			}
+		}
+	}
+	return underCount, lastCounted, nil
+}
+
+// copyCountSession returns a copy of countSession whose lines can be changed
+// without affecting the original.
+func copyCountSession(countSession *model.CountSession) *model.CountSession {
+	copied := *countSession
+	copied.Lines = make([]*model.CountLine, len(countSession.Lines))
+	for i, line := range countSession.Lines {
+		lineCopy := *line
+		copied.Lines[i] = &lineCopy
+	}
+	return &copied
+}
+
+func containsCountSessionStatus(statuses []model.CountSessionStatus, target model.CountSessionStatus) bool {
+	for _, s := range statuses {
+		if s == target {
+			return true
+		}
+	}
+	return false
+}
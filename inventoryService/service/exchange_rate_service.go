@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventoryService/model"
+	"inventoryService/repository"
+	"io"
+	"log"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+type ExchangeRateService struct {
+	repo *repository.ExchangeRateRepository
+}
+
+func NewExchangeRateService(repo *repository.ExchangeRateRepository) *ExchangeRateService {
+	return &ExchangeRateService{repo: repo}
+}
+
+// LoadExchangeRates reads exchange rates from a CSV file and saves them. Each
+// record holds the currency converted from, the currency converted to, the
+// rate and optionally the date it takes effect on as YYYY-MM-DD, such as
+// "EUR,USD,1.0842,2024-03-01"; a rate without a date takes effect now. Blank
+// lines and lines starting with '#' are skipped. A rate for a pair and date
+// already in the table replaces it. It returns the number of rates saved.
+func (s *ExchangeRateService) LoadExchangeRates(ctx context.Context, path string) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	now := time.Now().UTC()
+	var rates []*model.ExchangeRate
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+		line, _ := reader.FieldPos(0)
+		rate, err := parseExchangeRate(record, now)
+		if err != nil {
+			return 0, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		rates = append(rates, rate)
+	}
+
+	for _, rate := range rates {
+		if err := s.repo.SaveExchangeRate(ctx, rate); err != nil {
+			return 0, fmt.Errorf("error saving exchange rate %s/%s: %w", rate.FromCurrency, rate.ToCurrency, err)
+		}
+	}
+	log.Printf("Exchange rates loaded successfully: %d from %s", len(rates), path)
+	return len(rates), nil
+}
+
+// ListExchangeRates returns every rate ordered by pair and, within a pair,
+// newest first.
+func (s *ExchangeRateService) ListExchangeRates(ctx context.Context) ([]*model.ExchangeRate, error) {
+	rates, err := s.repo.ListExchangeRates(ctx)
+	if err != nil {
+		log.Printf("Error listing exchange rates: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing exchange rates: %v", err)
+	}
+	sort.Slice(rates, func(i, j int) bool {
+		if rates[i].FromCurrency != rates[j].FromCurrency {
+			return rates[i].FromCurrency < rates[j].FromCurrency
+		}
+		if rates[i].ToCurrency != rates[j].ToCurrency {
+			return rates[i].ToCurrency < rates[j].ToCurrency
+		}
+		return rates[i].EffectiveFrom.After(rates[j].EffectiveFrom)
+	})
+	log.Println("Exchange rates listed successfully")
+	return rates, nil
+}
+
+func parseExchangeRate(record []string, now time.Time) (*model.ExchangeRate, error) {
+	if len(record) < 3 || len(record) > 4 {
+		return nil, fmt.Errorf("expected from currency, to currency, rate and an optional date, got %d fields", len(record))
+	}
+	rate := &model.ExchangeRate{
+		FromCurrency:  strings.ToUpper(strings.TrimSpace(record[0])),
+		ToCurrency:    strings.ToUpper(strings.TrimSpace(record[1])),
+		Rate:          strings.TrimSpace(record[2]),
+		EffectiveFrom: now,
+	}
+	for _, code := range []string{rate.FromCurrency, rate.ToCurrency} {
+		if !currencyCodePattern.MatchString(code) {
+			return nil, fmt.Errorf("invalid currency code %q", code)
+		}
+	}
+	if rate.FromCurrency == rate.ToCurrency {
+		return nil, fmt.Errorf("cannot convert %s to itself", rate.FromCurrency)
+	}
+	value, ok := new(big.Rat).SetString(rate.Rate)
+	if !ok || value.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid rate %q", rate.Rate)
+	}
+	if len(record) == 4 && strings.TrimSpace(record[3]) != "" {
+		date, err := time.Parse(time.DateOnly, strings.TrimSpace(record[3]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q", record[3])
+		}
+		rate.EffectiveFrom = date
+	}
+	return rate, nil
+}
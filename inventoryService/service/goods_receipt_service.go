@@ -0,0 +1,361 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventoryService/model"
+	"inventoryService/repository"
+	"log"
+	"sort"
+	"time"
+)
+
+type GoodsReceiptService struct {
+	repo                 *repository.GoodsReceiptRepository
+	purchaseOrderRepo    *repository.PurchaseOrderRepository
+	itemRepo             *repository.InventoryItemRepository
+	productRepo          *repository.ProductRepository
+	stockMovementService *StockMovementService
+	auditService         *AuditService
+}
+
+func NewGoodsReceiptService(
+	repo *repository.GoodsReceiptRepository,
+	purchaseOrderRepo *repository.PurchaseOrderRepository,
+	itemRepo *repository.InventoryItemRepository,
+	productRepo *repository.ProductRepository,
+	stockMovementService *StockMovementService,
+	auditService *AuditService,
+) *GoodsReceiptService {
+	return &GoodsReceiptService{
+		repo:                 repo,
+		purchaseOrderRepo:    purchaseOrderRepo,
+		itemRepo:             itemRepo,
+		productRepo:          productRepo,
+		stockMovementService: stockMovementService,
+		auditService:         auditService,
+	}
+}
+
+// ReceiveGoods records a delivery against a submitted purchase order. The
+// accepted quantity of every line is added to the inventory item of the line's
+// product in its warehouse, creating the item if needed, and is added to the
+// line's received total. The order becomes received once every line has been
+// received in full, and partially received otherwise. Quantities are in the
+// unit of the order line and are converted to base units for the stock.
+//
+// The receipt holds the order while its lines are posted, and each line is
+// saved as posted together with its received total. A call that fails part way
+// leaves the order held; calling again with the same receipt id posts the
+// remaining lines and releases it.
+func (s *GoodsReceiptService) ReceiveGoods(ctx context.Context, receipt *model.GoodsReceipt) (*model.GoodsReceipt, *model.PurchaseOrder, error) {
+	order, err := s.getPurchaseOrder(ctx, receipt.PurchaseOrderID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if receipt.ID != uuid.Nil {
+		existing, err := s.repo.GetGoodsReceipt(ctx, receipt.ID.String())
+		if err == nil {
+			if existing.PurchaseOrderID != order.ID {
+				return nil, nil, status.Errorf(codes.InvalidArgument, "goods receipt %s belongs to purchase order %s", existing.ID, existing.PurchaseOrderID)
+			}
+			if order.ReceivingReceiptID != existing.ID {
+				// Posted in full by an earlier call.
+				return existing, order, nil
+			}
+			return s.postReceipt(ctx, existing, order)
+		}
+		if !errors.Is(err, repository.ErrGoodsReceiptNotFound) {
+			log.Printf("Error retrieving goods receipt: %v", err)
+			return nil, nil, status.Errorf(codes.Internal, "error retrieving goods receipt: %v", err)
+		}
+	} else {
+		receipt.ID = uuid.New()
+	}
+	if order.Status != model.PurchaseOrderSubmitted && order.Status != model.PurchaseOrderPartiallyReceived {
+		return nil, nil, status.Errorf(codes.FailedPrecondition, "goods cannot be received against a purchase order that is %s", order.Status)
+	}
+	if len(receipt.Lines) == 0 {
+		return nil, nil, status.Error(codes.InvalidArgument, "goods receipt must have at least one line")
+	}
+
+	claimed, holder, err := s.purchaseOrderRepo.ClaimReceipt(ctx, order.ID.String(), order.Status, receipt.ID)
+	if err != nil {
+		log.Printf("Error claiming purchase order: %v", err)
+		return nil, nil, status.Errorf(codes.Internal, "error claiming purchase order: %v", err)
+	}
+	if !claimed {
+		if holder != uuid.Nil {
+			return nil, nil, status.Errorf(codes.Aborted, "goods receipt %s is being posted against the purchase order", holder)
+		}
+		return nil, nil, status.Error(codes.Aborted, "purchase order changed concurrently")
+	}
+	// Lines received by other receipts until the claim are counted now.
+	order, err = s.getPurchaseOrder(ctx, order.ID)
+	if err == nil {
+		err = s.prepareReceipt(ctx, receipt, order)
+	}
+	if err == nil {
+		err = s.repo.CreateGoodsReceipt(ctx, receipt)
+		if err != nil {
+			log.Printf("Error creating goods receipt: %v", err)
+			err = status.Errorf(codes.Internal, "error creating goods receipt: %v", err)
+		}
+	}
+	if err != nil {
+		if releaseErr := s.purchaseOrderRepo.ReleaseReceipt(ctx, receipt.PurchaseOrderID.String(), receipt.ID); releaseErr != nil {
+			log.Printf("Error releasing purchase order %s: %v", receipt.PurchaseOrderID, releaseErr)
+		}
+		return nil, nil, err
+	}
+	s.auditService.Record(ctx, model.EntityGoodsReceipt, receipt.ID, model.AuditCreate, nil, receipt)
+	return s.postReceipt(ctx, receipt, order)
+}
+
+func (s *GoodsReceiptService) getPurchaseOrder(ctx context.Context, id uuid.UUID) (*model.PurchaseOrder, error) {
+	order, err := s.purchaseOrderRepo.GetPurchaseOrder(ctx, id.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrPurchaseOrderNotFound) {
+			return nil, status.Error(codes.NotFound, "purchase order not found")
+		}
+		log.Printf("Error retrieving purchase order: %v", err)
+		return nil, status.Errorf(codes.Internal, "error retrieving purchase order: %v", err)
+	}
+	return order, nil
+}
+
+// prepareReceipt checks the lines of a new receipt against its order and
+// fills in what they receive. Lines that put units into stock get the id of
+// their stock movement up front.
+func (s *GoodsReceiptService) prepareReceipt(ctx context.Context, receipt *model.GoodsReceipt, order *model.PurchaseOrder) error {
+	orderLines := make(map[uuid.UUID]*model.PurchaseOrderLine, len(order.Lines))
+	for _, line := range order.Lines {
+		orderLines[line.ID] = line
+	}
+	seen := make(map[uuid.UUID]bool, len(receipt.Lines))
+	for _, line := range receipt.Lines {
+		if _, ok := orderLines[line.PurchaseOrderLineID]; !ok {
+			return status.Errorf(codes.NotFound, "purchase order line %s not found", line.PurchaseOrderLineID)
+		}
+		if seen[line.PurchaseOrderLineID] {
+			return status.Errorf(codes.InvalidArgument, "purchase order line %s is received more than once", line.PurchaseOrderLineID)
+		}
+		seen[line.PurchaseOrderLineID] = true
+		if line.ReceivedQuantity < 0 || line.DamagedQuantity < 0 {
+			return status.Error(codes.InvalidArgument, "received and damaged quantities cannot be negative")
+		}
+		if line.DamagedQuantity > line.ReceivedQuantity {
+			return status.Error(codes.InvalidArgument, "damaged quantity cannot exceed received quantity")
+		}
+	}
+
+	receipt.SupplierID = order.SupplierID
+	if receipt.ReceivedAt.IsZero() {
+		receipt.ReceivedAt = time.Now().UTC()
+	}
+	for _, line := range receipt.Lines {
+		orderLine := orderLines[line.PurchaseOrderLineID]
+		line.ProductID = orderLine.ProductID
+		line.WarehouseID = orderLine.WarehouseID
+		line.AcceptedQuantity = line.ReceivedQuantity - line.DamagedQuantity
+		outstanding := orderLine.Quantity - orderLine.ReceivedQuantity
+		if outstanding < 0 {
+			outstanding = 0
+		}
+		line.Variance = line.AcceptedQuantity - outstanding
+		line.Posted = line.AcceptedQuantity == 0
+		if line.AcceptedQuantity > 0 {
+			product, err := s.productRepo.GetProduct(ctx, orderLine.ProductID.String())
+			if err != nil {
+				log.Printf("Error retrieving product: %v", err)
+				return status.Errorf(codes.Internal, "error retrieving product: %v", err)
+			}
+			if _, ok := product.UnitFactor(orderLine.Unit); !ok {
+				return status.Errorf(codes.FailedPrecondition, "product %s no longer has unit %q of purchase order line %s", product.ID, orderLine.Unit, orderLine.ID)
+			}
+			line.StockMovementID = uuid.New()
+		}
+	}
+	return nil
+}
+
+// postReceipt puts the accepted units of every line of a saved receipt that is
+// not posted yet into stock, then sets the status of the order and releases
+// it. A line whose movement was journaled before its progress could be saved
+// is not posted again.
+func (s *GoodsReceiptService) postReceipt(ctx context.Context, receipt *model.GoodsReceipt, order *model.PurchaseOrder) (*model.GoodsReceipt, *model.PurchaseOrder, error) {
+	before := copyPurchaseOrder(order)
+	orderLines := make(map[uuid.UUID]*model.PurchaseOrderLine, len(order.Lines))
+	for _, line := range order.Lines {
+		orderLines[line.ID] = line
+	}
+	for _, line := range receipt.Lines {
+		if line.Posted {
+			continue
+		}
+		orderLine, ok := orderLines[line.PurchaseOrderLineID]
+		if !ok {
+			return nil, nil, status.Errorf(codes.NotFound, "purchase order line %s not found", line.PurchaseOrderLineID)
+		}
+		if err := s.postLine(ctx, receipt, line, orderLine); err != nil {
+			return nil, nil, status.Errorf(status.Code(err), "%s; retry with receipt id %s", status.Convert(err).Message(), receipt.ID)
+		}
+	}
+
+	order.Status = model.PurchaseOrderReceived
+	for _, line := range order.Lines {
+		if line.ReceivedQuantity < line.Quantity {
+			order.Status = model.PurchaseOrderPartiallyReceived
+			break
+		}
+	}
+	order.UpdatedAt = time.Now().UTC()
+	order.ReceivingReceiptID = uuid.Nil
+	applied, err := s.purchaseOrderRepo.CompleteReceipt(ctx, order.ID.String(), receipt.ID, order.Status, order.UpdatedAt)
+	if err != nil {
+		log.Printf("Error updating purchase order status: %v", err)
+		return nil, nil, status.Errorf(codes.Internal, "error updating purchase order status: %v; retry with receipt id %s", err, receipt.ID)
+	}
+	if !applied {
+		return nil, nil, status.Errorf(codes.Aborted, "goods receipt %s no longer holds the purchase order", receipt.ID)
+	}
+	s.auditService.Record(ctx, model.EntityPurchaseOrder, order.ID, model.AuditUpdate, before, order)
+
+	log.Println("Goods receipt posted successfully:", receipt.ID)
+	return receipt, order, nil
+}
+
+// postLine posts the stock movement of a receipt line unless it is already
+// journaled, then saves the line as posted along with the order line's new
+// received total.
+func (s *GoodsReceiptService) postLine(ctx context.Context, receipt *model.GoodsReceipt, line *model.GoodsReceiptLine, orderLine *model.PurchaseOrderLine) error {
+	posted, err := s.stockMovementService.MovementPosted(ctx, line.StockMovementID)
+	if err != nil {
+		return err
+	}
+	if !posted {
+		product, err := s.productRepo.GetProduct(ctx, orderLine.ProductID.String())
+		if err != nil {
+			log.Printf("Error retrieving product: %v", err)
+			return status.Errorf(codes.Internal, "error retrieving product: %v", err)
+		}
+		factor, ok := product.UnitFactor(orderLine.Unit)
+		if !ok {
+			return status.Errorf(codes.FailedPrecondition, "product %s no longer has unit %q of purchase order line %s", product.ID, orderLine.Unit, orderLine.ID)
+		}
+		item, err := findOrCreateInventoryItem(ctx, s.itemRepo, s.auditService, orderLine.ProductID, orderLine.WarehouseID)
+		if err != nil {
+			return err
+		}
+		_, err = s.stockMovementService.PostStockMovement(ctx, &model.StockMovement{
+			ID:                     line.StockMovementID,
+			InventoryItemID:        item.ID,
+			Type:                   model.Addition,
+			Quantity:               line.AcceptedQuantity,
+			Unit:                   orderLine.Unit,
+			UnitQuantity:           line.AcceptedQuantity,
+			Date:                   receipt.ReceivedAt,
+			DestinationWarehouseID: orderLine.WarehouseID,
+			SerialNumbers:          line.SerialNumbers,
+			UnitCost:               orderLine.UnitCost.Div(factor),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	received := orderLine.ReceivedQuantity + line.AcceptedQuantity
+	err = s.repo.SaveLineReceived(ctx, receipt, line, received)
+	if err != nil {
+		log.Printf("Error saving goods receipt line: %v", err)
+		return status.Errorf(codes.Internal, "error saving goods receipt line: %v", err)
+	}
+	orderLine.ReceivedQuantity = received
+	line.Posted = true
+	return nil
+}
+
+func (s *GoodsReceiptService) GetGoodsReceipt(ctx context.Context, id uuid.UUID) (*model.GoodsReceipt, error) {
+	receipt, err := s.repo.GetGoodsReceipt(ctx, id.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrGoodsReceiptNotFound) {
+			return nil, status.Error(codes.NotFound, "goods receipt not found")
+		}
+		log.Printf("Error retrieving goods receipt: %v", err)
+		return nil, status.Errorf(codes.Internal, "error retrieving goods receipt: %v", err)
+	}
+	return receipt, nil
+}
+
+// ListGoodsReceipts returns every receipt, optionally restricted to a purchase
+// order (non-nil purchaseOrderID), oldest first.
+func (s *GoodsReceiptService) ListGoodsReceipts(ctx context.Context, purchaseOrderID uuid.UUID) ([]*model.GoodsReceipt, error) {
+	receipts, err := s.repo.ListGoodsReceipts(ctx)
+	if err != nil {
+		log.Printf("Error listing goods receipts: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing goods receipts: %v", err)
+	}
+	var filtered []*model.GoodsReceipt
+	for _, receipt := range receipts {
+		if purchaseOrderID != uuid.Nil && receipt.PurchaseOrderID != purchaseOrderID {
+			continue
+		}
+		filtered = append(filtered, receipt)
+	}
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].ReceivedAt.Before(filtered[j].ReceivedAt)
+	})
+	log.Println("Goods receipts listed successfully")
+	return filtered, nil
+}
+
+// findOrCreateInventoryItem returns the inventory item of a product in a
+// warehouse, creating an empty one when the warehouse has never held it. Of
+// two calls racing to create the item, the one that loses takes the item of
+// the other; an item whose creation failed after claiming its product and
+// warehouse is saved empty.
+func findOrCreateInventoryItem(ctx context.Context, itemRepo *repository.InventoryItemRepository, auditService *AuditService, productID, warehouseID uuid.UUID) (*model.InventoryItem, error) {
+	item, err := itemRepo.FindInventoryItem(ctx, productID.String(), warehouseID.String())
+	if err == nil {
+		return item, nil
+	}
+	if !errors.Is(err, repository.ErrInventoryItemNotFound) {
+		log.Printf("Error retrieving inventory item: %v", err)
+		return nil, status.Errorf(codes.Internal, "error retrieving inventory item: %v", err)
+	}
+	item = &model.InventoryItem{ID: uuid.New(), ProductID: productID, WarehouseID: warehouseID}
+	holder, err := itemRepo.CreateInventoryItem(ctx, item)
+	if errors.Is(err, repository.ErrInventoryItemExists) {
+		existing, err := itemRepo.GetInventoryItem(ctx, holder.String())
+		if err == nil {
+			return existing, nil
+		}
+		if !errors.Is(err, repository.ErrInventoryItemNotFound) {
+			log.Printf("Error retrieving inventory item: %v", err)
+			return nil, status.Errorf(codes.Internal, "error retrieving inventory item: %v", err)
+		}
+		item.ID = holder
+		err = itemRepo.RestoreInventoryItem(ctx, item)
+	}
+	if err != nil {
+		log.Printf("Error creating inventory item: %v", err)
+		return nil, status.Errorf(codes.Internal, "error creating inventory item: %v", err)
+	}
+	auditService.Record(ctx, model.EntityInventoryItem, item.ID, model.AuditCreate, nil, item)
+	return item, nil
+}
+
+// copyPurchaseOrder returns a copy of order whose lines can be changed without
+// affecting the original.
+func copyPurchaseOrder(order *model.PurchaseOrder) *model.PurchaseOrder {
+	copied := *order
+	copied.Lines = make([]*model.PurchaseOrderLine, len(order.Lines))
+	for i, line := range order.Lines {
+		lineCopy := *line
+		copied.Lines[i] = &lineCopy
+	}
+	return &copied
+}
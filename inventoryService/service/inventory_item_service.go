@@ -12,30 +12,50 @@ import (
 )
 
 type InventoryItemService struct {
-	repo          *repository.InventoryItemRepository
-	productRepo   *repository.ProductRepository
-	warehouseRepo *repository.WarehouseRepository
+	repo                 *repository.InventoryItemRepository
+	productRepo          *repository.ProductRepository
+	warehouseRepo        *repository.WarehouseRepository
+	lotRepo              *repository.LotRepository
+	binStockRepo         *repository.BinStockRepository
+	costLayerRepo        *repository.CostLayerRepository
+	stockMovementService *StockMovementService
+	auditService         *AuditService
+	replenishmentService *ReplenishmentService
 }
 
-func NewInventoryItemService(repo *repository.InventoryItemRepository, productRepo *repository.ProductRepository, warehouseRepo *repository.WarehouseRepository) *InventoryItemService {
+func NewInventoryItemService(repo *repository.InventoryItemRepository, productRepo *repository.ProductRepository, warehouseRepo *repository.WarehouseRepository, lotRepo *repository.LotRepository, binStockRepo *repository.BinStockRepository, costLayerRepo *repository.CostLayerRepository, stockMovementService *StockMovementService, auditService *AuditService, replenishmentService *ReplenishmentService) *InventoryItemService {
 	return &InventoryItemService{
-		repo:          repo,
-		productRepo:   productRepo,
-		warehouseRepo: warehouseRepo,
+		repo:                 repo,
+		productRepo:          productRepo,
+		warehouseRepo:        warehouseRepo,
+		lotRepo:              lotRepo,
+		binStockRepo:         binStockRepo,
+		costLayerRepo:        costLayerRepo,
+		stockMovementService: stockMovementService,
+		auditService:         auditService,
+		replenishmentService: replenishmentService,
 	}
 }
 
+// CreateInventoryItem creates an item. Its starting quantity is posted as an
+// opening addition at no cost, so that the stock has a movement, a cost layer
+// and bins like any other; the item is deleted again if it cannot be posted.
 func (s *InventoryItemService) CreateInventoryItem(ctx context.Context, item *model.InventoryItem) (*model.InventoryItem, error) {
 	item.ID = uuid.New()
+	item.ReservedQuantity = 0
+	item.QuarantinedQuantity = 0
 
-	exists, err := s.productRepo.ProductExists(ctx, item.ProductID.String())
+	product, err := s.productRepo.GetProduct(ctx, item.ProductID.String())
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "error checking product existence: %v", err)
+		if errors.Is(err, repository.ErrProductNotFound) {
+			return nil, status.Error(codes.NotFound, "product not found")
+		}
+		return nil, status.Errorf(codes.Internal, "error retrieving product: %v", err)
 	}
-	if !exists {
-		return nil, status.Error(codes.NotFound, "product not found")
+	if err := validateItemQuantities(item, product); err != nil {
+		return nil, err
 	}
-	exists, err = s.warehouseRepo.WarehouseExists(ctx, item.WarehouseID.String())
+	exists, err := s.warehouseRepo.WarehouseExists(ctx, item.WarehouseID.String())
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "error checking warehouse existence: %v", err)
 	}
@@ -43,10 +63,35 @@ func (s *InventoryItemService) CreateInventoryItem(ctx context.Context, item *mo
 		return nil, status.Error(codes.NotFound, "warehouse not found")
 	}
 
-	err = s.repo.CreateInventoryItem(ctx, item)
+	opening := item.Quantity
+	item.Quantity = 0
+	existing, err := s.repo.CreateInventoryItem(ctx, item)
+	if errors.Is(err, repository.ErrInventoryItemExists) {
+		return nil, status.Errorf(codes.AlreadyExists, "inventory item %s already holds product %s in warehouse %s", existing, item.ProductID, item.WarehouseID)
+	}
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "error creating inventory item: %v", err)
 	}
+	s.auditService.Record(ctx, model.EntityInventoryItem, item.ID, model.AuditCreate, nil, item)
+	if opening > 0 {
+		_, err := s.stockMovementService.PostStockMovement(ctx, &model.StockMovement{
+			InventoryItemID:        item.ID,
+			Type:                   model.Addition,
+			Quantity:               opening,
+			DestinationWarehouseID: item.WarehouseID,
+		})
+		if err != nil {
+			if deleteErr := s.repo.DeleteInventoryItem(ctx, item); deleteErr != nil {
+				log.Printf("Error deleting inventory item %s: %v", item.ID, deleteErr)
+			} else {
+				s.auditService.Record(ctx, model.EntityInventoryItem, item.ID, model.AuditDelete, item, nil)
+			}
+			return nil, err
+		}
+		item.Quantity = opening
+	} else {
+		s.replenishmentService.EvaluateItem(ctx, item.ID)
+	}
 
 	return item, nil
 }
@@ -74,15 +119,37 @@ func (s *InventoryItemService) ListInventoryItems(ctx context.Context) ([]*model
 }
 
 func (s *InventoryItemService) UpdateInventoryItem(ctx context.Context, item *model.InventoryItem) error {
-	exists, err := s.productRepo.ProductExists(ctx, item.ProductID.String())
+	before, err := s.repo.GetInventoryItem(ctx, item.ID.String())
+	if err != nil && !errors.Is(err, repository.ErrInventoryItemNotFound) {
+		log.Printf("Error retrieving inventory item: %v", err)
+		return status.Errorf(codes.Internal, "error retrieving inventory item: %v", err)
+	}
+	if before == nil {
+		return status.Error(codes.NotFound, "inventory item not found")
+	}
+	// Stock only changes through stock movements and adjustments, which keep
+	// its lots, bins, cost layers and serial numbers in step.
+	item.Quantity = before.Quantity
+	item.ReservedQuantity = before.ReservedQuantity
+	item.QuarantinedQuantity = before.QuarantinedQuantity
+	if item.ProductID != before.ProductID || item.WarehouseID != before.WarehouseID {
+		if err := s.checkUnused(ctx, before); err != nil {
+			return err
+		}
+	}
+
+	product, err := s.productRepo.GetProduct(ctx, item.ProductID.String())
 	if err != nil {
-		log.Printf("Error checking product existence: %v", err)
-		return status.Errorf(codes.Internal, "error checking product existence: %v", err)
+		if errors.Is(err, repository.ErrProductNotFound) {
+			return status.Error(codes.NotFound, "product not found")
+		}
+		log.Printf("Error retrieving product: %v", err)
+		return status.Errorf(codes.Internal, "error retrieving product: %v", err)
 	}
-	if !exists {
-		return status.Error(codes.NotFound, "product not found")
+	if err := validateItemQuantities(item, product); err != nil {
+		return err
 	}
-	exists, err = s.warehouseRepo.WarehouseExists(ctx, item.WarehouseID.String())
+	exists, err := s.warehouseRepo.WarehouseExists(ctx, item.WarehouseID.String())
 	if err != nil {
 		log.Printf("Error checking warehouse existence: %v", err)
 		return status.Errorf(codes.Internal, "error checking warehouse existence: %v", err)
@@ -90,24 +157,78 @@ func (s *InventoryItemService) UpdateInventoryItem(ctx context.Context, item *mo
 	if !exists {
 		return status.Error(codes.NotFound, "warehouse not found")
 	}
-	err = s.repo.UpdateInventoryItem(ctx, item)
+	err = s.repo.UpdateInventoryItem(ctx, before, item)
+	if errors.Is(err, repository.ErrInventoryItemExists) {
+		return status.Errorf(codes.AlreadyExists, "another inventory item already holds product %s in warehouse %s", item.ProductID, item.WarehouseID)
+	}
 	if err != nil {
 		log.Printf("Error updating inventory item: %v", err)
 		return status.Errorf(codes.Internal, "error updating inventory item: %v", err)
 	}
+	s.auditService.Record(ctx, model.EntityInventoryItem, item.ID, model.AuditUpdate, before, item)
+	s.replenishmentService.EvaluateItem(ctx, item.ID)
 	log.Println("Inventory item updated successfully:", item.ID)
 	return nil
 }
 
 func (s *InventoryItemService) DeleteInventoryItem(ctx context.Context, id uuid.UUID) error {
-	err := s.repo.DeleteInventoryItem(ctx, id.String())
+	before, err := s.repo.GetInventoryItem(ctx, id.String())
+	if err != nil && !errors.Is(err, repository.ErrInventoryItemNotFound) {
+		log.Printf("Error retrieving inventory item: %v", err)
+		return status.Errorf(codes.Internal, "error retrieving inventory item: %v", err)
+	}
+	if before == nil {
+		return status.Error(codes.NotFound, "inventory item not found")
+	}
+	err = s.repo.DeleteInventoryItem(ctx, before)
 	if err != nil {
-		if errors.Is(err, repository.ErrInventoryItemNotFound) {
-			return status.Error(codes.NotFound, "inventory item not found")
-		}
 		log.Printf("Error deleting inventory item: %v", err)
 		return status.Errorf(codes.Internal, "error deleting inventory item: %v", err)
 	}
+	s.auditService.Record(ctx, model.EntityInventoryItem, id, model.AuditDelete, before, nil)
+	s.replenishmentService.EvaluateItem(ctx, id)
 	log.Println("Inventory item deleted successfully:", id)
 	return nil
 }
+
+// checkUnused checks that an item has never held stock, so that its product
+// and warehouse can still change. Lots, bin stock and cost layers name the
+// item, and every posted addition leaves a cost layer behind, as do the
+// serial numbers and movements that come with it; they would all end up
+// describing another product or warehouse.
+func (s *InventoryItemService) checkUnused(ctx context.Context, item *model.InventoryItem) error {
+	if item.Quantity != 0 || item.ReservedQuantity != 0 || item.QuarantinedQuantity != 0 {
+		return status.Errorf(codes.FailedPrecondition, "inventory item %s holds stock; its product and warehouse cannot change", item.ID)
+	}
+	lots, err := s.lotRepo.ListLotsByInventoryItem(ctx, item.ID.String())
+	if err != nil {
+		log.Printf("Error listing lots: %v", err)
+		return status.Errorf(codes.Internal, "error listing lots: %v", err)
+	}
+	bins, err := s.binStockRepo.ListBinStockByInventoryItem(ctx, item.ID.String())
+	if err != nil {
+		log.Printf("Error listing bin stock: %v", err)
+		return status.Errorf(codes.Internal, "error listing bin stock: %v", err)
+	}
+	layers, err := s.costLayerRepo.ListCostLayers(ctx, item.ID.String())
+	if err != nil {
+		log.Printf("Error listing cost layers: %v", err)
+		return status.Errorf(codes.Internal, "error listing cost layers: %v", err)
+	}
+	if len(lots) > 0 || len(bins) > 0 || len(layers) > 0 {
+		return status.Errorf(codes.FailedPrecondition, "inventory item %s has held stock; its product and warehouse cannot change", item.ID)
+	}
+	return nil
+}
+
+// validateItemQuantities checks that the quantity and reorder settings of an
+// item are counted in the precision of its product.
+func validateItemQuantities(item *model.InventoryItem, product *model.Product) error {
+	if err := validateQuantity("quantity", item.Quantity, product); err != nil {
+		return err
+	}
+	if err := validateQuantity("reorder level", item.ReorderLevel, product); err != nil {
+		return err
+	}
+	return validateQuantity("reorder quantity", item.ReorderQuantity, product)
+}
@@ -0,0 +1,433 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventoryService/model"
+	"inventoryService/repository"
+	"log"
+	"sort"
+	"time"
+)
+
+// defaultPutawaySuggestions is how many bins SuggestPutaway proposes when no
+// limit is given.
+const defaultPutawaySuggestions = 5
+
+// LocationService manages the zones, aisles, racks, shelves and bins of a
+// warehouse and the stock held in bins. Bin quantities change with the
+// movements posted by StockMovementService.
+type LocationService struct {
+	repo                 *repository.LocationRepository
+	warehouseRepo        *repository.WarehouseRepository
+	binStockRepo         *repository.BinStockRepository
+	itemRepo             *repository.InventoryItemRepository
+	stockMovementService *StockMovementService
+	capacityService      *CapacityService
+	auditService         *AuditService
+}
+
+func NewLocationService(
+	repo *repository.LocationRepository,
+	warehouseRepo *repository.WarehouseRepository,
+	binStockRepo *repository.BinStockRepository,
+	itemRepo *repository.InventoryItemRepository,
+	stockMovementService *StockMovementService,
+	capacityService *CapacityService,
+	auditService *AuditService,
+) *LocationService {
+	return &LocationService{
+		repo:                 repo,
+		warehouseRepo:        warehouseRepo,
+		binStockRepo:         binStockRepo,
+		itemRepo:             itemRepo,
+		stockMovementService: stockMovementService,
+		capacityService:      capacityService,
+		auditService:         auditService,
+	}
+}
+
+// CreateLocation adds a location under a parent one level up, or a zone at
+// the top of a warehouse. The warehouse is taken from the parent.
+func (s *LocationService) CreateLocation(ctx context.Context, location *model.Location) (*model.Location, error) {
+	if location.Code == "" {
+		return nil, status.Error(codes.InvalidArgument, "code is required")
+	}
+	if location.Type < model.LocationZone || location.Type > model.LocationBin {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid location type: %d", location.Type)
+	}
+	if err := validateLocationCapacity(location); err != nil {
+		return nil, err
+	}
+	location.Path = location.Code
+	if location.Type == model.LocationZone {
+		if location.ParentID != uuid.Nil {
+			return nil, status.Error(codes.InvalidArgument, "zones cannot have a parent")
+		}
+		exists, err := s.warehouseRepo.WarehouseExists(ctx, location.WarehouseID.String())
+		if err != nil {
+			log.Printf("Error checking warehouse existence: %v", err)
+			return nil, status.Errorf(codes.Internal, "error checking warehouse existence: %v", err)
+		}
+		if !exists {
+			return nil, status.Error(codes.NotFound, "warehouse not found")
+		}
+	} else {
+		if location.ParentID == uuid.Nil {
+			return nil, status.Errorf(codes.InvalidArgument, "a %s needs a parent", location.Type)
+		}
+		parent, err := s.getLocation(ctx, location.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent.Type != location.Type-1 {
+			return nil, status.Errorf(codes.InvalidArgument, "a %s must be placed in a %s, not a %s", location.Type, location.Type-1, parent.Type)
+		}
+		location.WarehouseID = parent.WarehouseID
+		location.Path = parent.Path + "-" + location.Code
+	}
+
+	locations, err := s.listLocations(ctx, location.WarehouseID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkCodeUnique(locations, location); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	location.ID = uuid.New()
+	location.CreatedAt = now
+	location.UpdatedAt = now
+	err = s.repo.CreateLocation(ctx, location)
+	if err != nil {
+		log.Printf("Error creating location: %v", err)
+		return nil, status.Errorf(codes.Internal, "error creating location: %v", err)
+	}
+	s.auditService.Record(ctx, model.EntityLocation, location.ID, model.AuditCreate, nil, location)
+	log.Println("Location created successfully:", location.ID)
+	return location, nil
+}
+
+func (s *LocationService) GetLocation(ctx context.Context, id uuid.UUID) (*model.Location, error) {
+	return s.getLocation(ctx, id)
+}
+
+// ListLocations returns the locations of a warehouse ordered by path. When a
+// parent is given only its direct children are returned.
+func (s *LocationService) ListLocations(ctx context.Context, warehouseID, parentID uuid.UUID) ([]*model.Location, error) {
+	if warehouseID == uuid.Nil {
+		return nil, status.Error(codes.InvalidArgument, "warehouse ID is required")
+	}
+	locations, err := s.listLocations(ctx, warehouseID)
+	if err != nil {
+		return nil, err
+	}
+	if parentID != uuid.Nil {
+		var children []*model.Location
+		for _, location := range locations {
+			if location.ParentID == parentID {
+				children = append(children, location)
+			}
+		}
+		locations = children
+	}
+	sort.Slice(locations, func(i, j int) bool {
+		return locations[i].Path < locations[j].Path
+	})
+	return locations, nil
+}
+
+// UpdateLocation changes the code, name and capacity of a location. A new
+// code changes the path of the location and of everything below it. A bin's
+// capacity can be lowered below what it holds; it then takes no more stock.
+func (s *LocationService) UpdateLocation(ctx context.Context, location *model.Location) (*model.Location, error) {
+	if location.Code == "" {
+		return nil, status.Error(codes.InvalidArgument, "code is required")
+	}
+	existing, err := s.getLocation(ctx, location.ID)
+	if err != nil {
+		return nil, err
+	}
+	location.Type = existing.Type
+	if err := validateLocationCapacity(location); err != nil {
+		return nil, err
+	}
+	before := *existing
+	locations, err := s.listLocations(ctx, existing.WarehouseID)
+	if err != nil {
+		return nil, err
+	}
+	updated := *existing
+	updated.Code = location.Code
+	updated.Name = location.Name
+	updated.Capacity = location.Capacity
+	updated.UpdatedAt = time.Now().UTC()
+	if err := checkCodeUnique(locations, &updated); err != nil {
+		return nil, err
+	}
+
+	var descendants []*model.Location
+	if updated.Code != existing.Code {
+		prefix := ""
+		if updated.ParentID != uuid.Nil {
+			prefix = existing.Path[:len(existing.Path)-len(existing.Code)]
+		}
+		updated.Path = prefix + updated.Code
+		descendants = descendantsOf(locations, existing.ID)
+		for _, descendant := range descendants {
+			descendant.Path = updated.Path + descendant.Path[len(existing.Path):]
+			descendant.UpdatedAt = updated.UpdatedAt
+		}
+	}
+	err = s.repo.UpdateLocation(ctx, &updated)
+	if err != nil {
+		log.Printf("Error updating location: %v", err)
+		return nil, status.Errorf(codes.Internal, "error updating location: %v", err)
+	}
+	for _, descendant := range descendants {
+		if err := s.repo.UpdateLocation(ctx, descendant); err != nil {
+			log.Printf("Error updating path of location %s: %v", descendant.ID, err)
+			return nil, status.Errorf(codes.Internal, "error updating path of location %s: %v", descendant.ID, err)
+		}
+	}
+	s.auditService.Record(ctx, model.EntityLocation, updated.ID, model.AuditUpdate, &before, &updated)
+	log.Println("Location updated successfully:", updated.ID)
+	return &updated, nil
+}
+
+// DeleteLocation removes a location that has nothing below it and, for a
+// bin, holds no stock.
+func (s *LocationService) DeleteLocation(ctx context.Context, id uuid.UUID) error {
+	location, err := s.getLocation(ctx, id)
+	if err != nil {
+		return err
+	}
+	locations, err := s.listLocations(ctx, location.WarehouseID)
+	if err != nil {
+		return err
+	}
+	for _, other := range locations {
+		if other.ParentID == location.ID {
+			return status.Errorf(codes.FailedPrecondition, "location %s still has locations below it", location.Path)
+		}
+	}
+	if location.Type == model.LocationBin {
+		bins, err := s.binStockRepo.ListBinStockByWarehouse(ctx, location.WarehouseID.String())
+		if err != nil {
+			log.Printf("Error listing bin stock: %v", err)
+			return status.Errorf(codes.Internal, "error listing bin stock: %v", err)
+		}
+		for _, bin := range bins {
+			if bin.LocationID == location.ID && bin.Quantity > 0 {
+				return status.Errorf(codes.FailedPrecondition, "bin %s still holds stock", location.Path)
+			}
+		}
+	}
+	err = s.repo.DeleteLocation(ctx, id.String())
+	if err != nil {
+		log.Printf("Error deleting location: %v", err)
+		return status.Errorf(codes.Internal, "error deleting location: %v", err)
+	}
+	s.auditService.Record(ctx, model.EntityLocation, location.ID, model.AuditDelete, location, nil)
+	log.Println("Location deleted successfully:", location.ID)
+	return nil
+}
+
+// ListBinStock returns the bins holding stock of an inventory item ordered by
+// bin path, and the quantity of the item not placed in any bin.
+func (s *LocationService) ListBinStock(ctx context.Context, inventoryItemID uuid.UUID) ([]*model.BinStock, model.Quantity, error) {
+	item, err := s.itemRepo.GetInventoryItem(ctx, inventoryItemID.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrInventoryItemNotFound) {
+			return nil, 0, status.Error(codes.NotFound, "inventory item not found")
+		}
+		log.Printf("Error retrieving inventory item: %v", err)
+		return nil, 0, status.Errorf(codes.Internal, "error retrieving inventory item: %v", err)
+	}
+	bins, err := s.stockMovementService.binStock(ctx, item)
+	if err != nil {
+		return nil, 0, err
+	}
+	locations, err := s.listLocations(ctx, item.WarehouseID)
+	if err != nil {
+		return nil, 0, err
+	}
+	paths := make(map[uuid.UUID]string, len(locations))
+	for _, location := range locations {
+		paths[location.ID] = location.Path
+	}
+	var held []*model.BinStock
+	for _, bin := range bins {
+		if bin.Quantity > 0 {
+			held = append(held, bin)
+		}
+	}
+	sort.Slice(held, func(i, j int) bool {
+		return paths[held[i].LocationID] < paths[held[j].LocationID]
+	})
+	return held, item.Quantity - placedQuantity(bins), nil
+}
+
+// MoveBinStock moves units of an inventory item from one bin to another
+// within its warehouse with a relocation movement. A nil source bin puts
+// unplaced stock away; a nil destination bin takes stock back out of bins.
+func (s *LocationService) MoveBinStock(ctx context.Context, inventoryItemID, sourceBinID, destinationBinID uuid.UUID, quantity model.Quantity) (*model.StockMovement, error) {
+	item, err := s.itemRepo.GetInventoryItem(ctx, inventoryItemID.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrInventoryItemNotFound) {
+			return nil, status.Error(codes.NotFound, "inventory item not found")
+		}
+		log.Printf("Error retrieving inventory item: %v", err)
+		return nil, status.Errorf(codes.Internal, "error retrieving inventory item: %v", err)
+	}
+	return s.stockMovementService.PostStockMovement(ctx, &model.StockMovement{
+		InventoryItemID:        item.ID,
+		Type:                   model.Relocation,
+		Quantity:               quantity,
+		SourceWarehouseID:      item.WarehouseID,
+		DestinationWarehouseID: item.WarehouseID,
+		SourceBinID:            sourceBinID,
+		DestinationBinID:       destinationBinID,
+	})
+}
+
+// SuggestPutaway proposes bins of a warehouse to put incoming stock of a
+// product in. Bins already holding the product come first, fullest first, so
+// that stock is kept together; empty bins follow in path order. Bins holding
+// other products, or without room for quantity more units, are not proposed.
+func (s *LocationService) SuggestPutaway(ctx context.Context, productID, warehouseID uuid.UUID, quantity model.Quantity, limit int) ([]*model.PutawaySuggestion, error) {
+	if quantity < 0 {
+		return nil, status.Error(codes.InvalidArgument, "quantity cannot be negative")
+	}
+	if limit <= 0 {
+		limit = defaultPutawaySuggestions
+	}
+	item, err := s.itemRepo.FindInventoryItem(ctx, productID.String(), warehouseID.String())
+	if err != nil && !errors.Is(err, repository.ErrInventoryItemNotFound) {
+		log.Printf("Error retrieving inventory item: %v", err)
+		return nil, status.Errorf(codes.Internal, "error retrieving inventory item: %v", err)
+	}
+	locations, err := s.listLocations(ctx, warehouseID)
+	if err != nil {
+		return nil, err
+	}
+	bins, err := s.binStockRepo.ListBinStockByWarehouse(ctx, warehouseID.String())
+	if err != nil {
+		log.Printf("Error listing bin stock: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing bin stock: %v", err)
+	}
+	products, err := s.capacityService.products(ctx)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.capacityService.warehouseItems(ctx, warehouseID)
+	if err != nil {
+		return nil, err
+	}
+	loads, err := s.capacityService.binLoads(ctx, warehouseID, items, products)
+	if err != nil {
+		return nil, err
+	}
+	held := make(map[uuid.UUID]model.Quantity)
+	occupied := make(map[uuid.UUID]bool)
+	for _, bin := range bins {
+		if bin.Quantity <= 0 {
+			continue
+		}
+		if item != nil && bin.InventoryItemID == item.ID {
+			held[bin.LocationID] = bin.Quantity
+		} else {
+			occupied[bin.LocationID] = true
+		}
+	}
+
+	var suggestions []*model.PutawaySuggestion
+	for _, location := range locations {
+		if location.Type != model.LocationBin || occupied[location.ID] {
+			continue
+		}
+		load := loads[location.ID]
+		load.Add(products[productID], quantity)
+		if location.Capacity.Exceeded(load) != "" {
+			continue
+		}
+		suggestion := &model.PutawaySuggestion{Location: location, Reason: "empty bin"}
+		if units, ok := held[location.ID]; ok {
+			suggestion.Quantity = units
+			suggestion.Reason = "bin already holds the product"
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Quantity != suggestions[j].Quantity {
+			return suggestions[i].Quantity > suggestions[j].Quantity
+		}
+		return suggestions[i].Location.Path < suggestions[j].Location.Path
+	})
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions, nil
+}
+
+func (s *LocationService) getLocation(ctx context.Context, id uuid.UUID) (*model.Location, error) {
+	location, err := s.repo.GetLocation(ctx, id.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrLocationNotFound) {
+			return nil, status.Errorf(codes.NotFound, "location %s not found", id)
+		}
+		log.Printf("Error retrieving location: %v", err)
+		return nil, status.Errorf(codes.Internal, "error retrieving location: %v", err)
+	}
+	return location, nil
+}
+
+func (s *LocationService) listLocations(ctx context.Context, warehouseID uuid.UUID) ([]*model.Location, error) {
+	locations, err := s.repo.ListLocationsByWarehouse(ctx, warehouseID.String())
+	if err != nil {
+		log.Printf("Error listing locations: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing locations: %v", err)
+	}
+	return locations, nil
+}
+
+// validateLocationCapacity checks that only bins, which hold stock, are given
+// a capacity.
+func validateLocationCapacity(location *model.Location) error {
+	if location.Type != model.LocationBin && location.Capacity != (model.Capacity{}) {
+		return status.Errorf(codes.InvalidArgument, "a %s cannot have a capacity", location.Type)
+	}
+	return validateCapacity(location.Capacity)
+}
+
+// checkCodeUnique checks that no other location under the same parent uses
+// the code of location.
+func checkCodeUnique(locations []*model.Location, location *model.Location) error {
+	for _, other := range locations {
+		if other.ID != location.ID && other.ParentID == location.ParentID && other.Code == location.Code {
+			return status.Errorf(codes.AlreadyExists, "location %s already exists", other.Path)
+		}
+	}
+	return nil
+}
+
+// descendantsOf returns every location below the location with the given ID.
+func descendantsOf(locations []*model.Location, id uuid.UUID) []*model.Location {
+	var descendants []*model.Location
+	parents := map[uuid.UUID]bool{id: true}
+	for added := true; added; {
+		added = false
+		for _, location := range locations {
+			if parents[location.ParentID] && !parents[location.ID] {
+				parents[location.ID] = true
+				descendants = append(descendants, location)
+				added = true
+			}
+		}
+	}
+	return descendants
+}
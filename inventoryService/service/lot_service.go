@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventoryService/model"
+	"inventoryService/repository"
+	"log"
+	"sort"
+	"time"
+)
+
+// LotService tracks the batches an inventory item's stock arrives in. Stock
+// enters a lot through movements that name it and leaves the lots expiring
+// first, as StockMovementService decides.
+type LotService struct {
+	repo                 *repository.LotRepository
+	itemRepo             *repository.InventoryItemRepository
+	supplierRepo         *repository.SupplierRepository
+	stockMovementService *StockMovementService
+	auditService         *AuditService
+}
+
+func NewLotService(
+	repo *repository.LotRepository,
+	itemRepo *repository.InventoryItemRepository,
+	supplierRepo *repository.SupplierRepository,
+	stockMovementService *StockMovementService,
+	auditService *AuditService,
+) *LotService {
+	return &LotService{
+		repo:                 repo,
+		itemRepo:             itemRepo,
+		supplierRepo:         supplierRepo,
+		stockMovementService: stockMovementService,
+		auditService:         auditService,
+	}
+}
+
+// CreateLot registers a lot of an inventory item. A positive quantity is
+// received into the lot with an addition movement; a lot can also start
+// empty and be filled by later movements.
+func (s *LotService) CreateLot(ctx context.Context, lot *model.Lot) (*model.Lot, error) {
+	if lot.LotNumber == "" {
+		return nil, status.Error(codes.InvalidArgument, "lot number is required")
+	}
+	if lot.Quantity < 0 {
+		return nil, status.Error(codes.InvalidArgument, "quantity cannot be negative")
+	}
+	if !lot.ManufactureDate.IsZero() && !lot.ExpiryDate.IsZero() && lot.ExpiryDate.Before(lot.ManufactureDate) {
+		return nil, status.Error(codes.InvalidArgument, "expiry date cannot be before manufacture date")
+	}
+	item, err := s.itemRepo.GetInventoryItem(ctx, lot.InventoryItemID.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrInventoryItemNotFound) {
+			return nil, status.Error(codes.NotFound, "inventory item not found")
+		}
+		log.Printf("Error retrieving inventory item: %v", err)
+		return nil, status.Errorf(codes.Internal, "error retrieving inventory item: %v", err)
+	}
+	if lot.SupplierID != uuid.Nil {
+		exists, err := s.supplierRepo.ExistsByUUID(ctx, lot.SupplierID)
+		if err != nil {
+			log.Printf("Error checking supplier existence: %v", err)
+			return nil, status.Errorf(codes.Internal, "error checking supplier existence: %v", err)
+		}
+		if !exists {
+			return nil, status.Error(codes.NotFound, "supplier not found")
+		}
+	}
+	lots, err := s.repo.ListLotsByInventoryItem(ctx, item.ID.String())
+	if err != nil {
+		log.Printf("Error listing lots: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing lots: %v", err)
+	}
+	for _, existing := range lots {
+		if existing.LotNumber == lot.LotNumber {
+			return nil, status.Errorf(codes.AlreadyExists, "lot %s already exists for inventory item %s", lot.LotNumber, item.ID)
+		}
+	}
+
+	quantity := lot.Quantity
+	lot.ID = uuid.New()
+	lot.ProductID = item.ProductID
+	lot.WarehouseID = item.WarehouseID
+	lot.Quantity = 0
+	lot.CreatedAt = time.Now().UTC()
+	err = s.repo.CreateLot(ctx, lot)
+	if err != nil {
+		log.Printf("Error creating lot: %v", err)
+		return nil, status.Errorf(codes.Internal, "error creating lot: %v", err)
+	}
+	if quantity > 0 {
+		_, err = s.stockMovementService.PostStockMovement(ctx, &model.StockMovement{
+			InventoryItemID:        item.ID,
+			Type:                   model.Addition,
+			Quantity:               quantity,
+			DestinationWarehouseID: item.WarehouseID,
+			LotID:                  lot.ID,
+		})
+		if err != nil {
+			if deleteErr := s.repo.DeleteLot(ctx, lot.ID.String()); deleteErr != nil {
+				log.Printf("Error deleting lot %s: %v", lot.ID, deleteErr)
+			}
+			return nil, err
+		}
+		lot.Quantity = quantity
+	}
+	s.auditService.Record(ctx, model.EntityLot, lot.ID, model.AuditCreate, nil, lot)
+	log.Println("Lot created successfully:", lot.ID)
+	return lot, nil
+}
+
+func (s *LotService) GetLot(ctx context.Context, id uuid.UUID) (*model.Lot, error) {
+	lot, err := s.repo.GetLot(ctx, id.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrLotNotFound) {
+			return nil, status.Error(codes.NotFound, "lot not found")
+		}
+		log.Printf("Error retrieving lot: %v", err)
+		return nil, status.Errorf(codes.Internal, "error retrieving lot: %v", err)
+	}
+	return lot, nil
+}
+
+// ListLots returns the lots of an inventory item in the order stock leaves
+// them, or every lot when no item is given.
+func (s *LotService) ListLots(ctx context.Context, inventoryItemID uuid.UUID) ([]*model.Lot, error) {
+	var lots []*model.Lot
+	var err error
+	if inventoryItemID != uuid.Nil {
+		lots, err = s.repo.ListLotsByInventoryItem(ctx, inventoryItemID.String())
+	} else {
+		lots, err = s.repo.ListLots(ctx)
+	}
+	if err != nil {
+		log.Printf("Error listing lots: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing lots: %v", err)
+	}
+	sort.SliceStable(lots, func(i, j int) bool {
+		return lots[i].ExpiresBefore(lots[j])
+	})
+	return lots, nil
+}
+
+// ListExpiringLots returns the lots still holding stock that expire within
+// the given number of days, including those already expired, soonest first.
+func (s *LotService) ListExpiringLots(ctx context.Context, withinDays int, warehouseID, productID uuid.UUID) ([]*model.Lot, error) {
+	if withinDays < 0 {
+		return nil, status.Error(codes.InvalidArgument, "within days cannot be negative")
+	}
+	lots, err := s.repo.ListLots(ctx)
+	if err != nil {
+		log.Printf("Error listing lots: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing lots: %v", err)
+	}
+	cutoff := time.Now().AddDate(0, 0, withinDays)
+	var expiring []*model.Lot
+	for _, lot := range lots {
+		if lot.Quantity <= 0 || !lot.Expired(cutoff) {
+			continue
+		}
+		if warehouseID != uuid.Nil && lot.WarehouseID != warehouseID {
+			continue
+		}
+		if productID != uuid.Nil && lot.ProductID != productID {
+			continue
+		}
+		expiring = append(expiring, lot)
+	}
+	sort.SliceStable(expiring, func(i, j int) bool {
+		return expiring[i].ExpiresBefore(expiring[j])
+	})
+	return expiring, nil
+}
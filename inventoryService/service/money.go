@@ -0,0 +1,31 @@
+package service
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventoryService/model"
+	"regexp"
+)
+
+var currencyCodePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// validateMoney checks that an amount is well formed and not negative. Any
+// amount other than zero needs an ISO 4217 currency code.
+func validateMoney(field string, m model.Money) error {
+	if !m.Valid() {
+		return status.Errorf(codes.InvalidArgument, "%s: nanos must be below one unit and of the sign of units", field)
+	}
+	if m.Sign() < 0 {
+		return status.Errorf(codes.InvalidArgument, "%s cannot be negative", field)
+	}
+	if m.CurrencyCode == "" {
+		if !m.IsZero() {
+			return status.Errorf(codes.InvalidArgument, "%s needs a currency code", field)
+		}
+		return nil
+	}
+	if !currencyCodePattern.MatchString(m.CurrencyCode) {
+		return status.Errorf(codes.InvalidArgument, "%s: invalid currency code %q", field, m.CurrencyCode)
+	}
+	return nil
+}
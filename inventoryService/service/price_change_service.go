@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventoryService/model"
+	"inventoryService/repository"
+	"log"
+	"sort"
+	"time"
+)
+
+// PriceChangeService keeps the history of product prices and applies the
+// price changes scheduled for later.
+type PriceChangeService struct {
+	repo         *repository.PriceChangeRepository
+	productRepo  *repository.ProductRepository
+	auditService *AuditService
+}
+
+func NewPriceChangeService(repo *repository.PriceChangeRepository, productRepo *repository.ProductRepository, auditService *AuditService) *PriceChangeService {
+	return &PriceChangeService{repo: repo, productRepo: productRepo, auditService: auditService}
+}
+
+// Start applies the scheduled price changes that have become due once per
+// interval until ctx is done.
+func (s *PriceChangeService) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.ApplyDuePriceChanges(ctx); err != nil {
+					log.Printf("Error applying price changes: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// RecordPriceChange adds a price change that has just been made to the
+// history of a product. Failures are logged rather than returned, as the new
+// price has already been saved.
+func (s *PriceChangeService) RecordPriceChange(ctx context.Context, productID uuid.UUID, oldPrice, newPrice model.Money) {
+	now := time.Now().UTC()
+	change := &model.PriceChange{
+		ID:          uuid.New(),
+		ProductID:   productID,
+		OldPrice:    oldPrice,
+		NewPrice:    newPrice,
+		EffectiveAt: now,
+		Actor:       actorFromContext(ctx),
+		Status:      model.PriceChangeApplied,
+		CreatedAt:   now,
+		AppliedAt:   now,
+	}
+	if err := s.repo.CreatePriceChange(ctx, change); err != nil {
+		log.Printf("Error recording price change of product %s: %v", productID, err)
+	}
+}
+
+// SchedulePriceChange sets a new price for a product to take effect at a
+// later time.
+func (s *PriceChangeService) SchedulePriceChange(ctx context.Context, productID uuid.UUID, price model.Money, effectiveAt time.Time) (*model.PriceChange, error) {
+	if err := validateMoney("price", price); err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	if !effectiveAt.After(now) {
+		return nil, status.Error(codes.InvalidArgument, "effective at must be in the future; update the product to change its price now")
+	}
+	exists, err := s.productRepo.ProductExists(ctx, productID.String())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error checking product existence: %v", err)
+	}
+	if !exists {
+		return nil, status.Error(codes.NotFound, "product not found")
+	}
+	change := &model.PriceChange{
+		ID:          uuid.New(),
+		ProductID:   productID,
+		NewPrice:    price,
+		EffectiveAt: effectiveAt.UTC(),
+		Actor:       actorFromContext(ctx),
+		Status:      model.PriceChangeScheduled,
+		CreatedAt:   now,
+	}
+	err = s.repo.CreatePriceChange(ctx, change)
+	if err != nil {
+		log.Printf("Error scheduling price change: %v", err)
+		return nil, status.Errorf(codes.Internal, "error scheduling price change: %v", err)
+	}
+	s.auditService.Record(ctx, model.EntityPriceChange, change.ID, model.AuditCreate, nil, change)
+	log.Println("Price change scheduled successfully:", change.ID)
+	return change, nil
+}
+
+// CancelPriceChange withdraws a scheduled price change that has not been
+// applied yet.
+func (s *PriceChangeService) CancelPriceChange(ctx context.Context, id uuid.UUID) (*model.PriceChange, error) {
+	change, err := s.repo.GetPriceChange(ctx, id.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrPriceChangeNotFound) {
+			return nil, status.Error(codes.NotFound, "price change not found")
+		}
+		log.Printf("Error retrieving price change: %v", err)
+		return nil, status.Errorf(codes.Internal, "error retrieving price change: %v", err)
+	}
+	if change.Status != model.PriceChangeScheduled {
+		return nil, status.Errorf(codes.FailedPrecondition, "price change is %s, not scheduled", change.Status)
+	}
+	before := *change
+	change.Status = model.PriceChangeCancelled
+	applied, err := s.repo.UpdatePriceChangeStatus(ctx, change, model.PriceChangeScheduled)
+	if err != nil {
+		log.Printf("Error cancelling price change: %v", err)
+		return nil, status.Errorf(codes.Internal, "error cancelling price change: %v", err)
+	}
+	if !applied {
+		return nil, status.Error(codes.FailedPrecondition, "price change was applied or cancelled concurrently")
+	}
+	s.auditService.Record(ctx, model.EntityPriceChange, change.ID, model.AuditUpdate, &before, change)
+	log.Println("Price change cancelled successfully:", change.ID)
+	return change, nil
+}
+
+// GetPriceHistory returns the price changes of a product, scheduled ones
+// included, latest effective first.
+func (s *PriceChangeService) GetPriceHistory(ctx context.Context, productID uuid.UUID) ([]*model.PriceChange, error) {
+	changes, err := s.repo.ListPriceChangesByProduct(ctx, productID.String())
+	if err != nil {
+		log.Printf("Error listing price changes: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing price changes: %v", err)
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].EffectiveAt.After(changes[j].EffectiveAt)
+	})
+	log.Println("Price history listed successfully:", productID)
+	return changes, nil
+}
+
+// ApplyDuePriceChanges writes the price of every scheduled change that has
+// become due to its product, earliest first so that the latest due change of
+// a product wins. A change whose product no longer exists is cancelled.
+func (s *PriceChangeService) ApplyDuePriceChanges(ctx context.Context) error {
+	changes, err := s.repo.ListPriceChangesByStatus(ctx, model.PriceChangeScheduled)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	var due []*model.PriceChange
+	for _, change := range changes {
+		if !change.EffectiveAt.After(now) {
+			due = append(due, change)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].EffectiveAt.Before(due[j].EffectiveAt)
+	})
+	for _, change := range due {
+		if err := s.applyPriceChange(ctx, change); err != nil {
+			log.Printf("Error applying price change %s: %v", change.ID, err)
+		}
+	}
+	return nil
+}
+
+func (s *PriceChangeService) applyPriceChange(ctx context.Context, change *model.PriceChange) error {
+	product, err := s.productRepo.GetProduct(ctx, change.ProductID.String())
+	if err != nil && !errors.Is(err, repository.ErrProductNotFound) {
+		return err
+	}
+	before := *change
+	if product == nil {
+		change.Status = model.PriceChangeCancelled
+		if _, err := s.repo.UpdatePriceChangeStatus(ctx, change, model.PriceChangeScheduled); err != nil {
+			return err
+		}
+		s.auditService.Record(ctx, model.EntityPriceChange, change.ID, model.AuditUpdate, &before, change)
+		log.Println("Price change cancelled, product not found:", change.ID)
+		return nil
+	}
+
+	change.Status = model.PriceChangeApplied
+	change.OldPrice = product.Price
+	change.AppliedAt = time.Now().UTC()
+	applied, err := s.repo.UpdatePriceChangeStatus(ctx, change, model.PriceChangeScheduled)
+	if err != nil {
+		return err
+	}
+	if !applied {
+		// Cancelled or applied by another instance in the meantime.
+		return nil
+	}
+	updated := *product
+	updated.Price = change.NewPrice
+	if err := s.productRepo.UpdateProductPrice(ctx, product.ID.String(), change.NewPrice); err != nil {
+		// Put the change back so that the next run retries it.
+		if _, revertErr := s.repo.UpdatePriceChangeStatus(ctx, &before, model.PriceChangeApplied); revertErr != nil {
+			log.Printf("Error rescheduling price change %s: %v", change.ID, revertErr)
+		}
+		return err
+	}
+	s.auditService.Record(ctx, model.EntityProduct, product.ID, model.AuditUpdate, product, &updated)
+	s.auditService.Record(ctx, model.EntityPriceChange, change.ID, model.AuditUpdate, &before, change)
+	log.Println("Price change applied successfully:", change.ID)
+	return nil
+}
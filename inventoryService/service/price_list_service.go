@@ -0,0 +1,268 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventoryService/model"
+	"inventoryService/repository"
+	"log"
+	"sort"
+	"strings"
+	"time"
+)
+
+type PriceListService struct {
+	repo             *repository.PriceListRepository
+	exchangeRateRepo *repository.ExchangeRateRepository
+	productRepo      *repository.ProductRepository
+	auditService     *AuditService
+}
+
+func NewPriceListService(repo *repository.PriceListRepository, exchangeRateRepo *repository.ExchangeRateRepository, productRepo *repository.ProductRepository, auditService *AuditService) *PriceListService {
+	return &PriceListService{repo: repo, exchangeRateRepo: exchangeRateRepo, productRepo: productRepo, auditService: auditService}
+}
+
+func (s *PriceListService) CreatePriceList(ctx context.Context, list *model.PriceList) (*model.PriceList, error) {
+	if err := s.validatePriceList(ctx, list); err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	list.ID = uuid.New()
+	list.CreatedAt = now
+	list.UpdatedAt = now
+	err := s.repo.CreatePriceList(ctx, list)
+	if err != nil {
+		log.Printf("Error creating price list: %v", err)
+		return nil, status.Errorf(codes.Internal, "error creating price list: %v", err)
+	}
+	s.auditService.Record(ctx, model.EntityPriceList, list.ID, model.AuditCreate, nil, list)
+	log.Println("Price list created successfully:", list.ID)
+	return list, nil
+}
+
+func (s *PriceListService) GetPriceList(ctx context.Context, id uuid.UUID) (*model.PriceList, error) {
+	list, err := s.repo.GetPriceList(ctx, id.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrPriceListNotFound) {
+			return nil, status.Error(codes.NotFound, "price list not found")
+		}
+		log.Printf("Error retrieving price list: %v", err)
+		return nil, status.Errorf(codes.Internal, "error retrieving price list: %v", err)
+	}
+	return list, nil
+}
+
+// ListPriceLists returns every price list ordered by currency, customer group
+// and start date.
+func (s *PriceListService) ListPriceLists(ctx context.Context) ([]*model.PriceList, error) {
+	lists, err := s.repo.ListPriceLists(ctx)
+	if err != nil {
+		log.Printf("Error listing price lists: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing price lists: %v", err)
+	}
+	sort.Slice(lists, func(i, j int) bool {
+		if lists[i].CurrencyCode != lists[j].CurrencyCode {
+			return lists[i].CurrencyCode < lists[j].CurrencyCode
+		}
+		if lists[i].CustomerGroup != lists[j].CustomerGroup {
+			return lists[i].CustomerGroup < lists[j].CustomerGroup
+		}
+		return lists[i].EffectiveFrom.Before(lists[j].EffectiveFrom)
+	})
+	log.Println("Price lists listed successfully")
+	return lists, nil
+}
+
+// UpdatePriceList replaces the header and prices of a list.
+func (s *PriceListService) UpdatePriceList(ctx context.Context, list *model.PriceList) (*model.PriceList, error) {
+	before, err := s.GetPriceList(ctx, list.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validatePriceList(ctx, list); err != nil {
+		return nil, err
+	}
+	list.CreatedAt = before.CreatedAt
+	list.UpdatedAt = time.Now().UTC()
+	err = s.repo.UpdatePriceList(ctx, list)
+	if err != nil {
+		log.Printf("Error updating price list: %v", err)
+		return nil, status.Errorf(codes.Internal, "error updating price list: %v", err)
+	}
+	s.auditService.Record(ctx, model.EntityPriceList, list.ID, model.AuditUpdate, before, list)
+	log.Println("Price list updated successfully:", list.ID)
+	return list, nil
+}
+
+func (s *PriceListService) DeletePriceList(ctx context.Context, id uuid.UUID) error {
+	before, err := s.GetPriceList(ctx, id)
+	if err != nil {
+		return err
+	}
+	err = s.repo.DeletePriceList(ctx, id.String())
+	if err != nil {
+		log.Printf("Error deleting price list: %v", err)
+		return status.Errorf(codes.Internal, "error deleting price list: %v", err)
+	}
+	s.auditService.Record(ctx, model.EntityPriceList, id, model.AuditDelete, before, nil)
+	log.Println("Price list deleted successfully:", id)
+	return nil
+}
+
+// GetEffectivePrice resolves the price of a product in a currency at a time
+// for a customer group. Among the lists in effect that price the product and
+// apply to the group, a list in the currency asked for wins over one that
+// needs converting, a list for the group over one for every customer, and a
+// later list over an earlier one. Without such a list the product's own price
+// is used. Prices in another currency are converted at the exchange rate in
+// effect at the time. An empty currency asks for the currency of the
+// product's own price and a zero time for now.
+func (s *PriceListService) GetEffectivePrice(ctx context.Context, productID uuid.UUID, currencyCode string, at time.Time, customerGroup string) (*model.EffectivePrice, error) {
+	product, err := s.productRepo.GetProduct(ctx, productID.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrProductNotFound) {
+			return nil, status.Error(codes.NotFound, "product not found")
+		}
+		log.Printf("Error retrieving product: %v", err)
+		return nil, status.Errorf(codes.Internal, "error retrieving product: %v", err)
+	}
+	currencyCode = strings.ToUpper(strings.TrimSpace(currencyCode))
+	if currencyCode == "" {
+		currencyCode = product.Price.CurrencyCode
+	}
+	if currencyCode != "" && !currencyCodePattern.MatchString(currencyCode) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid currency code %q", currencyCode)
+	}
+	if at.IsZero() {
+		at = time.Now().UTC()
+	}
+	customerGroup = strings.TrimSpace(customerGroup)
+
+	lists, err := s.repo.ListPriceLists(ctx)
+	if err != nil {
+		log.Printf("Error listing price lists: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing price lists: %v", err)
+	}
+	var candidates []*model.PriceList
+	for _, list := range lists {
+		if !list.EffectiveAt(at) || (list.CustomerGroup != "" && list.CustomerGroup != customerGroup) {
+			continue
+		}
+		if _, ok := list.Price(productID); ok {
+			candidates = append(candidates, list)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if (candidates[i].CurrencyCode == currencyCode) != (candidates[j].CurrencyCode == currencyCode) {
+			return candidates[i].CurrencyCode == currencyCode
+		}
+		if (candidates[i].CustomerGroup == "") != (candidates[j].CustomerGroup == "") {
+			return candidates[i].CustomerGroup != ""
+		}
+		return candidates[i].EffectiveFrom.After(candidates[j].EffectiveFrom)
+	})
+
+	for _, list := range candidates {
+		price, _ := list.Price(productID)
+		effective, err := s.convertPrice(ctx, price, currencyCode, at)
+		if err != nil {
+			if errors.Is(err, repository.ErrExchangeRateNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		effective.ProductID = productID
+		effective.PriceListID = list.ID
+		return effective, nil
+	}
+
+	effective, err := s.convertPrice(ctx, product.Price, currencyCode, at)
+	if err != nil {
+		if errors.Is(err, repository.ErrExchangeRateNotFound) {
+			return nil, status.Errorf(codes.FailedPrecondition, "no price for product %s in %s: no exchange rate in effect on %s", productID, currencyCode, at.Format(time.DateOnly))
+		}
+		return nil, err
+	}
+	effective.ProductID = productID
+	return effective, nil
+}
+
+// convertPrice converts a price to a currency at the rate in effect at a
+// time, using the inverse of the opposite rate when the pair has none. It
+// returns repository.ErrExchangeRateNotFound when neither rate exists.
+func (s *PriceListService) convertPrice(ctx context.Context, price model.Money, currencyCode string, at time.Time) (*model.EffectivePrice, error) {
+	if price.CurrencyCode == currencyCode || (price.CurrencyCode == "" && price.IsZero()) {
+		return &model.EffectivePrice{Price: model.Money{CurrencyCode: currencyCode, Units: price.Units, Nanos: price.Nanos}}, nil
+	}
+	rate, err := s.exchangeRateRepo.GetExchangeRate(ctx, price.CurrencyCode, currencyCode, at)
+	if errors.Is(err, repository.ErrExchangeRateNotFound) {
+		var opposite *model.ExchangeRate
+		opposite, err = s.exchangeRateRepo.GetExchangeRate(ctx, currencyCode, price.CurrencyCode, at)
+		if err == nil {
+			rate, err = opposite.Inverse()
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "error inverting exchange rate: %v", err)
+			}
+		}
+	}
+	if err != nil {
+		if errors.Is(err, repository.ErrExchangeRateNotFound) {
+			return nil, err
+		}
+		log.Printf("Error retrieving exchange rate: %v", err)
+		return nil, status.Errorf(codes.Internal, "error retrieving exchange rate: %v", err)
+	}
+	converted, err := rate.Convert(price)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error converting price: %v", err)
+	}
+	return &model.EffectivePrice{Price: converted, SourcePrice: price, ExchangeRate: rate}, nil
+}
+
+// validatePriceList checks a list and normalizes its currency. Every price
+// must be in the currency of the list, for a product that exists, and appear
+// once.
+func (s *PriceListService) validatePriceList(ctx context.Context, list *model.PriceList) error {
+	list.Name = strings.TrimSpace(list.Name)
+	if list.Name == "" {
+		return status.Error(codes.InvalidArgument, "name is required")
+	}
+	list.CurrencyCode = strings.ToUpper(strings.TrimSpace(list.CurrencyCode))
+	if !currencyCodePattern.MatchString(list.CurrencyCode) {
+		return status.Errorf(codes.InvalidArgument, "invalid currency code %q", list.CurrencyCode)
+	}
+	list.CustomerGroup = strings.TrimSpace(list.CustomerGroup)
+	if list.EffectiveFrom.IsZero() {
+		return status.Error(codes.InvalidArgument, "effective from is required")
+	}
+	if !list.EffectiveTo.IsZero() && !list.EffectiveTo.After(list.EffectiveFrom) {
+		return status.Error(codes.InvalidArgument, "effective to must be after effective from")
+	}
+	seen := make(map[uuid.UUID]bool)
+	for _, price := range list.Prices {
+		if seen[price.ProductID] {
+			return status.Errorf(codes.InvalidArgument, "product %s is priced more than once", price.ProductID)
+		}
+		seen[price.ProductID] = true
+		if price.Price.CurrencyCode == "" {
+			price.Price.CurrencyCode = list.CurrencyCode
+		}
+		if err := validateMoney("price", price.Price); err != nil {
+			return err
+		}
+		if price.Price.CurrencyCode != list.CurrencyCode {
+			return status.Errorf(codes.InvalidArgument, "price of product %s is in %s, not in the list's currency %s", price.ProductID, price.Price.CurrencyCode, list.CurrencyCode)
+		}
+		exists, err := s.productRepo.ProductExists(ctx, price.ProductID.String())
+		if err != nil {
+			return status.Errorf(codes.Internal, "error checking product existence: %v", err)
+		}
+		if !exists {
+			return status.Errorf(codes.NotFound, "product %s not found", price.ProductID)
+		}
+	}
+	return nil
+}
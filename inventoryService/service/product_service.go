@@ -9,18 +9,38 @@ import (
 	"inventoryService/model"
 	"inventoryService/repository"
 	"log"
+	"strings"
 )
 
 type ProductService struct {
 	productRepo  *repository.ProductRepository
 	categoryRepo *repository.CategoryRepository
+	itemRepo     *repository.InventoryItemRepository
+	auditService *AuditService
+	// priceChangeService records every change of a product's price.
+	priceChangeService *PriceChangeService
 }
 
-func NewProductService(productRepo *repository.ProductRepository, categoryRepo *repository.CategoryRepository) *ProductService {
-	return &ProductService{productRepo: productRepo, categoryRepo: categoryRepo}
+func NewProductService(productRepo *repository.ProductRepository, categoryRepo *repository.CategoryRepository, itemRepo *repository.InventoryItemRepository, auditService *AuditService, priceChangeService *PriceChangeService) *ProductService {
+	return &ProductService{productRepo: productRepo, categoryRepo: categoryRepo, itemRepo: itemRepo, auditService: auditService, priceChangeService: priceChangeService}
 }
 
 func (s *ProductService) CreateProduct(ctx context.Context, product *model.Product) (*model.Product, error) {
+	if err := validateDimensions(product); err != nil {
+		return nil, err
+	}
+	if err := validateMoney("price", product.Price); err != nil {
+		return nil, err
+	}
+	if err := validateCostingMethod(product); err != nil {
+		return nil, err
+	}
+	if err := validateUnits(product); err != nil {
+		return nil, err
+	}
+	if err := validateQuantityPrecision(product); err != nil {
+		return nil, err
+	}
 	product.ID = uuid.New()
 	exists, err := s.productRepo.ExistsBySKUOrName(ctx, product.SKU, product.Name)
 	if err != nil {
@@ -43,6 +63,8 @@ func (s *ProductService) CreateProduct(ctx context.Context, product *model.Produ
 		log.Printf("Error creating product: %v", err)
 		return nil, status.Errorf(codes.Internal, "error creating product: %v", err)
 	}
+	s.auditService.Record(ctx, model.EntityProduct, product.ID, model.AuditCreate, nil, product)
+	s.priceChangeService.RecordPriceChange(ctx, product.ID, model.Money{}, product.Price)
 	log.Println("Product created successfully:", product.ID)
 	return product, nil
 }
@@ -70,6 +92,21 @@ func (s *ProductService) ListProducts(ctx context.Context) ([]*model.Product, er
 }
 
 func (s *ProductService) UpdateProduct(ctx context.Context, product *model.Product) error {
+	if err := validateDimensions(product); err != nil {
+		return err
+	}
+	if err := validateMoney("price", product.Price); err != nil {
+		return err
+	}
+	if err := validateCostingMethod(product); err != nil {
+		return err
+	}
+	if err := validateUnits(product); err != nil {
+		return err
+	}
+	if err := validateQuantityPrecision(product); err != nil {
+		return err
+	}
 	exists, err := s.productRepo.ExistsBySKUOrNameExcludingID(ctx, product.SKU, product.Name, product.ID)
 	if err != nil {
 		log.Printf("Error checking for existing product: %v", err)
@@ -78,6 +115,38 @@ func (s *ProductService) UpdateProduct(ctx context.Context, product *model.Produ
 	if exists {
 		return status.Error(codes.AlreadyExists, "another product with the same SKU or Name already exists")
 	}
+	before, err := s.productRepo.GetProduct(ctx, product.ID.String())
+	if err != nil && !errors.Is(err, repository.ErrProductNotFound) {
+		log.Printf("Error retrieving product: %v", err)
+		return status.Errorf(codes.Internal, "error retrieving product: %v", err)
+	}
+	// Units already in stock have no serial numbers to move them by, and
+	// are counted in the base unit they were received in.
+	var needsNoStock string
+	if product.Serialized && (before == nil || !before.Serialized) {
+		needsNoStock = "be serialized"
+	} else if before != nil && product.BaseUnit != before.BaseUnit {
+		needsNoStock = "change its base unit"
+	}
+	// Stock already held must be countable in a lower precision.
+	coarser := before != nil && product.QuantityPrecision < before.QuantityPrecision
+	if needsNoStock != "" || coarser {
+		items, err := s.itemRepo.ListInventoryItemsByProduct(ctx, product.ID.String())
+		if err != nil {
+			log.Printf("Error listing inventory items: %v", err)
+			return status.Errorf(codes.Internal, "error listing inventory items: %v", err)
+		}
+		for _, item := range items {
+			if needsNoStock != "" && (item.Quantity > 0 || item.QuarantinedQuantity > 0) {
+				return status.Errorf(codes.FailedPrecondition, "product %s cannot %s while inventory item %s holds stock", product.ID, needsNoStock, item.ID)
+			}
+			for _, q := range []model.Quantity{item.Quantity, item.ReservedQuantity, item.QuarantinedQuantity} {
+				if !q.HasPrecision(product.QuantityPrecision) {
+					return status.Errorf(codes.FailedPrecondition, "product %s cannot be counted in %d decimals while inventory item %s holds %s units", product.ID, product.QuantityPrecision, item.ID, q)
+				}
+			}
+		}
+	}
 	err = s.productRepo.UpdateProduct(ctx, product)
 	if err != nil {
 		if errors.Is(err, repository.ErrProductNotFound) {
@@ -86,12 +155,25 @@ func (s *ProductService) UpdateProduct(ctx context.Context, product *model.Produ
 		log.Printf("Error updating product: %v", err)
 		return status.Errorf(codes.Internal, "error updating product: %v", err)
 	}
+	s.auditService.Record(ctx, model.EntityProduct, product.ID, model.AuditUpdate, before, product)
+	if before == nil || before.Price != product.Price {
+		var oldPrice model.Money
+		if before != nil {
+			oldPrice = before.Price
+		}
+		s.priceChangeService.RecordPriceChange(ctx, product.ID, oldPrice, product.Price)
+	}
 	log.Println("Product updated successfully:", product.ID)
 	return nil
 }
 
 func (s *ProductService) DeleteProduct(ctx context.Context, id uuid.UUID) error {
-	err := s.productRepo.DeleteProduct(ctx, id.String())
+	before, err := s.productRepo.GetProduct(ctx, id.String())
+	if err != nil && !errors.Is(err, repository.ErrProductNotFound) {
+		log.Printf("Error retrieving product: %v", err)
+		return status.Errorf(codes.Internal, "error retrieving product: %v", err)
+	}
+	err = s.productRepo.DeleteProduct(ctx, id.String())
 	if err != nil {
 		if errors.Is(err, repository.ErrProductNotFound) {
 			return status.Error(codes.NotFound, "product not found")
@@ -99,6 +181,63 @@ func (s *ProductService) DeleteProduct(ctx context.Context, id uuid.UUID) error
 		log.Printf("Error deleting product: %v", err)
 		return status.Errorf(codes.Internal, "error deleting product: %v", err)
 	}
+	s.auditService.Record(ctx, model.EntityProduct, id, model.AuditDelete, before, nil)
 	log.Println("Product deleted successfully:", id)
 	return nil
 }
+
+// validateDimensions checks that the dimensions and weight of a product are
+// not negative.
+func validateDimensions(product *model.Product) error {
+	if product.Length < 0 || product.Width < 0 || product.Height < 0 || product.Weight < 0 {
+		return status.Error(codes.InvalidArgument, "dimensions and weight cannot be negative")
+	}
+	return nil
+}
+
+// validateCostingMethod checks that a product uses a known costing method.
+// The method can change at any time; it applies to stock taken out from then
+// on.
+func validateCostingMethod(product *model.Product) error {
+	if product.CostingMethod < model.CostingFIFO || product.CostingMethod > model.CostingMovingAverage {
+		return status.Errorf(codes.InvalidArgument, "invalid costing method: %d", product.CostingMethod)
+	}
+	return nil
+}
+
+// validateQuantityPrecision checks the number of decimals a product is
+// counted in. Serialized products are counted in whole units.
+func validateQuantityPrecision(product *model.Product) error {
+	if product.QuantityPrecision < 0 || product.QuantityPrecision > model.QuantityDecimals {
+		return status.Errorf(codes.InvalidArgument, "quantity precision must be between 0 and %d decimals", model.QuantityDecimals)
+	}
+	if product.Serialized && product.QuantityPrecision != 0 {
+		return status.Error(codes.InvalidArgument, "serialized products are counted in whole units")
+	}
+	return nil
+}
+
+// validateUnits checks the units of measure of a product and normalizes their
+// names. A product without a base unit is counted in eaches. Every alternate
+// unit needs a distinct name and holds a positive number of base units.
+func validateUnits(product *model.Product) error {
+	product.BaseUnit = strings.TrimSpace(product.BaseUnit)
+	if product.BaseUnit == "" {
+		product.BaseUnit = model.DefaultBaseUnit
+	}
+	seen := map[string]bool{product.BaseUnit: true}
+	for _, unit := range product.Units {
+		unit.Name = strings.TrimSpace(unit.Name)
+		if unit.Name == "" {
+			return status.Error(codes.InvalidArgument, "unit name is required")
+		}
+		if seen[unit.Name] {
+			return status.Errorf(codes.InvalidArgument, "unit %q is defined more than once", unit.Name)
+		}
+		seen[unit.Name] = true
+		if unit.Factor <= 0 {
+			return status.Errorf(codes.InvalidArgument, "unit %q must hold a positive number of base units", unit.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,290 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventoryService/model"
+	"inventoryService/repository"
+	"log"
+	"strings"
+	"time"
+)
+
+type PurchaseOrderService struct {
+	repo           *repository.PurchaseOrderRepository
+	supplierRepo   *repository.SupplierRepository
+	productRepo    *repository.ProductRepository
+	warehouseRepo  *repository.WarehouseRepository
+	suggestionRepo *repository.ReorderSuggestionRepository
+	auditService   *AuditService
+}
+
+func NewPurchaseOrderService(
+	repo *repository.PurchaseOrderRepository,
+	supplierRepo *repository.SupplierRepository,
+	productRepo *repository.ProductRepository,
+	warehouseRepo *repository.WarehouseRepository,
+	suggestionRepo *repository.ReorderSuggestionRepository,
+	auditService *AuditService,
+) *PurchaseOrderService {
+	return &PurchaseOrderService{
+		repo:           repo,
+		supplierRepo:   supplierRepo,
+		productRepo:    productRepo,
+		warehouseRepo:  warehouseRepo,
+		suggestionRepo: suggestionRepo,
+		auditService:   auditService,
+	}
+}
+
+func (s *PurchaseOrderService) CreatePurchaseOrder(ctx context.Context, order *model.PurchaseOrder) (*model.PurchaseOrder, error) {
+	if err := s.validatePurchaseOrder(ctx, order); err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	order.ID = uuid.New()
+	order.Status = model.PurchaseOrderDraft
+	order.CreatedAt = now
+	order.UpdatedAt = now
+	order.SubmittedAt = time.Time{}
+	for _, line := range order.Lines {
+		line.ID = uuid.New()
+		line.ReceivedQuantity = 0
+	}
+
+	err := s.repo.CreatePurchaseOrder(ctx, order)
+	if err != nil {
+		log.Printf("Error creating purchase order: %v", err)
+		return nil, status.Errorf(codes.Internal, "error creating purchase order: %v", err)
+	}
+	s.auditService.Record(ctx, model.EntityPurchaseOrder, order.ID, model.AuditCreate, nil, order)
+	log.Println("Purchase order created successfully:", order.ID)
+	return order, nil
+}
+
+func (s *PurchaseOrderService) GetPurchaseOrder(ctx context.Context, id uuid.UUID) (*model.PurchaseOrder, error) {
+	order, err := s.repo.GetPurchaseOrder(ctx, id.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrPurchaseOrderNotFound) {
+			return nil, status.Error(codes.NotFound, "purchase order not found")
+		}
+		log.Printf("Error retrieving purchase order: %v", err)
+		return nil, status.Errorf(codes.Internal, "error retrieving purchase order: %v", err)
+	}
+	return order, nil
+}
+
+// ListPurchaseOrders returns every order, optionally restricted to a supplier
+// (non-nil supplierID) and to a set of statuses (non-empty statuses).
+func (s *PurchaseOrderService) ListPurchaseOrders(ctx context.Context, supplierID uuid.UUID, statuses []model.PurchaseOrderStatus) ([]*model.PurchaseOrder, error) {
+	orders, err := s.repo.ListPurchaseOrders(ctx)
+	if err != nil {
+		log.Printf("Error listing purchase orders: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing purchase orders: %v", err)
+	}
+	var filtered []*model.PurchaseOrder
+	for _, order := range orders {
+		if supplierID != uuid.Nil && order.SupplierID != supplierID {
+			continue
+		}
+		if len(statuses) > 0 && !containsStatus(statuses, order.Status) {
+			continue
+		}
+		filtered = append(filtered, order)
+	}
+	log.Println("Purchase orders listed successfully")
+	return filtered, nil
+}
+
+// UpdatePurchaseOrder replaces the supplier, notes and lines of a draft order.
+func (s *PurchaseOrderService) UpdatePurchaseOrder(ctx context.Context, order *model.PurchaseOrder) (*model.PurchaseOrder, error) {
+	before, err := s.GetPurchaseOrder(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	if before.Status != model.PurchaseOrderDraft {
+		return nil, status.Errorf(codes.FailedPrecondition, "only draft purchase orders can be edited, order is %s", before.Status)
+	}
+	if err := s.validatePurchaseOrder(ctx, order); err != nil {
+		return nil, err
+	}
+	order.Status = before.Status
+	order.CreatedAt = before.CreatedAt
+	order.SubmittedAt = before.SubmittedAt
+	order.UpdatedAt = time.Now().UTC()
+	for _, line := range order.Lines {
+		if line.ID == uuid.Nil {
+			line.ID = uuid.New()
+		}
+		line.ReceivedQuantity = 0
+	}
+
+	err = s.repo.UpdatePurchaseOrder(ctx, order)
+	if err != nil {
+		log.Printf("Error updating purchase order: %v", err)
+		return nil, status.Errorf(codes.Internal, "error updating purchase order: %v", err)
+	}
+	s.auditService.Record(ctx, model.EntityPurchaseOrder, order.ID, model.AuditUpdate, before, order)
+	log.Println("Purchase order updated successfully:", order.ID)
+	return order, nil
+}
+
+// DeletePurchaseOrder removes a draft or cancelled order. Orders that have been
+// sent to the supplier are kept for the receipt history.
+func (s *PurchaseOrderService) DeletePurchaseOrder(ctx context.Context, id uuid.UUID) error {
+	before, err := s.GetPurchaseOrder(ctx, id)
+	if err != nil {
+		return err
+	}
+	if before.Status != model.PurchaseOrderDraft && before.Status != model.PurchaseOrderCancelled {
+		return status.Errorf(codes.FailedPrecondition, "only draft or cancelled purchase orders can be deleted, order is %s", before.Status)
+	}
+	err = s.repo.DeletePurchaseOrder(ctx, id.String())
+	if err != nil {
+		log.Printf("Error deleting purchase order: %v", err)
+		return status.Errorf(codes.Internal, "error deleting purchase order: %v", err)
+	}
+	s.auditService.Record(ctx, model.EntityPurchaseOrder, id, model.AuditDelete, before, nil)
+	log.Println("Purchase order deleted successfully:", id)
+	return nil
+}
+
+func (s *PurchaseOrderService) SubmitPurchaseOrder(ctx context.Context, id uuid.UUID) (*model.PurchaseOrder, error) {
+	return s.transition(ctx, id, model.PurchaseOrderSubmitted, model.PurchaseOrderDraft)
+}
+
+func (s *PurchaseOrderService) CancelPurchaseOrder(ctx context.Context, id uuid.UUID) (*model.PurchaseOrder, error) {
+	return s.transition(ctx, id, model.PurchaseOrderCancelled, model.PurchaseOrderDraft, model.PurchaseOrderSubmitted)
+}
+
+// transition moves an order to the target status if it is currently in one of
+// the allowed statuses.
+func (s *PurchaseOrderService) transition(ctx context.Context, id uuid.UUID, target model.PurchaseOrderStatus, allowed ...model.PurchaseOrderStatus) (*model.PurchaseOrder, error) {
+	order, err := s.GetPurchaseOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !containsStatus(allowed, order.Status) {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot move purchase order from %s to %s", order.Status, target)
+	}
+	before := *order
+	order.Status = target
+	order.UpdatedAt = time.Now().UTC()
+
+	var applied bool
+	if target == model.PurchaseOrderSubmitted {
+		order.SubmittedAt = order.UpdatedAt
+		applied, err = s.repo.MarkSubmitted(ctx, id.String(), order.SubmittedAt)
+	} else {
+		applied, err = s.repo.UpdatePurchaseOrderStatus(ctx, id.String(), before.Status, order.Status, order.UpdatedAt)
+	}
+	if err != nil {
+		log.Printf("Error updating purchase order status: %v", err)
+		return nil, status.Errorf(codes.Internal, "error updating purchase order status: %v", err)
+	}
+	if !applied {
+		return nil, status.Error(codes.Aborted, "purchase order changed concurrently or goods are being received against it")
+	}
+	s.auditService.Record(ctx, model.EntityPurchaseOrder, order.ID, model.AuditUpdate, &before, order)
+	log.Printf("Purchase order %s moved from %s to %s", order.ID, before.Status, order.Status)
+	return order, nil
+}
+
+// CreatePurchaseOrderFromSuggestions turns open reorder suggestions into a
+// single draft purchase order for the given supplier, one line per suggestion.
+// Suggestions that already belong to a purchase order are rejected.
+func (s *PurchaseOrderService) CreatePurchaseOrderFromSuggestions(ctx context.Context, supplierID uuid.UUID, inventoryItemIDs []uuid.UUID) (*model.PurchaseOrder, error) {
+	if len(inventoryItemIDs) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one reorder suggestion is required")
+	}
+	order := &model.PurchaseOrder{SupplierID: supplierID, Notes: "Created from reorder suggestions"}
+	for _, itemID := range inventoryItemIDs {
+		suggestion, err := s.suggestionRepo.GetReorderSuggestion(ctx, itemID.String())
+		if err != nil {
+			if errors.Is(err, repository.ErrReorderSuggestionNotFound) {
+				return nil, status.Errorf(codes.NotFound, "no reorder suggestion for inventory item %s", itemID)
+			}
+			log.Printf("Error retrieving reorder suggestion: %v", err)
+			return nil, status.Errorf(codes.Internal, "error retrieving reorder suggestion: %v", err)
+		}
+		if suggestion.PurchaseOrderID != uuid.Nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "reorder suggestion for inventory item %s is already on purchase order %s", itemID, suggestion.PurchaseOrderID)
+		}
+		order.Lines = append(order.Lines, &model.PurchaseOrderLine{
+			ProductID:   suggestion.ProductID,
+			WarehouseID: suggestion.WarehouseID,
+			Quantity:    suggestion.SuggestedQuantity,
+		})
+	}
+
+	created, err := s.CreatePurchaseOrder(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+	for _, itemID := range inventoryItemIDs {
+		if err := s.suggestionRepo.SetPurchaseOrder(ctx, itemID.String(), created.ID); err != nil {
+			log.Printf("Error linking reorder suggestion %s to purchase order %s: %v", itemID, created.ID, err)
+		}
+	}
+	return created, nil
+}
+
+func (s *PurchaseOrderService) validatePurchaseOrder(ctx context.Context, order *model.PurchaseOrder) error {
+	exists, err := s.supplierRepo.ExistsByUUID(ctx, order.SupplierID)
+	if err != nil {
+		log.Printf("Error checking supplier existence: %v", err)
+		return status.Errorf(codes.Internal, "error checking supplier existence: %v", err)
+	}
+	if !exists {
+		return status.Error(codes.NotFound, "supplier not found")
+	}
+	if len(order.Lines) == 0 {
+		return status.Error(codes.InvalidArgument, "purchase order must have at least one line")
+	}
+	for _, line := range order.Lines {
+		if line.Quantity <= 0 {
+			return status.Error(codes.InvalidArgument, "line quantity must be positive")
+		}
+		if err := validateMoney("line unit cost", line.UnitCost); err != nil {
+			return err
+		}
+		if !line.UnitCost.SameCurrency(order.Lines[0].UnitCost) {
+			return status.Error(codes.InvalidArgument, "all lines of a purchase order must be in the same currency")
+		}
+		product, err := s.productRepo.GetProduct(ctx, line.ProductID.String())
+		if err != nil {
+			if errors.Is(err, repository.ErrProductNotFound) {
+				return status.Errorf(codes.NotFound, "product %s not found", line.ProductID)
+			}
+			return status.Errorf(codes.Internal, "error retrieving product: %v", err)
+		}
+		line.Unit = strings.TrimSpace(line.Unit)
+		units, err := product.ToBaseQuantity(line.Quantity, line.Unit)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		if err := validateQuantity("line quantity", units, product); err != nil {
+			return err
+		}
+		exists, err := s.warehouseRepo.WarehouseExists(ctx, line.WarehouseID.String())
+		if err != nil {
+			return status.Errorf(codes.Internal, "error checking warehouse existence: %v", err)
+		}
+		if !exists {
+			return status.Errorf(codes.NotFound, "warehouse %s not found", line.WarehouseID)
+		}
+	}
+	return nil
+}
+
+func containsStatus(statuses []model.PurchaseOrderStatus, target model.PurchaseOrderStatus) bool {
+	for _, s := range statuses {
+		if s == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventoryService/model"
+)
+
+// validateQuantity checks that a quantity of a product has no more decimals
+// than the product is counted in; products without a precision are counted
+// in whole units.
+func validateQuantity(field string, q model.Quantity, product *model.Product) error {
+	if q.HasPrecision(product.QuantityPrecision) {
+		return nil
+	}
+	if product.QuantityPrecision == 0 {
+		return status.Errorf(codes.InvalidArgument, "%s %s must be whole: product %s is counted in whole units", field, q, product.ID)
+	}
+	return status.Errorf(codes.InvalidArgument, "%s %s has more than the %d decimals product %s is counted in", field, q, product.QuantityPrecision, product.ID)
+}
+
+// absQuantity returns the absolute value of q.
+func absQuantity(q model.Quantity) model.Quantity {
+	if q < 0 {
+		return -q
+	}
+	return q
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventoryService/model"
+	"inventoryService/repository"
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+// alertBufferSize is how many alerts a slow subscriber may fall behind by
+// before further alerts to it are dropped.
+const alertBufferSize = 64
+
+// ReplenishmentService raises reorder suggestions for inventory items that are
+// at or below their reorder level and notifies subscribers when one is raised.
+type ReplenishmentService struct {
+	itemRepo       *repository.InventoryItemRepository
+	suggestionRepo *repository.ReorderSuggestionRepository
+
+	mu          sync.Mutex
+	subscribers map[int]chan *model.ReorderSuggestion
+	nextID      int
+}
+
+func NewReplenishmentService(itemRepo *repository.InventoryItemRepository, suggestionRepo *repository.ReorderSuggestionRepository) *ReplenishmentService {
+	return &ReplenishmentService{
+		itemRepo:       itemRepo,
+		suggestionRepo: suggestionRepo,
+		subscribers:    make(map[int]chan *model.ReorderSuggestion),
+	}
+}
+
+// Start evaluates every inventory item once per interval until ctx is done.
+func (s *ReplenishmentService) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.EvaluateAll(ctx); err != nil {
+					log.Printf("Error running scheduled reorder scan: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+func (s *ReplenishmentService) EvaluateAll(ctx context.Context) error {
+	items, err := s.itemRepo.ListInventoryItems(ctx)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := s.evaluate(ctx, item); err != nil {
+			log.Printf("Error evaluating reorder level of inventory item %s: %v", item.ID, err)
+		}
+	}
+	log.Println("Reorder scan completed for", len(items), "inventory items")
+	return nil
+}
+
+// EvaluateItem raises or clears the reorder suggestion of a single inventory
+// item. It is called after every change that can affect the item's stock,
+// including its deletion.
+func (s *ReplenishmentService) EvaluateItem(ctx context.Context, inventoryItemID uuid.UUID) {
+	item, err := s.itemRepo.GetInventoryItem(ctx, inventoryItemID.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrInventoryItemNotFound) {
+			err = s.suggestionRepo.DeleteReorderSuggestion(ctx, inventoryItemID.String())
+		}
+		if err != nil {
+			log.Printf("Error checking reorder level of inventory item %s: %v", inventoryItemID, err)
+		}
+		return
+	}
+	if err := s.evaluate(ctx, item); err != nil {
+		log.Printf("Error evaluating reorder level of inventory item %s: %v", item.ID, err)
+	}
+}
+
+func (s *ReplenishmentService) evaluate(ctx context.Context, item *model.InventoryItem) error {
+	existing, err := s.suggestionRepo.GetReorderSuggestion(ctx, item.ID.String())
+	if err != nil && !errors.Is(err, repository.ErrReorderSuggestionNotFound) {
+		return err
+	}
+
+	if item.ReorderQuantity <= 0 || item.Quantity > item.ReorderLevel {
+		if existing != nil {
+			return s.suggestionRepo.DeleteReorderSuggestion(ctx, item.ID.String())
+		}
+		return nil
+	}
+
+	suggestion := &model.ReorderSuggestion{
+		InventoryItemID:   item.ID,
+		ProductID:         item.ProductID,
+		WarehouseID:       item.WarehouseID,
+		QuantityOnHand:    item.Quantity,
+		ReorderLevel:      item.ReorderLevel,
+		SuggestedQuantity: item.ReorderQuantity,
+		CreatedAt:         time.Now().UTC(),
+	}
+	if existing != nil {
+		suggestion.CreatedAt = existing.CreatedAt
+		suggestion.PurchaseOrderID = existing.PurchaseOrderID
+	}
+	if err := s.suggestionRepo.SaveReorderSuggestion(ctx, suggestion); err != nil {
+		return err
+	}
+	if existing == nil {
+		log.Println("Reorder suggestion raised for inventory item:", item.ID)
+		s.publish(suggestion)
+	}
+	return nil
+}
+
+// ListReorderSuggestions returns the open suggestions grouped by warehouse.
+// A non-nil warehouseID restricts the result to that warehouse.
+func (s *ReplenishmentService) ListReorderSuggestions(ctx context.Context, warehouseID uuid.UUID) (map[uuid.UUID][]*model.ReorderSuggestion, error) {
+	suggestions, err := s.suggestionRepo.ListReorderSuggestions(ctx)
+	if err != nil {
+		log.Printf("Error listing reorder suggestions: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing reorder suggestions: %v", err)
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		return suggestions[i].CreatedAt.Before(suggestions[j].CreatedAt)
+	})
+
+	grouped := make(map[uuid.UUID][]*model.ReorderSuggestion)
+	for _, suggestion := range suggestions {
+		if warehouseID != uuid.Nil && suggestion.WarehouseID != warehouseID {
+			continue
+		}
+		grouped[suggestion.WarehouseID] = append(grouped[suggestion.WarehouseID], suggestion)
+	}
+	return grouped, nil
+}
+
+// Subscribe returns a channel receiving every newly raised suggestion and a
+// function that must be called to unsubscribe.
+func (s *ReplenishmentService) Subscribe() (<-chan *model.ReorderSuggestion, func()) {
+	ch := make(chan *model.ReorderSuggestion, alertBufferSize)
+
+	s.mu.Lock()
+	id := s.nextID
+	s.nextID++
+	s.subscribers[id] = ch
+	s.mu.Unlock()
+
+	return ch, func() {
+		s.mu.Lock()
+		delete(s.subscribers, id)
+		s.mu.Unlock()
+	}
+}
+
+func (s *ReplenishmentService) publish(suggestion *model.ReorderSuggestion) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, ch := range s.subscribers {
+		select {
+		case ch <- suggestion:
+		default:
+			log.Printf("Dropping reorder alert for slow subscriber %d", id)
+		}
+	}
+}
@@ -0,0 +1,449 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventoryService/model"
+	"inventoryService/repository"
+	"log"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ReturnAuthorizationService handles customer returns from authorization
+// through inspection.
+type ReturnAuthorizationService struct {
+	repo                 *repository.ReturnAuthorizationRepository
+	salesOrderRepo       *repository.SalesOrderRepository
+	productRepo          *repository.ProductRepository
+	warehouseRepo        *repository.WarehouseRepository
+	supplierRepo         *repository.SupplierRepository
+	itemRepo             *repository.InventoryItemRepository
+	stockMovementService *StockMovementService
+	auditService         *AuditService
+}
+
+func NewReturnAuthorizationService(
+	repo *repository.ReturnAuthorizationRepository,
+	salesOrderRepo *repository.SalesOrderRepository,
+	productRepo *repository.ProductRepository,
+	warehouseRepo *repository.WarehouseRepository,
+	supplierRepo *repository.SupplierRepository,
+	itemRepo *repository.InventoryItemRepository,
+	stockMovementService *StockMovementService,
+	auditService *AuditService,
+) *ReturnAuthorizationService {
+	return &ReturnAuthorizationService{
+		repo:                 repo,
+		salesOrderRepo:       salesOrderRepo,
+		productRepo:          productRepo,
+		warehouseRepo:        warehouseRepo,
+		supplierRepo:         supplierRepo,
+		itemRepo:             itemRepo,
+		stockMovementService: stockMovementService,
+		auditService:         auditService,
+	}
+}
+
+// CreateReturnAuthorization opens a return. When it refers to a sales order,
+// the order must have been shipped with the product, and all returns against
+// the order cannot exceed what was shipped. The customer and warehouse then
+// default to those of the order.
+func (s *ReturnAuthorizationService) CreateReturnAuthorization(ctx context.Context, rma *model.ReturnAuthorization) (*model.ReturnAuthorization, error) {
+	if rma.Quantity <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "return quantity must be positive")
+	}
+	product, err := s.productRepo.GetProduct(ctx, rma.ProductID.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrProductNotFound) {
+			return nil, status.Error(codes.NotFound, "product not found")
+		}
+		return nil, status.Errorf(codes.Internal, "error retrieving product: %v", err)
+	}
+	if err := validateQuantity("return quantity", rma.Quantity, product); err != nil {
+		return nil, err
+	}
+	var shipped model.Quantity
+	if rma.SalesOrderID != uuid.Nil {
+		shipped, err = s.checkAgainstSalesOrder(ctx, rma)
+		if err != nil {
+			return nil, err
+		}
+	}
+	rma.Customer = strings.TrimSpace(rma.Customer)
+	if rma.Customer == "" {
+		return nil, status.Error(codes.InvalidArgument, "customer is required")
+	}
+	exists, err := s.warehouseRepo.WarehouseExists(ctx, rma.WarehouseID.String())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error checking warehouse existence: %v", err)
+	}
+	if !exists {
+		return nil, status.Error(codes.NotFound, "warehouse not found")
+	}
+	if rma.SalesOrderID != uuid.Nil {
+		if err := s.countReturned(ctx, rma, shipped); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now().UTC()
+	rma.ID = uuid.New()
+	rma.Status = model.ReturnAuthorizationOpen
+	rma.Dispositions = nil
+	rma.CreatedAt = now
+	rma.UpdatedAt = now
+	rma.InspectedAt = time.Time{}
+	err = s.repo.CreateReturnAuthorization(ctx, rma)
+	if err != nil {
+		log.Printf("Error creating return authorization: %v", err)
+		s.unreturn(ctx, rma)
+		return nil, status.Errorf(codes.Internal, "error creating return authorization: %v", err)
+	}
+	s.auditService.Record(ctx, model.EntityReturnAuthorization, rma.ID, model.AuditCreate, nil, rma)
+	log.Println("Return authorization created successfully:", rma.ID)
+	return rma, nil
+}
+
+func (s *ReturnAuthorizationService) GetReturnAuthorization(ctx context.Context, id uuid.UUID) (*model.ReturnAuthorization, error) {
+	rma, err := s.repo.GetReturnAuthorization(ctx, id.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrReturnAuthorizationNotFound) {
+			return nil, status.Error(codes.NotFound, "return authorization not found")
+		}
+		log.Printf("Error retrieving return authorization: %v", err)
+		return nil, status.Errorf(codes.Internal, "error retrieving return authorization: %v", err)
+	}
+	return rma, nil
+}
+
+// ListReturnAuthorizations returns every return, optionally restricted to a
+// sales order (non-nil salesOrderID) and to a set of statuses.
+func (s *ReturnAuthorizationService) ListReturnAuthorizations(ctx context.Context, salesOrderID uuid.UUID, statuses []model.ReturnAuthorizationStatus) ([]*model.ReturnAuthorization, error) {
+	rmas, err := s.repo.ListReturnAuthorizations(ctx)
+	if err != nil {
+		log.Printf("Error listing return authorizations: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing return authorizations: %v", err)
+	}
+	var filtered []*model.ReturnAuthorization
+	for _, rma := range rmas {
+		if salesOrderID != uuid.Nil && rma.SalesOrderID != salesOrderID {
+			continue
+		}
+		if len(statuses) > 0 && !containsReturnStatus(statuses, rma.Status) {
+			continue
+		}
+		filtered = append(filtered, rma)
+	}
+	log.Println("Return authorizations listed successfully")
+	return filtered, nil
+}
+
+// InspectReturnAuthorization records how the returned units are dispositioned
+// and moves them accordingly: restocked units are added to the inventory item
+// of the product in the return's warehouse, refurbished and supplier-bound
+// units are quarantined there, and scrapped units are not taken into stock.
+// The dispositions must account for the whole returned quantity.
+//
+// The return is claimed as inspected before anything is posted, and the
+// dispositions are saved with the ids of their movements first. If a movement
+// fails the return is opened again; inspecting it again with the same
+// dispositions skips the movements already posted.
+func (s *ReturnAuthorizationService) InspectReturnAuthorization(ctx context.Context, id uuid.UUID, dispositions []*model.ReturnDisposition) (*model.ReturnAuthorization, error) {
+	rma, err := s.GetReturnAuthorization(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if rma.Status != model.ReturnAuthorizationOpen {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot inspect a return that is %s", rma.Status)
+	}
+	if err := s.validateDispositions(ctx, rma, dispositions); err != nil {
+		return nil, err
+	}
+	if err := s.claim(ctx, id, model.ReturnAuthorizationOpen, model.ReturnAuthorizationInspected); err != nil {
+		return nil, err
+	}
+	// A failed inspection may have saved its dispositions since the return
+	// was read.
+	rma, err = s.GetReturnAuthorization(ctx, id)
+	if err != nil {
+		s.reopen(ctx, id)
+		return nil, err
+	}
+	before := *rma
+	before.Status = model.ReturnAuthorizationOpen
+	posted, err := s.matchPosted(ctx, rma.Dispositions, dispositions)
+	if err != nil {
+		s.reopen(ctx, id)
+		return nil, err
+	}
+	rma.Dispositions = dispositions
+	err = s.repo.SaveDispositions(ctx, rma)
+	if err != nil {
+		log.Printf("Error saving return dispositions: %v", err)
+		s.reopen(ctx, id)
+		return nil, status.Errorf(codes.Internal, "error saving return dispositions: %v", err)
+	}
+
+	now := time.Now().UTC()
+	var item *model.InventoryItem
+	for _, disposition := range dispositions {
+		movementType, moves := dispositionMovementType(disposition.Disposition)
+		if !moves || posted[disposition] {
+			continue
+		}
+		if item == nil {
+			item, err = findOrCreateInventoryItem(ctx, s.itemRepo, s.auditService, rma.ProductID, rma.WarehouseID)
+			if err != nil {
+				s.reopen(ctx, id)
+				return nil, err
+			}
+		}
+		_, err = s.stockMovementService.PostStockMovement(ctx, &model.StockMovement{
+			ID:                     disposition.StockMovementID,
+			InventoryItemID:        item.ID,
+			Type:                   movementType,
+			Quantity:               disposition.Quantity,
+			Date:                   now,
+			DestinationWarehouseID: rma.WarehouseID,
+			SerialNumbers:          disposition.SerialNumbers,
+		})
+		if err != nil {
+			s.reopen(ctx, id)
+			return nil, err
+		}
+	}
+
+	rma.Status = model.ReturnAuthorizationInspected
+	rma.UpdatedAt = now
+	rma.InspectedAt = now
+	err = s.repo.UpdateReturnAuthorizationTimes(ctx, rma)
+	if err != nil {
+		log.Printf("Error saving return inspection time: %v", err)
+		return nil, status.Errorf(codes.Internal, "error saving return inspection time: %v", err)
+	}
+	s.auditService.Record(ctx, model.EntityReturnAuthorization, rma.ID, model.AuditUpdate, &before, rma)
+	log.Println("Return authorization inspected successfully:", rma.ID)
+	return rma, nil
+}
+
+// matchPosted gives each disposition that moves stock the id of its movement.
+// Dispositions whose movement an earlier inspection already posted keep its
+// id and are reported as posted; they must not have changed since.
+func (s *ReturnAuthorizationService) matchPosted(ctx context.Context, saved, dispositions []*model.ReturnDisposition) (map[*model.ReturnDisposition]bool, error) {
+	requested := make(map[model.Disposition]*model.ReturnDisposition, len(dispositions))
+	for _, disposition := range dispositions {
+		disposition.StockMovementID = uuid.Nil
+		if _, moves := dispositionMovementType(disposition.Disposition); moves {
+			disposition.StockMovementID = uuid.New()
+		}
+		requested[disposition.Disposition] = disposition
+	}
+	posted := make(map[*model.ReturnDisposition]bool)
+	for _, previous := range saved {
+		if previous.StockMovementID == uuid.Nil {
+			continue
+		}
+		ok, err := s.stockMovementService.MovementPosted(ctx, previous.StockMovementID)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		disposition := requested[previous.Disposition]
+		if disposition == nil || disposition.Quantity != previous.Quantity || !slices.Equal(disposition.SerialNumbers, previous.SerialNumbers) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s units were already posted as %s; inspect the return with the same %s disposition", previous.Quantity, previous.Disposition, previous.Disposition)
+		}
+		disposition.StockMovementID = previous.StockMovementID
+		posted[disposition] = true
+	}
+	return posted, nil
+}
+
+// dispositionMovementType returns how units of a disposition enter stock, or
+// false when they do not.
+func dispositionMovementType(disposition model.Disposition) (model.StockMovementType, bool) {
+	switch disposition {
+	case model.DispositionRestock:
+		return model.Addition, true
+	case model.DispositionRefurbish, model.DispositionReturnToSupplier:
+		return model.Quarantine, true
+	}
+	return 0, false
+}
+
+// CancelReturnAuthorization closes a return whose goods never arrived. Its
+// units can then be returned again against the sales order.
+func (s *ReturnAuthorizationService) CancelReturnAuthorization(ctx context.Context, id uuid.UUID) (*model.ReturnAuthorization, error) {
+	rma, err := s.GetReturnAuthorization(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if rma.Status != model.ReturnAuthorizationOpen {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot cancel a return that is %s", rma.Status)
+	}
+	if err := s.claim(ctx, id, model.ReturnAuthorizationOpen, model.ReturnAuthorizationCancelled); err != nil {
+		return nil, err
+	}
+	before := *rma
+	rma.Status = model.ReturnAuthorizationCancelled
+	rma.UpdatedAt = time.Now().UTC()
+	s.unreturn(ctx, rma)
+	err = s.repo.UpdateReturnAuthorizationTimes(ctx, rma)
+	if err != nil {
+		log.Printf("Error updating return authorization: %v", err)
+		return nil, status.Errorf(codes.Internal, "error updating return authorization: %v", err)
+	}
+	s.auditService.Record(ctx, model.EntityReturnAuthorization, rma.ID, model.AuditUpdate, &before, rma)
+	log.Println("Return authorization cancelled successfully:", rma.ID)
+	return rma, nil
+}
+
+// claim moves a return between statuses, failing with Aborted when it has
+// been inspected or cancelled since it was read.
+func (s *ReturnAuthorizationService) claim(ctx context.Context, id uuid.UUID, from, to model.ReturnAuthorizationStatus) error {
+	applied, err := s.repo.UpdateReturnAuthorizationStatus(ctx, id.String(), from, to)
+	if err != nil {
+		log.Printf("Error updating return authorization status: %v", err)
+		return status.Errorf(codes.Internal, "error updating return authorization status: %v", err)
+	}
+	if !applied {
+		return status.Error(codes.Aborted, "return authorization was changed concurrently")
+	}
+	return nil
+}
+
+// reopen moves a return claimed for inspection back to open after the
+// inspection failed. Failures are logged; the return then stays inspected.
+func (s *ReturnAuthorizationService) reopen(ctx context.Context, id uuid.UUID) {
+	_, err := s.repo.UpdateReturnAuthorizationStatus(ctx, id.String(), model.ReturnAuthorizationInspected, model.ReturnAuthorizationOpen)
+	if err != nil {
+		log.Printf("Error reopening return authorization %s: %v", id, err)
+	}
+}
+
+// unreturn gives the units of a return that will not happen back to its
+// sales order. Failures are logged; the units then stay counted as returned.
+func (s *ReturnAuthorizationService) unreturn(ctx context.Context, rma *model.ReturnAuthorization) {
+	if rma.SalesOrderID == uuid.Nil {
+		return
+	}
+	_, err := s.repo.AdjustReturnedQuantity(ctx, rma.SalesOrderID.String(), rma.ProductID.String(), -rma.Quantity, 0, func() (model.Quantity, error) {
+		return 0, nil
+	})
+	if err != nil {
+		log.Printf("Error releasing %s units returned against sales order %s: %v", rma.Quantity, rma.SalesOrderID, err)
+	}
+}
+
+// checkAgainstSalesOrder checks that the sales order of a return shipped its
+// product, defaults the customer and warehouse of the return to the order's
+// and returns the units shipped.
+func (s *ReturnAuthorizationService) checkAgainstSalesOrder(ctx context.Context, rma *model.ReturnAuthorization) (model.Quantity, error) {
+	order, err := s.salesOrderRepo.GetSalesOrder(ctx, rma.SalesOrderID.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrSalesOrderNotFound) {
+			return 0, status.Error(codes.NotFound, "sales order not found")
+		}
+		log.Printf("Error retrieving sales order: %v", err)
+		return 0, status.Errorf(codes.Internal, "error retrieving sales order: %v", err)
+	}
+	if order.Status != model.SalesOrderShipped {
+		return 0, status.Errorf(codes.FailedPrecondition, "only shipped sales orders can be returned, order is %s", order.Status)
+	}
+	var shipped model.Quantity
+	for _, line := range order.Lines {
+		if line.ProductID != rma.ProductID {
+			continue
+		}
+		shipped += line.Quantity
+		if rma.WarehouseID == uuid.Nil && len(line.Allocations) > 0 {
+			rma.WarehouseID = line.Allocations[0].WarehouseID
+		}
+	}
+	if shipped == 0 {
+		return 0, status.Errorf(codes.InvalidArgument, "product %s was not sold on sales order %s", rma.ProductID, order.ID)
+	}
+	if rma.Customer == "" {
+		rma.Customer = order.Customer
+	}
+	return shipped, nil
+}
+
+// countReturned counts the units of a return as returned against its sales
+// order before the return is saved, so that concurrent returns cannot exceed
+// the units shipped between them.
+func (s *ReturnAuthorizationService) countReturned(ctx context.Context, rma *model.ReturnAuthorization, shipped model.Quantity) error {
+	returned, err := s.repo.AdjustReturnedQuantity(ctx, rma.SalesOrderID.String(), rma.ProductID.String(), rma.Quantity, shipped, func() (model.Quantity, error) {
+		previous, err := s.ListReturnAuthorizations(ctx, rma.SalesOrderID, nil)
+		if err != nil {
+			return 0, err
+		}
+		var returned model.Quantity
+		for _, other := range previous {
+			if other.ProductID == rma.ProductID && other.Status != model.ReturnAuthorizationCancelled {
+				returned += other.Quantity
+			}
+		}
+		return returned, nil
+	})
+	if errors.Is(err, repository.ErrReturnLimitExceeded) {
+		return status.Errorf(codes.FailedPrecondition, "only %s of the %s units shipped on sales order %s can still be returned", shipped-returned, shipped, rma.SalesOrderID)
+	}
+	if err != nil {
+		log.Printf("Error counting returned units: %v", err)
+		return status.Errorf(codes.Internal, "error counting returned units: %v", err)
+	}
+	return nil
+}
+
+func (s *ReturnAuthorizationService) validateDispositions(ctx context.Context, rma *model.ReturnAuthorization, dispositions []*model.ReturnDisposition) error {
+	var total model.Quantity
+	seen := make(map[model.Disposition]bool)
+	for _, disposition := range dispositions {
+		if disposition.Disposition < model.DispositionRestock || disposition.Disposition > model.DispositionReturnToSupplier {
+			return status.Errorf(codes.InvalidArgument, "unknown disposition %d", disposition.Disposition)
+		}
+		if seen[disposition.Disposition] {
+			return status.Errorf(codes.InvalidArgument, "disposition %s is given more than once", disposition.Disposition)
+		}
+		seen[disposition.Disposition] = true
+		if disposition.Quantity <= 0 {
+			return status.Error(codes.InvalidArgument, "disposition quantity must be positive")
+		}
+		total += disposition.Quantity
+		if disposition.Disposition != model.DispositionReturnToSupplier {
+			disposition.SupplierID = uuid.Nil
+			continue
+		}
+		if disposition.SupplierID == uuid.Nil {
+			continue
+		}
+		exists, err := s.supplierRepo.ExistsByUUID(ctx, disposition.SupplierID)
+		if err != nil {
+			log.Printf("Error checking supplier existence: %v", err)
+			return status.Errorf(codes.Internal, "error checking supplier existence: %v", err)
+		}
+		if !exists {
+			return status.Error(codes.NotFound, "supplier not found")
+		}
+	}
+	if total != rma.Quantity {
+		return status.Errorf(codes.InvalidArgument, "dispositions account for %s units but %s were returned", total, rma.Quantity)
+	}
+	return nil
+}
+
+func containsReturnStatus(statuses []model.ReturnAuthorizationStatus, target model.ReturnAuthorizationStatus) bool {
+	for _, s := range statuses {
+		if s == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,526 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventoryService/model"
+	"inventoryService/repository"
+	"log"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SalesOrderService reserves stock for sales orders across warehouses and
+// ships or releases it.
+type SalesOrderService struct {
+	repo                 *repository.SalesOrderRepository
+	itemRepo             *repository.InventoryItemRepository
+	productRepo          *repository.ProductRepository
+	warehouseRepo        *repository.WarehouseRepository
+	serialRepo           *repository.SerialRepository
+	stockMovementService *StockMovementService
+	auditService         *AuditService
+}
+
+func NewSalesOrderService(
+	repo *repository.SalesOrderRepository,
+	itemRepo *repository.InventoryItemRepository,
+	productRepo *repository.ProductRepository,
+	warehouseRepo *repository.WarehouseRepository,
+	serialRepo *repository.SerialRepository,
+	stockMovementService *StockMovementService,
+	auditService *AuditService,
+) *SalesOrderService {
+	return &SalesOrderService{
+		repo:                 repo,
+		itemRepo:             itemRepo,
+		productRepo:          productRepo,
+		warehouseRepo:        warehouseRepo,
+		serialRepo:           serialRepo,
+		stockMovementService: stockMovementService,
+		auditService:         auditService,
+	}
+}
+
+// Start releases the reservations of expired orders once per interval until
+// ctx is done.
+func (s *SalesOrderService) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.ExpireSalesOrders(ctx); err != nil {
+					log.Printf("Error expiring sales orders: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// CreateSalesOrder reserves the stock of every line and saves the order. A
+// line without a warehouse is reserved from the warehouses with the most
+// available stock first, splitting it across warehouses when needed. Either
+// every line is reserved in full or nothing is.
+func (s *SalesOrderService) CreateSalesOrder(ctx context.Context, order *model.SalesOrder) (*model.SalesOrder, error) {
+	if err := s.validateSalesOrder(ctx, order); err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	order.ID = uuid.New()
+	order.Status = model.SalesOrderReserved
+	order.CreatedAt = now
+	order.UpdatedAt = now
+	order.ShippedAt = time.Time{}
+
+	for _, line := range order.Lines {
+		line.ID = uuid.New()
+		if err := s.allocate(ctx, line); err != nil {
+			s.release(ctx, order)
+			return nil, err
+		}
+	}
+
+	err := s.repo.CreateSalesOrder(ctx, order)
+	if err != nil {
+		log.Printf("Error creating sales order: %v", err)
+		s.release(ctx, order)
+		return nil, status.Errorf(codes.Internal, "error creating sales order: %v", err)
+	}
+	s.auditService.Record(ctx, model.EntitySalesOrder, order.ID, model.AuditCreate, nil, order)
+	log.Println("Sales order created successfully:", order.ID)
+	return order, nil
+}
+
+func (s *SalesOrderService) GetSalesOrder(ctx context.Context, id uuid.UUID) (*model.SalesOrder, error) {
+	order, err := s.repo.GetSalesOrder(ctx, id.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrSalesOrderNotFound) {
+			return nil, status.Error(codes.NotFound, "sales order not found")
+		}
+		log.Printf("Error retrieving sales order: %v", err)
+		return nil, status.Errorf(codes.Internal, "error retrieving sales order: %v", err)
+	}
+	return order, nil
+}
+
+// ListSalesOrders returns every order, optionally restricted to a customer
+// (non-empty customer) and to a set of statuses (non-empty statuses).
+func (s *SalesOrderService) ListSalesOrders(ctx context.Context, customer string, statuses []model.SalesOrderStatus) ([]*model.SalesOrder, error) {
+	orders, err := s.repo.ListSalesOrders(ctx)
+	if err != nil {
+		log.Printf("Error listing sales orders: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing sales orders: %v", err)
+	}
+	var filtered []*model.SalesOrder
+	for _, order := range orders {
+		if customer != "" && order.Customer != customer {
+			continue
+		}
+		if len(statuses) > 0 && !containsSalesOrderStatus(statuses, order.Status) {
+			continue
+		}
+		filtered = append(filtered, order)
+	}
+	log.Println("Sales orders listed successfully")
+	return filtered, nil
+}
+
+// CancelSalesOrder releases the reservations of an unshipped order.
+func (s *SalesOrderService) CancelSalesOrder(ctx context.Context, id uuid.UUID) (*model.SalesOrder, error) {
+	order, err := s.GetSalesOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != model.SalesOrderReserved {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot cancel a sales order that is %s", order.Status)
+	}
+	return s.close(ctx, order, model.SalesOrderCancelled)
+}
+
+// ShipSalesOrder takes the reserved stock of an order out of its warehouses
+// with a removal movement per allocation. The order is claimed as shipped
+// first, so that it is shipped, cancelled or expired only once. Lines of
+// serialized products name the units shipped, keyed by line ID; each unit
+// goes out with the allocation of the item holding it.
+//
+// The unshipped allocations are saved with the ids of their movements before
+// any is posted, and a movement already posted is not posted again. If a
+// movement fails the allocations already shipped are saved and the order is
+// reserved again; retrying ships the rest. A call that fails with the order
+// left shipped but not every allocation saved as shipped, say after a crash,
+// is finished by calling again, which ignores the serial numbers given then.
+func (s *SalesOrderService) ShipSalesOrder(ctx context.Context, id uuid.UUID, serialNumbers map[uuid.UUID][]string) (*model.SalesOrder, error) {
+	order, err := s.GetSalesOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status == model.SalesOrderShipped && !fullyShipped(order) {
+		return s.shipAllocations(ctx, order)
+	}
+	if order.Status != model.SalesOrderReserved {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot ship a sales order that is %s", order.Status)
+	}
+	if err := s.claim(ctx, id, model.SalesOrderShipped, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	// An earlier attempt may have shipped allocations since the order was read.
+	order, err = s.GetSalesOrder(ctx, id)
+	if err != nil {
+		s.reopen(ctx, id)
+		return nil, err
+	}
+	shipped, err := s.assignSerials(ctx, order, serialNumbers)
+	if err != nil {
+		s.reopen(ctx, id)
+		return nil, err
+	}
+	// Movements of allocations left unshipped by an earlier attempt were
+	// never posted, so the allocations get new ones.
+	for _, line := range order.Lines {
+		for _, allocation := range line.Allocations {
+			if !allocation.Shipped {
+				allocation.StockMovementID = uuid.New()
+				allocation.SerialNumbers = shipped[allocation]
+			}
+		}
+	}
+	err = s.repo.SaveShipment(ctx, order)
+	if err != nil {
+		// The order stays claimed: the movement ids may have been saved all
+		// the same, and a retry ships with whichever were.
+		log.Printf("Error saving sales order shipment: %v", err)
+		return nil, status.Errorf(codes.Internal, "error saving sales order shipment: %v; retry to finish shipping", err)
+	}
+	return s.shipAllocations(ctx, order)
+}
+
+// shipAllocations posts the movements of the unshipped allocations of an order
+// claimed as shipped, then saves the shipment. A movement that fails to post
+// reserves the order again, unless it may have been posted; then the order
+// stays claimed for a retry.
+func (s *SalesOrderService) shipAllocations(ctx context.Context, order *model.SalesOrder) (*model.SalesOrder, error) {
+	before := copySalesOrder(order)
+	now := time.Now().UTC()
+
+	var shipErr error
+ship:
+	for _, line := range order.Lines {
+		for _, allocation := range line.Allocations {
+			if allocation.Shipped {
+				continue
+			}
+			err := s.shipAllocation(ctx, allocation, now)
+			if err == nil {
+				continue
+			}
+			posted, checkErr := s.stockMovementService.MovementPosted(ctx, allocation.StockMovementID)
+			if checkErr != nil || posted {
+				return nil, status.Errorf(status.Code(err), "%s; retry to finish shipping", status.Convert(err).Message())
+			}
+			shipErr = err
+			break ship
+		}
+	}
+
+	order.UpdatedAt = now
+	if shipErr == nil {
+		order.Status = model.SalesOrderShipped
+		order.ShippedAt = now
+	}
+	err := s.repo.SaveShipment(ctx, order)
+	if err != nil {
+		// The order stays claimed, and a retry finds the movements posted.
+		log.Printf("Error saving sales order shipment: %v", err)
+		return nil, status.Errorf(codes.Internal, "error saving sales order shipment: %v; retry to finish shipping", err)
+	}
+	if shipErr != nil {
+		s.reopen(ctx, order.ID)
+		order.Status = model.SalesOrderReserved
+	}
+	s.auditService.Record(ctx, model.EntitySalesOrder, order.ID, model.AuditUpdate, before, order)
+	if shipErr != nil {
+		return nil, shipErr
+	}
+	log.Println("Sales order shipped successfully:", order.ID)
+	return order, nil
+}
+
+// shipAllocation posts the removal of an allocation under its chosen movement
+// id, unless it is already journaled.
+func (s *SalesOrderService) shipAllocation(ctx context.Context, allocation *model.SalesOrderAllocation, now time.Time) error {
+	posted, err := s.stockMovementService.MovementPosted(ctx, allocation.StockMovementID)
+	if err != nil {
+		return err
+	}
+	if !posted {
+		_, err = s.stockMovementService.PostReservedRemoval(ctx, &model.StockMovement{
+			ID:                allocation.StockMovementID,
+			InventoryItemID:   allocation.InventoryItemID,
+			Quantity:          allocation.Quantity,
+			Date:              now,
+			SourceWarehouseID: allocation.WarehouseID,
+			SerialNumbers:     allocation.SerialNumbers,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	allocation.Shipped = true
+	return nil
+}
+
+// fullyShipped reports whether every allocation of an order has been shipped.
+func fullyShipped(order *model.SalesOrder) bool {
+	for _, line := range order.Lines {
+		for _, allocation := range line.Allocations {
+			if !allocation.Shipped {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// claim moves a reserved order to target, failing with Aborted when it has
+// been shipped, cancelled or expired since it was read.
+func (s *SalesOrderService) claim(ctx context.Context, id uuid.UUID, target model.SalesOrderStatus, now time.Time) error {
+	applied, err := s.repo.UpdateSalesOrderStatus(ctx, id.String(), model.SalesOrderReserved, target, now)
+	if err != nil {
+		log.Printf("Error updating sales order status: %v", err)
+		return status.Errorf(codes.Internal, "error updating sales order status: %v", err)
+	}
+	if !applied {
+		return status.Error(codes.Aborted, "sales order was changed concurrently")
+	}
+	return nil
+}
+
+// reopen moves an order claimed for shipping back to reserved after the
+// shipment failed. Failures are logged; the order then stays shipped.
+func (s *SalesOrderService) reopen(ctx context.Context, id uuid.UUID) {
+	_, err := s.repo.UpdateSalesOrderStatus(ctx, id.String(), model.SalesOrderShipped, model.SalesOrderReserved, time.Now().UTC())
+	if err != nil {
+		log.Printf("Error reopening sales order %s: %v", id, err)
+	}
+}
+
+// assignSerials shares the serial numbers given for each line out among its
+// unshipped allocations, by the inventory item holding each unit.
+func (s *SalesOrderService) assignSerials(ctx context.Context, order *model.SalesOrder, serialNumbers map[uuid.UUID][]string) (map[*model.SalesOrderAllocation][]string, error) {
+	lines := make(map[uuid.UUID]*model.SalesOrderLine, len(order.Lines))
+	for _, line := range order.Lines {
+		lines[line.ID] = line
+	}
+	shipped := make(map[*model.SalesOrderAllocation][]string)
+	for lineID, numbers := range serialNumbers {
+		line, ok := lines[lineID]
+		if !ok {
+			return nil, status.Errorf(codes.NotFound, "sales order line %s not found", lineID)
+		}
+		for _, number := range numbers {
+			serial, err := s.serialRepo.GetSerial(ctx, line.ProductID.String(), number)
+			if err != nil {
+				if errors.Is(err, repository.ErrSerialNotFound) {
+					return nil, status.Errorf(codes.NotFound, "serial %s of product %s not found", number, line.ProductID)
+				}
+				log.Printf("Error retrieving serial: %v", err)
+				return nil, status.Errorf(codes.Internal, "error retrieving serial: %v", err)
+			}
+			var target *model.SalesOrderAllocation
+			for _, allocation := range line.Allocations {
+				if !allocation.Shipped && allocation.InventoryItemID == serial.InventoryItemID && model.Units(len(shipped[allocation])) < allocation.Quantity {
+					target = allocation
+					break
+				}
+			}
+			if target == nil {
+				return nil, status.Errorf(codes.FailedPrecondition, "serial %s is not in stock where line %s is reserved", number, lineID)
+			}
+			shipped[target] = append(shipped[target], number)
+		}
+	}
+	return shipped, nil
+}
+
+// ExpireSalesOrders releases the reservations of every reserved order whose
+// expiry has passed.
+func (s *SalesOrderService) ExpireSalesOrders(ctx context.Context) error {
+	orders, err := s.repo.ListSalesOrders(ctx)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	expired := 0
+	for _, order := range orders {
+		if order.Status != model.SalesOrderReserved || order.ExpiresAt.IsZero() || order.ExpiresAt.After(now) {
+			continue
+		}
+		if _, err := s.close(ctx, order, model.SalesOrderExpired); err != nil {
+			log.Printf("Error expiring sales order %s: %v", order.ID, err)
+			continue
+		}
+		expired++
+	}
+	if expired > 0 {
+		log.Println("Sales orders expired:", expired)
+	}
+	return nil
+}
+
+// close moves a reserved order to a final status and then releases its
+// unshipped reservations. Only the caller that moves the order releases them.
+func (s *SalesOrderService) close(ctx context.Context, order *model.SalesOrder, target model.SalesOrderStatus) (*model.SalesOrder, error) {
+	now := time.Now().UTC()
+	if err := s.claim(ctx, order.ID, target, now); err != nil {
+		return nil, err
+	}
+	// Allocations shipped by a failed shipment since the order was read are
+	// no longer reserved.
+	current, err := s.GetSalesOrder(ctx, order.ID)
+	if err != nil {
+		log.Printf("Error retrieving sales order %s to release its reservations: %v", order.ID, err)
+		return nil, err
+	}
+	before := copySalesOrder(current)
+	before.Status = model.SalesOrderReserved
+	s.release(ctx, current)
+	current.Status = target
+	current.UpdatedAt = now
+	s.auditService.Record(ctx, model.EntitySalesOrder, current.ID, model.AuditUpdate, before, current)
+	log.Printf("Sales order %s moved from %s to %s", current.ID, before.Status, current.Status)
+	return current, nil
+}
+
+// allocate reserves the quantity of a line, recording where it was reserved.
+func (s *SalesOrderService) allocate(ctx context.Context, line *model.SalesOrderLine) error {
+	items, err := s.itemRepo.ListInventoryItemsByProduct(ctx, line.ProductID.String())
+	if err != nil {
+		log.Printf("Error listing inventory items: %v", err)
+		return status.Errorf(codes.Internal, "error listing inventory items: %v", err)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].AvailableQuantity() > items[j].AvailableQuantity()
+	})
+
+	remaining := line.Quantity
+	for _, item := range items {
+		if remaining == 0 {
+			break
+		}
+		if line.WarehouseID != uuid.Nil && item.WarehouseID != line.WarehouseID {
+			continue
+		}
+		quantity := min(item.AvailableQuantity(), remaining)
+		if quantity <= 0 {
+			continue
+		}
+		_, err := s.itemRepo.AdjustReservation(ctx, item.ID.String(), quantity)
+		if errors.Is(err, repository.ErrInsufficientStock) {
+			// Reserved by someone else since the items were listed.
+			continue
+		}
+		if err != nil {
+			log.Printf("Error reserving stock: %v", err)
+			return status.Errorf(codes.Internal, "error reserving stock: %v", err)
+		}
+		line.Allocations = append(line.Allocations, &model.SalesOrderAllocation{
+			InventoryItemID: item.ID,
+			WarehouseID:     item.WarehouseID,
+			Quantity:        quantity,
+		})
+		remaining -= quantity
+	}
+	if remaining > 0 {
+		return status.Errorf(codes.FailedPrecondition, "insufficient available stock of product %s: %s of %s units short", line.ProductID, remaining, line.Quantity)
+	}
+	return nil
+}
+
+// release gives back every unshipped reservation of an order. Failures are
+// logged so that the remaining reservations are still released.
+func (s *SalesOrderService) release(ctx context.Context, order *model.SalesOrder) {
+	for _, line := range order.Lines {
+		for _, allocation := range line.Allocations {
+			if allocation.Shipped {
+				continue
+			}
+			if _, err := s.itemRepo.AdjustReservation(ctx, allocation.InventoryItemID.String(), -allocation.Quantity); err != nil {
+				log.Printf("Error releasing %s units reserved in inventory item %s: %v", allocation.Quantity, allocation.InventoryItemID, err)
+			}
+		}
+	}
+}
+
+func (s *SalesOrderService) validateSalesOrder(ctx context.Context, order *model.SalesOrder) error {
+	order.Customer = strings.TrimSpace(order.Customer)
+	if order.Customer == "" {
+		return status.Error(codes.InvalidArgument, "customer is required")
+	}
+	if !order.ExpiresAt.IsZero() && !order.ExpiresAt.After(time.Now()) {
+		return status.Error(codes.InvalidArgument, "expiry must be in the future")
+	}
+	if len(order.Lines) == 0 {
+		return status.Error(codes.InvalidArgument, "sales order must have at least one line")
+	}
+	for _, line := range order.Lines {
+		line.Allocations = nil
+		if line.Quantity <= 0 {
+			return status.Error(codes.InvalidArgument, "line quantity must be positive")
+		}
+		product, err := s.productRepo.GetProduct(ctx, line.ProductID.String())
+		if err != nil {
+			if errors.Is(err, repository.ErrProductNotFound) {
+				return status.Errorf(codes.NotFound, "product %s not found", line.ProductID)
+			}
+			return status.Errorf(codes.Internal, "error retrieving product: %v", err)
+		}
+		if err := validateQuantity("line quantity", line.Quantity, product); err != nil {
+			return err
+		}
+		if line.WarehouseID == uuid.Nil {
+			continue
+		}
+		exists, err := s.warehouseRepo.WarehouseExists(ctx, line.WarehouseID.String())
+		if err != nil {
+			return status.Errorf(codes.Internal, "error checking warehouse existence: %v", err)
+		}
+		if !exists {
+			return status.Errorf(codes.NotFound, "warehouse %s not found", line.WarehouseID)
+		}
+	}
+	return nil
+}
+
+// copySalesOrder returns a copy of order whose lines and allocations can be
+// changed without affecting the original.
+func copySalesOrder(order *model.SalesOrder) *model.SalesOrder {
+	copied := *order
+	copied.Lines = make([]*model.SalesOrderLine, len(order.Lines))
+	for i, line := range order.Lines {
+		lineCopy := *line
+		lineCopy.Allocations = make([]*model.SalesOrderAllocation, len(line.Allocations))
+		for j, allocation := range line.Allocations {
+			allocationCopy := *allocation
+			lineCopy.Allocations[j] = &allocationCopy
+		}
+		copied.Lines[i] = &lineCopy
+	}
+	return &copied
+}
+
+func containsSalesOrderStatus(statuses []model.SalesOrderStatus, target model.SalesOrderStatus) bool {
+	for _, s := range statuses {
+		if s == target {
+			return true
+		}
+	}
+	return false
+}
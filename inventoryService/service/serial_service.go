@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventoryService/model"
+	"inventoryService/repository"
+	"log"
+)
+
+// SerialService answers where the units of serialized products are and how
+// they got there. Units are moved by StockMovementService.
+type SerialService struct {
+	repo *repository.SerialRepository
+}
+
+func NewSerialService(repo *repository.SerialRepository) *SerialService {
+	return &SerialService{repo: repo}
+}
+
+// LookupSerial finds a unit by serial number. The product is only needed
+// when several products have a unit with that number.
+func (s *SerialService) LookupSerial(ctx context.Context, serialNumber string, productID uuid.UUID) (*model.Serial, error) {
+	if serialNumber == "" {
+		return nil, status.Error(codes.InvalidArgument, "serial number is required")
+	}
+	if productID != uuid.Nil {
+		serial, err := s.repo.GetSerial(ctx, productID.String(), serialNumber)
+		if err != nil {
+			if errors.Is(err, repository.ErrSerialNotFound) {
+				return nil, status.Error(codes.NotFound, "serial not found")
+			}
+			log.Printf("Error retrieving serial: %v", err)
+			return nil, status.Errorf(codes.Internal, "error retrieving serial: %v", err)
+		}
+		return serial, nil
+	}
+	serials, err := s.repo.ListSerialsByNumber(ctx, serialNumber)
+	if err != nil {
+		log.Printf("Error retrieving serial: %v", err)
+		return nil, status.Errorf(codes.Internal, "error retrieving serial: %v", err)
+	}
+	switch len(serials) {
+	case 0:
+		return nil, status.Error(codes.NotFound, "serial not found")
+	case 1:
+		return serials[0], nil
+	}
+	return nil, status.Errorf(codes.InvalidArgument, "serial number %s is used by %d products; a product id is required", serialNumber, len(serials))
+}
+
+// GetSerialHistory returns the movements of a unit, oldest first.
+func (s *SerialService) GetSerialHistory(ctx context.Context, serialNumber string, productID uuid.UUID) ([]*model.SerialEvent, error) {
+	serial, err := s.LookupSerial(ctx, serialNumber, productID)
+	if err != nil {
+		return nil, err
+	}
+	events, err := s.repo.ListSerialEvents(ctx, serial.ProductID.String(), serial.SerialNumber)
+	if err != nil {
+		log.Printf("Error listing serial events: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing serial events: %v", err)
+	}
+	return events, nil
+}
@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventoryService/model"
+	"inventoryService/repository"
+	"log"
+	"sort"
+	"time"
+)
+
+// AdjustmentThresholds decide which adjustments wait for approval: those
+// changing more units than Quantity or worth more than Value. Adjustments
+// valued in another currency than Value always wait. A zero threshold is not
+// applied.
+type AdjustmentThresholds struct {
+	Quantity model.Quantity
+	Value    model.Money
+}
+
+// StockAdjustmentService corrects stock with a reason code, queueing large
+// corrections for approval.
+type StockAdjustmentService struct {
+	repo                 *repository.StockAdjustmentRepository
+	itemRepo             *repository.InventoryItemRepository
+	productRepo          *repository.ProductRepository
+	stockMovementService *StockMovementService
+	auditService         *AuditService
+	thresholds           AdjustmentThresholds
+}
+
+func NewStockAdjustmentService(
+	repo *repository.StockAdjustmentRepository,
+	itemRepo *repository.InventoryItemRepository,
+	productRepo *repository.ProductRepository,
+	stockMovementService *StockMovementService,
+	auditService *AuditService,
+	thresholds AdjustmentThresholds,
+) *StockAdjustmentService {
+	return &StockAdjustmentService{
+		repo:                 repo,
+		itemRepo:             itemRepo,
+		productRepo:          productRepo,
+		stockMovementService: stockMovementService,
+		auditService:         auditService,
+		thresholds:           thresholds,
+	}
+}
+
+// AdjustStock requests a correction of an inventory item's quantity. Below
+// the approval thresholds it is applied at once with an adjustment movement;
+// above them it is saved as pending for ApproveStockAdjustment or
+// RejectStockAdjustment.
+func (s *StockAdjustmentService) AdjustStock(ctx context.Context, adjustment *model.StockAdjustment) (*model.StockAdjustment, error) {
+	return s.create(ctx, adjustment, false, "")
+}
+
+// AdjustStockApproved applies a correction that was approved elsewhere, such
+// as the result of a stock count, regardless of the approval thresholds. The
+// caller is recorded as its reviewer.
+func (s *StockAdjustmentService) AdjustStockApproved(ctx context.Context, adjustment *model.StockAdjustment, reviewNotes string) (*model.StockAdjustment, error) {
+	return s.create(ctx, adjustment, true, reviewNotes)
+}
+
+func (s *StockAdjustmentService) create(ctx context.Context, adjustment *model.StockAdjustment, approved bool, reviewNotes string) (*model.StockAdjustment, error) {
+	if err := validateStockAdjustment(adjustment); err != nil {
+		return nil, err
+	}
+	item, err := s.itemRepo.GetInventoryItem(ctx, adjustment.InventoryItemID.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrInventoryItemNotFound) {
+			return nil, status.Error(codes.NotFound, "inventory item not found")
+		}
+		log.Printf("Error retrieving inventory item: %v", err)
+		return nil, status.Errorf(codes.Internal, "error retrieving inventory item: %v", err)
+	}
+	if item.Quantity+adjustment.QuantityChange < item.ReservedQuantity {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot adjust inventory item %s by %s: %s on hand, %s reserved", item.ID, adjustment.QuantityChange, item.Quantity, item.ReservedQuantity)
+	}
+	product, err := s.productRepo.GetProduct(ctx, item.ProductID.String())
+	if err != nil {
+		log.Printf("Error retrieving product: %v", err)
+		return nil, status.Errorf(codes.Internal, "error retrieving product: %v", err)
+	}
+	if err := validateQuantity("quantity change", adjustment.QuantityChange, product); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	units := absQuantity(adjustment.QuantityChange)
+	adjustment.ID = uuid.New()
+	adjustment.ProductID = item.ProductID
+	adjustment.WarehouseID = item.WarehouseID
+	adjustment.Value = product.Price.Mul(units)
+	adjustment.Status = model.AdjustmentPending
+	adjustment.RequestedBy = actorFromContext(ctx)
+	adjustment.ReviewedBy = ""
+	adjustment.ReviewNotes = ""
+	adjustment.StockMovementID = uuid.Nil
+	adjustment.CreatedAt = now
+	adjustment.ReviewedAt = time.Time{}
+
+	needsApproval := s.thresholds.Quantity > 0 && units > s.thresholds.Quantity ||
+		!s.thresholds.Value.IsZero() && (!adjustment.Value.SameCurrency(s.thresholds.Value) || adjustment.Value.Cmp(s.thresholds.Value) > 0)
+	if approved || !needsApproval {
+		movement, err := s.post(ctx, adjustment)
+		if err != nil {
+			return nil, err
+		}
+		adjustment.Status = model.AdjustmentApplied
+		adjustment.StockMovementID = movement.ID
+		if approved {
+			adjustment.ReviewedBy = adjustment.RequestedBy
+			adjustment.ReviewNotes = reviewNotes
+			adjustment.ReviewedAt = now
+		}
+	}
+
+	err = s.repo.CreateStockAdjustment(ctx, adjustment)
+	if err != nil {
+		log.Printf("Error creating stock adjustment: %v", err)
+		return nil, status.Errorf(codes.Internal, "error creating stock adjustment: %v", err)
+	}
+	s.auditService.Record(ctx, model.EntityStockAdjustment, adjustment.ID, model.AuditCreate, nil, adjustment)
+	log.Printf("Stock adjustment %s created as %s", adjustment.ID, adjustment.Status)
+	return adjustment, nil
+}
+
+func (s *StockAdjustmentService) GetStockAdjustment(ctx context.Context, id uuid.UUID) (*model.StockAdjustment, error) {
+	adjustment, err := s.repo.GetStockAdjustment(ctx, id.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrStockAdjustmentNotFound) {
+			return nil, status.Error(codes.NotFound, "stock adjustment not found")
+		}
+		log.Printf("Error retrieving stock adjustment: %v", err)
+		return nil, status.Errorf(codes.Internal, "error retrieving stock adjustment: %v", err)
+	}
+	return adjustment, nil
+}
+
+// ListStockAdjustments returns adjustments oldest first, optionally restricted
+// to an inventory item (non-nil inventoryItemID) and to a set of statuses
+// (non-empty statuses). Listing the pending ones gives the approval queue.
+func (s *StockAdjustmentService) ListStockAdjustments(ctx context.Context, inventoryItemID uuid.UUID, statuses []model.AdjustmentStatus) ([]*model.StockAdjustment, error) {
+	adjustments, err := s.repo.ListStockAdjustments(ctx)
+	if err != nil {
+		log.Printf("Error listing stock adjustments: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing stock adjustments: %v", err)
+	}
+	var filtered []*model.StockAdjustment
+	for _, adjustment := range adjustments {
+		if inventoryItemID != uuid.Nil && adjustment.InventoryItemID != inventoryItemID {
+			continue
+		}
+		if len(statuses) > 0 && !containsAdjustmentStatus(statuses, adjustment.Status) {
+			continue
+		}
+		filtered = append(filtered, adjustment)
+	}
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].CreatedAt.Before(filtered[j].CreatedAt)
+	})
+	log.Println("Stock adjustments listed successfully")
+	return filtered, nil
+}
+
+// ApproveStockAdjustment applies a pending adjustment.
+func (s *StockAdjustmentService) ApproveStockAdjustment(ctx context.Context, id uuid.UUID, notes string) (*model.StockAdjustment, error) {
+	adjustment, err := s.claim(ctx, id, model.AdjustmentApplied)
+	if err != nil {
+		return nil, err
+	}
+	before := *adjustment
+	movement, err := s.post(ctx, adjustment)
+	if err != nil {
+		if releaseErr := s.repo.ReleaseStockAdjustment(ctx, id.String(), model.AdjustmentApplied); releaseErr != nil {
+			log.Printf("Error returning stock adjustment %s to pending: %v", id, releaseErr)
+		}
+		return nil, err
+	}
+	adjustment.StockMovementID = movement.ID
+	return s.review(ctx, &before, adjustment, model.AdjustmentApplied, notes)
+}
+
+// RejectStockAdjustment closes a pending adjustment without changing stock.
+func (s *StockAdjustmentService) RejectStockAdjustment(ctx context.Context, id uuid.UUID, notes string) (*model.StockAdjustment, error) {
+	adjustment, err := s.claim(ctx, id, model.AdjustmentRejected)
+	if err != nil {
+		return nil, err
+	}
+	before := *adjustment
+	return s.review(ctx, &before, adjustment, model.AdjustmentRejected, notes)
+}
+
+// claim moves a pending adjustment to target, failing when it has already
+// been reviewed.
+func (s *StockAdjustmentService) claim(ctx context.Context, id uuid.UUID, target model.AdjustmentStatus) (*model.StockAdjustment, error) {
+	adjustment, err := s.GetStockAdjustment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if adjustment.Status != model.AdjustmentPending {
+		return nil, status.Errorf(codes.FailedPrecondition, "stock adjustment is already %s", adjustment.Status)
+	}
+	claimed, err := s.repo.ClaimStockAdjustment(ctx, id.String(), target)
+	if err != nil {
+		log.Printf("Error updating stock adjustment status: %v", err)
+		return nil, status.Errorf(codes.Internal, "error updating stock adjustment status: %v", err)
+	}
+	if !claimed {
+		return nil, status.Error(codes.Aborted, "stock adjustment was reviewed concurrently")
+	}
+	return adjustment, nil
+}
+
+func (s *StockAdjustmentService) review(ctx context.Context, before, adjustment *model.StockAdjustment, target model.AdjustmentStatus, notes string) (*model.StockAdjustment, error) {
+	adjustment.Status = target
+	adjustment.ReviewedBy = actorFromContext(ctx)
+	adjustment.ReviewNotes = notes
+	adjustment.ReviewedAt = time.Now().UTC()
+	err := s.repo.SaveReview(ctx, adjustment)
+	if err != nil {
+		log.Printf("Error saving stock adjustment review: %v", err)
+		return nil, status.Errorf(codes.Internal, "error saving stock adjustment review: %v", err)
+	}
+	s.auditService.Record(ctx, model.EntityStockAdjustment, adjustment.ID, model.AuditUpdate, before, adjustment)
+	log.Printf("Stock adjustment %s moved from %s to %s", adjustment.ID, before.Status, adjustment.Status)
+	return adjustment, nil
+}
+
+// post applies an adjustment to stock with an adjustment movement.
+func (s *StockAdjustmentService) post(ctx context.Context, adjustment *model.StockAdjustment) (*model.StockMovement, error) {
+	movement := &model.StockMovement{
+		InventoryItemID: adjustment.InventoryItemID,
+		Type:            model.Adjustment,
+		Quantity:        adjustment.QuantityChange,
+		LotID:           adjustment.LotID,
+		SerialNumbers:   adjustment.SerialNumbers,
+	}
+	if adjustment.QuantityChange < 0 {
+		movement.SourceWarehouseID = adjustment.WarehouseID
+	} else {
+		movement.DestinationWarehouseID = adjustment.WarehouseID
+	}
+	return s.stockMovementService.PostStockMovement(ctx, movement)
+}
+
+// validateStockAdjustment checks the reason code and that its direction fits
+// the change: damage, theft and expiry only remove stock and found stock only
+// adds it.
+func validateStockAdjustment(adjustment *model.StockAdjustment) error {
+	if adjustment.QuantityChange == 0 {
+		return status.Error(codes.InvalidArgument, "quantity change must not be zero")
+	}
+	switch adjustment.Reason {
+	case model.AdjustmentDamage, model.AdjustmentTheft, model.AdjustmentExpiry:
+		if adjustment.QuantityChange > 0 {
+			return status.Errorf(codes.InvalidArgument, "an adjustment for %s must lower the quantity", adjustment.Reason)
+		}
+	case model.AdjustmentFound:
+		if adjustment.QuantityChange < 0 {
+			return status.Errorf(codes.InvalidArgument, "an adjustment for %s must raise the quantity", adjustment.Reason)
+		}
+	case model.AdjustmentCountCorrection:
+	case model.AdjustmentReasonUnspecified:
+		return status.Error(codes.InvalidArgument, "reason is required")
+	default:
+		return status.Errorf(codes.InvalidArgument, "unknown adjustment reason %d", adjustment.Reason)
+	}
+	return nil
+}
+
+func containsAdjustmentStatus(statuses []model.AdjustmentStatus, target model.AdjustmentStatus) bool {
+	for _, s := range statuses {
+		if s == target {
+			return true
+		}
+	}
+	return false
+}
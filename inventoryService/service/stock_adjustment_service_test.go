@@ -0,0 +1,36 @@
+package service
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventoryService/model"
+	"testing"
+)
+
+func TestValidateStockAdjustment(t *testing.T) {
+	tests := []struct {
+		name     string
+		reason   model.AdjustmentReason
+		change   model.Quantity
+		wantCode codes.Code
+	}{
+		{name: "damage lowers stock", reason: model.AdjustmentDamage, change: -model.Units(2), wantCode: codes.OK},
+		{name: "damage raising stock", reason: model.AdjustmentDamage, change: model.Units(2), wantCode: codes.InvalidArgument},
+		{name: "theft raising stock", reason: model.AdjustmentTheft, change: model.Unit, wantCode: codes.InvalidArgument},
+		{name: "expiry of a fraction", reason: model.AdjustmentExpiry, change: -model.Unit / 4, wantCode: codes.OK},
+		{name: "found raises stock", reason: model.AdjustmentFound, change: model.Unit, wantCode: codes.OK},
+		{name: "found lowering stock", reason: model.AdjustmentFound, change: -model.Unit, wantCode: codes.InvalidArgument},
+		{name: "count correction either way", reason: model.AdjustmentCountCorrection, change: -model.Unit, wantCode: codes.OK},
+		{name: "no change", reason: model.AdjustmentCountCorrection, change: 0, wantCode: codes.InvalidArgument},
+		{name: "no reason", reason: model.AdjustmentReasonUnspecified, change: model.Unit, wantCode: codes.InvalidArgument},
+		{name: "unknown reason", reason: model.AdjustmentReason(99), change: model.Unit, wantCode: codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateStockAdjustment(&model.StockAdjustment{Reason: tt.reason, QuantityChange: tt.change})
+			if got := status.Code(err); got != tt.wantCode {
+				t.Errorf("validateStockAdjustment() = %v, want code %v", err, tt.wantCode)
+			}
+		})
+	}
+}
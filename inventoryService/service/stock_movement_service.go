@@ -4,30 +4,1045 @@ import (
 	"context"
 	"errors"
 	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"inventoryService/model"
 	"inventoryService/repository"
 	"log"
+	"sort"
+	"time"
 )
 
 type StockMovementService struct {
-	repo *repository.StockMovementRepository
+	repo                 *repository.StockMovementRepository
+	itemRepo             *repository.InventoryItemRepository
+	lotRepo              *repository.LotRepository
+	serialRepo           *repository.SerialRepository
+	binStockRepo         *repository.BinStockRepository
+	locationRepo         *repository.LocationRepository
+	costLayerRepo        *repository.CostLayerRepository
+	productRepo          *repository.ProductRepository
+	capacityService      *CapacityService
+	auditService         *AuditService
+	replenishmentService *ReplenishmentService
 }
 
-func NewStockMovementService(repo *repository.StockMovementRepository) *StockMovementService {
-	return &StockMovementService{repo: repo}
+func NewStockMovementService(
+	repo *repository.StockMovementRepository,
+	itemRepo *repository.InventoryItemRepository,
+	lotRepo *repository.LotRepository,
+	serialRepo *repository.SerialRepository,
+	binStockRepo *repository.BinStockRepository,
+	locationRepo *repository.LocationRepository,
+	costLayerRepo *repository.CostLayerRepository,
+	productRepo *repository.ProductRepository,
+	capacityService *CapacityService,
+	auditService *AuditService,
+	replenishmentService *ReplenishmentService,
+) *StockMovementService {
+	return &StockMovementService{
+		repo:                 repo,
+		itemRepo:             itemRepo,
+		lotRepo:              lotRepo,
+		serialRepo:           serialRepo,
+		binStockRepo:         binStockRepo,
+		locationRepo:         locationRepo,
+		costLayerRepo:        costLayerRepo,
+		productRepo:          productRepo,
+		capacityService:      capacityService,
+		auditService:         auditService,
+		replenishmentService: replenishmentService,
+	}
 }
 
+// CreateStockMovement journals a movement entered by a client without
+// changing stock; PostStockMovement both journals and applies one. The
+// movement is checked as by validateJournaled.
 func (s *StockMovementService) CreateStockMovement(ctx context.Context, movement *model.StockMovement) (*model.StockMovement, error) {
+	if err := s.validateJournaled(ctx, movement); err != nil {
+		return nil, err
+	}
 	movement.ID = uuid.New()
 	err := s.repo.CreateStockMovement(ctx, movement)
 	if err != nil {
 		log.Printf("Error creating stock movement: %v", err)
 		return nil, err
 	}
+	s.auditService.Record(ctx, model.EntityStockMovement, movement.ID, model.AuditCreate, nil, movement)
+	s.replenishmentService.EvaluateItem(ctx, movement.InventoryItemID)
 	log.Println("Stock movement created successfully:", movement.ID)
 	return movement, nil
 }
 
+// PostStockMovement records a movement generated by the service itself, such
+// as a goods receipt, and applies it to the quantity of its inventory item:
+// additions increase it, removals and transfers decrease it and quarantine
+// movements add to its quarantined quantity instead. A transfer is posted
+// against the item in the source warehouse; the units are added to the
+// destination by a separate addition once they arrive. Adjustments carry a
+// signed quantity that is added as is, and relocations only move units
+// between bins. Unlike CreateStockMovement, which only journals a movement,
+// the stock is changed here. A movement entered in another unit of the product
+// is converted to base units first. A movement that already has an id is
+// journaled under it.
+func (s *StockMovementService) PostStockMovement(ctx context.Context, movement *model.StockMovement) (*model.StockMovement, error) {
+	if err := s.normalizeUnit(ctx, movement); err != nil {
+		return nil, err
+	}
+	if movement.Type == model.Adjustment {
+		if movement.Quantity == 0 {
+			return nil, status.Error(codes.InvalidArgument, "adjustment quantity must not be zero")
+		}
+	} else if movement.Quantity <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "movement quantity must be positive")
+	}
+	itemID := movement.InventoryItemID.String()
+	var delta model.Quantity
+	switch movement.Type {
+	case model.Addition, model.Adjustment:
+		delta = movement.Quantity
+	case model.Removal, model.Transfer:
+		delta = -movement.Quantity
+	case model.Quarantine:
+		return s.post(ctx, movement, func() error {
+			_, err := s.itemRepo.AdjustQuarantine(ctx, itemID, movement.Quantity)
+			return err
+		}, func() error {
+			_, err := s.itemRepo.AdjustQuarantine(ctx, itemID, -movement.Quantity)
+			return err
+		})
+	case model.Relocation:
+		return s.post(ctx, movement, func() error {
+			return nil
+		}, func() error {
+			return nil
+		})
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "stock movements of type %d cannot be posted", movement.Type)
+	}
+	return s.post(ctx, movement, func() error {
+		_, err := s.itemRepo.AdjustQuantity(ctx, itemID, delta)
+		return err
+	}, func() error {
+		_, err := s.itemRepo.AdjustQuantity(ctx, itemID, -delta)
+		return err
+	})
+}
+
+// PostReservedRemoval posts a removal of stock that was reserved beforehand,
+// lowering the reserved quantity of the inventory item along with its
+// on-hand quantity.
+func (s *StockMovementService) PostReservedRemoval(ctx context.Context, movement *model.StockMovement) (*model.StockMovement, error) {
+	if err := s.normalizeUnit(ctx, movement); err != nil {
+		return nil, err
+	}
+	if movement.Quantity <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "movement quantity must be positive")
+	}
+	movement.Type = model.Removal
+	itemID := movement.InventoryItemID.String()
+	return s.post(ctx, movement, func() error {
+		return s.itemRepo.ConsumeReservation(ctx, itemID, movement.Quantity)
+	}, func() error {
+		if _, err := s.itemRepo.AdjustQuantity(ctx, itemID, movement.Quantity); err != nil {
+			return err
+		}
+		_, err := s.itemRepo.AdjustReservation(ctx, itemID, movement.Quantity)
+		return err
+	})
+}
+
+// post applies a movement to stock and journals it, undoing the stock change
+// with revert if the movement cannot be saved. A movement taking stock out of
+// an item with lots is journaled as one movement per lot it draws from, as
+// chosen by splitByLot, and the first of them is returned. The units of a
+// serialized product are moved as checked by checkSerials, and the bins of the
+// item are changed as planned by planBins. Incoming stock must fit in its
+// warehouse, as checked by checkCapacity. The movement is valued and the cost
+// layers of the item changed as planned by planCost.
+func (s *StockMovementService) post(ctx context.Context, movement *model.StockMovement, apply, revert func() error) (*model.StockMovement, error) {
+	item, product, err := s.movedProduct(ctx, movement)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateQuantity("movement quantity", movement.Quantity, product); err != nil {
+		return nil, err
+	}
+	serials, err := s.checkSerials(ctx, movement, item, product)
+	if err != nil {
+		return nil, err
+	}
+	bins, err := s.planBins(ctx, movement, item, product)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkCapacity(ctx, movement, item, product); err != nil {
+		return nil, err
+	}
+	costs, err := s.planCost(ctx, movement, item, product)
+	if err != nil {
+		return nil, err
+	}
+	parts, err := s.splitByLot(ctx, movement)
+	if err != nil {
+		return nil, err
+	}
+	err = apply()
+	if err != nil {
+		if errors.Is(err, repository.ErrInventoryItemNotFound) {
+			return nil, status.Error(codes.NotFound, "inventory item not found")
+		}
+		if errors.Is(err, repository.ErrInsufficientStock) {
+			return nil, status.Errorf(codes.FailedPrecondition, "insufficient stock in inventory item %s", movement.InventoryItemID)
+		}
+		log.Printf("Error adjusting inventory item quantity: %v", err)
+		return nil, status.Errorf(codes.Internal, "error adjusting inventory item quantity: %v", err)
+	}
+
+	var drawn []*model.StockMovement
+	var placed []binChange
+	var costed []*layerChange
+	var moved []*serialMove
+	undo := func() {
+		for _, move := range moved {
+			s.restoreSerial(ctx, move)
+		}
+		for _, change := range costed {
+			s.revertLayerChange(ctx, change)
+		}
+		for _, change := range placed {
+			if _, err := s.binStockRepo.AdjustBinQuantity(ctx, item.ID.String(), change.locationID.String(), item.WarehouseID.String(), -change.delta); err != nil {
+				log.Printf("Error reverting quantity in bin %s: %v", change.locationID, err)
+			}
+		}
+		for _, part := range drawn {
+			if _, err := s.lotRepo.AdjustLotQuantity(ctx, part.LotID.String(), -lotDelta(part)); err != nil {
+				log.Printf("Error reverting quantity of lot %s: %v", part.LotID, err)
+			}
+		}
+		if err := revert(); err != nil {
+			log.Printf("Error reverting quantity of inventory item %s: %v", movement.InventoryItemID, err)
+		}
+	}
+	for _, part := range parts {
+		if part.LotID == uuid.Nil {
+			continue
+		}
+		if _, err := s.lotRepo.AdjustLotQuantity(ctx, part.LotID.String(), lotDelta(part)); err != nil {
+			undo()
+			if errors.Is(err, repository.ErrInsufficientStock) {
+				return nil, status.Errorf(codes.Aborted, "lot %s changed concurrently", part.LotID)
+			}
+			log.Printf("Error adjusting lot quantity: %v", err)
+			return nil, status.Errorf(codes.Internal, "error adjusting lot quantity: %v", err)
+		}
+		drawn = append(drawn, part)
+	}
+	for _, change := range bins {
+		if _, err := s.binStockRepo.AdjustBinQuantity(ctx, item.ID.String(), change.locationID.String(), item.WarehouseID.String(), change.delta); err != nil {
+			undo()
+			if errors.Is(err, repository.ErrInsufficientStock) {
+				return nil, status.Errorf(codes.FailedPrecondition, "bin %s does not hold %s units of inventory item %s", change.locationID, -change.delta, item.ID)
+			}
+			log.Printf("Error adjusting bin quantity: %v", err)
+			return nil, status.Errorf(codes.Internal, "error adjusting bin quantity: %v", err)
+		}
+		placed = append(placed, change)
+	}
+
+	date := movement.Date
+	if date.IsZero() {
+		date = time.Now().UTC()
+	}
+	movementIDs := make(map[string]uuid.UUID)
+	for i, part := range parts {
+		part.ID = uuid.New()
+		if i == 0 && movement.ID != uuid.Nil {
+			// The caller chose the id up front to find out later whether
+			// the movement was posted.
+			part.ID = movement.ID
+		}
+		part.Date = date
+		part.Posted = true
+		if len(parts) > 1 {
+			part.Cost = part.UnitCost.Mul(absQuantity(part.Quantity))
+			// A lot may hold part of a unit other than the base unit.
+			part.Unit = ""
+			part.UnitQuantity = 0
+		}
+		for _, number := range part.SerialNumbers {
+			movementIDs[number] = part.ID
+		}
+	}
+	for _, change := range costs {
+		if change.receives {
+			change.next.StockMovementID = parts[0].ID
+		}
+		if err := s.applyLayerChange(ctx, change, item); err != nil {
+			undo()
+			return nil, err
+		}
+		costed = append(costed, change)
+	}
+	for _, move := range serials {
+		move.next.StockMovementID = movementIDs[move.next.SerialNumber]
+		applied, err := s.serialRepo.MoveSerial(ctx, move.next, move.previous)
+		if err != nil || !applied {
+			undo()
+			if err != nil {
+				log.Printf("Error moving serial %s: %v", move.next.SerialNumber, err)
+				return nil, status.Errorf(codes.Internal, "error moving serial %s: %v", move.next.SerialNumber, err)
+			}
+			return nil, status.Errorf(codes.Aborted, "serial %s changed concurrently", move.next.SerialNumber)
+		}
+		moved = append(moved, move)
+	}
+
+	for i, part := range parts {
+		err = s.repo.CreateStockMovement(ctx, part)
+		if err != nil {
+			log.Printf("Error creating stock movement: %v", err)
+			for _, saved := range parts[:i] {
+				if deleteErr := s.repo.DeleteStockMovement(ctx, saved.ID.String()); deleteErr != nil {
+					log.Printf("Error deleting stock movement %s: %v", saved.ID, deleteErr)
+				}
+			}
+			undo()
+			return nil, status.Errorf(codes.Internal, "error creating stock movement: %v", err)
+		}
+	}
+	for _, part := range parts {
+		s.auditService.Record(ctx, model.EntityStockMovement, part.ID, model.AuditCreate, nil, part)
+		log.Println("Stock movement posted successfully:", part.ID)
+	}
+	s.recordSerialEvents(ctx, movement.Type, serials)
+	s.replenishmentService.EvaluateItem(ctx, movement.InventoryItemID)
+	return parts[0], nil
+}
+
+// validateJournaled checks a movement to be journaled without being posted
+// and works out its cost from its unit cost. Its quantity, unit and serial
+// numbers must suit its product and its stock must fit in its warehouse. A lot
+// it names is checked as splitByLot checks it for a posted movement: it must
+// belong to its inventory item, and stock taken out of it must follow
+// first-expired-first-out.
+func (s *StockMovementService) validateJournaled(ctx context.Context, movement *model.StockMovement) error {
+	item, product, err := s.movedProduct(ctx, movement)
+	if err != nil {
+		return err
+	}
+	if err := toBaseUnit(movement, product); err != nil {
+		return err
+	}
+	if err := validateQuantity("movement quantity", movement.Quantity, product); err != nil {
+		return err
+	}
+	if err := validateSerialNumbers(movement, product); err != nil {
+		return err
+	}
+	if movement.LotID != uuid.Nil {
+		if _, err := s.splitByLot(ctx, movement); err != nil {
+			return err
+		}
+	}
+	if err := s.checkCapacity(ctx, movement, item, product); err != nil {
+		return err
+	}
+	if err := validateMoney("unit cost", movement.UnitCost); err != nil {
+		return err
+	}
+	movement.Cost = movement.UnitCost.Mul(absQuantity(movement.Quantity))
+	movement.Posted = false
+	return nil
+}
+
+// checkLot checks that the lot a movement names, if any, is a lot of its
+// inventory item.
+func (s *StockMovementService) checkLot(ctx context.Context, movement *model.StockMovement) error {
+	if movement.LotID == uuid.Nil {
+		return nil
+	}
+	lot, err := s.lotRepo.GetLot(ctx, movement.LotID.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrLotNotFound) {
+			return status.Errorf(codes.NotFound, "lot %s not found", movement.LotID)
+		}
+		log.Printf("Error retrieving lot: %v", err)
+		return status.Errorf(codes.Internal, "error retrieving lot: %v", err)
+	}
+	if lot.InventoryItemID != movement.InventoryItemID {
+		return status.Errorf(codes.InvalidArgument, "lot %s does not belong to inventory item %s", lot.ID, movement.InventoryItemID)
+	}
+	return nil
+}
+
+// splitByLot returns the movements to journal for movement. Quarantine
+// movements and movements adding stock are journaled as they are. A movement
+// taking stock out of a given lot must leave no unexpired lot that expires
+// sooner, and cannot draw from an expired lot; expired stock is written off
+// with an adjustment instead. Without a lot, stock is drawn from the lots
+// expiring first and then from stock not tracked by lot. Adjustments are
+// exempt from first-expired-first-out and may draw from expired lots. The
+// serial numbers of a split movement are shared out among its parts in order.
+func (s *StockMovementService) splitByLot(ctx context.Context, movement *model.StockMovement) ([]*model.StockMovement, error) {
+	whole := *movement
+	switch movement.Type {
+	case model.Quarantine:
+		if movement.LotID != uuid.Nil {
+			return nil, status.Error(codes.InvalidArgument, "quarantine movements cannot reference a lot")
+		}
+		return []*model.StockMovement{&whole}, nil
+	case model.Relocation:
+		if movement.LotID != uuid.Nil {
+			return nil, status.Error(codes.InvalidArgument, "relocations cannot reference a lot")
+		}
+		return []*model.StockMovement{&whole}, nil
+	}
+	if lotDelta(movement) > 0 {
+		if err := s.checkLot(ctx, movement); err != nil {
+			return nil, err
+		}
+		return []*model.StockMovement{&whole}, nil
+	}
+
+	lots, err := s.lotRepo.ListLotsByInventoryItem(ctx, movement.InventoryItemID.String())
+	if err != nil {
+		log.Printf("Error listing lots: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing lots: %v", err)
+	}
+	var untracked model.Quantity
+	if movement.LotID == uuid.Nil && len(lots) > 0 {
+		item, err := s.itemRepo.GetInventoryItem(ctx, movement.InventoryItemID.String())
+		if err != nil {
+			if errors.Is(err, repository.ErrInventoryItemNotFound) {
+				return nil, status.Error(codes.NotFound, "inventory item not found")
+			}
+			log.Printf("Error retrieving inventory item: %v", err)
+			return nil, status.Errorf(codes.Internal, "error retrieving inventory item: %v", err)
+		}
+		untracked = item.Quantity
+		for _, lot := range lots {
+			untracked -= lot.Quantity
+		}
+	}
+	return drawFromLots(movement, lots, untracked, time.Now())
+}
+
+// drawFromLots splits a movement taking stock out of an item among the lots
+// of the item as splitByLot describes. untracked is the stock of the item not
+// tracked by lot, which only movements naming no lot draw from.
+func drawFromLots(movement *model.StockMovement, lots []*model.Lot, untracked model.Quantity, now time.Time) ([]*model.StockMovement, error) {
+	whole := *movement
+	fefo := movement.Type != model.Adjustment
+	quantity := -lotDelta(movement)
+
+	if movement.LotID != uuid.Nil {
+		var lot *model.Lot
+		for _, candidate := range lots {
+			if candidate.ID == movement.LotID {
+				lot = candidate
+			}
+		}
+		if lot == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "lot %s does not belong to inventory item %s", movement.LotID, movement.InventoryItemID)
+		}
+		if lot.Quantity < quantity {
+			return nil, status.Errorf(codes.FailedPrecondition, "lot %s holds only %s units", lot.LotNumber, lot.Quantity)
+		}
+		if fefo {
+			if lot.Expired(now) {
+				return nil, status.Errorf(codes.FailedPrecondition, "lot %s expired on %s and can only be written off", lot.LotNumber, lot.ExpiryDate.Format(time.DateOnly))
+			}
+			for _, other := range lots {
+				if other.Quantity > 0 && !other.Expired(now) && other.ExpiresBefore(lot) {
+					return nil, status.Errorf(codes.FailedPrecondition, "lot %s expires before lot %s and must be used first", other.LotNumber, lot.LotNumber)
+				}
+			}
+		}
+		return []*model.StockMovement{&whole}, nil
+	}
+	if len(lots) == 0 {
+		return []*model.StockMovement{&whole}, nil
+	}
+
+	sort.SliceStable(lots, func(i, j int) bool {
+		return lots[i].ExpiresBefore(lots[j])
+	})
+
+	var parts []*model.StockMovement
+	take := func(lotID uuid.UUID, units model.Quantity) {
+		part := *movement
+		part.LotID = lotID
+		part.Quantity = units
+		if len(movement.SerialNumbers) > 0 {
+			// Serialized products are counted in whole units.
+			drawn := len(movement.SerialNumbers) - quantity.Int()
+			part.SerialNumbers = movement.SerialNumbers[drawn : drawn+units.Int()]
+		}
+		if movement.Type == model.Adjustment {
+			part.Quantity = -units
+		}
+		parts = append(parts, &part)
+		quantity -= units
+	}
+	for _, lot := range lots {
+		if quantity == 0 {
+			break
+		}
+		if lot.Quantity <= 0 || fefo && lot.Expired(now) {
+			continue
+		}
+		take(lot.ID, min(lot.Quantity, quantity))
+	}
+	if quantity > 0 && untracked > 0 {
+		take(uuid.Nil, min(untracked, quantity))
+	}
+	if quantity > 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "insufficient unexpired stock in inventory item %s: %s units short", movement.InventoryItemID, quantity)
+	}
+	return parts, nil
+}
+
+// lotDelta returns the change a movement makes to the quantity of its lot.
+func lotDelta(movement *model.StockMovement) model.Quantity {
+	switch movement.Type {
+	case model.Addition, model.Adjustment:
+		return movement.Quantity
+	}
+	return -movement.Quantity
+}
+
+// binChange is the change a movement makes to the quantity held in one bin.
+type binChange struct {
+	locationID uuid.UUID
+	delta      model.Quantity
+}
+
+// planBins returns the changes a movement makes to the bins of its item.
+// Stock added to an item goes to its destination bin, or stays unplaced
+// without one. Stock taken out comes from its source bin; without one it is
+// drawn from unplaced stock first and then from the bins holding the least,
+// so that bins are emptied. A relocation moves units from its source bin, or
+// from unplaced stock, to its destination bin. Quarantine movements do not
+// touch bins. Stock put in a bin must fit in it.
+func (s *StockMovementService) planBins(ctx context.Context, movement *model.StockMovement, item *model.InventoryItem, product *model.Product) ([]binChange, error) {
+	for _, binID := range []uuid.UUID{movement.SourceBinID, movement.DestinationBinID} {
+		if binID == uuid.Nil {
+			continue
+		}
+		if movement.Type == model.Quarantine {
+			return nil, status.Error(codes.InvalidArgument, "quarantine movements cannot reference a bin")
+		}
+		bin, err := s.checkBin(ctx, binID, item)
+		if err != nil {
+			return nil, err
+		}
+		if binID == movement.DestinationBinID && movement.Quantity > 0 {
+			if err := s.capacityService.CheckBin(ctx, bin, product, movement.Quantity); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if movement.Type == model.Quarantine {
+		return nil, nil
+	}
+
+	if movement.Type == model.Relocation {
+		if movement.Quantity <= 0 {
+			return nil, status.Error(codes.InvalidArgument, "relocations must move a positive quantity")
+		}
+		if movement.SourceBinID == movement.DestinationBinID {
+			return nil, status.Error(codes.InvalidArgument, "relocations must move units between different bins")
+		}
+		var changes []binChange
+		if movement.SourceBinID == uuid.Nil {
+			bins, err := s.binStock(ctx, item)
+			if err != nil {
+				return nil, err
+			}
+			if unplaced := item.Quantity - placedQuantity(bins); unplaced < movement.Quantity {
+				return nil, status.Errorf(codes.FailedPrecondition, "inventory item %s has only %s unplaced units", item.ID, unplaced)
+			}
+		} else {
+			changes = append(changes, binChange{locationID: movement.SourceBinID, delta: -movement.Quantity})
+		}
+		if movement.DestinationBinID != uuid.Nil {
+			changes = append(changes, binChange{locationID: movement.DestinationBinID, delta: movement.Quantity})
+		}
+		return changes, nil
+	}
+
+	delta := lotDelta(movement)
+	if delta >= 0 {
+		if movement.SourceBinID != uuid.Nil {
+			return nil, status.Error(codes.InvalidArgument, "movements adding stock can only reference a destination bin")
+		}
+		if movement.DestinationBinID == uuid.Nil || delta == 0 {
+			return nil, nil
+		}
+		return []binChange{{locationID: movement.DestinationBinID, delta: delta}}, nil
+	}
+	if movement.DestinationBinID != uuid.Nil {
+		return nil, status.Error(codes.InvalidArgument, "movements taking stock out can only reference a source bin")
+	}
+	if movement.SourceBinID != uuid.Nil {
+		return []binChange{{locationID: movement.SourceBinID, delta: delta}}, nil
+	}
+	bins, err := s.binStock(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+	remaining := -delta - (item.Quantity - placedQuantity(bins))
+	sort.Slice(bins, func(i, j int) bool {
+		return bins[i].Quantity < bins[j].Quantity
+	})
+	var changes []binChange
+	for _, bin := range bins {
+		if remaining <= 0 {
+			break
+		}
+		if bin.Quantity <= 0 {
+			continue
+		}
+		units := bin.Quantity
+		if units > remaining {
+			units = remaining
+		}
+		changes = append(changes, binChange{locationID: bin.LocationID, delta: -units})
+		remaining -= units
+	}
+	return changes, nil
+}
+
+// checkBin returns the bin a movement of item names, checking that it is a
+// bin of the item's warehouse.
+func (s *StockMovementService) checkBin(ctx context.Context, binID uuid.UUID, item *model.InventoryItem) (*model.Location, error) {
+	location, err := s.locationRepo.GetLocation(ctx, binID.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrLocationNotFound) {
+			return nil, status.Errorf(codes.NotFound, "location %s not found", binID)
+		}
+		log.Printf("Error retrieving location: %v", err)
+		return nil, status.Errorf(codes.Internal, "error retrieving location: %v", err)
+	}
+	if location.Type != model.LocationBin {
+		return nil, status.Errorf(codes.InvalidArgument, "location %s is a %s, not a bin", location.ID, location.Type)
+	}
+	if location.WarehouseID != item.WarehouseID {
+		return nil, status.Errorf(codes.InvalidArgument, "bin %s is not in warehouse %s", location.ID, item.WarehouseID)
+	}
+	return location, nil
+}
+
+// checkCapacity checks that the units a movement brings into a warehouse fit
+// in it: units added to or quarantined in the item's warehouse, and units a
+// transfer sends to its destination. Units arriving from another warehouse
+// were counted against this one while in transit and are not checked again.
+func (s *StockMovementService) checkCapacity(ctx context.Context, movement *model.StockMovement, item *model.InventoryItem, product *model.Product) error {
+	warehouseID := item.WarehouseID
+	switch movement.Type {
+	case model.Addition, model.Adjustment, model.Quarantine:
+		if movement.SourceWarehouseID != uuid.Nil && movement.SourceWarehouseID != item.WarehouseID {
+			return nil
+		}
+	case model.Transfer:
+		warehouseID = movement.DestinationWarehouseID
+	default:
+		return nil
+	}
+	if movement.Quantity <= 0 {
+		return nil
+	}
+	return s.capacityService.CheckWarehouse(ctx, warehouseID, product, movement.Quantity)
+}
+
+// binStock returns the bins holding stock of an item.
+func (s *StockMovementService) binStock(ctx context.Context, item *model.InventoryItem) ([]*model.BinStock, error) {
+	bins, err := s.binStockRepo.ListBinStockByInventoryItem(ctx, item.ID.String())
+	if err != nil {
+		log.Printf("Error listing bin stock: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing bin stock: %v", err)
+	}
+	return bins, nil
+}
+
+// placedQuantity returns the units held in bins.
+func placedQuantity(bins []*model.BinStock) model.Quantity {
+	var placed model.Quantity
+	for _, bin := range bins {
+		placed += bin.Quantity
+	}
+	return placed
+}
+
+// layerChange is the change a movement makes to one cost layer of its item;
+// previous is nil for a layer the movement creates.
+type layerChange struct {
+	previous *model.CostLayer
+	next     *model.CostLayer
+	// receives is set on the layer taking in the units of a movement adding
+	// stock.
+	receives bool
+}
+
+// planCost values a movement by the costing method of its product, setting
+// its unit cost and cost, and returns the changes it makes to the cost layers
+// of its item. Stock added at a unit cost becomes a new layer, or is averaged
+// into the item's single layer under moving average; stock added without a
+// cost, such as a restocked return, comes in at the item's average unit cost.
+// Stock taken out consumes layers oldest first under FIFO and newest first
+// under LIFO; units beyond what the layers hold, such as stock that predates
+// costing, are taken at no cost. Quarantine movements and relocations carry no
+// cost.
+func (s *StockMovementService) planCost(ctx context.Context, movement *model.StockMovement, item *model.InventoryItem, product *model.Product) ([]*layerChange, error) {
+	var layers []*model.CostLayer
+	switch movement.Type {
+	case model.Addition, model.Adjustment, model.Removal, model.Transfer:
+		var err error
+		layers, err = s.costLayerRepo.ListCostLayers(ctx, item.ID.String())
+		if err != nil {
+			log.Printf("Error listing cost layers: %v", err)
+			return nil, status.Errorf(codes.Internal, "error listing cost layers: %v", err)
+		}
+	}
+	return valueMovement(movement, item, product, layers, time.Now().UTC())
+}
+
+// valueMovement values a movement against the cost layers of its item as
+// planCost describes. Layers created for stock added without a date are
+// received at now.
+func valueMovement(movement *model.StockMovement, item *model.InventoryItem, product *model.Product, layers []*model.CostLayer, now time.Time) ([]*layerChange, error) {
+	var delta model.Quantity
+	switch movement.Type {
+	case model.Addition, model.Adjustment:
+		delta = movement.Quantity
+	case model.Removal, model.Transfer:
+		delta = -movement.Quantity
+	default:
+		if !movement.UnitCost.IsZero() {
+			return nil, status.Error(codes.InvalidArgument, "quarantine movements and relocations carry no cost")
+		}
+		return nil, nil
+	}
+	if err := validateMoney("unit cost", movement.UnitCost); err != nil {
+		return nil, err
+	}
+	var changes []*layerChange
+	var remaining model.Quantity
+	var value model.Money
+	for _, layer := range layers {
+		if layer.RemainingQuantity <= 0 {
+			continue
+		}
+		next := *layer
+		changes = append(changes, &layerChange{previous: layer, next: &next})
+		remaining += layer.RemainingQuantity
+		value = value.Add(layer.UnitCost.Mul(layer.RemainingQuantity))
+	}
+	averageCost := model.Money{CurrencyCode: value.CurrencyCode}
+	if remaining > 0 {
+		averageCost = value.Div(remaining)
+	}
+	if product.CostingMethod == model.CostingMovingAverage && len(changes) > 1 {
+		// Layers left from another costing method are merged into the oldest.
+		changes[0].next.UnitCost = averageCost
+		changes[0].next.RemainingQuantity = remaining
+		for _, change := range changes[1:] {
+			change.next.RemainingQuantity = 0
+		}
+	}
+
+	if delta > 0 {
+		if movement.UnitCost.IsZero() {
+			movement.UnitCost = averageCost
+		}
+		if !movement.UnitCost.SameCurrency(value) {
+			return nil, status.Errorf(codes.InvalidArgument, "unit cost must be in %s, the currency inventory item %s is valued in", value.CurrencyCode, item.ID)
+		}
+		movement.Cost = movement.UnitCost.Mul(delta)
+		if product.CostingMethod == model.CostingMovingAverage && len(changes) > 0 {
+			layer := changes[0].next
+			layer.UnitCost = value.Add(movement.Cost).Div(remaining + delta)
+			layer.ReceivedQuantity += delta
+			layer.RemainingQuantity += delta
+			changes[0].receives = true
+		} else {
+			receivedAt := movement.Date
+			if receivedAt.IsZero() {
+				receivedAt = now
+			}
+			changes = append(changes, &layerChange{
+				next: &model.CostLayer{
+					ID:                uuid.New(),
+					InventoryItemID:   item.ID,
+					UnitCost:          movement.UnitCost,
+					ReceivedQuantity:  delta,
+					RemainingQuantity: delta,
+					ReceivedAt:        receivedAt,
+				},
+				receives: true,
+			})
+		}
+		return changedLayers(changes), nil
+	}
+
+	units := -delta
+	if product.CostingMethod == model.CostingLIFO {
+		for i, j := 0, len(changes)-1; i < j; i, j = i+1, j-1 {
+			changes[i], changes[j] = changes[j], changes[i]
+		}
+	}
+	movement.Cost = model.Money{CurrencyCode: value.CurrencyCode}
+	for _, change := range changes {
+		if units == 0 {
+			break
+		}
+		taken := change.next.RemainingQuantity
+		if taken > units {
+			taken = units
+		}
+		movement.Cost = movement.Cost.Add(change.next.UnitCost.Mul(taken))
+		change.next.RemainingQuantity -= taken
+		units -= taken
+	}
+	movement.UnitCost = movement.Cost.Div(-delta)
+	return changedLayers(changes), nil
+}
+
+// changedLayers drops the changes that leave a layer as it was.
+func changedLayers(changes []*layerChange) []*layerChange {
+	var changed []*layerChange
+	for _, change := range changes {
+		if change.previous == nil || *change.next != *change.previous {
+			changed = append(changed, change)
+		}
+	}
+	return changed
+}
+
+// applyLayerChange writes a planned change to a cost layer, failing if the
+// layer has changed since it was planned.
+func (s *StockMovementService) applyLayerChange(ctx context.Context, change *layerChange, item *model.InventoryItem) error {
+	if change.previous == nil {
+		if err := s.costLayerRepo.SaveCostLayer(ctx, change.next); err != nil {
+			log.Printf("Error creating cost layer: %v", err)
+			return status.Errorf(codes.Internal, "error creating cost layer: %v", err)
+		}
+		return nil
+	}
+	applied, err := s.costLayerRepo.UpdateCostLayer(ctx, change.next, change.previous)
+	if err != nil {
+		log.Printf("Error updating cost layer: %v", err)
+		return status.Errorf(codes.Internal, "error updating cost layer: %v", err)
+	}
+	if !applied {
+		return status.Errorf(codes.Aborted, "cost layers of inventory item %s changed concurrently", item.ID)
+	}
+	return nil
+}
+
+// revertLayerChange undoes a change to a cost layer made by a movement that
+// could not be completed.
+func (s *StockMovementService) revertLayerChange(ctx context.Context, change *layerChange) {
+	var err error
+	if change.previous == nil {
+		err = s.costLayerRepo.DeleteCostLayer(ctx, change.next)
+	} else {
+		err = s.costLayerRepo.SaveCostLayer(ctx, change.previous)
+	}
+	if err != nil {
+		log.Printf("Error reverting cost layer %s: %v", change.next.ID, err)
+	}
+}
+
+// serialMove is the change a movement makes to one unit of a serialized
+// product; previous is nil for a unit entering stock for the first time.
+type serialMove struct {
+	previous *model.Serial
+	next     *model.Serial
+}
+
+// normalizeUnit converts a movement entered in another unit than the base
+// unit of its product, as toBaseUnit does.
+func (s *StockMovementService) normalizeUnit(ctx context.Context, movement *model.StockMovement) error {
+	if movement.Unit == "" {
+		return nil
+	}
+	_, product, err := s.movedProduct(ctx, movement)
+	if err != nil {
+		return err
+	}
+	return toBaseUnit(movement, product)
+}
+
+// toBaseUnit sets the quantity of a movement entered in a unit of its product
+// to the number of base units in its unit quantity. The unit cost of a
+// movement is always that of one base unit.
+func toBaseUnit(movement *model.StockMovement, product *model.Product) error {
+	if movement.Unit == "" {
+		return nil
+	}
+	quantity, err := product.ToBaseQuantity(movement.UnitQuantity, movement.Unit)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	movement.Quantity = quantity
+	return nil
+}
+
+// movedProduct returns the inventory item of a movement and its product.
+func (s *StockMovementService) movedProduct(ctx context.Context, movement *model.StockMovement) (*model.InventoryItem, *model.Product, error) {
+	item, err := s.itemRepo.GetInventoryItem(ctx, movement.InventoryItemID.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrInventoryItemNotFound) {
+			return nil, nil, status.Error(codes.NotFound, "inventory item not found")
+		}
+		log.Printf("Error retrieving inventory item: %v", err)
+		return nil, nil, status.Errorf(codes.Internal, "error retrieving inventory item: %v", err)
+	}
+	product, err := s.productRepo.GetProduct(ctx, item.ProductID.String())
+	if err != nil {
+		log.Printf("Error retrieving product: %v", err)
+		return nil, nil, status.Errorf(codes.Internal, "error retrieving product: %v", err)
+	}
+	return item, product, nil
+}
+
+// validateSerialNumbers checks that a movement names one distinct serial
+// number per unit when its product is serialized. Additions, removals and
+// transfers must name them; quarantine movements and adjustments may.
+func validateSerialNumbers(movement *model.StockMovement, product *model.Product) error {
+	if !product.Serialized {
+		if len(movement.SerialNumbers) > 0 {
+			return status.Errorf(codes.InvalidArgument, "product %s is not serialized", product.ID)
+		}
+		return nil
+	}
+	units := movement.Quantity
+	if units < 0 {
+		units = -units
+	}
+	switch movement.Type {
+	case model.Quarantine, model.Adjustment:
+		if len(movement.SerialNumbers) == 0 {
+			return nil
+		}
+	}
+	if model.Units(len(movement.SerialNumbers)) != units {
+		return status.Errorf(codes.InvalidArgument, "product %s is serialized: %d serial numbers given for %s units", product.ID, len(movement.SerialNumbers), units)
+	}
+	seen := make(map[string]bool)
+	for _, number := range movement.SerialNumbers {
+		if number == "" {
+			return status.Error(codes.InvalidArgument, "serial numbers cannot be empty")
+		}
+		if seen[number] {
+			return status.Errorf(codes.InvalidArgument, "serial number %s is given more than once", number)
+		}
+		seen[number] = true
+	}
+	return nil
+}
+
+// checkSerials returns the changes a movement makes to the units it names.
+// Units entering an item must be new, have left stock before or be in
+// transit to the item's warehouse; units leaving an item must be in stock
+// there. A transfer leaves its units in transit to the destination.
+func (s *StockMovementService) checkSerials(ctx context.Context, movement *model.StockMovement, item *model.InventoryItem, product *model.Product) ([]*serialMove, error) {
+	if movement.Type == model.Relocation {
+		if len(movement.SerialNumbers) > 0 {
+			return nil, status.Error(codes.InvalidArgument, "relocations cannot reference serial numbers")
+		}
+		return nil, nil
+	}
+	if err := validateSerialNumbers(movement, product); err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	var moves []*serialMove
+	for _, number := range movement.SerialNumbers {
+		previous, err := s.serialRepo.GetSerial(ctx, product.ID.String(), number)
+		if err != nil {
+			if !errors.Is(err, repository.ErrSerialNotFound) {
+				log.Printf("Error retrieving serial: %v", err)
+				return nil, status.Errorf(codes.Internal, "error retrieving serial: %v", err)
+			}
+			previous = nil
+		}
+		next := &model.Serial{
+			SerialNumber: number,
+			ProductID:    product.ID,
+			WarehouseID:  item.WarehouseID,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		}
+		if previous != nil {
+			next.CreatedAt = previous.CreatedAt
+		}
+
+		if lotDelta(movement) > 0 || movement.Type == model.Quarantine {
+			if previous != nil && previous.Status != model.SerialRemoved &&
+				(previous.Status != model.SerialInTransit || previous.WarehouseID != item.WarehouseID) {
+				return nil, status.Errorf(codes.FailedPrecondition, "serial %s is already %s in warehouse %s", number, previous.Status, previous.WarehouseID)
+			}
+			next.InventoryItemID = item.ID
+			next.Status = model.SerialInStock
+			if movement.Type == model.Quarantine {
+				next.Status = model.SerialQuarantined
+			}
+		} else {
+			if previous == nil || previous.Status != model.SerialInStock || previous.InventoryItemID != item.ID {
+				return nil, status.Errorf(codes.FailedPrecondition, "serial %s is not in stock in inventory item %s", number, item.ID)
+			}
+			next.Status = model.SerialRemoved
+			if movement.Type == model.Transfer {
+				next.Status = model.SerialInTransit
+				next.WarehouseID = movement.DestinationWarehouseID
+			}
+		}
+		moves = append(moves, &serialMove{previous: previous, next: next})
+	}
+	return moves, nil
+}
+
+// restoreSerial undoes a move of a unit made by a movement that could not be
+// completed.
+func (s *StockMovementService) restoreSerial(ctx context.Context, move *serialMove) {
+	var err error
+	if move.previous == nil {
+		err = s.serialRepo.DeleteSerial(ctx, move.next.ProductID.String(), move.next.SerialNumber)
+	} else {
+		err = s.serialRepo.RestoreSerial(ctx, move.previous)
+	}
+	if err != nil {
+		log.Printf("Error restoring serial %s: %v", move.next.SerialNumber, err)
+	}
+}
+
+// recordSerialEvents adds the moves of a posted movement to the history of
+// its units. A failure is logged rather than returned since the stock has
+// already moved.
+func (s *StockMovementService) recordSerialEvents(ctx context.Context, movementType model.StockMovementType, moves []*serialMove) {
+	actor := actorFromContext(ctx)
+	for _, move := range moves {
+		event := &model.SerialEvent{
+			ID:              uuid.New(),
+			SerialNumber:    move.next.SerialNumber,
+			ProductID:       move.next.ProductID,
+			StockMovementID: move.next.StockMovementID,
+			MovementType:    movementType,
+			Status:          move.next.Status,
+			InventoryItemID: move.next.InventoryItemID,
+			WarehouseID:     move.next.WarehouseID,
+			Actor:           actor,
+			RecordedAt:      move.next.UpdatedAt,
+		}
+		if err := s.serialRepo.CreateSerialEvent(ctx, event); err != nil {
+			log.Printf("Error recording history of serial %s: %v", event.SerialNumber, err)
+		}
+	}
+}
+
 func (s *StockMovementService) GetStockMovement(ctx context.Context, id uuid.UUID) (*model.StockMovement, error) {
 	movement, err := s.repo.GetStockMovement(ctx, id.String())
 	if err != nil {
@@ -40,6 +1055,21 @@ func (s *StockMovementService) GetStockMovement(ctx context.Context, id uuid.UUI
 	return movement, nil
 }
 
+// MovementPosted reports whether the movement with the given id has been
+// journaled. Callers that post in several steps choose the ids of their
+// movements up front and use it to skip movements already posted on retry.
+func (s *StockMovementService) MovementPosted(ctx context.Context, id uuid.UUID) (bool, error) {
+	_, err := s.repo.GetStockMovement(ctx, id.String())
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, repository.ErrStockMovementNotFound) {
+		return false, nil
+	}
+	log.Printf("Error retrieving stock movement: %v", err)
+	return false, status.Errorf(codes.Internal, "error retrieving stock movement: %v", err)
+}
+
 func (s *StockMovementService) ListStockMovements(ctx context.Context) ([]*model.StockMovement, error) {
 	movements, err := s.repo.ListStockMovements(ctx)
 	if err != nil {
@@ -50,22 +1080,61 @@ func (s *StockMovementService) ListStockMovements(ctx context.Context) ([]*model
 	return movements, nil
 }
 
+// UpdateStockMovement rewrites a movement journaled by CreateStockMovement,
+// checking it again as by validateJournaled. Posted movements changed stock
+// and cannot be rewritten; they are corrected with a compensating movement
+// or adjustment instead.
 func (s *StockMovementService) UpdateStockMovement(ctx context.Context, movement *model.StockMovement) error {
-	err := s.repo.UpdateStockMovement(ctx, movement)
+	before, err := s.journaledMovement(ctx, movement.ID)
+	if err != nil {
+		return err
+	}
+	if err := s.validateJournaled(ctx, movement); err != nil {
+		return err
+	}
+	err = s.repo.UpdateStockMovement(ctx, movement)
 	if err != nil {
 		log.Printf("Error updating stock movement: %v", err)
 		return err
 	}
+	s.auditService.Record(ctx, model.EntityStockMovement, movement.ID, model.AuditUpdate, before, movement)
+	s.replenishmentService.EvaluateItem(ctx, movement.InventoryItemID)
 	log.Println("Stock movement updated successfully:", movement.ID)
 	return nil
 }
 
+// DeleteStockMovement deletes a movement journaled by CreateStockMovement.
+// Posted movements are kept: goods receipts and return inspections look them
+// up to avoid posting them twice.
 func (s *StockMovementService) DeleteStockMovement(ctx context.Context, id uuid.UUID) error {
-	err := s.repo.DeleteStockMovement(ctx, id.String())
+	before, err := s.journaledMovement(ctx, id)
+	if err != nil {
+		return err
+	}
+	err = s.repo.DeleteStockMovement(ctx, id.String())
 	if err != nil {
 		log.Printf("Error deleting stock movement: %v", err)
 		return err
 	}
+	s.auditService.Record(ctx, model.EntityStockMovement, id, model.AuditDelete, before, nil)
+	s.replenishmentService.EvaluateItem(ctx, before.InventoryItemID)
 	log.Println("Stock movement deleted successfully:", id)
 	return nil
 }
+
+// journaledMovement returns the movement with the given id, which must have
+// been journaled without being posted.
+func (s *StockMovementService) journaledMovement(ctx context.Context, id uuid.UUID) (*model.StockMovement, error) {
+	movement, err := s.repo.GetStockMovement(ctx, id.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrStockMovementNotFound) {
+			return nil, status.Error(codes.NotFound, "stock movement not found")
+		}
+		log.Printf("Error retrieving stock movement: %v", err)
+		return nil, status.Errorf(codes.Internal, "error retrieving stock movement: %v", err)
+	}
+	if movement.Posted {
+		return nil, status.Errorf(codes.FailedPrecondition, "stock movement %s has been posted and can only be corrected by another movement", id)
+	}
+	return movement, nil
+}
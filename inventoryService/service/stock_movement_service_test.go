@@ -0,0 +1,282 @@
+package service
+
+import (
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventoryService/model"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDrawFromLots(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	expired := &model.Lot{ID: uuid.New(), LotNumber: "EXPIRED", ExpiryDate: now.AddDate(0, 0, -1), Quantity: model.Units(5)}
+	soon := &model.Lot{ID: uuid.New(), LotNumber: "SOON", ExpiryDate: now.AddDate(0, 1, 0), Quantity: model.Units(3)}
+	later := &model.Lot{ID: uuid.New(), LotNumber: "LATER", ExpiryDate: now.AddDate(1, 0, 0), Quantity: model.Units(4)}
+	never := &model.Lot{ID: uuid.New(), LotNumber: "NEVER", Quantity: model.Units(2)}
+
+	// part is a movement drawn from a lot, or from untracked stock for
+	// uuid.Nil.
+	type part struct {
+		lotID    uuid.UUID
+		quantity model.Quantity
+		serials  []string
+	}
+	tests := []struct {
+		name      string
+		movement  model.StockMovement
+		lots      []*model.Lot
+		untracked model.Quantity
+		want      []part
+		wantCode  codes.Code
+	}{
+		{
+			name:     "without lots the movement is journaled whole",
+			movement: model.StockMovement{Type: model.Removal, Quantity: model.Units(4)},
+			want:     []part{{quantity: model.Units(4)}},
+		},
+		{
+			name:      "draws from the lots expiring first, skipping expired ones",
+			movement:  model.StockMovement{Type: model.Removal, Quantity: model.Units(5)},
+			lots:      []*model.Lot{never, later, expired, soon},
+			untracked: model.Units(1),
+			want:      []part{{lotID: soon.ID, quantity: model.Units(3)}, {lotID: later.ID, quantity: model.Units(2)}},
+		},
+		{
+			name:      "draws from untracked stock once the lots run out",
+			movement:  model.StockMovement{Type: model.Transfer, Quantity: model.Units(10)},
+			lots:      []*model.Lot{later, soon, never},
+			untracked: model.Units(2),
+			want: []part{
+				{lotID: soon.ID, quantity: model.Units(3)},
+				{lotID: later.ID, quantity: model.Units(4)},
+				{lotID: never.ID, quantity: model.Units(2)},
+				{lotID: uuid.Nil, quantity: model.Units(1)},
+			},
+		},
+		{
+			name:      "shares out serial numbers in order",
+			movement:  model.StockMovement{Type: model.Removal, Quantity: model.Units(4), SerialNumbers: []string{"A", "B", "C", "D"}},
+			lots:      []*model.Lot{later, soon},
+			untracked: 0,
+			want: []part{
+				{lotID: soon.ID, quantity: model.Units(3), serials: []string{"A", "B", "C"}},
+				{lotID: later.ID, quantity: model.Units(1), serials: []string{"D"}},
+			},
+		},
+		{
+			name:     "adjustments draw from expired lots and stay negative",
+			movement: model.StockMovement{Type: model.Adjustment, Quantity: -model.Units(6)},
+			lots:     []*model.Lot{soon, expired},
+			want:     []part{{lotID: expired.ID, quantity: -model.Units(5)}, {lotID: soon.ID, quantity: -model.Units(1)}},
+		},
+		{
+			name:      "short of unexpired stock",
+			movement:  model.StockMovement{Type: model.Removal, Quantity: model.Units(6)},
+			lots:      []*model.Lot{expired, soon},
+			untracked: model.Units(2),
+			wantCode:  codes.FailedPrecondition,
+		},
+		{
+			name:     "a named lot expiring first",
+			movement: model.StockMovement{Type: model.Removal, Quantity: model.Units(2), LotID: soon.ID},
+			lots:     []*model.Lot{soon, later},
+			want:     []part{{lotID: soon.ID, quantity: model.Units(2)}},
+		},
+		{
+			name:     "a named lot while another expires sooner",
+			movement: model.StockMovement{Type: model.Removal, Quantity: model.Units(2), LotID: later.ID},
+			lots:     []*model.Lot{soon, later},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name:     "a named lot that has expired",
+			movement: model.StockMovement{Type: model.Removal, Quantity: model.Units(1), LotID: expired.ID},
+			lots:     []*model.Lot{expired},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name:     "a named lot holding too little",
+			movement: model.StockMovement{Type: model.Removal, Quantity: model.Units(4), LotID: soon.ID},
+			lots:     []*model.Lot{soon},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name:     "a named lot of another item",
+			movement: model.StockMovement{Type: model.Removal, Quantity: model.Units(1), LotID: uuid.New()},
+			lots:     []*model.Lot{soon},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lots := append([]*model.Lot{}, tt.lots...)
+			movement := tt.movement
+			got, err := drawFromLots(&movement, lots, tt.untracked, now)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("drawFromLots() error = %v, want code %v", err, tt.wantCode)
+			}
+			if err != nil {
+				return
+			}
+			var parts []part
+			for _, m := range got {
+				parts = append(parts, part{lotID: m.LotID, quantity: m.Quantity, serials: m.SerialNumbers})
+			}
+			if !reflect.DeepEqual(parts, tt.want) {
+				t.Errorf("drawFromLots() = %+v, want %+v", parts, tt.want)
+			}
+		})
+	}
+}
+
+func TestValueMovement(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	usd := func(amount string) model.Money {
+		m, err := model.ParseMoney(amount, "USD")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return m
+	}
+	item := &model.InventoryItem{ID: uuid.New()}
+	layer := func(received model.Quantity, remaining model.Quantity, unitCost string) *model.CostLayer {
+		return &model.CostLayer{ID: uuid.New(), InventoryItemID: item.ID, UnitCost: usd(unitCost), ReceivedQuantity: received, RemainingQuantity: remaining}
+	}
+	older := layer(model.Units(3), model.Units(3), "1.00")
+	newer := layer(model.Units(4), model.Units(4), "2.00")
+	spent := layer(model.Units(5), 0, "9.00")
+
+	// change is a layer as a movement leaves it; created is set on layers
+	// the movement creates.
+	type change struct {
+		created   bool
+		unitCost  model.Money
+		remaining model.Quantity
+	}
+	tests := []struct {
+		name         string
+		method       model.CostingMethod
+		movement     model.StockMovement
+		layers       []*model.CostLayer
+		wantCost     model.Money
+		wantUnitCost model.Money
+		want         []change
+		wantCode     codes.Code
+	}{
+		{
+			name:         "FIFO takes the oldest layer first",
+			method:       model.CostingFIFO,
+			movement:     model.StockMovement{Type: model.Removal, Quantity: model.Units(5)},
+			layers:       []*model.CostLayer{spent, older, newer},
+			wantCost:     usd("7.00"),
+			wantUnitCost: usd("1.40"),
+			want:         []change{{unitCost: usd("1.00")}, {unitCost: usd("2.00"), remaining: model.Units(2)}},
+		},
+		{
+			name:         "LIFO takes the newest layer first",
+			method:       model.CostingLIFO,
+			movement:     model.StockMovement{Type: model.Transfer, Quantity: model.Units(5)},
+			layers:       []*model.CostLayer{older, newer},
+			wantCost:     usd("9.00"),
+			wantUnitCost: usd("1.80"),
+			want:         []change{{unitCost: usd("2.00")}, {unitCost: usd("1.00"), remaining: model.Units(2)}},
+		},
+		{
+			name:         "units beyond the layers are taken at no cost",
+			method:       model.CostingFIFO,
+			movement:     model.StockMovement{Type: model.Removal, Quantity: model.Units(8)},
+			layers:       []*model.CostLayer{older, newer},
+			wantCost:     usd("11.00"),
+			wantUnitCost: usd("1.375"),
+			want:         []change{{unitCost: usd("1.00")}, {unitCost: usd("2.00")}},
+		},
+		{
+			name:         "FIFO receives stock into a new layer",
+			method:       model.CostingFIFO,
+			movement:     model.StockMovement{Type: model.Addition, Quantity: model.Units(2), UnitCost: usd("3.00")},
+			layers:       []*model.CostLayer{older},
+			wantCost:     usd("6.00"),
+			wantUnitCost: usd("3.00"),
+			want:         []change{{created: true, unitCost: usd("3.00"), remaining: model.Units(2)}},
+		},
+		{
+			name:         "stock added without a cost comes in at the average",
+			method:       model.CostingFIFO,
+			movement:     model.StockMovement{Type: model.Addition, Quantity: model.Units(1)},
+			layers:       []*model.CostLayer{older, newer},
+			wantCost:     usd("1.571428571"),
+			wantUnitCost: usd("1.571428571"),
+			want:         []change{{created: true, unitCost: usd("1.571428571"), remaining: model.Units(1)}},
+		},
+		{
+			name:         "moving average merges stock into its layer",
+			method:       model.CostingMovingAverage,
+			movement:     model.StockMovement{Type: model.Addition, Quantity: model.Units(2), UnitCost: usd("4.00")},
+			layers:       []*model.CostLayer{newer},
+			wantCost:     usd("8.00"),
+			wantUnitCost: usd("4.00"),
+			want:         []change{{unitCost: usd("2.666666667"), remaining: model.Units(6)}},
+		},
+		{
+			name:         "moving average merges layers left from another method",
+			method:       model.CostingMovingAverage,
+			movement:     model.StockMovement{Type: model.Removal, Quantity: model.Units(1)},
+			layers:       []*model.CostLayer{older, newer},
+			wantCost:     usd("1.571428571"),
+			wantUnitCost: usd("1.571428571"),
+			want:         []change{{unitCost: usd("1.571428571"), remaining: model.Units(6)}, {unitCost: usd("2.00")}},
+		},
+		{
+			name:     "a unit cost in another currency",
+			method:   model.CostingFIFO,
+			movement: model.StockMovement{Type: model.Addition, Quantity: model.Units(1), UnitCost: model.Money{CurrencyCode: "EUR", Units: 1}},
+			layers:   []*model.CostLayer{older},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "a negative unit cost",
+			method:   model.CostingFIFO,
+			movement: model.StockMovement{Type: model.Addition, Quantity: model.Units(1), UnitCost: usd("-1.00")},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "a quarantine movement with a cost",
+			method:   model.CostingFIFO,
+			movement: model.StockMovement{Type: model.Quarantine, Quantity: model.Units(1), UnitCost: usd("1.00")},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "a relocation carries no cost",
+			method:   model.CostingFIFO,
+			movement: model.StockMovement{Type: model.Relocation, Quantity: model.Units(1)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			movement := tt.movement
+			got, err := valueMovement(&movement, item, &model.Product{CostingMethod: tt.method}, tt.layers, now)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("valueMovement() error = %v, want code %v", err, tt.wantCode)
+			}
+			if err != nil {
+				return
+			}
+			if movement.Cost.Cmp(tt.wantCost) != 0 || movement.UnitCost.Cmp(tt.wantUnitCost) != 0 {
+				t.Errorf("valueMovement() cost = %v at %v, want %v at %v", movement.Cost, movement.UnitCost, tt.wantCost, tt.wantUnitCost)
+			}
+			var changes []change
+			for _, c := range got {
+				changes = append(changes, change{created: c.previous == nil, unitCost: c.next.UnitCost, remaining: c.next.RemainingQuantity})
+				if c.previous == nil && !c.next.ReceivedAt.Equal(now) {
+					t.Errorf("valueMovement() created a layer received at %v, want %v", c.next.ReceivedAt, now)
+				}
+			}
+			if !reflect.DeepEqual(changes, tt.want) {
+				t.Errorf("valueMovement() = %+v, want %+v", changes, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventoryService/model"
+	"inventoryService/repository"
+	"log"
+	"sort"
+	"strings"
+)
+
+type SupplierProductService struct {
+	repo         *repository.SupplierProductRepository
+	supplierRepo *repository.SupplierRepository
+	productRepo  *repository.ProductRepository
+	auditService *AuditService
+}
+
+func NewSupplierProductService(repo *repository.SupplierProductRepository, supplierRepo *repository.SupplierRepository, productRepo *repository.ProductRepository, auditService *AuditService) *SupplierProductService {
+	return &SupplierProductService{repo: repo, supplierRepo: supplierRepo, productRepo: productRepo, auditService: auditService}
+}
+
+// CreateSupplierProduct adds a product to a supplier's catalog. A supplier can
+// list a product only once.
+func (s *SupplierProductService) CreateSupplierProduct(ctx context.Context, sp *model.SupplierProduct) (*model.SupplierProduct, error) {
+	if err := validateSupplierProduct(sp); err != nil {
+		return nil, err
+	}
+	exists, err := s.supplierRepo.ExistsByUUID(ctx, sp.SupplierID)
+	if err != nil {
+		log.Printf("Error checking supplier existence: %v", err)
+		return nil, status.Errorf(codes.Internal, "error checking supplier existence: %v", err)
+	}
+	if !exists {
+		return nil, status.Error(codes.NotFound, "supplier not found")
+	}
+	exists, err = s.productRepo.ProductExists(ctx, sp.ProductID.String())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error checking product existence: %v", err)
+	}
+	if !exists {
+		return nil, status.Error(codes.NotFound, "product not found")
+	}
+	catalog, err := s.repo.ListSupplierProductsBySupplier(ctx, sp.SupplierID.String())
+	if err != nil {
+		log.Printf("Error listing supplier products: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing supplier products: %v", err)
+	}
+	for _, existing := range catalog {
+		if existing.ProductID == sp.ProductID {
+			return nil, status.Errorf(codes.AlreadyExists, "supplier already lists product %s as %s", sp.ProductID, existing.ID)
+		}
+	}
+
+	sp.ID = uuid.New()
+	err = s.repo.CreateSupplierProduct(ctx, sp)
+	if err != nil {
+		log.Printf("Error creating supplier product: %v", err)
+		return nil, status.Errorf(codes.Internal, "error creating supplier product: %v", err)
+	}
+	s.auditService.Record(ctx, model.EntitySupplierProduct, sp.ID, model.AuditCreate, nil, sp)
+	if sp.Preferred {
+		s.clearOtherPreferred(ctx, sp)
+	}
+	log.Println("Supplier product created successfully:", sp.ID)
+	return sp, nil
+}
+
+func (s *SupplierProductService) GetSupplierProduct(ctx context.Context, id uuid.UUID) (*model.SupplierProduct, error) {
+	sp, err := s.repo.GetSupplierProduct(ctx, id.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrSupplierProductNotFound) {
+			return nil, status.Error(codes.NotFound, "supplier product not found")
+		}
+		log.Printf("Error retrieving supplier product: %v", err)
+		return nil, status.Errorf(codes.Internal, "error retrieving supplier product: %v", err)
+	}
+	return sp, nil
+}
+
+// UpdateSupplierProduct changes the terms of a catalog entry. The supplier and
+// product of an entry cannot be changed.
+func (s *SupplierProductService) UpdateSupplierProduct(ctx context.Context, sp *model.SupplierProduct) (*model.SupplierProduct, error) {
+	before, err := s.GetSupplierProduct(ctx, sp.ID)
+	if err != nil {
+		return nil, err
+	}
+	sp.SupplierID = before.SupplierID
+	sp.ProductID = before.ProductID
+	if err := validateSupplierProduct(sp); err != nil {
+		return nil, err
+	}
+	err = s.repo.UpdateSupplierProduct(ctx, sp)
+	if err != nil {
+		log.Printf("Error updating supplier product: %v", err)
+		return nil, status.Errorf(codes.Internal, "error updating supplier product: %v", err)
+	}
+	s.auditService.Record(ctx, model.EntitySupplierProduct, sp.ID, model.AuditUpdate, before, sp)
+	if sp.Preferred && !before.Preferred {
+		s.clearOtherPreferred(ctx, sp)
+	}
+	log.Println("Supplier product updated successfully:", sp.ID)
+	return sp, nil
+}
+
+func (s *SupplierProductService) DeleteSupplierProduct(ctx context.Context, id uuid.UUID) error {
+	before, err := s.GetSupplierProduct(ctx, id)
+	if err != nil {
+		return err
+	}
+	err = s.repo.DeleteSupplierProduct(ctx, id.String())
+	if err != nil {
+		log.Printf("Error deleting supplier product: %v", err)
+		return status.Errorf(codes.Internal, "error deleting supplier product: %v", err)
+	}
+	s.auditService.Record(ctx, model.EntitySupplierProduct, id, model.AuditDelete, before, nil)
+	log.Println("Supplier product deleted successfully:", id)
+	return nil
+}
+
+// ListSuppliersForProduct returns every offer for a product, best source
+// first: the preferred supplier, then by unit cost and lead time. Offers in
+// different currencies are not compared on cost and come by currency code.
+func (s *SupplierProductService) ListSuppliersForProduct(ctx context.Context, productID uuid.UUID) ([]*model.SupplierProduct, error) {
+	offers, err := s.repo.ListSupplierProductsByProduct(ctx, productID.String())
+	if err != nil {
+		log.Printf("Error listing suppliers for product: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing suppliers for product: %v", err)
+	}
+	sort.Slice(offers, func(i, j int) bool {
+		if offers[i].Preferred != offers[j].Preferred {
+			return offers[i].Preferred
+		}
+		if offers[i].UnitCost.CurrencyCode != offers[j].UnitCost.CurrencyCode {
+			return offers[i].UnitCost.CurrencyCode < offers[j].UnitCost.CurrencyCode
+		}
+		if cmp := offers[i].UnitCost.Cmp(offers[j].UnitCost); cmp != 0 {
+			return cmp < 0
+		}
+		return offers[i].LeadTimeDays < offers[j].LeadTimeDays
+	})
+	log.Println("Suppliers for product listed successfully:", productID)
+	return offers, nil
+}
+
+// ListProductsForSupplier returns the catalog of a supplier ordered by the
+// supplier's SKU.
+func (s *SupplierProductService) ListProductsForSupplier(ctx context.Context, supplierID uuid.UUID) ([]*model.SupplierProduct, error) {
+	catalog, err := s.repo.ListSupplierProductsBySupplier(ctx, supplierID.String())
+	if err != nil {
+		log.Printf("Error listing products for supplier: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing products for supplier: %v", err)
+	}
+	sort.Slice(catalog, func(i, j int) bool {
+		return catalog[i].SupplierSKU < catalog[j].SupplierSKU
+	})
+	log.Println("Products for supplier listed successfully:", supplierID)
+	return catalog, nil
+}
+
+// clearOtherPreferred unsets the preferred flag of every other offer for the
+// product of sp. Failures are logged; the new preference has already been
+// saved.
+func (s *SupplierProductService) clearOtherPreferred(ctx context.Context, sp *model.SupplierProduct) {
+	offers, err := s.repo.ListSupplierProductsByProduct(ctx, sp.ProductID.String())
+	if err != nil {
+		log.Printf("Error listing suppliers for product %s: %v", sp.ProductID, err)
+		return
+	}
+	for _, offer := range offers {
+		if offer.ID == sp.ID || !offer.Preferred {
+			continue
+		}
+		before := *offer
+		offer.Preferred = false
+		if err := s.repo.SetPreferred(ctx, offer.ID.String(), false); err != nil {
+			log.Printf("Error clearing preferred flag of supplier product %s: %v", offer.ID, err)
+			continue
+		}
+		s.auditService.Record(ctx, model.EntitySupplierProduct, offer.ID, model.AuditUpdate, &before, offer)
+	}
+}
+
+func validateSupplierProduct(sp *model.SupplierProduct) error {
+	sp.UnitCost.CurrencyCode = strings.ToUpper(strings.TrimSpace(sp.UnitCost.CurrencyCode))
+	if len(sp.UnitCost.CurrencyCode) != 3 {
+		return status.Error(codes.InvalidArgument, "currency must be a three-letter ISO 4217 code")
+	}
+	if err := validateMoney("unit cost", sp.UnitCost); err != nil {
+		return err
+	}
+	if sp.MinimumOrderQuantity < 0 || sp.LeadTimeDays < 0 {
+		return status.Error(codes.InvalidArgument, "minimum order quantity and lead time cannot be negative")
+	}
+	if sp.PackSize < 0 {
+		return status.Error(codes.InvalidArgument, "pack size cannot be negative")
+	}
+	if sp.PackSize == 0 {
+		sp.PackSize = 1
+	}
+	return nil
+}
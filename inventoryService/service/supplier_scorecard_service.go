@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventoryService/model"
+	"inventoryService/repository"
+	"log"
+	"sort"
+	"time"
+)
+
+// defaultScorecardWindow is the period scored when the caller gives no start.
+const defaultScorecardWindow = 90 * 24 * time.Hour
+
+// Weights of the rates in SupplierScorecard.Score.
+const (
+	onTimeWeight = 0.4
+	fillWeight   = 0.35
+	damageWeight = 0.25
+)
+
+// SupplierScorecardService scores suppliers from their purchase orders and
+// goods receipts.
+type SupplierScorecardService struct {
+	supplierRepo        *repository.SupplierRepository
+	purchaseOrderRepo   *repository.PurchaseOrderRepository
+	goodsReceiptRepo    *repository.GoodsReceiptRepository
+	supplierProductRepo *repository.SupplierProductRepository
+}
+
+func NewSupplierScorecardService(
+	supplierRepo *repository.SupplierRepository,
+	purchaseOrderRepo *repository.PurchaseOrderRepository,
+	goodsReceiptRepo *repository.GoodsReceiptRepository,
+	supplierProductRepo *repository.SupplierProductRepository,
+) *SupplierScorecardService {
+	return &SupplierScorecardService{
+		supplierRepo:        supplierRepo,
+		purchaseOrderRepo:   purchaseOrderRepo,
+		goodsReceiptRepo:    goodsReceiptRepo,
+		supplierProductRepo: supplierProductRepo,
+	}
+}
+
+func (s *SupplierScorecardService) GetSupplierScorecard(ctx context.Context, supplierID uuid.UUID, from, to time.Time) (*model.SupplierScorecard, error) {
+	from, to, err := scorecardWindow(from, to)
+	if err != nil {
+		return nil, err
+	}
+	exists, err := s.supplierRepo.ExistsByUUID(ctx, supplierID)
+	if err != nil {
+		log.Printf("Error checking supplier existence: %v", err)
+		return nil, status.Errorf(codes.Internal, "error checking supplier existence: %v", err)
+	}
+	if !exists {
+		return nil, status.Error(codes.NotFound, "supplier not found")
+	}
+	orders, receipts, err := s.loadHistory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.score(ctx, supplierID, from, to, orders, receipts)
+}
+
+// RankSuppliers scores every supplier with at least one receipt in the window
+// and orders them best first by the given metric. A positive limit caps the
+// number of suppliers returned.
+func (s *SupplierScorecardService) RankSuppliers(ctx context.Context, from, to time.Time, metric model.SupplierRankingMetric, limit int) ([]*model.SupplierScorecard, error) {
+	from, to, err := scorecardWindow(from, to)
+	if err != nil {
+		return nil, err
+	}
+	suppliers, err := s.supplierRepo.ListSuppliers(ctx)
+	if err != nil {
+		log.Printf("Error listing suppliers: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing suppliers: %v", err)
+	}
+	orders, receipts, err := s.loadHistory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var scorecards []*model.SupplierScorecard
+	for _, supplier := range suppliers {
+		scorecard, err := s.score(ctx, supplier.ID, from, to, orders, receipts)
+		if err != nil {
+			return nil, err
+		}
+		if scorecard.Receipts > 0 {
+			scorecards = append(scorecards, scorecard)
+		}
+	}
+	sort.SliceStable(scorecards, func(i, j int) bool {
+		a, b := scorecards[i], scorecards[j]
+		switch metric {
+		case model.RankByOnTimeRate:
+			return a.OnTimeRate > b.OnTimeRate
+		case model.RankByFillRate:
+			return a.FillRate > b.FillRate
+		case model.RankByLeadTime:
+			return a.AverageLeadTimeDays < b.AverageLeadTimeDays
+		case model.RankByDamageRate:
+			return a.DamageRate < b.DamageRate
+		}
+		return a.Score > b.Score
+	})
+	if limit > 0 && len(scorecards) > limit {
+		scorecards = scorecards[:limit]
+	}
+	log.Println("Suppliers ranked successfully")
+	return scorecards, nil
+}
+
+func (s *SupplierScorecardService) loadHistory(ctx context.Context) ([]*model.PurchaseOrder, []*model.GoodsReceipt, error) {
+	orders, err := s.purchaseOrderRepo.ListPurchaseOrders(ctx)
+	if err != nil {
+		log.Printf("Error listing purchase orders: %v", err)
+		return nil, nil, status.Errorf(codes.Internal, "error listing purchase orders: %v", err)
+	}
+	receipts, err := s.goodsReceiptRepo.ListGoodsReceipts(ctx)
+	if err != nil {
+		log.Printf("Error listing goods receipts: %v", err)
+		return nil, nil, status.Errorf(codes.Internal, "error listing goods receipts: %v", err)
+	}
+	return orders, receipts, nil
+}
+
+func (s *SupplierScorecardService) score(ctx context.Context, supplierID uuid.UUID, from, to time.Time, orders []*model.PurchaseOrder, receipts []*model.GoodsReceipt) (*model.SupplierScorecard, error) {
+	catalog, err := s.supplierProductRepo.ListSupplierProductsBySupplier(ctx, supplierID.String())
+	if err != nil {
+		log.Printf("Error listing products for supplier: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing products for supplier: %v", err)
+	}
+	promisedDays := make(map[uuid.UUID]int, len(catalog))
+	for _, sp := range catalog {
+		promisedDays[sp.ProductID] = sp.LeadTimeDays
+	}
+	ordersByID := make(map[uuid.UUID]*model.PurchaseOrder)
+	for _, order := range orders {
+		if order.SupplierID == supplierID {
+			ordersByID[order.ID] = order
+		}
+	}
+
+	scorecard := &model.SupplierScorecard{SupplierID: supplierID, From: from, To: to}
+	receivedOrders := make(map[uuid.UUID]bool)
+	var leadTimeDays, promisedLeadTimeDays float64
+	var timedLines, promisedLines, onTimeLines int
+	for _, receipt := range receipts {
+		if receipt.SupplierID != supplierID || receipt.ReceivedAt.Before(from) || !receipt.ReceivedAt.Before(to) {
+			continue
+		}
+		scorecard.Receipts++
+		receivedOrders[receipt.PurchaseOrderID] = true
+		order := ordersByID[receipt.PurchaseOrderID]
+		for _, line := range receipt.Lines {
+			scorecard.ReceivedQuantity += line.ReceivedQuantity
+			scorecard.DamagedQuantity += line.DamagedQuantity
+			if order == nil || order.SubmittedAt.IsZero() || line.ReceivedQuantity == 0 {
+				continue
+			}
+			actual := receipt.ReceivedAt.Sub(order.SubmittedAt).Hours() / 24
+			leadTimeDays += actual
+			timedLines++
+			if promised, ok := promisedDays[line.ProductID]; ok {
+				promisedLeadTimeDays += float64(promised)
+				promisedLines++
+				if actual <= float64(promised) {
+					onTimeLines++
+				}
+			}
+		}
+	}
+
+	var fulfilled model.Quantity
+	for orderID := range receivedOrders {
+		order, ok := ordersByID[orderID]
+		if !ok {
+			continue
+		}
+		scorecard.PurchaseOrders++
+		for _, line := range order.Lines {
+			scorecard.OrderedQuantity += line.Quantity
+			fulfilled += min(line.ReceivedQuantity, line.Quantity)
+		}
+	}
+
+	scorecard.OnTimeRate = ratio(float64(onTimeLines), float64(promisedLines))
+	scorecard.FillRate = ratio(fulfilled.Float64(), scorecard.OrderedQuantity.Float64())
+	scorecard.AverageLeadTimeDays = ratio(leadTimeDays, float64(timedLines))
+	scorecard.AveragePromisedLeadTimeDays = ratio(promisedLeadTimeDays, float64(promisedLines))
+	scorecard.DamageRate = ratio(scorecard.DamagedQuantity.Float64(), scorecard.ReceivedQuantity.Float64())
+	scorecard.Score = onTimeWeight*scorecard.OnTimeRate + fillWeight*scorecard.FillRate + damageWeight*(1-scorecard.DamageRate)
+	return scorecard, nil
+}
+
+// scorecardWindow applies the defaults to a scoring window: it ends now and
+// starts defaultScorecardWindow before its end.
+func scorecardWindow(from, to time.Time) (time.Time, time.Time, error) {
+	if to.IsZero() {
+		to = time.Now().UTC()
+	}
+	if from.IsZero() {
+		from = to.Add(-defaultScorecardWindow)
+	}
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, status.Error(codes.InvalidArgument, "scorecard window must start before it ends")
+	}
+	return from, to, nil
+}
+
+func ratio(numerator, denominator float64) float64 {
+	if denominator == 0 {
+		return 0
+	}
+	return numerator / denominator
+}
@@ -10,11 +10,12 @@ import (
 )
 
 type SupplierService struct {
-	repo *repository.SupplierRepository
+	repo         *repository.SupplierRepository
+	auditService *AuditService
 }
 
-func NewSupplierService(repo *repository.SupplierRepository) *SupplierService {
-	return &SupplierService{repo: repo}
+func NewSupplierService(repo *repository.SupplierRepository, auditService *AuditService) *SupplierService {
+	return &SupplierService{repo: repo, auditService: auditService}
 }
 
 func (s *SupplierService) CreateSupplier(ctx context.Context, supplier *model.Supplier) (*model.Supplier, error) {
@@ -32,6 +33,7 @@ func (s *SupplierService) CreateSupplier(ctx context.Context, supplier *model.Su
 		log.Printf("Error creating supplier: %v", err)
 		return nil, err
 	}
+	s.auditService.Record(ctx, model.EntitySupplier, supplier.ID, model.AuditCreate, nil, supplier)
 	log.Println("Supplier created successfully:", supplier.ID)
 	return supplier, nil
 }
@@ -67,21 +69,33 @@ func (s *SupplierService) UpdateSupplier(ctx context.Context, supplier *model.Su
 	if exists {
 		return errors.New("another supplier with the same name already exists")
 	}
+	before, err := s.repo.GetSupplier(ctx, supplier.ID.String())
+	if err != nil && !errors.Is(err, repository.ErrSupplierNotFound) {
+		log.Printf("Error retrieving supplier: %v", err)
+		return err
+	}
 	err = s.repo.UpdateSupplier(ctx, supplier)
 	if err != nil {
 		log.Printf("Error updating supplier: %v", err)
 		return err
 	}
+	s.auditService.Record(ctx, model.EntitySupplier, supplier.ID, model.AuditUpdate, before, supplier)
 	log.Println("Supplier updated successfully:", supplier.ID)
 	return nil
 }
 
 func (s *SupplierService) DeleteSupplier(ctx context.Context, id uuid.UUID) error {
-	err := s.repo.DeleteSupplier(ctx, id.String())
+	before, err := s.repo.GetSupplier(ctx, id.String())
+	if err != nil && !errors.Is(err, repository.ErrSupplierNotFound) {
+		log.Printf("Error retrieving supplier: %v", err)
+		return err
+	}
+	err = s.repo.DeleteSupplier(ctx, id.String())
 	if err != nil {
 		log.Printf("Error deleting supplier: %v", err)
 		return err
 	}
+	s.auditService.Record(ctx, model.EntitySupplier, id, model.AuditDelete, before, nil)
 	log.Println("Supplier deleted successfully:", id)
 	return nil
 }
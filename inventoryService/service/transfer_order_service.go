@@ -0,0 +1,611 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventoryService/model"
+	"inventoryService/repository"
+	"log"
+	"time"
+)
+
+// TransferOrderService moves stock between warehouses in two steps, keeping
+// track of the units that have left the source but not yet reached the
+// destination.
+type TransferOrderService struct {
+	repo                 *repository.TransferOrderRepository
+	itemRepo             *repository.InventoryItemRepository
+	productRepo          *repository.ProductRepository
+	warehouseRepo        *repository.WarehouseRepository
+	stockMovementService *StockMovementService
+	auditService         *AuditService
+}
+
+func NewTransferOrderService(
+	repo *repository.TransferOrderRepository,
+	itemRepo *repository.InventoryItemRepository,
+	productRepo *repository.ProductRepository,
+	warehouseRepo *repository.WarehouseRepository,
+	stockMovementService *StockMovementService,
+	auditService *AuditService,
+) *TransferOrderService {
+	return &TransferOrderService{
+		repo:                 repo,
+		itemRepo:             itemRepo,
+		productRepo:          productRepo,
+		warehouseRepo:        warehouseRepo,
+		stockMovementService: stockMovementService,
+		auditService:         auditService,
+	}
+}
+
+// CreateTransferOrder saves a draft transfer. No stock moves until it is
+// shipped.
+func (s *TransferOrderService) CreateTransferOrder(ctx context.Context, order *model.TransferOrder) (*model.TransferOrder, error) {
+	if err := s.validateTransferOrder(ctx, order); err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	order.ID = uuid.New()
+	order.Status = model.TransferOrderDraft
+	order.Receipts = nil
+	order.CreatedAt = now
+	order.UpdatedAt = now
+	order.ShippedAt = time.Time{}
+	order.ReceivedAt = time.Time{}
+	for _, line := range order.Lines {
+		line.ID = uuid.New()
+		line.ShippedQuantity = 0
+		line.ReceivedQuantity = 0
+		line.DiscrepancyQuantity = 0
+		line.ShipmentMovementID = uuid.Nil
+	}
+
+	err := s.repo.CreateTransferOrder(ctx, order)
+	if err != nil {
+		log.Printf("Error creating transfer order: %v", err)
+		return nil, status.Errorf(codes.Internal, "error creating transfer order: %v", err)
+	}
+	s.auditService.Record(ctx, model.EntityTransferOrder, order.ID, model.AuditCreate, nil, order)
+	log.Println("Transfer order created successfully:", order.ID)
+	return order, nil
+}
+
+func (s *TransferOrderService) GetTransferOrder(ctx context.Context, id uuid.UUID) (*model.TransferOrder, error) {
+	order, err := s.repo.GetTransferOrder(ctx, id.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrTransferOrderNotFound) {
+			return nil, status.Error(codes.NotFound, "transfer order not found")
+		}
+		log.Printf("Error retrieving transfer order: %v", err)
+		return nil, status.Errorf(codes.Internal, "error retrieving transfer order: %v", err)
+	}
+	return order, nil
+}
+
+// ListTransferOrders returns every order, optionally restricted to those
+// leaving or arriving at a warehouse (non-nil warehouseID) and to a set of
+// statuses (non-empty statuses).
+func (s *TransferOrderService) ListTransferOrders(ctx context.Context, warehouseID uuid.UUID, statuses []model.TransferOrderStatus) ([]*model.TransferOrder, error) {
+	orders, err := s.repo.ListTransferOrders(ctx)
+	if err != nil {
+		log.Printf("Error listing transfer orders: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing transfer orders: %v", err)
+	}
+	var filtered []*model.TransferOrder
+	for _, order := range orders {
+		if warehouseID != uuid.Nil && order.SourceWarehouseID != warehouseID && order.DestinationWarehouseID != warehouseID {
+			continue
+		}
+		if len(statuses) > 0 && !containsTransferOrderStatus(statuses, order.Status) {
+			continue
+		}
+		filtered = append(filtered, order)
+	}
+	log.Println("Transfer orders listed successfully")
+	return filtered, nil
+}
+
+// ShipTransferOrder takes the stock of every line out of the source warehouse
+// with a transfer movement, putting it in transit. The order is claimed as in
+// transit first, so that it is shipped only once. If a movement fails the
+// lines already shipped are saved and the order goes back to draft; retrying
+// skips the lines that have a movement. Lines of serialized products name the
+// units shipped, keyed by line ID.
+func (s *TransferOrderService) ShipTransferOrder(ctx context.Context, id uuid.UUID, serialNumbers map[uuid.UUID][]string) (*model.TransferOrder, error) {
+	order, err := s.GetTransferOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != model.TransferOrderDraft {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot ship a transfer order that is %s", order.Status)
+	}
+	lines := make(map[uuid.UUID]bool, len(order.Lines))
+	for _, line := range order.Lines {
+		lines[line.ID] = true
+	}
+	for lineID := range serialNumbers {
+		if !lines[lineID] {
+			return nil, status.Errorf(codes.InvalidArgument, "line %s is not part of transfer order %s", lineID, order.ID)
+		}
+	}
+	if err := s.claim(ctx, id, model.TransferOrderDraft, model.TransferOrderInTransit); err != nil {
+		return nil, err
+	}
+	// An earlier attempt may have shipped lines since the order was read.
+	order, err = s.GetTransferOrder(ctx, id)
+	if err != nil {
+		s.revert(ctx, id, model.TransferOrderInTransit, model.TransferOrderDraft)
+		return nil, err
+	}
+	before := copyTransferOrder(order)
+	before.Status = model.TransferOrderDraft
+	now := time.Now().UTC()
+
+	var shipErr error
+	for _, line := range order.Lines {
+		if line.ShipmentMovementID != uuid.Nil {
+			continue
+		}
+		item, err := s.itemRepo.FindInventoryItem(ctx, line.ProductID.String(), order.SourceWarehouseID.String())
+		if err != nil {
+			if errors.Is(err, repository.ErrInventoryItemNotFound) {
+				shipErr = status.Errorf(codes.FailedPrecondition, "warehouse %s holds no stock of product %s", order.SourceWarehouseID, line.ProductID)
+			} else {
+				log.Printf("Error retrieving inventory item: %v", err)
+				shipErr = status.Errorf(codes.Internal, "error retrieving inventory item: %v", err)
+			}
+			break
+		}
+		movement, err := s.stockMovementService.PostStockMovement(ctx, &model.StockMovement{
+			InventoryItemID:        item.ID,
+			Type:                   model.Transfer,
+			Quantity:               line.Quantity,
+			Date:                   now,
+			SourceWarehouseID:      order.SourceWarehouseID,
+			DestinationWarehouseID: order.DestinationWarehouseID,
+			SerialNumbers:          serialNumbers[line.ID],
+		})
+		if err != nil {
+			shipErr = err
+			break
+		}
+		line.ShipmentMovementID = movement.ID
+		line.ShippedQuantity = line.Quantity
+		line.SerialNumbers = serialNumbers[line.ID]
+	}
+
+	order.UpdatedAt = now
+	if shipErr == nil {
+		order.ShippedAt = now
+	} else {
+		order.Status = model.TransferOrderDraft
+	}
+	err = s.repo.SaveProgress(ctx, order)
+	if err != nil {
+		// The order stays in transit: without the saved movements a retry
+		// would ship the same lines again.
+		log.Printf("Error saving transfer order shipment: %v", err)
+		return nil, status.Errorf(codes.Internal, "error saving transfer order shipment: %v", err)
+	}
+	if shipErr != nil {
+		s.revert(ctx, id, model.TransferOrderInTransit, model.TransferOrderDraft)
+	}
+	s.auditService.Record(ctx, model.EntityTransferOrder, order.ID, model.AuditUpdate, before, order)
+	if shipErr != nil {
+		return nil, shipErr
+	}
+	log.Println("Transfer order shipped successfully:", order.ID)
+	return order, nil
+}
+
+// ReceiveTransferOrder adds the delivered units of each receipt line to the
+// destination warehouse. The order is received once every shipped unit has
+// arrived, or straight away when closeOrder is set, at which point the
+// difference between received and shipped quantities is recorded on each line
+// as its discrepancy. Otherwise it stays partially received and can be
+// received again. A line cannot receive more than it has in transit, and the
+// order accepts one receipt at a time.
+//
+// The receipt holds the order under receivingID, or a new id when it is
+// uuid.Nil, while its lines are posted. Each line is saved with the id of its
+// stock movement before the movement is posted, and marked posted together
+// with its line's received total. A call that fails part way leaves the order
+// held; calling again with the receiving id of the error or the order posts
+// the remaining lines and releases it, ignoring the lines of the new call, so
+// that no line is received twice and a receipt left behind by a crash can
+// always be finished.
+func (s *TransferOrderService) ReceiveTransferOrder(ctx context.Context, id, receivingID uuid.UUID, receipts []*model.TransferReceiptLine, closeOrder bool) (*model.TransferOrder, error) {
+	order, err := s.GetTransferOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if receivingID != uuid.Nil && order.ReceivingID == receivingID {
+		return s.postReceipts(ctx, order, closeOrder)
+	}
+	if order.Status != model.TransferOrderInTransit && order.Status != model.TransferOrderPartiallyReceived {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot receive a transfer order that is %s", order.Status)
+	}
+	if len(receipts) == 0 && !closeOrder {
+		return nil, status.Error(codes.InvalidArgument, "at least one receipt line is required")
+	}
+	if receivingID == uuid.Nil {
+		receivingID = uuid.New()
+	}
+	claimed := order.Status
+	applied, holder, err := s.repo.ClaimReceiving(ctx, id.String(), claimed, receivingID)
+	if err != nil {
+		log.Printf("Error claiming transfer order: %v", err)
+		return nil, status.Errorf(codes.Internal, "error claiming transfer order: %v", err)
+	}
+	if !applied {
+		if holder != uuid.Nil {
+			return nil, status.Errorf(codes.Aborted, "transfer order is being received under receiving id %s", holder)
+		}
+		return nil, status.Error(codes.Aborted, "transfer order was changed concurrently")
+	}
+	// Receipts finished since the order was read are counted now.
+	order, err = s.GetTransferOrder(ctx, id)
+	if err == nil {
+		err = validateTransferReceipts(order, receipts)
+	}
+	if err != nil {
+		if _, releaseErr := s.repo.FinishReceiving(ctx, id.String(), receivingID, claimed); releaseErr != nil {
+			log.Printf("Error releasing transfer order %s: %v", id, releaseErr)
+		}
+		return nil, err
+	}
+	now := time.Now().UTC()
+	for _, receipt := range receipts {
+		receipt.ID = uuid.New()
+		receipt.ReceivedAt = now
+		receipt.StockMovementID = uuid.New()
+		receipt.Posted = false
+	}
+	err = s.repo.SaveReceipts(ctx, order, receipts)
+	if err != nil {
+		// The order stays held: the receipts may have been saved all the
+		// same, and a retry posts whichever were.
+		log.Printf("Error saving transfer order receipt: %v", err)
+		return nil, status.Errorf(codes.Internal, "error saving transfer order receipt: %v; retry with receiving id %s", err, receivingID)
+	}
+	order.ReceivingID = receivingID
+	order.Receipts = append(order.Receipts, receipts...)
+	return s.postReceipts(ctx, order, closeOrder)
+}
+
+// postReceipts posts the receipt lines of the order not posted yet, then sets
+// the status of the order and releases it. A line whose movement was posted
+// before its progress could be saved is not posted again. A line that fails
+// to post is dropped together with the lines after it and the order is
+// released with what was received, unless its movement may have been posted;
+// then the order stays held for a retry.
+func (s *TransferOrderService) postReceipts(ctx context.Context, order *model.TransferOrder, closeOrder bool) (*model.TransferOrder, error) {
+	before := copyTransferOrder(order)
+	lines := make(map[uuid.UUID]*model.TransferOrderLine, len(order.Lines))
+	for _, line := range order.Lines {
+		lines[line.ID] = line
+	}
+	var pending []*model.TransferReceiptLine
+	for _, receipt := range order.Receipts {
+		if !receipt.Posted {
+			pending = append(pending, receipt)
+		}
+	}
+	var receiveErr error
+	for i, receipt := range pending {
+		line, ok := lines[receipt.LineID]
+		if !ok {
+			return nil, status.Errorf(codes.NotFound, "transfer order line %s not found", receipt.LineID)
+		}
+		err := s.postReceipt(ctx, order, line, receipt)
+		if err == nil {
+			continue
+		}
+		posted, checkErr := s.stockMovementService.MovementPosted(ctx, receipt.StockMovementID)
+		if checkErr == nil && !posted {
+			checkErr = s.repo.DeleteReceipts(ctx, order, pending[i:])
+			if checkErr != nil {
+				log.Printf("Error deleting transfer order receipts: %v", checkErr)
+			}
+		}
+		if checkErr != nil || posted {
+			return nil, status.Errorf(status.Code(err), "%s; retry with receiving id %s", status.Convert(err).Message(), order.ReceivingID)
+		}
+		receiveErr = err
+		break
+	}
+	if receiveErr != nil {
+		received := order.Receipts[:0:0]
+		for _, receipt := range order.Receipts {
+			if receipt.Posted {
+				received = append(received, receipt)
+			}
+		}
+		order.Receipts = received
+	}
+
+	now := time.Now().UTC()
+	order.UpdatedAt = now
+	complete := true
+	anyReceived := false
+	for _, line := range order.Lines {
+		if line.InTransit() > 0 {
+			complete = false
+		}
+		if line.ReceivedQuantity > 0 {
+			anyReceived = true
+		}
+	}
+	if receiveErr == nil && (complete || closeOrder) {
+		order.Status = model.TransferOrderReceived
+		order.ReceivedAt = now
+		for _, line := range order.Lines {
+			line.DiscrepancyQuantity = line.ReceivedQuantity - line.ShippedQuantity
+		}
+	} else if anyReceived {
+		order.Status = model.TransferOrderPartiallyReceived
+	}
+	err := s.repo.SaveProgress(ctx, order)
+	if err != nil {
+		log.Printf("Error saving transfer order receipt: %v", err)
+		return nil, status.Errorf(codes.Internal, "error saving transfer order receipt: %v; retry with receiving id %s", err, order.ReceivingID)
+	}
+	applied, err := s.repo.FinishReceiving(ctx, order.ID.String(), order.ReceivingID, order.Status)
+	if err != nil {
+		log.Printf("Error updating transfer order status: %v", err)
+		return nil, status.Errorf(codes.Internal, "error updating transfer order status: %v; retry with receiving id %s", err, order.ReceivingID)
+	}
+	if !applied {
+		return nil, status.Errorf(codes.Aborted, "receiving id %s no longer holds the transfer order", order.ReceivingID)
+	}
+	order.ReceivingID = uuid.Nil
+	s.auditService.Record(ctx, model.EntityTransferOrder, order.ID, model.AuditUpdate, before, order)
+	if receiveErr != nil {
+		return nil, receiveErr
+	}
+	log.Printf("Transfer order %s moved from %s to %s", order.ID, before.Status, order.Status)
+	return order, nil
+}
+
+// postReceipt posts the stock movement of a receipt line unless it is already
+// journaled, then saves the receipt line as posted along with its line's new
+// received total. The units arrive at the cost they left the source warehouse
+// at.
+func (s *TransferOrderService) postReceipt(ctx context.Context, order *model.TransferOrder, line *model.TransferOrderLine, receipt *model.TransferReceiptLine) error {
+	posted, err := s.stockMovementService.MovementPosted(ctx, receipt.StockMovementID)
+	if err != nil {
+		return err
+	}
+	if !posted {
+		item, err := findOrCreateInventoryItem(ctx, s.itemRepo, s.auditService, line.ProductID, order.DestinationWarehouseID)
+		if err != nil {
+			return err
+		}
+		shipment, err := s.stockMovementService.GetStockMovement(ctx, line.ShipmentMovementID)
+		if err != nil {
+			return status.Errorf(codes.Internal, "error retrieving shipment of line %s: %v", line.ID, err)
+		}
+		_, err = s.stockMovementService.PostStockMovement(ctx, &model.StockMovement{
+			ID:                     receipt.StockMovementID,
+			InventoryItemID:        item.ID,
+			Type:                   model.Addition,
+			Quantity:               receipt.Quantity,
+			Date:                   receipt.ReceivedAt,
+			SourceWarehouseID:      order.SourceWarehouseID,
+			DestinationWarehouseID: order.DestinationWarehouseID,
+			SerialNumbers:          receipt.SerialNumbers,
+			UnitCost:               shipment.UnitCost,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	received := line.ReceivedQuantity + receipt.Quantity
+	err = s.repo.SaveReceiptPosted(ctx, order, receipt, received)
+	if err != nil {
+		log.Printf("Error saving transfer order receipt: %v", err)
+		return status.Errorf(codes.Internal, "error saving transfer order receipt: %v", err)
+	}
+	line.ReceivedQuantity = received
+	receipt.Posted = true
+	return nil
+}
+
+// validateTransferReceipts checks the receipt lines of a receipt against the
+// lines of its order: every line must be on the order, receive a positive
+// quantity no larger than it has in transit, and name only serial numbers
+// shipped on it.
+func validateTransferReceipts(order *model.TransferOrder, receipts []*model.TransferReceiptLine) error {
+	lines := make(map[uuid.UUID]*model.TransferOrderLine, len(order.Lines))
+	for _, line := range order.Lines {
+		lines[line.ID] = line
+	}
+	receiving := make(map[uuid.UUID]model.Quantity, len(receipts))
+	for _, receipt := range receipts {
+		line, ok := lines[receipt.LineID]
+		if !ok {
+			return status.Errorf(codes.InvalidArgument, "line %s is not part of transfer order %s", receipt.LineID, order.ID)
+		}
+		if receipt.Quantity <= 0 {
+			return status.Error(codes.InvalidArgument, "received quantity must be positive")
+		}
+		receiving[line.ID] += receipt.Quantity
+		if receiving[line.ID] > line.InTransit() {
+			return status.Errorf(codes.FailedPrecondition, "line %s has only %s units in transit", line.ID, line.InTransit())
+		}
+		if shipped := line.SerialNumbers; len(shipped) > 0 {
+			onLine := make(map[string]bool, len(shipped))
+			for _, number := range shipped {
+				onLine[number] = true
+			}
+			for _, number := range receipt.SerialNumbers {
+				if !onLine[number] {
+					return status.Errorf(codes.InvalidArgument, "serial %s was not shipped on line %s", number, receipt.LineID)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// CancelTransferOrder cancels a draft transfer none of whose lines has been
+// shipped.
+func (s *TransferOrderService) CancelTransferOrder(ctx context.Context, id uuid.UUID) (*model.TransferOrder, error) {
+	order, err := s.GetTransferOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != model.TransferOrderDraft {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot cancel a transfer order that is %s", order.Status)
+	}
+	if err := s.claim(ctx, id, model.TransferOrderDraft, model.TransferOrderCancelled); err != nil {
+		return nil, err
+	}
+	// A failed shipment may have shipped lines since the order was read.
+	order, err = s.GetTransferOrder(ctx, id)
+	if err != nil {
+		s.revert(ctx, id, model.TransferOrderCancelled, model.TransferOrderDraft)
+		return nil, err
+	}
+	for _, line := range order.Lines {
+		if line.ShipmentMovementID != uuid.Nil {
+			s.revert(ctx, id, model.TransferOrderCancelled, model.TransferOrderDraft)
+			return nil, status.Error(codes.FailedPrecondition, "cannot cancel a transfer order that has been partly shipped; ship the remaining lines instead")
+		}
+	}
+	before := copyTransferOrder(order)
+	before.Status = model.TransferOrderDraft
+	order.UpdatedAt = time.Now().UTC()
+	err = s.repo.SaveProgress(ctx, order)
+	if err != nil {
+		log.Printf("Error updating transfer order: %v", err)
+		return nil, status.Errorf(codes.Internal, "error updating transfer order: %v", err)
+	}
+	s.auditService.Record(ctx, model.EntityTransferOrder, order.ID, model.AuditUpdate, before, order)
+	log.Printf("Transfer order %s moved from %s to %s", order.ID, before.Status, order.Status)
+	return order, nil
+}
+
+// claim moves an order between statuses, failing with Aborted when it has
+// changed since it was read or is being received.
+func (s *TransferOrderService) claim(ctx context.Context, id uuid.UUID, from, to model.TransferOrderStatus) error {
+	applied, err := s.repo.UpdateTransferOrderStatus(ctx, id.String(), from, to)
+	if err != nil {
+		log.Printf("Error updating transfer order status: %v", err)
+		return status.Errorf(codes.Internal, "error updating transfer order status: %v", err)
+	}
+	if !applied {
+		return status.Error(codes.Aborted, "transfer order was changed concurrently")
+	}
+	return nil
+}
+
+// revert undoes a claim after the change it was made for failed. Failures are
+// logged; the order then stays in the claimed status.
+func (s *TransferOrderService) revert(ctx context.Context, id uuid.UUID, claimed, previous model.TransferOrderStatus) {
+	if _, err := s.repo.UpdateTransferOrderStatus(ctx, id.String(), claimed, previous); err != nil {
+		log.Printf("Error moving transfer order %s back to %s: %v", id, previous, err)
+	}
+}
+
+// ListInTransitStock returns the units shipped by open transfer orders that
+// have not arrived yet, optionally restricted to a source warehouse, a
+// destination warehouse and a product (each when non-nil).
+func (s *TransferOrderService) ListInTransitStock(ctx context.Context, sourceWarehouseID, destinationWarehouseID, productID uuid.UUID) ([]*model.InTransitStock, error) {
+	orders, err := s.ListTransferOrders(ctx, uuid.Nil, []model.TransferOrderStatus{model.TransferOrderInTransit, model.TransferOrderPartiallyReceived})
+	if err != nil {
+		return nil, err
+	}
+	var stock []*model.InTransitStock
+	for _, order := range orders {
+		if sourceWarehouseID != uuid.Nil && order.SourceWarehouseID != sourceWarehouseID {
+			continue
+		}
+		if destinationWarehouseID != uuid.Nil && order.DestinationWarehouseID != destinationWarehouseID {
+			continue
+		}
+		for _, line := range order.Lines {
+			if productID != uuid.Nil && line.ProductID != productID {
+				continue
+			}
+			if line.InTransit() == 0 {
+				continue
+			}
+			stock = append(stock, &model.InTransitStock{
+				TransferOrderID:        order.ID,
+				LineID:                 line.ID,
+				ProductID:              line.ProductID,
+				SourceWarehouseID:      order.SourceWarehouseID,
+				DestinationWarehouseID: order.DestinationWarehouseID,
+				Quantity:               line.InTransit(),
+				ShippedAt:              order.ShippedAt,
+			})
+		}
+	}
+	return stock, nil
+}
+
+func (s *TransferOrderService) validateTransferOrder(ctx context.Context, order *model.TransferOrder) error {
+	if order.SourceWarehouseID == order.DestinationWarehouseID {
+		return status.Error(codes.InvalidArgument, "source and destination warehouses must differ")
+	}
+	for _, warehouseID := range []uuid.UUID{order.SourceWarehouseID, order.DestinationWarehouseID} {
+		exists, err := s.warehouseRepo.WarehouseExists(ctx, warehouseID.String())
+		if err != nil {
+			return status.Errorf(codes.Internal, "error checking warehouse existence: %v", err)
+		}
+		if !exists {
+			return status.Errorf(codes.NotFound, "warehouse %s not found", warehouseID)
+		}
+	}
+	if len(order.Lines) == 0 {
+		return status.Error(codes.InvalidArgument, "transfer order must have at least one line")
+	}
+	for _, line := range order.Lines {
+		if line.Quantity <= 0 {
+			return status.Error(codes.InvalidArgument, "line quantity must be positive")
+		}
+		product, err := s.productRepo.GetProduct(ctx, line.ProductID.String())
+		if err != nil {
+			if errors.Is(err, repository.ErrProductNotFound) {
+				return status.Errorf(codes.NotFound, "product %s not found", line.ProductID)
+			}
+			return status.Errorf(codes.Internal, "error retrieving product: %v", err)
+		}
+		if err := validateQuantity("line quantity", line.Quantity, product); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyTransferOrder returns a copy of order whose lines can be changed without
+// affecting the original.
+func copyTransferOrder(order *model.TransferOrder) *model.TransferOrder {
+	copied := *order
+	copied.Lines = make([]*model.TransferOrderLine, len(order.Lines))
+	for i, line := range order.Lines {
+		lineCopy := *line
+		copied.Lines[i] = &lineCopy
+	}
+	copied.Receipts = make([]*model.TransferReceiptLine, len(order.Receipts))
+	for i, receipt := range order.Receipts {
+		receiptCopy := *receipt
+		copied.Receipts[i] = &receiptCopy
+	}
+	return &copied
+}
+
+func containsTransferOrderStatus(statuses []model.TransferOrderStatus, target model.TransferOrderStatus) bool {
+	for _, s := range statuses {
+		if s == target {
+			return true
+		}
+	}
+	return false
+}
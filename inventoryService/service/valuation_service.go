@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventoryService/model"
+	"inventoryService/repository"
+	"log"
+	"sort"
+	"time"
+)
+
+// ValuationService values stock from the cost carried by posted stock
+// movements, which StockMovementService works out from the cost layers of
+// each inventory item.
+type ValuationService struct {
+	stockMovementRepo *repository.StockMovementRepository
+	costLayerRepo     *repository.CostLayerRepository
+	itemRepo          *repository.InventoryItemRepository
+	productRepo       *repository.ProductRepository
+}
+
+func NewValuationService(
+	stockMovementRepo *repository.StockMovementRepository,
+	costLayerRepo *repository.CostLayerRepository,
+	itemRepo *repository.InventoryItemRepository,
+	productRepo *repository.ProductRepository,
+) *ValuationService {
+	return &ValuationService{
+		stockMovementRepo: stockMovementRepo,
+		costLayerRepo:     costLayerRepo,
+		itemRepo:          itemRepo,
+		productRepo:       productRepo,
+	}
+}
+
+// GetInventoryValuation values the stock on hand at asOf, or now when zero,
+// by replaying the posted stock movements up to then, and adds up the cost of
+// goods removed from since on. Movements only journaled never changed stock
+// and are left out. Results can be restricted to a warehouse and a category
+// (each when non-nil). Lines are ordered by currency and then by value,
+// highest first; items without stock or removals are left out.
+func (s *ValuationService) GetInventoryValuation(ctx context.Context, warehouseID, categoryID uuid.UUID, asOf, since time.Time) (*model.InventoryValuation, error) {
+	if asOf.IsZero() {
+		asOf = time.Now().UTC()
+	}
+	if since.After(asOf) {
+		return nil, status.Error(codes.InvalidArgument, "since cannot be after as of")
+	}
+	items, err := s.itemRepo.ListInventoryItems(ctx)
+	if err != nil {
+		log.Printf("Error listing inventory items: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing inventory items: %v", err)
+	}
+	products, err := s.productRepo.ListProducts(ctx)
+	if err != nil {
+		log.Printf("Error listing products: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing products: %v", err)
+	}
+	movements, err := s.stockMovementRepo.ListStockMovements(ctx)
+	if err != nil {
+		log.Printf("Error listing stock movements: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing stock movements: %v", err)
+	}
+
+	productsByID := make(map[uuid.UUID]*model.Product, len(products))
+	for _, product := range products {
+		productsByID[product.ID] = product
+	}
+	lines := make(map[uuid.UUID]*model.ValuationLine)
+	for _, item := range items {
+		if warehouseID != uuid.Nil && item.WarehouseID != warehouseID {
+			continue
+		}
+		product, ok := productsByID[item.ProductID]
+		if !ok || (categoryID != uuid.Nil && product.CategoryID != categoryID) {
+			continue
+		}
+		lines[item.ID] = &model.ValuationLine{
+			InventoryItemID: item.ID,
+			ProductID:       item.ProductID,
+			WarehouseID:     item.WarehouseID,
+			CategoryID:      product.CategoryID,
+			CostingMethod:   product.CostingMethod,
+		}
+	}
+	for _, movement := range movements {
+		line, ok := lines[movement.InventoryItemID]
+		if !ok || !movement.Posted || movement.Date.After(asOf) {
+			continue
+		}
+		switch movement.Type {
+		case model.Addition:
+			line.Quantity += movement.Quantity
+			line.Value = line.Value.Add(movement.Cost)
+		case model.Removal, model.Transfer:
+			line.Quantity -= movement.Quantity
+			line.Value = line.Value.Sub(movement.Cost)
+		case model.Adjustment:
+			line.Quantity += movement.Quantity
+			if movement.Quantity > 0 {
+				line.Value = line.Value.Add(movement.Cost)
+			} else {
+				line.Value = line.Value.Sub(movement.Cost)
+			}
+		}
+		removed := movement.Type == model.Removal || (movement.Type == model.Adjustment && movement.Quantity < 0)
+		if removed && !movement.Date.Before(since) {
+			line.CostOfGoodsRemoved = line.CostOfGoodsRemoved.Add(movement.Cost)
+		}
+	}
+
+	valuation := &model.InventoryValuation{AsOf: asOf, Since: since}
+	for _, line := range lines {
+		if line.Quantity == 0 && line.Value.IsZero() && line.CostOfGoodsRemoved.IsZero() {
+			continue
+		}
+		valuation.Lines = append(valuation.Lines, line)
+		valuation.Quantity += line.Quantity
+		valuation.Value = model.AddToTotals(valuation.Value, line.Value)
+		valuation.CostOfGoodsRemoved = model.AddToTotals(valuation.CostOfGoodsRemoved, line.CostOfGoodsRemoved)
+	}
+	sort.Slice(valuation.Lines, func(i, j int) bool {
+		if valuation.Lines[i].Value.CurrencyCode != valuation.Lines[j].Value.CurrencyCode {
+			return valuation.Lines[i].Value.CurrencyCode < valuation.Lines[j].Value.CurrencyCode
+		}
+		if cmp := valuation.Lines[i].Value.Cmp(valuation.Lines[j].Value); cmp != 0 {
+			return cmp > 0
+		}
+		return valuation.Lines[i].InventoryItemID.String() < valuation.Lines[j].InventoryItemID.String()
+	})
+	return valuation, nil
+}
+
+// ListCostLayers returns the cost layers of an inventory item still holding
+// units, oldest first.
+func (s *ValuationService) ListCostLayers(ctx context.Context, inventoryItemID uuid.UUID) ([]*model.CostLayer, error) {
+	if _, err := s.itemRepo.GetInventoryItem(ctx, inventoryItemID.String()); err != nil {
+		if errors.Is(err, repository.ErrInventoryItemNotFound) {
+			return nil, status.Error(codes.NotFound, "inventory item not found")
+		}
+		log.Printf("Error retrieving inventory item: %v", err)
+		return nil, status.Errorf(codes.Internal, "error retrieving inventory item: %v", err)
+	}
+	layers, err := s.costLayerRepo.ListCostLayers(ctx, inventoryItemID.String())
+	if err != nil {
+		log.Printf("Error listing cost layers: %v", err)
+		return nil, status.Errorf(codes.Internal, "error listing cost layers: %v", err)
+	}
+	var open []*model.CostLayer
+	for _, layer := range layers {
+		if layer.RemainingQuantity > 0 {
+			open = append(open, layer)
+		}
+	}
+	return open, nil
+}
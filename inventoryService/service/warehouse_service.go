@@ -10,14 +10,18 @@ import (
 )
 
 type WarehouseService struct {
-	repo *repository.WarehouseRepository
+	repo         *repository.WarehouseRepository
+	auditService *AuditService
 }
 
-func NewWarehouseService(repo *repository.WarehouseRepository) *WarehouseService {
-	return &WarehouseService{repo: repo}
+func NewWarehouseService(repo *repository.WarehouseRepository, auditService *AuditService) *WarehouseService {
+	return &WarehouseService{repo: repo, auditService: auditService}
 }
 
 func (s *WarehouseService) CreateWarehouse(ctx context.Context, warehouse *model.Warehouse) (*model.Warehouse, error) {
+	if err := validateWarehouseCapacity(warehouse); err != nil {
+		return nil, err
+	}
 	warehouse.ID = uuid.New()
 	exists, err := s.repo.ExistsByUUID(ctx, warehouse.ID)
 	if err != nil {
@@ -32,6 +36,7 @@ func (s *WarehouseService) CreateWarehouse(ctx context.Context, warehouse *model
 		log.Printf("Error creating warehouse: %v", err)
 		return nil, err
 	}
+	s.auditService.Record(ctx, model.EntityWarehouse, warehouse.ID, model.AuditCreate, nil, warehouse)
 	log.Println("Warehouse created successfully:", warehouse.ID)
 	return warehouse, nil
 }
@@ -59,6 +64,9 @@ func (s *WarehouseService) ListWarehouses(ctx context.Context) ([]*model.Warehou
 }
 
 func (s *WarehouseService) UpdateWarehouse(ctx context.Context, warehouse *model.Warehouse) error {
+	if err := validateWarehouseCapacity(warehouse); err != nil {
+		return err
+	}
 	exists, err := s.repo.ExistsByNameExcludingUUID(ctx, warehouse.Name, warehouse.ID)
 	if err != nil {
 		log.Printf("Error checking for existing warehouse: %v", err)
@@ -67,21 +75,33 @@ func (s *WarehouseService) UpdateWarehouse(ctx context.Context, warehouse *model
 	if exists {
 		return errors.New("another warehouse with the same name already exists")
 	}
+	before, err := s.repo.GetWarehouse(ctx, warehouse.ID.String())
+	if err != nil && !errors.Is(err, repository.ErrWarehouseNotFound) {
+		log.Printf("Error retrieving warehouse: %v", err)
+		return err
+	}
 	err = s.repo.UpdateWarehouse(ctx, warehouse)
 	if err != nil {
 		log.Printf("Error updating warehouse: %v", err)
 		return err
 	}
+	s.auditService.Record(ctx, model.EntityWarehouse, warehouse.ID, model.AuditUpdate, before, warehouse)
 	log.Println("Warehouse updated successfully:", warehouse.ID)
 	return nil
 }
 
 func (s *WarehouseService) DeleteWarehouse(ctx context.Context, id uuid.UUID) error {
-	err := s.repo.DeleteWarehouse(ctx, id.String())
+	before, err := s.repo.GetWarehouse(ctx, id.String())
+	if err != nil && !errors.Is(err, repository.ErrWarehouseNotFound) {
+		log.Printf("Error retrieving warehouse: %v", err)
+		return err
+	}
+	err = s.repo.DeleteWarehouse(ctx, id.String())
 	if err != nil {
 		log.Printf("Error deleting warehouse: %v", err)
 		return err
 	}
+	s.auditService.Record(ctx, model.EntityWarehouse, id, model.AuditDelete, before, nil)
 	log.Println("Warehouse deleted successfully:", id)
 	return nil
 }